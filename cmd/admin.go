@@ -49,6 +49,7 @@ func subcmdRegenerate() *cli.Command {
 		Commands: []*cli.Command{
 			microcmdRegenHooks,
 			microcmdRegenKeys,
+			microcmdRegenSecretKey,
 		},
 	}
 }
@@ -71,12 +71,22 @@ func microcmdAuthList() *cli.Command {
 	}
 }
 
+// createAuthSource creates the authentication source, there is no doer to record when it is done from the command line.
+func createAuthSource(ctx context.Context, source *auth_model.Source) error {
+	return auth_service.CreateSource(ctx, nil, source)
+}
+
+// updateAuthSource updates the authentication source, there is no doer to record when it is done from the command line.
+func updateAuthSource(ctx context.Context, source *auth_model.Source) error {
+	return auth_service.UpdateSource(ctx, nil, source)
+}
+
 // newAuthService creates a service with default functions.
 func newAuthService() *authService {
 	return &authService{
 		initDB:            initDB,
-		createAuthSource:  auth_model.CreateSource,
-		updateAuthSource:  auth_model.UpdateSource,
+		createAuthSource:  createAuthSource,
+		updateAuthSource:  updateAuthSource,
 		getAuthSourceByID: auth_model.GetSourceByID,
 	}
 }
@@ -132,5 +142,5 @@ func runDeleteAuth(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	return auth_service.DeleteSource(ctx, source)
+	return auth_service.DeleteSource(ctx, nil, source)
 }
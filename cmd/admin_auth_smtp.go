@@ -162,7 +162,7 @@ func runAddSMTP(ctx context.Context, c *cli.Command) error {
 		smtpConfig.Auth = "PLAIN"
 	}
 
-	return auth_model.CreateSource(ctx, &auth_model.Source{
+	return createAuthSource(ctx, &auth_model.Source{
 		Type:     auth_model.SMTP,
 		Name:     c.String("name"),
 		IsActive: active,
@@ -203,5 +203,5 @@ func runUpdateSMTP(ctx context.Context, c *cli.Command) error {
 
 	source.Cfg = smtpConfig
 
-	return auth_model.UpdateSource(ctx, source)
+	return updateAuthSource(ctx, source)
 }
@@ -5,10 +5,12 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	asymkey_model "forgejo.org/models/asymkey"
 	"forgejo.org/modules/graceful"
 	repo_service "forgejo.org/services/repository"
+	secretkey_service "forgejo.org/services/secretkey"
 
 	"github.com/urfave/cli/v3"
 )
@@ -27,6 +29,14 @@ var (
 		Before: noDanglingArgs,
 		Action: runRegenerateKeys,
 	}
+
+	microcmdRegenSecretKey = &cli.Command{
+		Name:        "secret-key",
+		Usage:       "Re-encrypt the stored secrets with the current secret key",
+		Description: "Re-encrypt every secret stored in the database that is still encrypted with one of the [security].PREVIOUS_SECRET_KEYS. It can be interrupted and run again, the secrets that were already re-encrypted are skipped.",
+		Before:      noDanglingArgs,
+		Action:      runRegenerateSecretKey,
+	}
 )
 
 func runRegenerateHooks(ctx context.Context, c *cli.Command) error {
@@ -48,3 +58,26 @@ func runRegenerateKeys(ctx context.Context, c *cli.Command) error {
 	}
 	return asymkey_model.RewriteAllPublicKeys(ctx)
 }
+
+func runRegenerateSecretKey(ctx context.Context, c *cli.Command) error {
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	all, err := secretkey_service.Process(ctx, secretkey_service.Options{Reencrypt: true})
+	undecryptable := 0
+	for _, stats := range all {
+		fmt.Printf("%s: %d re-encrypted, %d undecryptable\n", stats.Name, stats.Reencrypted, len(stats.Undecryptable))
+		undecryptable += len(stats.Undecryptable)
+	}
+	if err != nil {
+		return err
+	}
+	if undecryptable > 0 {
+		return fmt.Errorf("%d secrets cannot be decrypted with any of the secret keys, run `forgejo doctor check --run check-encrypted-secrets` for details", undecryptable)
+	}
+	return nil
+}
@@ -15,6 +15,8 @@ import (
 	pwd "forgejo.org/modules/auth/password"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
 
 	"github.com/urfave/cli/v3"
 )
@@ -202,11 +204,12 @@ func runCreateUser(ctx context.Context, c *cli.Command) error {
 		return fmt.Errorf("CreateUser: %w", err)
 	}
 	fmt.Printf("New user '%s' has been successfully created!\n", username)
+	audit_service.RecordAdminChange(ctx, nil, u, false)
 
 	// create the access token
 	if accessTokenScope != "" {
 		t := &auth_model.AccessToken{Name: accessTokenName, UID: u.ID, Scope: accessTokenScope}
-		if err := auth_model.NewAccessToken(ctx, t); err != nil {
+		if err := auth_service.CreateAccessToken(ctx, nil, t); err != nil {
 			return err
 		}
 		fmt.Printf("Access token was successfully created... %s\n", t.Token)
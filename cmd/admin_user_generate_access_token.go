@@ -10,6 +10,7 @@ import (
 
 	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
+	auth_service "forgejo.org/services/auth"
 
 	"github.com/urfave/cli/v3"
 )
@@ -87,7 +88,7 @@ func runGenerateAccessToken(ctx context.Context, c *cli.Command) error {
 	t.Scope = accessTokenScope
 
 	// create the token
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
+	if err := auth_service.CreateAccessToken(ctx, nil, t); err != nil {
 		return err
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
+	auth_service "forgejo.org/services/auth"
 
 	"github.com/urfave/cli/v3"
 )
@@ -60,7 +61,7 @@ func runResetMFA(ctx context.Context, c *cli.Command) error {
 
 	tfaModes, err := auth_model.GetTwoFactorByUID(ctx, user.ID)
 	if err == nil && tfaModes != nil {
-		if err := auth_model.DeleteTwoFactorByID(ctx, tfaModes.ID, user.ID); err != nil {
+		if err := auth_service.DeleteTwoFactor(ctx, nil, user, tfaModes); err != nil {
 			return err
 		}
 	} else {
@@ -206,6 +206,7 @@ Forgejo or set your environment appropriately.`, "")
 		GitQuarantinePath:               os.Getenv(private.GitQuarantinePath),
 		GitPushOptions:                  pushoptions.New().ReadEnv().Map(),
 		PullRequestID:                   prID,
+		PushTrigger:                     repo_module.PushTrigger(os.Getenv(repo_module.EnvPushTrigger)),
 		DeployKeyID:                     deployKeyID,
 		ActionPerm:                      int(actionPerm),
 	}
@@ -0,0 +1,382 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// MaxEnvironmentWaitTimer is the maximum wait timer of an environment, in minutes
+const MaxEnvironmentWaitTimer = 30 * 24 * 60
+
+// ActionEnvironment is a deployment environment of a repository. The jobs naming it
+// with `environment:` get its secrets and variables, and only start once its
+// protection rules are satisfied.
+type ActionEnvironment struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name   string `xorm:"VARCHAR(255) UNIQUE(repo_name) NOT NULL"`
+	// the jobs wait for WaitTimer minutes before starting
+	WaitTimer int64 `xorm:"NOT NULL DEFAULT 0"`
+	// if not empty, one of these users must approve the jobs before they start
+	ReviewerIDs []int64 `xorm:"JSON TEXT"`
+	// if not empty, only the runs of the branches matching one of these globs can deploy
+	BranchPatterns []string           `xorm:"JSON TEXT"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+// DeploymentStatus is the status of a deployment
+type DeploymentStatus int
+
+const (
+	DeploymentStatusWaiting  DeploymentStatus = iota + 1 // waiting for a reviewer of the environment
+	DeploymentStatusApproved                             // approved, waiting for the wait timer of the environment
+	DeploymentStatusQueued                               // the job can be picked by a runner
+	DeploymentStatusRejected                             // rejected by a reviewer or by the branch patterns of the environment
+)
+
+var deploymentStatusNames = map[DeploymentStatus]string{
+	DeploymentStatusWaiting:  "waiting",
+	DeploymentStatusApproved: "approved",
+	DeploymentStatusQueued:   "queued",
+	DeploymentStatusRejected: "rejected",
+}
+
+func (status DeploymentStatus) String() string {
+	return deploymentStatusNames[status]
+}
+
+func DeploymentStatusFromString(name string) (DeploymentStatus, bool) {
+	for status, statusName := range deploymentStatusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// ActionDeployment is an attempt of a job to deploy to an environment
+type ActionDeployment struct {
+	ID            int64         `xorm:"pk autoincr"`
+	RepoID        int64         `xorm:"INDEX NOT NULL"`
+	EnvironmentID int64         `xorm:"INDEX NOT NULL"`
+	RunID         int64         `xorm:"NOT NULL"`
+	JobID         int64         `xorm:"INDEX NOT NULL"`
+	Job           *ActionRunJob `xorm:"-"`
+	// the attempt of the job the deployment is for
+	Attempt    int64 `xorm:"NOT NULL"`
+	Ref        string
+	CommitSHA  string
+	Status     DeploymentStatus   `xorm:"INDEX NOT NULL"`
+	ReviewerID int64              `xorm:"NOT NULL DEFAULT 0"`
+	StartAfter timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	Created    timeutil.TimeStamp `xorm:"created"`
+	Updated    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// ValidateEnvironment checks the name and the protection rules of the environment
+func ValidateEnvironment(env *ActionEnvironment) error {
+	if env.Name == "" || env.Name != strings.TrimSpace(env.Name) || len(env.Name) > 255 {
+		return util.NewInvalidArgumentErrorf("invalid environment name %q", env.Name)
+	}
+	if env.WaitTimer < 0 || env.WaitTimer > MaxEnvironmentWaitTimer {
+		return util.NewInvalidArgumentErrorf("the wait timer must be between 0 and %d minutes", MaxEnvironmentWaitTimer)
+	}
+	for _, pattern := range env.BranchPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// CanDeployRef returns whether the runs of the ref can deploy to the environment
+func (env *ActionEnvironment) CanDeployRef(ref string) bool {
+	if len(env.BranchPatterns) == 0 {
+		return true
+	}
+	branch := git.RefName(ref).BranchName()
+	if branch == "" {
+		return false
+	}
+	for _, pattern := range env.BranchPatterns {
+		if g, err := glob.Compile(pattern, '/'); err == nil && g.Match(branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReviewer returns whether the user can review the deployments to the environment
+func (env *ActionEnvironment) IsReviewer(userID int64) bool {
+	return slices.Contains(env.ReviewerIDs, userID)
+}
+
+// GetEnvironmentByName returns the environment of the repository with the given name
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env, has, err := db.Get[ActionEnvironment](ctx, builder.Eq{"repo_id": repoID, "name": name})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %q of repository %d", name, repoID)
+	}
+	return env, nil
+}
+
+// GetEnvironmentsByRepoID returns the environments of the repository sorted by name
+func GetEnvironmentsByRepoID(ctx context.Context, repoID int64) ([]*ActionEnvironment, error) {
+	envs := make([]*ActionEnvironment, 0, 5)
+	return envs, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("name").Find(&envs)
+}
+
+// CreateEnvironment creates a new environment
+func CreateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	return db.Insert(ctx, env)
+}
+
+// UpdateEnvironment updates the protection rules of the environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(env.ID).Cols("wait_timer", "reviewer_ids", "branch_patterns").Update(env)
+	return err
+}
+
+// DeleteEnvironment deletes the environment with its secrets, variables and deployments.
+// It fails if a job still waits to deploy to it.
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		pending, err := db.GetEngine(ctx).Table("action_deployment").
+			Join("INNER", "action_run_job", "action_run_job.id = action_deployment.job_id").
+			Where(builder.Eq{"action_deployment.environment_id": env.ID}).
+			And(builder.In("action_deployment.status", DeploymentStatusWaiting, DeploymentStatusApproved, DeploymentStatusQueued)).
+			And(builder.Eq{"action_run_job.status": StatusWaiting}).
+			Exist()
+		if err != nil {
+			return err
+		} else if pending {
+			return util.NewInvalidArgumentErrorf("jobs are waiting to deploy to the environment %q", env.Name)
+		}
+
+		if _, err := db.GetEngine(ctx).Table("secret").Where("environment_id = ?", env.ID).Delete(); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Table("action_variable").Where("environment_id = ?", env.ID).Delete(); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("environment_id = ?", env.ID).Delete(new(ActionDeployment)); err != nil {
+			return err
+		}
+		_, err = db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
+
+// getOrCreateEnvironment returns the environment of the repository with the given name,
+// it is created without protection rules if the workflows name it first
+func getOrCreateEnvironment(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env, err := GetEnvironmentByName(ctx, repoID, name)
+	if err == nil || !errors.Is(err, util.ErrNotExist) {
+		return env, err
+	}
+	env = &ActionEnvironment{RepoID: repoID, Name: name}
+	return env, CreateEnvironment(ctx, env)
+}
+
+// createDeployment records the deployment of the job, which is now waiting, to its environment.
+// The job fails if its ref cannot deploy to the environment.
+func createDeployment(ctx context.Context, job *ActionRunJob) error {
+	if err := job.LoadRun(ctx); err != nil {
+		return err
+	}
+	env, err := getOrCreateEnvironment(ctx, job.RepoID, job.Environment)
+	if err != nil {
+		return fmt.Errorf("environment %q: %w", job.Environment, err)
+	}
+
+	now := timeutil.TimeStampNow()
+	deployment := &ActionDeployment{
+		RepoID:        job.RepoID,
+		EnvironmentID: env.ID,
+		RunID:         job.RunID,
+		JobID:         job.ID,
+		Attempt:       job.Attempt + 1,
+		Ref:           job.Run.Ref,
+		CommitSHA:     job.CommitSHA,
+		StartAfter:    now.AddDuration(time.Duration(env.WaitTimer) * time.Minute),
+	}
+	switch {
+	case !env.CanDeployRef(job.Run.Ref):
+		deployment.Status = DeploymentStatusRejected
+	case len(env.ReviewerIDs) > 0:
+		deployment.Status = DeploymentStatusWaiting
+	case deployment.StartAfter > now:
+		deployment.Status = DeploymentStatusApproved
+	default:
+		deployment.Status = DeploymentStatusQueued
+	}
+	if err := db.Insert(ctx, deployment); err != nil {
+		return err
+	}
+
+	if deployment.Status == DeploymentStatusRejected {
+		job.Status = StatusFailure
+		job.Stopped = now
+		_, err := UpdateRunJobWithoutNotification(ctx, job, nil, "status", "stopped")
+		return err
+	}
+	return nil
+}
+
+// createDeploymentsOfRun records the deployments of the waiting jobs of the run which
+// have an environment and no deployment for their next attempt yet
+func createDeploymentsOfRun(ctx context.Context, run *ActionRun) error {
+	jobs, err := GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if job.Environment == "" || !job.Status.IsWaiting() {
+			continue
+		}
+		exists, err := db.GetEngine(ctx).Where("job_id = ? AND attempt = ?", job.ID, job.Attempt+1).Exist(new(ActionDeployment))
+		if err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+		job.Run = run
+		if err := createDeployment(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deploymentCond matches the jobs which have no environment, or which can deploy to it
+func deploymentCond() builder.Cond {
+	return builder.Eq{"action_run_job.environment": ""}.Or(builder.Exists(builder.Select("id").From("action_deployment").
+		Where(builder.Eq{
+			"action_deployment.job_id": builder.Expr("action_run_job.id"),
+			"action_deployment.status": DeploymentStatusQueued,
+		}).
+		And(builder.Expr("action_deployment.attempt = action_run_job.attempt + 1"))))
+}
+
+// GetDeploymentByID returns the deployment of the repository with the given id
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	deployment, has, err := db.Get[ActionDeployment](ctx, builder.Eq{"id": id, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("deployment %d of repository %d", id, repoID)
+	}
+	return deployment, nil
+}
+
+// FindDeploymentsOptions are the options to list the deployments of an environment
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	EnvironmentID int64
+	Status        DeploymentStatus
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"environment_id": opts.EnvironmentID})
+	if opts.Status != 0 {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// LoadJob loads the job of the deployment
+func (deployment *ActionDeployment) LoadJob(ctx context.Context) error {
+	if deployment.Job != nil {
+		return nil
+	}
+	job, err := GetRunJobByID(ctx, deployment.JobID)
+	if err != nil {
+		return err
+	}
+	deployment.Job = job
+	return nil
+}
+
+// queueDeployment lets the runners pick the job of the deployment
+func queueDeployment(ctx context.Context, deployment *ActionDeployment) error {
+	deployment.Status = DeploymentStatusQueued
+	if _, err := db.GetEngine(ctx).ID(deployment.ID).Cols("status").Update(deployment); err != nil {
+		return err
+	}
+	if err := deployment.LoadJob(ctx); err != nil {
+		return err
+	}
+	return IncreaseTaskVersion(ctx, deployment.Job.OwnerID, deployment.RepoID)
+}
+
+// ReviewDeployment approves or rejects the deployment, which must be waiting for a review.
+// Failing the job of a rejected deployment is left to the caller.
+func ReviewDeployment(ctx context.Context, deployment *ActionDeployment, reviewerID int64, approve bool) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		deployment.ReviewerID = reviewerID
+		deployment.Status = DeploymentStatusRejected
+		if approve {
+			deployment.Status = DeploymentStatusApproved
+		}
+		n, err := db.GetEngine(ctx).ID(deployment.ID).Where("status = ?", DeploymentStatusWaiting).
+			Cols("status", "reviewer_id").Update(deployment)
+		if err != nil {
+			return err
+		} else if n == 0 {
+			return util.NewInvalidArgumentErrorf("deployment %d is not waiting for a review", deployment.ID)
+		}
+
+		if approve && deployment.StartAfter <= timeutil.TimeStampNow() {
+			return queueDeployment(ctx, deployment)
+		}
+		return nil
+	})
+}
+
+// QueueDeployments queues the approved deployments whose wait timer is over
+func QueueDeployments(ctx context.Context) error {
+	deployments := make([]*ActionDeployment, 0, 10)
+	if err := db.GetEngine(ctx).Where("status = ? AND start_after <= ?", DeploymentStatusApproved, timeutil.TimeStampNow()).
+		Find(&deployments); err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if err := queueDeployment(ctx, deployment); err != nil {
+			return fmt.Errorf("queue deployment %d: %w", deployment.ID, err)
+		}
+	}
+	return nil
+}
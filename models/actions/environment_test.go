@@ -0,0 +1,143 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEnvironment(t *testing.T) {
+	require.NoError(t, ValidateEnvironment(&ActionEnvironment{Name: "production", WaitTimer: 10, BranchPatterns: []string{"main", "release/*"}}))
+
+	for _, env := range []*ActionEnvironment{
+		{Name: ""},
+		{Name: " production"},
+		{Name: "production", WaitTimer: -1},
+		{Name: "production", WaitTimer: MaxEnvironmentWaitTimer + 1},
+		{Name: "production", BranchPatterns: []string{"release/[a-"}},
+	} {
+		assert.ErrorIs(t, ValidateEnvironment(env), util.ErrInvalidArgument, "%+v", env)
+	}
+}
+
+func TestActionEnvironment_CanDeployRef(t *testing.T) {
+	env := &ActionEnvironment{}
+	assert.True(t, env.CanDeployRef("refs/heads/feature"))
+	assert.True(t, env.CanDeployRef("refs/tags/v1.0"))
+
+	env.BranchPatterns = []string{"main", "release/*"}
+	assert.True(t, env.CanDeployRef("refs/heads/main"))
+	assert.True(t, env.CanDeployRef("refs/heads/release/1.0"))
+	assert.False(t, env.CanDeployRef("refs/heads/release/1.0/fix"))
+	assert.False(t, env.CanDeployRef("refs/heads/feature"))
+	assert.False(t, env.CanDeployRef("refs/tags/main"))
+}
+
+func TestDeployments(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	newWaitingJob := func(t *testing.T, environment string) *ActionRunJob {
+		t.Helper()
+		job := &ActionRunJob{
+			RunID:       793,
+			RepoID:      4,
+			OwnerID:     1,
+			CommitSHA:   "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+			Name:        "deploy",
+			JobID:       "deploy",
+			Status:      StatusBlocked,
+			Environment: environment,
+		}
+		require.NoError(t, db.Insert(ctx, job))
+		job.Status = StatusWaiting
+		_, err := UpdateRunJobWithoutNotification(ctx, job, nil, "status")
+		require.NoError(t, err)
+		return job
+	}
+	getDeployment := func(t *testing.T, job *ActionRunJob) *ActionDeployment {
+		t.Helper()
+		return unittest.AssertExistsAndLoadBean(t, &ActionDeployment{JobID: job.ID, Attempt: job.Attempt + 1})
+	}
+	canBePicked := func(t *testing.T, job *ActionRunJob) bool {
+		t.Helper()
+		exists, err := db.GetEngine(ctx).Where("action_run_job.id = ?", job.ID).And(deploymentCond()).Exist(new(ActionRunJob))
+		require.NoError(t, err)
+		return exists
+	}
+
+	t.Run("Unprotected", func(t *testing.T) {
+		job := newWaitingJob(t, "staging")
+		env, err := GetEnvironmentByName(ctx, 4, "staging")
+		require.NoError(t, err)
+
+		deployment := getDeployment(t, job)
+		assert.Equal(t, env.ID, deployment.EnvironmentID)
+		assert.Equal(t, DeploymentStatusQueued, deployment.Status)
+		assert.Equal(t, "refs/heads/master", deployment.Ref)
+		assert.True(t, canBePicked(t, job))
+	})
+
+	t.Run("Reviewers", func(t *testing.T) {
+		require.NoError(t, CreateEnvironment(ctx, &ActionEnvironment{RepoID: 4, Name: "production", ReviewerIDs: []int64{2}}))
+		job := newWaitingJob(t, "production")
+
+		deployment := getDeployment(t, job)
+		assert.Equal(t, DeploymentStatusWaiting, deployment.Status)
+		assert.False(t, canBePicked(t, job))
+
+		require.NoError(t, ReviewDeployment(ctx, deployment, 2, true))
+		deployment = getDeployment(t, job)
+		assert.Equal(t, DeploymentStatusQueued, deployment.Status)
+		assert.EqualValues(t, 2, deployment.ReviewerID)
+		assert.True(t, canBePicked(t, job))
+
+		assert.ErrorIs(t, ReviewDeployment(ctx, deployment, 2, false), util.ErrInvalidArgument)
+	})
+
+	t.Run("WaitTimer", func(t *testing.T) {
+		require.NoError(t, CreateEnvironment(ctx, &ActionEnvironment{RepoID: 4, Name: "later", WaitTimer: 10}))
+		job := newWaitingJob(t, "later")
+
+		deployment := getDeployment(t, job)
+		assert.Equal(t, DeploymentStatusApproved, deployment.Status)
+		require.NoError(t, QueueDeployments(ctx))
+		assert.Equal(t, DeploymentStatusApproved, getDeployment(t, job).Status)
+
+		_, err := db.GetEngine(ctx).ID(deployment.ID).Cols("start_after").Update(&ActionDeployment{StartAfter: deployment.Created})
+		require.NoError(t, err)
+		require.NoError(t, QueueDeployments(ctx))
+		assert.Equal(t, DeploymentStatusQueued, getDeployment(t, job).Status)
+		assert.True(t, canBePicked(t, job))
+	})
+
+	t.Run("BranchPatterns", func(t *testing.T) {
+		require.NoError(t, CreateEnvironment(ctx, &ActionEnvironment{RepoID: 4, Name: "release", BranchPatterns: []string{"release/*"}}))
+		job := newWaitingJob(t, "release")
+
+		assert.Equal(t, DeploymentStatusRejected, getDeployment(t, job).Status)
+		job = unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: job.ID})
+		assert.Equal(t, StatusFailure, job.Status)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// the job of the deployment to production is still waiting for a runner
+		env, err := GetEnvironmentByName(ctx, 4, "production")
+		require.NoError(t, err)
+		assert.ErrorIs(t, DeleteEnvironment(ctx, env), util.ErrInvalidArgument)
+
+		env, err = GetEnvironmentByName(ctx, 4, "release")
+		require.NoError(t, err)
+		require.NoError(t, DeleteEnvironment(ctx, env))
+		unittest.AssertNotExistsBean(t, &ActionEnvironment{ID: env.ID})
+		unittest.AssertNotExistsBean(t, &ActionDeployment{EnvironmentID: env.ID})
+	})
+}
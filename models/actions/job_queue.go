@@ -0,0 +1,131 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// QueuedJob is a job waiting for a runner, with what the runners which can be used by its repository offer
+type QueuedJob struct {
+	Job *ActionRunJob
+	// the labels of the online runners which can be used by the repository of the job
+	OnlineLabels []string
+	// the number of registered runners, online or not, which have the labels of the job
+	MatchingRunners int
+	// the number of online runners which have the labels of the job
+	MatchingOnlineRunners int
+}
+
+// QueuedSince returns when the job started waiting for a runner
+func (q *QueuedJob) QueuedSince() timeutil.TimeStamp {
+	return q.Job.Updated
+}
+
+// MissingLabels returns the labels of the job which no online runner offers
+func (q *QueuedJob) MissingLabels() []string {
+	missing := make([]string, 0, len(q.Job.RunsOn))
+	for _, label := range q.Job.RunsOn {
+		if !slices.Contains(q.OnlineLabels, label) {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// HasNoMatchingRunner returns whether no registered runner could ever pick the job
+func (q *QueuedJob) HasNoMatchingRunner() bool {
+	return q.MatchingRunners == 0
+}
+
+// Diagnosis tells whether an online runner can pick the job, only an offline one, or none
+func (q *QueuedJob) Diagnosis() string {
+	switch {
+	case q.MatchingOnlineRunners > 0:
+		return "runner_available"
+	case q.MatchingRunners > 0:
+		return "no_online_runner"
+	default:
+		return "no_matching_runner"
+	}
+}
+
+type FindQueuedJobsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+}
+
+func (opts FindQueuedJobsOptions) ToConds() builder.Cond {
+	return FindRunJobOptions{
+		OwnerID:  opts.OwnerID,
+		RepoID:   opts.RepoID,
+		Statuses: []Status{StatusWaiting},
+	}.ToConds()
+}
+
+func (opts FindQueuedJobsOptions) ToOrders() string {
+	return "updated ASC, id ASC"
+}
+
+// canPickJobOf returns whether the runner can be used by the repository of the job
+func (r *ActionRunner) canPickJobOf(job *ActionRunJob) bool {
+	if r.RepoID != 0 {
+		return r.RepoID == job.RepoID
+	}
+	return r.OwnerID == 0 || r.OwnerID == job.OwnerID
+}
+
+// DiagnoseQueuedJobs matches the waiting jobs with the runners which can be used by their repositories
+func DiagnoseQueuedJobs(ctx context.Context, jobs []*ActionRunJob) ([]*QueuedJob, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	ownerIDs := make(container.Set[int64])
+	repoIDs := make(container.Set[int64])
+	for _, job := range jobs {
+		ownerIDs.Add(job.OwnerID)
+		repoIDs.Add(job.RepoID)
+	}
+	runners := make([]*ActionRunner, 0, 10)
+	if err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": 0, "repo_id": 0}.
+		Or(builder.In("owner_id", ownerIDs.Values())).
+		Or(builder.In("repo_id", repoIDs.Values()))).
+		Find(&runners); err != nil {
+		return nil, err
+	}
+
+	queued := make([]*QueuedJob, 0, len(jobs))
+	for _, job := range jobs {
+		q := &QueuedJob{Job: job}
+		labels := make(container.Set[string])
+		for _, runner := range runners {
+			if !runner.canPickJobOf(job) {
+				continue
+			}
+			online := runner.IsOnline()
+			if online {
+				labels.AddMultiple(runner.AgentLabels...)
+			}
+			if job.ItRunsOn(runner.AgentLabels) {
+				q.MatchingRunners++
+				if online {
+					q.MatchingOnlineRunners++
+				}
+			}
+		}
+		q.OnlineLabels = labels.Values()
+		slices.Sort(q.OnlineLabels)
+		queued = append(queued, q)
+	}
+	return queued, nil
+}
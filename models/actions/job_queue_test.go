@@ -0,0 +1,57 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseQueuedJobs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	// the runners of the fixtures are all offline
+	labels := []string{"docker", "gpu"}
+	runner, err := RegisterRunner(ctx, 0, 0, "0123456789012345678901234567890123456789", &labels, "online", "v1.2.3")
+	require.NoError(t, err)
+	_, err = db.GetEngine(ctx).ID(runner.ID).Cols("last_online").Update(&ActionRunner{LastOnline: timeutil.TimeStampNow()})
+	require.NoError(t, err)
+
+	queued, err := DiagnoseQueuedJobs(ctx, []*ActionRunJob{
+		{RepoID: 1, OwnerID: 2, RunsOn: []string{"docker"}},
+		{RepoID: 1, OwnerID: 2, RunsOn: []string{"woop"}},
+		{RepoID: 1, OwnerID: 2, RunsOn: []string{"docker", "arm64"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, queued, 3)
+
+	// three global runners, the runner of the repository and the new online runner
+	assert.Equal(t, 4, queued[0].MatchingRunners)
+	assert.Equal(t, 1, queued[0].MatchingOnlineRunners)
+	assert.Equal(t, []string{"docker", "gpu"}, queued[0].OnlineLabels)
+	assert.Empty(t, queued[0].MissingLabels())
+	assert.Equal(t, "runner_available", queued[0].Diagnosis())
+
+	assert.Equal(t, 1, queued[1].MatchingRunners)
+	assert.Equal(t, 0, queued[1].MatchingOnlineRunners)
+	assert.Equal(t, []string{"woop"}, queued[1].MissingLabels())
+	assert.Equal(t, "no_online_runner", queued[1].Diagnosis())
+
+	assert.True(t, queued[2].HasNoMatchingRunner())
+	assert.Equal(t, []string{"arm64"}, queued[2].MissingLabels())
+	assert.Equal(t, "no_matching_runner", queued[2].Diagnosis())
+
+	// the runners of the owner can be used, not those of another repository
+	queued, err = DiagnoseQueuedJobs(ctx, []*ActionRunJob{{RepoID: 2, OwnerID: 35, RunsOn: []string{"docker"}}})
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, 5, queued[0].MatchingRunners)
+}
@@ -142,6 +142,14 @@ func TestTranslatePreExecutionError(t *testing.T) {
 			},
 			expected: "Unable to evaluate `with` of job blocked_job: unknown error.",
 		},
+		{
+			name: "ErrorCodeNoMatchingRunner",
+			run: &ActionRun{
+				PreExecutionErrorCode:    ErrorCodeNoMatchingRunner,
+				PreExecutionErrorDetails: []any{"build", "ubuntu-latest, gpu"},
+			},
+			expected: "Job build was abandoned because no registered runner has its labels (ubuntu-latest, gpu).",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
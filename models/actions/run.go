@@ -83,6 +83,9 @@ type ActionRun struct {
 	PreExecutionError        string `xorm:"LONGTEXT"` // deprecated: replaced with PreExecutionErrorCode and PreExecutionErrorDetails for better i18n
 	PreExecutionErrorCode    PreExecutionError
 	PreExecutionErrorDetails []any `xorm:"JSON LONGTEXT"`
+
+	// the deployment environments of the jobs by job id, used by InsertRunJobs
+	JobEnvironments map[string]string `xorm:"-"`
 }
 
 func init() {
@@ -351,7 +354,7 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 // Adds `ActionRunJob` instances from `SingleWorkflows` to an existing ActionRun.
 func InsertRunJobs(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWorkflow) error {
 	runJobs := make([]*ActionRunJob, 0, len(jobs))
-	var hasWaiting bool
+	var hasWaiting, hasEnvironment bool
 	for _, v := range jobs {
 		id, job := v.Job()
 		status := StatusFailure
@@ -359,6 +362,7 @@ func InsertRunJobs(ctx context.Context, run *ActionRun, jobs []*jobparser.Single
 		needs := []string{}
 		name := run.Title
 		runsOn := []string{}
+		environment := ""
 		if job != nil {
 			needs = job.Needs()
 			if err := v.SetJob(id, job.EraseNeeds()); err != nil {
@@ -374,6 +378,8 @@ func InsertRunJobs(ctx context.Context, run *ActionRun, jobs []*jobparser.Single
 			}
 			name, _ = util.SplitStringAtByteN(job.Name, 255)
 			runsOn = job.RunsOn()
+			environment, _ = util.SplitStringAtByteN(run.JobEnvironments[id], 255)
+			hasEnvironment = hasEnvironment || environment != ""
 		}
 		runJobs = append(runJobs, &ActionRunJob{
 			RunID:             run.ID,
@@ -386,6 +392,7 @@ func InsertRunJobs(ctx context.Context, run *ActionRun, jobs []*jobparser.Single
 			JobID:             id,
 			Needs:             needs,
 			RunsOn:            runsOn,
+			Environment:       environment,
 			Status:            status,
 		})
 	}
@@ -403,6 +410,10 @@ func InsertRunJobs(ctx context.Context, run *ActionRun, jobs []*jobparser.Single
 		}
 	}
 
+	if hasEnvironment {
+		return createDeploymentsOfRun(ctx, run)
+	}
+
 	return nil
 }
 
@@ -34,6 +34,7 @@ type ActionRunJob struct {
 	JobID             string   `xorm:"VARCHAR(255)"` // job id in workflow, not job's id
 	Needs             []string `xorm:"JSON TEXT"`
 	RunsOn            []string `xorm:"JSON TEXT"`
+	Environment       string   `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"` // the deployment environment of the job, if any
 	TaskID            int64    // the latest task of the job
 	Status            Status   `xorm:"index"`
 	Started           timeutil.TimeStamp
@@ -165,6 +166,12 @@ func UpdateRunJobWithoutNotification(ctx context.Context, job *ActionRunJob, con
 		if err := IncreaseTaskVersion(ctx, job.OwnerID, job.RepoID); err != nil {
 			return 0, err
 		}
+		// the job waits for its deployment to be queued
+		if job.Environment != "" {
+			if err := createDeployment(ctx, job); err != nil {
+				return 0, err
+			}
+		}
 	}
 
 	if job.RunID == 0 {
@@ -50,6 +50,7 @@ type ActionRunner struct {
 	Description string                 `xorm:"TEXT"`
 	Base        int                    // 0 native 1 docker 2 virtual machine
 	RepoRange   string                 // glob match which repositories could use this runner
+	Ephemeral   bool                   `xorm:"NOT NULL DEFAULT false"` // picks a single task and is deleted once it is done
 
 	Token     string `xorm:"-"`
 	TokenHash string `xorm:"UNIQUE"` // sha256 of token
@@ -98,7 +99,7 @@ func (r *ActionRunner) BelongsToOwnerType() types.OwnerType {
 	return types.OwnerTypeSystemGlobal
 }
 
-// if the logic here changed, you should also modify FindRunnerOptions.ToCond
+// if the logic here changed, you should also modify FindRunnerOptions.ToCond and CountRunnersByOwnerAndState
 func (r *ActionRunner) Status() runnerv1.RunnerStatus {
 	if time.Since(r.LastOnline.AsTime()) > RunnerOfflineTime {
 		return runnerv1.RunnerStatus_RUNNER_STATUS_OFFLINE
@@ -297,6 +298,33 @@ func DeleteRunner(ctx context.Context, r *ActionRunner) error {
 	return err
 }
 
+// DeleteEphemeralRunner deletes the runner if it is ephemeral, which invalidates its token.
+func DeleteEphemeralRunner(ctx context.Context, r *ActionRunner) error {
+	if !r.Ephemeral {
+		return nil
+	}
+	if err := DeleteRunner(ctx, r); err != nil {
+		return err
+	}
+	log.Info("Deleted ephemeral runner [ID: %d, Name: %s]", r.ID, r.Name)
+	return nil
+}
+
+// DeleteDoneEphemeralRunners deletes the ephemeral runners whose task is done but which
+// were not deleted when it ended, e.g. because they never reported the end of the task.
+func DeleteDoneEphemeralRunners(ctx context.Context) error {
+	cond := builder.Eq{"ephemeral": true}.And(builder.Exists(builder.Select("id").From("action_task").
+		Where(builder.Expr("action_task.runner_id = action_runner.id")).
+		And(builder.In("action_task.status", DoneStatuses()))))
+
+	return db.Iterate(ctx, cond, func(ctx context.Context, r *ActionRunner) error {
+		if err := DeleteEphemeralRunner(ctx, r); err != nil {
+			return fmt.Errorf("DeleteDoneEphemeralRunners: %w", err)
+		}
+		return nil
+	})
+}
+
 // CreateRunner creates new runner.
 func CreateRunner(ctx context.Context, t *ActionRunner) error {
 	if t.OwnerID != 0 && t.RepoID != 0 {
@@ -13,6 +13,7 @@ import (
 	"forgejo.org/models/unittest"
 	"forgejo.org/modules/timeutil"
 
+	gouuid "github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -141,6 +142,37 @@ func TestDeleteOfflineRunnersErrorOnInvalidOlderThanValue(t *testing.T) {
 	require.Error(t, DeleteOfflineRunners(db.DefaultContext, timeutil.TimeStampNow(), false))
 }
 
+func TestDeleteDoneEphemeralRunners(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newRunner := func(t *testing.T, ephemeral bool, taskStatus Status) *ActionRunner {
+		t.Helper()
+		runner := &ActionRunner{UUID: gouuid.NewString(), Name: "runner", Ephemeral: ephemeral}
+		runner.GenerateToken()
+		require.NoError(t, CreateRunner(db.DefaultContext, runner))
+		if taskStatus != StatusUnknown {
+			require.NoError(t, db.Insert(db.DefaultContext, &ActionTask{RunnerID: runner.ID, Status: taskStatus, TokenHash: runner.UUID}))
+		}
+		return runner
+	}
+
+	done := newRunner(t, true, StatusSuccess)
+	running := newRunner(t, true, StatusRunning)
+	idle := newRunner(t, true, StatusUnknown)
+	persistent := newRunner(t, false, StatusFailure)
+
+	require.NoError(t, DeleteDoneEphemeralRunners(db.DefaultContext))
+
+	unittest.AssertNotExistsBean(t, &ActionRunner{ID: done.ID})
+	unittest.AssertExistsAndLoadBean(t, &ActionRunner{ID: running.ID})
+	unittest.AssertExistsAndLoadBean(t, &ActionRunner{ID: idle.ID})
+	unittest.AssertExistsAndLoadBean(t, &ActionRunner{ID: persistent.ID})
+
+	// only ephemeral runners are deleted
+	require.NoError(t, DeleteEphemeralRunner(db.DefaultContext, persistent))
+	unittest.AssertExistsAndLoadBean(t, &ActionRunner{ID: persistent.ID})
+}
+
 func TestRunnerEditable(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -0,0 +1,85 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"forgejo.org/models/db"
+
+	"xorm.io/builder"
+)
+
+const (
+	RunnerStateOffline = "offline"
+	RunnerStateIdle    = "idle"
+	RunnerStateBusy    = "busy"
+)
+
+// RunnerStateCount contains the number of runners of an owner in a state
+type RunnerStateCount struct {
+	OwnerName string
+	State     string
+	Count     int64
+}
+
+type jobStatusCount struct {
+	Status Status
+	Count  int64
+}
+
+// CountRunJobsByStatus returns the number of jobs for every status, including those without any job
+func CountRunJobsByStatus(ctx context.Context) (map[Status]int64, error) {
+	var rows []jobStatusCount
+	if err := db.GetEngine(ctx).Table("action_run_job").
+		Select("status, COUNT(*) AS count").
+		GroupBy("status").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[Status]int64, len(statusNames))
+	for status := range statusNames {
+		counts[status] = 0
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// CountRunnersByOwnerAndState returns the number of offline, idle and busy runners of every owner.
+// An online runner is busy when one of its tasks is running. The runners of a repository
+// belong to the owner of the repository and the global runners to an empty owner name.
+func CountRunnersByOwnerAndState(ctx context.Context) ([]*RunnerStateCount, error) {
+	// if the logic here changed, you should also modify ActionRunner.Status
+	state := fmt.Sprintf("CASE WHEN action_runner.last_online <= %d THEN '%s' "+
+		"WHEN EXISTS (SELECT id FROM action_task WHERE action_task.runner_id = action_runner.id AND action_task.status = %d) THEN '%s' "+
+		"ELSE '%s' END AS state",
+		time.Now().Add(-RunnerOfflineTime).Unix(), RunnerStateOffline, int(StatusRunning), RunnerStateBusy, RunnerStateIdle)
+	runnerStates := builder.Select("COALESCE(repository.owner_name, `user`.name, '') AS owner_name", state).
+		From("action_runner").
+		LeftJoin("repository", "repository.id = action_runner.repo_id").
+		LeftJoin("`user`", "`user`.id = action_runner.owner_id").
+		Where(builder.IsNull{"action_runner.deleted"}.Or(builder.Eq{"action_runner.deleted": 0}))
+
+	var result []*RunnerStateCount
+	if err := db.GetEngine(ctx).SQL(builder.Select("owner_name", "state", "COUNT(*) AS count").
+		From(runnerStates, "runner_state").
+		GroupBy("owner_name, state")).
+		Find(&result); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OwnerName != result[j].OwnerName {
+			return result[i].OwnerName < result[j].OwnerName
+		}
+		return result[i].State < result[j].State
+	})
+	return result, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountRunJobsByStatus(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	counts, err := CountRunJobsByStatus(db.DefaultContext)
+	require.NoError(t, err)
+	assert.Len(t, counts, len(statusNames))
+	assert.EqualValues(t, 6, counts[StatusSuccess])
+	assert.EqualValues(t, 2, counts[StatusFailure])
+	assert.EqualValues(t, 6, counts[StatusWaiting])
+	assert.EqualValues(t, 1, counts[StatusRunning])
+	assert.EqualValues(t, 0, counts[StatusBlocked])
+}
+
+func TestCountRunnersByOwnerAndState(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	// the runners of the fixtures are all offline
+	counts, err := CountRunnersByOwnerAndState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*RunnerStateCount{
+		{OwnerName: "", State: RunnerStateOffline, Count: 3},
+		{OwnerName: "private_org35", State: RunnerStateOffline, Count: 2},
+		{OwnerName: "user1", State: RunnerStateOffline, Count: 2},
+		{OwnerName: "user2", State: RunnerStateOffline, Count: 1},
+	}, counts)
+
+	setOnline := func(t *testing.T, ids ...int64) {
+		t.Helper()
+		_, err := db.GetEngine(ctx).In("id", ids).Cols("last_online").Update(&ActionRunner{LastOnline: timeutil.TimeStampNow()})
+		require.NoError(t, err)
+	}
+	setOnline(t, 10000004, 10000005)
+	_, err = db.GetEngine(ctx).ID(47).Cols("runner_id").Update(&ActionTask{RunnerID: 10000004})
+	require.NoError(t, err)
+
+	counts, err = CountRunnersByOwnerAndState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*RunnerStateCount{
+		{OwnerName: "", State: RunnerStateOffline, Count: 3},
+		{OwnerName: "private_org35", State: RunnerStateOffline, Count: 2},
+		{OwnerName: "user1", State: RunnerStateBusy, Count: 1},
+		{OwnerName: "user1", State: RunnerStateIdle, Count: 1},
+		{OwnerName: "user2", State: RunnerStateOffline, Count: 1},
+	}, counts)
+}
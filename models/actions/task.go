@@ -53,6 +53,9 @@ type ActionTask struct {
 
 	Created timeutil.TimeStamp `xorm:"created"`
 	Updated timeutil.TimeStamp `xorm:"updated index"`
+
+	// when the job started waiting for a runner, only known when the task is created
+	QueuedSince timeutil.TimeStamp `xorm:"-"`
 }
 
 var successfulTokenTaskCache *lru.Cache[string, any]
@@ -314,7 +317,7 @@ func GetAvailableJobsForRunner(e db.Engine, runner *ActionRunner) ([]*ActionRunJ
 	}
 
 	var jobs []*ActionRunJob
-	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).Asc("updated", "id").Find(&jobs); err != nil {
+	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).And(deploymentCond()).Asc("updated", "id").Find(&jobs); err != nil {
 		return nil, err
 	}
 	return jobs, nil
@@ -365,6 +368,7 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 		OwnerID:           job.OwnerID,
 		CommitSHA:         job.CommitSHA,
 		IsForkPullRequest: job.IsForkPullRequest,
+		QueuedSince:       job.Updated,
 	}
 	task.GenerateToken()
 
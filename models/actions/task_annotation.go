@@ -0,0 +1,134 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+	webhook_module "forgejo.org/modules/webhook"
+
+	"xorm.io/builder"
+)
+
+// MaxTaskAnnotations is the maximum number of annotations of a task, the next ones are dropped
+const MaxTaskAnnotations = 50
+
+type AnnotationLevel string
+
+const (
+	AnnotationLevelNotice  AnnotationLevel = "notice"
+	AnnotationLevelWarning AnnotationLevel = "warning"
+	AnnotationLevelError   AnnotationLevel = "error"
+)
+
+// ActionTaskAnnotation is a notice, warning or error written by a step of an ActionTask,
+// optionally about some lines of a file of the repository
+type ActionTaskAnnotation struct {
+	ID          int64
+	TaskID      int64           `xorm:"INDEX"`
+	Task        *ActionTask     `xorm:"-"`
+	RepoID      int64           `xorm:"INDEX(repo_commit)"`
+	CommitSHA   string          `xorm:"VARCHAR(64) INDEX(repo_commit)"`
+	Level       AnnotationLevel `xorm:"VARCHAR(16)"`
+	Title       string          `xorm:"VARCHAR(255)"`
+	Message     string          `xorm:"TEXT"`
+	Path        string          `xorm:"TEXT"`
+	StartLine   int64
+	EndLine     int64
+	StartColumn int64
+	EndColumn   int64
+	Created     timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskAnnotation))
+}
+
+// Lines returns the lines of the file the annotation is about, 0 for both when it is not about lines
+func (a *ActionTaskAnnotation) Lines() (start, end int64) {
+	if a.StartLine <= 0 {
+		return 0, 0
+	}
+	if a.EndLine < a.StartLine {
+		return a.StartLine, a.StartLine
+	}
+	return a.StartLine, a.EndLine
+}
+
+type TaskAnnotationList []*ActionTaskAnnotation
+
+// LoadTasks loads the tasks of the annotations, with their job and the run of the job and its repository
+func (annotations TaskAnnotationList) LoadTasks(ctx context.Context) error {
+	taskIDs := container.FilterSlice(annotations, func(a *ActionTaskAnnotation) (int64, bool) {
+		return a.TaskID, a.Task == nil
+	})
+	tasks := make(map[int64]*ActionTask, len(taskIDs))
+	if err := db.GetEngine(ctx).In("id", taskIDs).Find(&tasks); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := task.LoadJob(ctx); err != nil {
+			return err
+		}
+		if err := task.Job.LoadAttributes(ctx); err != nil {
+			return err
+		}
+	}
+	for _, a := range annotations {
+		if a.Task == nil {
+			a.Task = tasks[a.TaskID]
+		}
+	}
+	return nil
+}
+
+// InsertTaskAnnotation saves an annotation of the task.
+// It returns false when the annotation was dropped because the task already has too many.
+func InsertTaskAnnotation(ctx context.Context, annotation *ActionTaskAnnotation) (bool, error) {
+	var inserted bool
+	return inserted, db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("task_id=?", annotation.TaskID).Count(new(ActionTaskAnnotation))
+		if err != nil {
+			return err
+		}
+		if count >= MaxTaskAnnotations {
+			return nil
+		}
+		inserted = true
+		return db.Insert(ctx, annotation)
+	})
+}
+
+// GetTaskAnnotations returns the annotations of the task in the order they were written
+func GetTaskAnnotations(ctx context.Context, taskID int64) (TaskAnnotationList, error) {
+	var annotations TaskAnnotationList
+	return annotations, db.GetEngine(ctx).Where("task_id=?", taskID).OrderBy("id ASC").Find(&annotations)
+}
+
+// FindPullRequestAnnotations returns the annotations about the files of a commit written by the last attempt
+// of the jobs of the runs triggered by pull request events
+func FindPullRequestAnnotations(ctx context.Context, repoID int64, commitSHA string) (TaskAnnotationList, error) {
+	var annotations TaskAnnotationList
+	return annotations, db.GetEngine(ctx).
+		Select("`action_task_annotation`.*").
+		Join("INNER", "action_run_job", "action_run_job.task_id = action_task_annotation.task_id").
+		Join("INNER", "action_run", "action_run.id = action_run_job.run_id").
+		Where(builder.Eq{
+			"action_task_annotation.repo_id":    repoID,
+			"action_task_annotation.commit_sha": commitSHA,
+		}).
+		And(builder.Neq{"action_task_annotation.path": ""}).
+		And(builder.In("action_run.event",
+			webhook_module.HookEventPullRequest,
+			webhook_module.HookEventPullRequestSync,
+			webhook_module.HookEventPullRequestAssign,
+			webhook_module.HookEventPullRequestMilestone,
+			webhook_module.HookEventPullRequestLabel,
+		)).
+		OrderBy("action_task_annotation.id ASC").
+		Find(&annotations)
+}
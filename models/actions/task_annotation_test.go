@@ -0,0 +1,84 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionTaskAnnotationLines(t *testing.T) {
+	for _, tt := range []struct {
+		startLine, endLine int64
+		wantStart, wantEnd int64
+	}{
+		{0, 0, 0, 0},
+		{0, 5, 0, 0},
+		{3, 0, 3, 3},
+		{3, 5, 3, 5},
+	} {
+		start, end := (&ActionTaskAnnotation{StartLine: tt.startLine, EndLine: tt.endLine}).Lines()
+		assert.Equal(t, tt.wantStart, start)
+		assert.Equal(t, tt.wantEnd, end)
+	}
+}
+
+func TestInsertTaskAnnotation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	for i := range MaxTaskAnnotations {
+		inserted, err := InsertTaskAnnotation(ctx, &ActionTaskAnnotation{TaskID: 47, RepoID: 4, Level: AnnotationLevelNotice, StartLine: int64(i + 1)})
+		require.NoError(t, err)
+		assert.True(t, inserted)
+	}
+	inserted, err := InsertTaskAnnotation(ctx, &ActionTaskAnnotation{TaskID: 47, RepoID: 4, Level: AnnotationLevelError})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	annotations, err := GetTaskAnnotations(ctx, 47)
+	require.NoError(t, err)
+	require.Len(t, annotations, MaxTaskAnnotations)
+	assert.EqualValues(t, 1, annotations[0].StartLine)
+	assert.EqualValues(t, MaxTaskAnnotations, annotations[MaxTaskAnnotations-1].StartLine)
+}
+
+func TestFindPullRequestAnnotations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	const commitSHA = "c2d72f548424103f01ee1dc02889c1e2bff816b0"
+	for _, a := range []*ActionTaskAnnotation{
+		{TaskID: 47, RepoID: 4, CommitSHA: commitSHA, Level: AnnotationLevelError, Path: "README.md", StartLine: 1},
+		{TaskID: 47, RepoID: 4, CommitSHA: commitSHA, Level: AnnotationLevelNotice},
+		// task 46 is not the last attempt of its job
+		{TaskID: 46, RepoID: 4, CommitSHA: commitSHA, Level: AnnotationLevelError, Path: "README.md", StartLine: 1},
+	} {
+		_, err := InsertTaskAnnotation(ctx, a)
+		require.NoError(t, err)
+	}
+
+	// run 791 was triggered by a push
+	annotations, err := FindPullRequestAnnotations(ctx, 4, commitSHA)
+	require.NoError(t, err)
+	assert.Empty(t, annotations)
+
+	_, err = db.GetEngine(ctx).ID(791).Cols("event").Update(&ActionRun{Event: "pull_request"})
+	require.NoError(t, err)
+	annotations, err = FindPullRequestAnnotations(ctx, 4, commitSHA)
+	require.NoError(t, err)
+	require.Len(t, annotations, 1)
+	assert.EqualValues(t, 47, annotations[0].TaskID)
+	assert.Equal(t, "README.md", annotations[0].Path)
+
+	require.NoError(t, annotations.LoadTasks(ctx))
+	require.NotNil(t, annotations[0].Task)
+	assert.EqualValues(t, 192, annotations[0].Task.Job.ID)
+	assert.EqualValues(t, 791, annotations[0].Task.Job.Run.ID)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+)
+
+// MaxTaskSummarySize is the maximum size of the summary of a task, the steps can't write more once it is reached
+const MaxTaskSummarySize = 1024 * 1024
+
+// ActionTaskSummary is the Markdown summary written by the steps of an ActionTask.
+// Like the outputs, it is bound to a task so that the summary of a rerun job starts empty.
+type ActionTaskSummary struct {
+	ID      int64
+	TaskID  int64              `xorm:"UNIQUE"`
+	RepoID  int64              `xorm:"INDEX"`
+	Content string             `xorm:"LONGTEXT"`
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskSummary))
+}
+
+// GetTaskSummary returns the summary of the task, empty if its steps did not write any
+func GetTaskSummary(ctx context.Context, taskID int64) (string, error) {
+	summary := &ActionTaskSummary{}
+	if _, err := db.GetEngine(ctx).Where("task_id=?", taskID).Get(summary); err != nil {
+		return "", err
+	}
+	return summary.Content, nil
+}
+
+// AppendTaskSummary adds the summary of a step after the summaries of the previous steps of the task.
+// It returns false when the summary was dropped because the summary of the task would be too large.
+func AppendTaskSummary(ctx context.Context, task *ActionTask, content string) (bool, error) {
+	var appended bool
+	return appended, db.WithTx(ctx, func(ctx context.Context) error {
+		summary := &ActionTaskSummary{}
+		has, err := db.GetEngine(ctx).Where("task_id=?", task.ID).Get(summary)
+		if err != nil {
+			return err
+		}
+		if !has {
+			if len(content) > MaxTaskSummarySize {
+				return nil
+			}
+			appended = true
+			return db.Insert(ctx, &ActionTaskSummary{
+				TaskID:  task.ID,
+				RepoID:  task.RepoID,
+				Content: content,
+			})
+		}
+
+		if len(summary.Content)+1+len(content) > MaxTaskSummarySize {
+			return nil
+		}
+		summary.Content += "\n" + content
+		appended = true
+		_, err = db.GetEngine(ctx).ID(summary.ID).Cols("content").Update(summary)
+		return err
+	})
+}
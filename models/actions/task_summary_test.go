@@ -0,0 +1,41 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendTaskSummary(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+	task := unittest.AssertExistsAndLoadBean(t, &ActionTask{ID: 47})
+
+	summary, err := GetTaskSummary(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+
+	appended, err := AppendTaskSummary(ctx, task, "# Build")
+	require.NoError(t, err)
+	assert.True(t, appended)
+	appended, err = AppendTaskSummary(ctx, task, "# Test")
+	require.NoError(t, err)
+	assert.True(t, appended)
+
+	// the summary would be larger than MaxTaskSummarySize
+	appended, err = AppendTaskSummary(ctx, task, strings.Repeat("a", MaxTaskSummarySize))
+	require.NoError(t, err)
+	assert.False(t, appended)
+
+	summary, err = GetTaskSummary(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "# Build\n# Test", summary)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of security relevant change an audit event records
+type Action string
+
+const (
+	ActionRepoCreate   Action = "repo_create"
+	ActionRepoDelete   Action = "repo_delete"
+	ActionRepoRename   Action = "repo_rename"
+	ActionRepoTransfer Action = "repo_transfer"
+
+	ActionCollaboratorAdd          Action = "collaborator_add"
+	ActionCollaboratorRemove       Action = "collaborator_remove"
+	ActionCollaboratorChangeAccess Action = "collaborator_change_access"
+
+	ActionTeamCreate       Action = "team_create"
+	ActionTeamUpdate       Action = "team_update"
+	ActionTeamDelete       Action = "team_delete"
+	ActionTeamMemberAdd    Action = "team_member_add"
+	ActionTeamMemberRemove Action = "team_member_remove"
+
+	ActionBranchProtectionUpdate Action = "branch_protection_update"
+	ActionBranchProtectionDelete Action = "branch_protection_delete"
+
+	ActionDeployKeyAdd    Action = "deploy_key_add"
+	ActionDeployKeyDelete Action = "deploy_key_delete"
+
+	ActionAccessTokenCreate Action = "access_token_create"
+	ActionAccessTokenDelete Action = "access_token_delete"
+
+	ActionTwoFactorDisable Action = "two_factor_disable"
+
+	ActionUserAdminGrant  Action = "user_admin_grant"
+	ActionUserAdminRevoke Action = "user_admin_revoke"
+
+	ActionAuthSourceCreate Action = "auth_source_create"
+	ActionAuthSourceUpdate Action = "auth_source_update"
+	ActionAuthSourceDelete Action = "auth_source_delete"
+)
+
+// Actions are all the actions that are recorded, in the order they are listed in the filters
+var Actions = []Action{
+	ActionRepoCreate, ActionRepoDelete, ActionRepoRename, ActionRepoTransfer,
+	ActionCollaboratorAdd, ActionCollaboratorRemove, ActionCollaboratorChangeAccess,
+	ActionTeamCreate, ActionTeamUpdate, ActionTeamDelete, ActionTeamMemberAdd, ActionTeamMemberRemove,
+	ActionBranchProtectionUpdate, ActionBranchProtectionDelete,
+	ActionDeployKeyAdd, ActionDeployKeyDelete,
+	ActionAccessTokenCreate, ActionAccessTokenDelete,
+	ActionTwoFactorDisable,
+	ActionUserAdminGrant, ActionUserAdminRevoke,
+	ActionAuthSourceCreate, ActionAuthSourceUpdate, ActionAuthSourceDelete,
+}
+
+// TargetType is the kind of object an audit event is about
+type TargetType string
+
+const (
+	TargetRepository       TargetType = "repository"
+	TargetUser             TargetType = "user"
+	TargetTeam             TargetType = "team"
+	TargetBranchProtection TargetType = "branch_protection"
+	TargetDeployKey        TargetType = "deploy_key"
+	TargetAccessToken      TargetType = "access_token"
+	TargetAuthSource       TargetType = "auth_source"
+)
+
+// Event is an entry of the audit log. The names are copied when the event is recorded so that
+// the event remains readable after the doer or the target were deleted or renamed.
+type Event struct {
+	ID       int64            `xorm:"pk autoincr"`
+	Action   Action           `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	DoerID   int64            `xorm:"INDEX NOT NULL"`
+	DoerName string           `xorm:"VARCHAR(255)"`
+	Doer     *user_model.User `xorm:"-"`
+	// OwnerID is the user or organization the event belongs to, zero for instance wide events
+	OwnerID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// RepoID is the repository the event belongs to, if any
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetType  TargetType         `xorm:"VARCHAR(32)"`
+	TargetID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	TargetName  string             `xorm:"VARCHAR(255)"`
+	Detail      string             `xorm:"TEXT"`
+	IPAddress   string             `xorm:"VARCHAR(64)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TableName return database table name for xorm
+func (Event) TableName() string {
+	return "audit_event"
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// LoadDoer loads the user who caused the event, it is nil if the user was deleted
+func (e *Event) LoadDoer(ctx context.Context) (err error) {
+	if e.Doer != nil || e.DoerID <= 0 {
+		return nil
+	}
+	e.Doer, err = user_model.GetUserByID(ctx, e.DoerID)
+	if user_model.IsErrUserNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// InsertEvent adds an event to the audit log
+func InsertEvent(ctx context.Context, event *Event) error {
+	return db.Insert(ctx, event)
+}
+
+// FindEventsOptions are the options to list audit events
+type FindEventsOptions struct {
+	db.ListOptions
+	// UserID lists the events caused by the user or belonging to the user
+	UserID  int64
+	OwnerID int64
+	RepoID  int64
+	DoerID  int64
+	Action  Action
+}
+
+// ToConds implements db.FindOptions
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.UserID > 0 {
+		cond = cond.And(builder.Or(
+			builder.Eq{"doer_id": opts.UserID},
+			builder.Eq{"owner_id": opts.UserID},
+			builder.Eq{"target_type": TargetUser, "target_id": opts.UserID},
+		))
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.DoerID > 0 {
+		cond = cond.And(builder.Eq{"doer_id": opts.DoerID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Eq{"action": opts.Action})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindEventsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// DeleteOldEvents deletes the audit events older than the given duration
+func DeleteOldEvents(ctx context.Context, olderThan time.Duration) error {
+	if olderThan <= 0 {
+		return nil
+	}
+
+	_, err := db.GetEngine(ctx).Where("created_unix < ?", time.Now().Add(-olderThan).Unix()).Delete(&Event{})
+	return err
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, event := range []*audit_model.Event{
+		{Action: audit_model.ActionCollaboratorAdd, DoerID: 2, OwnerID: 2, RepoID: 1, TargetType: audit_model.TargetUser, TargetID: 4},
+		{Action: audit_model.ActionTeamCreate, DoerID: 2, OwnerID: 3, TargetType: audit_model.TargetTeam, TargetID: 1},
+		{Action: audit_model.ActionUserAdminGrant, DoerID: 1, OwnerID: 5, TargetType: audit_model.TargetUser, TargetID: 5},
+		{Action: audit_model.ActionAuthSourceCreate, DoerID: 1, TargetType: audit_model.TargetAuthSource, TargetID: 1},
+	} {
+		require.NoError(t, audit_model.InsertEvent(db.DefaultContext, event))
+	}
+
+	find := func(opts audit_model.FindEventsOptions) []audit_model.Action {
+		events, err := db.Find[audit_model.Event](db.DefaultContext, opts)
+		require.NoError(t, err)
+		actions := make([]audit_model.Action, 0, len(events))
+		for _, event := range events {
+			actions = append(actions, event.Action)
+		}
+		return actions
+	}
+
+	assert.Equal(t, []audit_model.Action{
+		audit_model.ActionAuthSourceCreate, audit_model.ActionUserAdminGrant, audit_model.ActionTeamCreate, audit_model.ActionCollaboratorAdd,
+	}, find(audit_model.FindEventsOptions{}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionCollaboratorAdd}, find(audit_model.FindEventsOptions{RepoID: 1}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionTeamCreate}, find(audit_model.FindEventsOptions{OwnerID: 3}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionTeamCreate, audit_model.ActionCollaboratorAdd}, find(audit_model.FindEventsOptions{UserID: 2}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionCollaboratorAdd}, find(audit_model.FindEventsOptions{UserID: 4}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionUserAdminGrant}, find(audit_model.FindEventsOptions{UserID: 5}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionAuthSourceCreate}, find(audit_model.FindEventsOptions{DoerID: 1, Action: audit_model.ActionAuthSourceCreate}))
+}
+
+func TestDeleteOldEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	old := &audit_model.Event{Action: audit_model.ActionRepoDelete, DoerID: 2}
+	recent := &audit_model.Event{Action: audit_model.ActionRepoCreate, DoerID: 2}
+	require.NoError(t, audit_model.InsertEvent(db.DefaultContext, old))
+	require.NoError(t, audit_model.InsertEvent(db.DefaultContext, recent))
+	_, err := db.GetEngine(db.DefaultContext).ID(old.ID).Cols("created_unix").NoAutoTime().
+		Update(&audit_model.Event{CreatedUnix: timeutil.TimeStamp(time.Now().Add(-48 * time.Hour).Unix())})
+	require.NoError(t, err)
+
+	require.NoError(t, audit_model.DeleteOldEvents(db.DefaultContext, 24*time.Hour))
+	unittest.AssertNotExistsBean(t, &audit_model.Event{ID: old.ID})
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{ID: recent.ID})
+}
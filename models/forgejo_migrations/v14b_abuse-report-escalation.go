@@ -0,0 +1,38 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add assignee_id to abuse_report and create the moderation_action table",
+		Upgrade:     addAbuseReportEscalation,
+	})
+}
+
+func addAbuseReportEscalation(x *xorm.Engine) error {
+	type AbuseReport struct {
+		AssigneeID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(AbuseReport)); err != nil {
+		return err
+	}
+
+	type ModerationAction struct {
+		ID           int64              `xorm:"pk autoincr"`
+		ContentType  int                `xorm:"INDEX(content) NOT NULL"`
+		ContentID    int64              `xorm:"INDEX(content) NOT NULL"`
+		DoerID       int64              `xorm:"NOT NULL DEFAULT 0"`
+		Type         int                `xorm:"NOT NULL"`
+		TargetUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+		Details      string             `xorm:"TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+	return x.Sync(new(ModerationAction))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add action_environment and action_deployment tables, scope secrets and variables to environments",
+		Upgrade:     addActionEnvironment,
+	})
+}
+
+func addActionEnvironment(x *xorm.Engine) error {
+	type ActionEnvironment struct {
+		ID             int64              `xorm:"pk autoincr"`
+		RepoID         int64              `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name           string             `xorm:"VARCHAR(255) UNIQUE(repo_name) NOT NULL"`
+		WaitTimer      int64              `xorm:"NOT NULL DEFAULT 0"`
+		ReviewerIDs    []int64            `xorm:"JSON TEXT"`
+		BranchPatterns []string           `xorm:"JSON TEXT"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionDeployment struct {
+		ID            int64 `xorm:"pk autoincr"`
+		RepoID        int64 `xorm:"INDEX NOT NULL"`
+		EnvironmentID int64 `xorm:"INDEX NOT NULL"`
+		RunID         int64 `xorm:"NOT NULL"`
+		JobID         int64 `xorm:"INDEX NOT NULL"`
+		Attempt       int64 `xorm:"NOT NULL"`
+		Ref           string
+		CommitSHA     string
+		Status        int                `xorm:"INDEX NOT NULL"`
+		ReviewerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		StartAfter    timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		Created       timeutil.TimeStamp `xorm:"created"`
+		Updated       timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionRunJob struct {
+		Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+	if err := x.Sync(new(ActionEnvironment), new(ActionDeployment), new(ActionRunJob)); err != nil {
+		return err
+	}
+
+	// the environment becomes part of the unique constraint of the secrets and the variables
+	type Secret struct {
+		ID            int64
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	type ActionVariable struct {
+		ID            int64  `xorm:"pk autoincr"`
+		OwnerID       int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64  `xorm:"UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	if err := dropIndexIfExists(x, "secret", "UQE_secret_owner_repo_name"); err != nil {
+		return err
+	}
+	if err := dropIndexIfExists(x, "action_variable", "UQE_action_variable_owner_repo_name"); err != nil {
+		return err
+	}
+	return x.Sync(new(Secret), new(ActionVariable))
+}
@@ -0,0 +1,22 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add ephemeral to action_runner",
+		Upgrade:     addActionRunnerEphemeral,
+	})
+}
+
+func addActionRunnerEphemeral(x *xorm.Engine) error {
+	type ActionRunner struct {
+		Ephemeral bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	return x.Sync(new(ActionRunner))
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add action_task_summary and action_task_annotation tables",
+		Upgrade:     addActionTaskSummaryAndAnnotation,
+	})
+}
+
+func addActionTaskSummaryAndAnnotation(x *xorm.Engine) error {
+	type ActionTaskSummary struct {
+		ID      int64
+		TaskID  int64              `xorm:"UNIQUE"`
+		RepoID  int64              `xorm:"INDEX"`
+		Content string             `xorm:"LONGTEXT"`
+		Created timeutil.TimeStamp `xorm:"created"`
+		Updated timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionTaskAnnotation struct {
+		ID          int64
+		TaskID      int64  `xorm:"INDEX"`
+		RepoID      int64  `xorm:"INDEX(repo_commit)"`
+		CommitSHA   string `xorm:"VARCHAR(64) INDEX(repo_commit)"`
+		Level       string `xorm:"VARCHAR(16)"`
+		Title       string `xorm:"VARCHAR(255)"`
+		Message     string `xorm:"TEXT"`
+		Path        string `xorm:"TEXT"`
+		StartLine   int64
+		EndLine     int64
+		StartColumn int64
+		EndColumn   int64
+		Created     timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(ActionTaskSummary), new(ActionTaskAnnotation))
+}
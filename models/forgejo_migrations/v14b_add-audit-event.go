@@ -0,0 +1,35 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "create the audit_event table",
+		Upgrade:     addAuditEvent,
+	})
+}
+
+func addAuditEvent(x *xorm.Engine) error {
+	type AuditEvent struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Action      string             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+		DoerID      int64              `xorm:"INDEX NOT NULL"`
+		DoerName    string             `xorm:"VARCHAR(255)"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		TargetType  string             `xorm:"VARCHAR(32)"`
+		TargetID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		TargetName  string             `xorm:"VARCHAR(255)"`
+		Detail      string             `xorm:"TEXT"`
+		IPAddress   string             `xorm:"VARCHAR(64)"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+	return x.Sync(new(AuditEvent))
+}
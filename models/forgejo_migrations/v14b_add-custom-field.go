@@ -0,0 +1,38 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add custom fields of issues and their values",
+		Upgrade:     addCustomField,
+	})
+}
+
+func addCustomField(x *xorm.Engine) error {
+	type CustomField struct {
+		ID      int64    `xorm:"pk autoincr"`
+		RepoID  int64    `xorm:"INDEX NOT NULL DEFAULT 0"`
+		OrgID   int64    `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name    string   `xorm:"NOT NULL"`
+		Type    int      `xorm:"NOT NULL"`
+		Options []string `xorm:"TEXT JSON"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	type IssueCustomFieldValue struct {
+		ID      int64  `xorm:"pk autoincr"`
+		IssueID int64  `xorm:"UNIQUE(s) NOT NULL"`
+		FieldID int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Value   string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	}
+	return x.Sync(new(CustomField), new(IssueCustomFieldValue))
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "create the federated_issue and federated_comment tables",
+		Upgrade:     addFederatedIssue,
+	})
+}
+
+func addFederatedIssue(x *xorm.Engine) error {
+	type FederatedIssue struct {
+		ID               int64  `xorm:"pk autoincr"`
+		IssueID          int64  `xorm:"UNIQUE NOT NULL"`
+		FederationHostID int64  `xorm:"INDEX NOT NULL"`
+		ObjectID         string `xorm:"UNIQUE NOT NULL"`
+	}
+	type FederatedComment struct {
+		ID               int64  `xorm:"pk autoincr"`
+		CommentID        int64  `xorm:"UNIQUE NOT NULL"`
+		IssueID          int64  `xorm:"INDEX NOT NULL"`
+		FederationHostID int64  `xorm:"INDEX NOT NULL"`
+		ObjectID         string `xorm:"UNIQUE NOT NULL"`
+	}
+	return x.Sync(new(FederatedIssue), new(FederatedComment))
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add issue reminders and the record of the deadline reminders sent",
+		Upgrade:     addIssueReminder,
+	})
+}
+
+func addIssueReminder(x *xorm.Engine) error {
+	type IssueReminder struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"UNIQUE(reminder) NOT NULL"`
+		IssueID     int64              `xorm:"UNIQUE(reminder) NOT NULL"`
+		RemindUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+	type IssueDeadlineReminder struct {
+		ID           int64              `xorm:"pk autoincr"`
+		IssueID      int64              `xorm:"UNIQUE(reminded) NOT NULL"`
+		Type         int                `xorm:"UNIQUE(reminded) NOT NULL"`
+		DeadlineUnix timeutil.TimeStamp `xorm:"UNIQUE(reminded) NOT NULL"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+	return x.Sync(new(IssueReminder), new(IssueDeadlineReminder))
+}
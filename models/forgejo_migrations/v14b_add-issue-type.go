@@ -0,0 +1,23 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add type column to issue table",
+		Upgrade:     addIssueType,
+	})
+}
+
+func addIssueType(x *xorm.Engine) error {
+	type Issue struct {
+		Type int `xorm:"NOT NULL DEFAULT 0"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(Issue))
+	return err
+}
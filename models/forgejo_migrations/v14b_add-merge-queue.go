@@ -0,0 +1,43 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add enable_merge_queue to protected_branch and create the pull_merge_queue table",
+		Upgrade:     addMergeQueue,
+	})
+}
+
+func addMergeQueue(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		EnableMergeQueue bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	if err := x.Sync(new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	type PullMergeQueue struct {
+		ID                     int64              `xorm:"pk autoincr"`
+		RepoID                 int64              `xorm:"INDEX(s) NOT NULL"`
+		BaseBranch             string             `xorm:"INDEX(s) NOT NULL"`
+		PullID                 int64              `xorm:"UNIQUE NOT NULL"`
+		DoerID                 int64              `xorm:"INDEX NOT NULL"`
+		MergeStyle             string             `xorm:"varchar(30)"`
+		Message                string             `xorm:"LONGTEXT"`
+		DeleteBranchAfterMerge bool               `xorm:"NOT NULL DEFAULT false"`
+		HeadCommitID           string             `xorm:"VARCHAR(64)"`
+		BaseCommitID           string             `xorm:"VARCHAR(64)"`
+		MergeCommitID          string             `xorm:"VARCHAR(64)"`
+		CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(PullMergeQueue))
+}
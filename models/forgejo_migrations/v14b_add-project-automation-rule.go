@@ -0,0 +1,32 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add project automation rules",
+		Upgrade:     addProjectAutomationRule,
+	})
+}
+
+func addProjectAutomationRule(x *xorm.Engine) error {
+	type ProjectAutomationRule struct {
+		ID          int64 `xorm:"pk autoincr"`
+		ProjectID   int64 `xorm:"INDEX NOT NULL"`
+		TriggerType int   `xorm:"NOT NULL"`
+		LabelID     int64 `xorm:"NOT NULL DEFAULT 0"`
+		ColumnID    int64 `xorm:"INDEX NOT NULL"`
+		CreatorID   int64 `xorm:"NOT NULL"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(ProjectAutomationRule))
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add status_check_sources to protected_branch and workflow_id to commit_status",
+		Upgrade:     addStatusCheckSources,
+	})
+}
+
+func addStatusCheckSources(x *xorm.Engine) error {
+	type StatusCheckSource struct {
+		Context  string `json:"context"`
+		Workflow string `json:"workflow,omitempty"`
+		UserID   int64  `json:"user_id,omitempty"`
+	}
+	type ProtectedBranch struct {
+		StatusCheckSources []*StatusCheckSource `xorm:"JSON TEXT"`
+	}
+	if err := x.Sync(new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	type CommitStatus struct {
+		WorkflowID string `xorm:"VARCHAR(255)"`
+	}
+	return x.Sync(new(CommitStatus))
+}
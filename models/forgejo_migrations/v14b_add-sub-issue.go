@@ -0,0 +1,29 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add sub_issue table",
+		Upgrade:     addSubIssue,
+	})
+}
+
+func addSubIssue(x *xorm.Engine) error {
+	type SubIssue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ParentID    int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+		Position    int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(SubIssue))
+}
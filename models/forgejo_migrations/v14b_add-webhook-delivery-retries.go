@@ -0,0 +1,29 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add the retry state of webhook deliveries and count the consecutive failures of webhooks",
+		Upgrade:     addWebhookDeliveryRetries,
+	})
+}
+
+func addWebhookDeliveryRetries(x *xorm.Engine) error {
+	type HookTask struct {
+		Attempts      int                `xorm:"NOT NULL DEFAULT 0"`
+		NextRetryUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsDeadLetter  bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+	type Webhook struct {
+		ConsecutiveFailures int `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(HookTask), new(Webhook))
+}
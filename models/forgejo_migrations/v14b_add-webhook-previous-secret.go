@@ -0,0 +1,25 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add the previous secret of webhooks, valid during the rotation of their secret",
+		Upgrade:     addWebhookPreviousSecret,
+	})
+}
+
+func addWebhookPreviousSecret(x *xorm.Engine) error {
+	type Webhook struct {
+		PreviousSecret            string             `xorm:"TEXT"`
+		PreviousSecretExpiresUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(Webhook))
+}
@@ -42,6 +42,8 @@ type CommitStatus struct {
 	Context     string                 `xorm:"TEXT"`
 	Creator     *user_model.User       `xorm:"-"`
 	CreatorID   int64
+	// WorkflowID is the file name of the Actions workflow that reported the status, it is only set by Forgejo itself
+	WorkflowID string `xorm:"VARCHAR(255)"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
@@ -267,6 +269,26 @@ func GetLatestCommitStatus(ctx context.Context, repoID int64, sha string, listOp
 	return statuses, count, getBase().And(builder.In("`index`", indices)).Find(&statuses)
 }
 
+// GetLatestCommitStatusPerSource returns the latest status of every context for each of the sources that
+// reported it, the most recent first. Unlike GetLatestCommitStatus, a status reported for a context by another
+// user or workflow does not hide the one reported by the source a required status check is pinned to.
+func GetLatestCommitStatusPerSource(ctx context.Context, repoID int64, sha string) ([]*CommitStatus, error) {
+	getBase := func() *xorm.Session {
+		return db.GetEngine(ctx).Table(&CommitStatus{}).
+			Where("repo_id = ?", repoID).And("sha = ?", sha)
+	}
+	indices := make([]int64, 0, 10)
+	if err := getBase().Select("max( `index` ) as `index`").
+		GroupBy("context_hash, creator_id, workflow_id").Find(&indices); err != nil {
+		return nil, err
+	}
+	statuses := make([]*CommitStatus, 0, len(indices))
+	if len(indices) == 0 {
+		return statuses, nil
+	}
+	return statuses, getBase().And(builder.In("`index`", indices)).OrderBy("`index` DESC").Find(&statuses)
+}
+
 // GetLatestCommitStatusForPairs returns all statuses with a unique context for a given list of repo-sha pairs
 func GetLatestCommitStatusForPairs(ctx context.Context, repoSHAs []RepoSHA) (map[int64][]*CommitStatus, error) {
 	results := []*CommitStatus{}
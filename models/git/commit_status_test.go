@@ -244,3 +244,58 @@ func TestFindRepoRecentCommitStatusContexts(t *testing.T) {
 		assert.Equal(t, "compliance/lint-backend", contexts[0])
 	}
 }
+
+func TestGetLatestCommitStatusPerSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	gitRepo, err := gitrepo.OpenRepository(git.DefaultContext, repo2)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo2.DefaultBranch)
+	require.NoError(t, err)
+
+	defer func() {
+		_, err := db.GetEngine(db.DefaultContext).Where("repo_id = ? AND sha = ?", repo2.ID, commit.ID.String()).Delete(&git_model.CommitStatus{})
+		require.NoError(t, err)
+	}()
+
+	for _, status := range []struct {
+		creator *user_model.User
+		state   structs.CommitStatusState
+	}{
+		{user2, structs.CommitStatusPending},
+		{user2, structs.CommitStatusSuccess},
+		{user4, structs.CommitStatusFailure},
+	} {
+		err = git_model.NewCommitStatus(db.DefaultContext, git_model.NewCommitStatusOptions{
+			Repo:    repo2,
+			Creator: status.creator,
+			SHA:     commit.ID,
+			CommitStatus: &git_model.CommitStatus{
+				State:   status.state,
+				Context: "ci/build",
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	// the status reported by user4 hides the one reported by user2
+	statuses, _, err := git_model.GetLatestCommitStatus(db.DefaultContext, repo2.ID, commit.ID.String(), db.ListOptionsAll)
+	require.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, user4.ID, statuses[0].CreatorID)
+	}
+
+	statuses, err = git_model.GetLatestCommitStatusPerSource(db.DefaultContext, repo2.ID, commit.ID.String())
+	require.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, user4.ID, statuses[0].CreatorID)
+		assert.Equal(t, structs.CommitStatusFailure, statuses[0].State)
+		assert.Equal(t, user2.ID, statuses[1].CreatorID)
+		assert.Equal(t, structs.CommitStatusSuccess, statuses[1].State)
+	}
+}
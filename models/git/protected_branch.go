@@ -17,6 +17,7 @@ import (
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
@@ -38,27 +39,29 @@ type ProtectedBranch struct {
 	isPlainName                   bool                   `xorm:"-"`
 	CanPush                       bool                   `xorm:"NOT NULL DEFAULT false"`
 	EnableWhitelist               bool
-	WhitelistUserIDs              []int64  `xorm:"JSON TEXT"`
-	WhitelistTeamIDs              []int64  `xorm:"JSON TEXT"`
-	EnableMergeWhitelist          bool     `xorm:"NOT NULL DEFAULT false"`
-	WhitelistDeployKeys           bool     `xorm:"NOT NULL DEFAULT false"`
-	MergeWhitelistUserIDs         []int64  `xorm:"JSON TEXT"`
-	MergeWhitelistTeamIDs         []int64  `xorm:"JSON TEXT"`
-	EnableStatusCheck             bool     `xorm:"NOT NULL DEFAULT false"`
-	StatusCheckContexts           []string `xorm:"JSON TEXT"`
-	EnableApprovalsWhitelist      bool     `xorm:"NOT NULL DEFAULT false"`
-	ApprovalsWhitelistUserIDs     []int64  `xorm:"JSON TEXT"`
-	ApprovalsWhitelistTeamIDs     []int64  `xorm:"JSON TEXT"`
-	RequiredApprovals             int64    `xorm:"NOT NULL DEFAULT 0"`
-	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
-	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
-	IgnoreStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
-	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
-	ProtectedFilePatterns         string   `xorm:"TEXT"`
-	UnprotectedFilePatterns       string   `xorm:"TEXT"`
-	ApplyToAdmins                 bool     `xorm:"NOT NULL DEFAULT false"`
+	WhitelistUserIDs              []int64              `xorm:"JSON TEXT"`
+	WhitelistTeamIDs              []int64              `xorm:"JSON TEXT"`
+	EnableMergeWhitelist          bool                 `xorm:"NOT NULL DEFAULT false"`
+	WhitelistDeployKeys           bool                 `xorm:"NOT NULL DEFAULT false"`
+	MergeWhitelistUserIDs         []int64              `xorm:"JSON TEXT"`
+	MergeWhitelistTeamIDs         []int64              `xorm:"JSON TEXT"`
+	EnableStatusCheck             bool                 `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts           []string             `xorm:"JSON TEXT"`
+	StatusCheckSources            []*StatusCheckSource `xorm:"JSON TEXT"`
+	EnableApprovalsWhitelist      bool                 `xorm:"NOT NULL DEFAULT false"`
+	ApprovalsWhitelistUserIDs     []int64              `xorm:"JSON TEXT"`
+	ApprovalsWhitelistTeamIDs     []int64              `xorm:"JSON TEXT"`
+	RequiredApprovals             int64                `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews        bool                 `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOfficialReviewRequests bool                 `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOutdatedBranch         bool                 `xorm:"NOT NULL DEFAULT false"`
+	DismissStaleApprovals         bool                 `xorm:"NOT NULL DEFAULT false"`
+	IgnoreStaleApprovals          bool                 `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits          bool                 `xorm:"NOT NULL DEFAULT false"`
+	ProtectedFilePatterns         string               `xorm:"TEXT"`
+	UnprotectedFilePatterns       string               `xorm:"TEXT"`
+	ApplyToAdmins                 bool                 `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeQueue              bool                 `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -68,6 +71,53 @@ func init() {
 	db.RegisterModel(new(ProtectedBranch))
 }
 
+// StatusCheckSource pins a required status check context to the only source allowed to report it.
+// Statuses of the context reported by anyone else do not satisfy the check.
+type StatusCheckSource struct {
+	// Context is the pattern of ProtectedBranch.StatusCheckContexts the source is pinned for
+	Context string `json:"context"`
+	// Workflow is the file name of the Actions workflow that must have reported the status, e.g. build.yml
+	Workflow string `json:"workflow,omitempty"`
+	// UserID is the user, usually a bot account, whose token must have been used to report the status
+	UserID int64 `json:"user_id,omitempty"`
+}
+
+// Matches returns whether the commit status was reported by the source
+func (source *StatusCheckSource) Matches(status *CommitStatus) bool {
+	if source.Workflow != "" {
+		return status.CreatorID == user_model.ActionsUserID && status.WorkflowID == source.Workflow
+	}
+	return status.CreatorID == source.UserID
+}
+
+// GetStatusCheckSource returns the source the required status check context pattern is pinned to, if any
+func (protectBranch *ProtectedBranch) GetStatusCheckSource(pattern string) *StatusCheckSource {
+	for _, source := range protectBranch.StatusCheckSources {
+		if source.Context == pattern {
+			return source
+		}
+	}
+	return nil
+}
+
+// CheckStatusCheckSources returns an error if a source is not pinned to exactly one workflow or user, or is
+// pinned for a context that is not a required status check
+func (protectBranch *ProtectedBranch) CheckStatusCheckSources() error {
+	seen := make(container.Set[string], len(protectBranch.StatusCheckSources))
+	for _, source := range protectBranch.StatusCheckSources {
+		if (source.Workflow == "") == (source.UserID == 0) {
+			return util.NewInvalidArgumentErrorf("the source of status check %q must be either a workflow or a user", source.Context)
+		}
+		if !slices.Contains(protectBranch.StatusCheckContexts, source.Context) {
+			return util.NewInvalidArgumentErrorf("%q is not a required status check", source.Context)
+		}
+		if !seen.Add(source.Context) {
+			return util.NewInvalidArgumentErrorf("status check %q is pinned to more than one source", source.Context)
+		}
+	}
+	return nil
+}
+
 // IsRuleNameSpecial return true if it contains special character
 func IsRuleNameSpecial(ruleName string) bool {
 	for i := 0; i < len(ruleName); i++ {
@@ -6,7 +6,10 @@ package git
 import (
 	"testing"
 
+	"forgejo.org/modules/util"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBranchRuleMatch(t *testing.T) {
@@ -67,3 +70,29 @@ func TestBranchRuleMatch(t *testing.T) {
 		assert.Equal(t, kase.ExpectedMatch, pb.Match(kase.BranchName), "%s - %s", kase.BranchName, kase.Rule)
 	}
 }
+
+func TestCheckStatusCheckSources(t *testing.T) {
+	pb := &ProtectedBranch{StatusCheckContexts: []string{"ci/*", "deploy"}}
+	require.NoError(t, pb.CheckStatusCheckSources())
+
+	pb.StatusCheckSources = []*StatusCheckSource{
+		{Context: "ci/*", Workflow: "build.yml"},
+		{Context: "deploy", UserID: 5},
+	}
+	require.NoError(t, pb.CheckStatusCheckSources())
+
+	pb.StatusCheckSources = []*StatusCheckSource{{Context: "ci/*", Workflow: "build.yml", UserID: 5}}
+	require.ErrorIs(t, pb.CheckStatusCheckSources(), util.ErrInvalidArgument)
+
+	pb.StatusCheckSources = []*StatusCheckSource{{Context: "ci/*"}}
+	require.ErrorIs(t, pb.CheckStatusCheckSources(), util.ErrInvalidArgument)
+
+	pb.StatusCheckSources = []*StatusCheckSource{{Context: "lint", Workflow: "lint.yml"}}
+	require.ErrorIs(t, pb.CheckStatusCheckSources(), util.ErrInvalidArgument)
+
+	pb.StatusCheckSources = []*StatusCheckSource{
+		{Context: "deploy", UserID: 5},
+		{Context: "deploy", Workflow: "deploy.yml"},
+	}
+	require.ErrorIs(t, pb.CheckStatusCheckSources(), util.ErrInvalidArgument)
+}
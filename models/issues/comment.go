@@ -116,6 +116,9 @@ const (
 	CommentTypeUnpin // 37 unpin Issue
 
 	CommentTypeAggregator // 38 Aggregator of comments
+
+	CommentTypePRAddedToMergeQueue     // 39 pr was added to the merge queue of its base branch
+	CommentTypePRRemovedFromMergeQueue // 40 pr was removed from the merge queue of its base branch
 )
 
 var commentStrings = []string{
@@ -158,6 +161,8 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"action_aggregator",
+	"pull_add_merge_queue",
+	"pull_remove_merge_queue",
 }
 
 func (t CommentType) String() string {
@@ -0,0 +1,367 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CustomFieldType is the type of the values of a custom field
+type CustomFieldType int
+
+const (
+	// CustomFieldTypeText a single line of text
+	CustomFieldTypeText CustomFieldType = iota + 1
+	// CustomFieldTypeNumber a decimal number
+	CustomFieldTypeNumber
+	// CustomFieldTypeDate a date formatted as 2006-01-02
+	CustomFieldTypeDate
+	// CustomFieldTypeSingleSelect one of the options of the field
+	CustomFieldTypeSingleSelect
+	// CustomFieldTypeMultiSelect any number of the options of the field
+	CustomFieldTypeMultiSelect
+	// CustomFieldTypeUser a user, stored by ID
+	CustomFieldTypeUser
+)
+
+var customFieldTypeNames = map[CustomFieldType]string{
+	CustomFieldTypeText:         "text",
+	CustomFieldTypeNumber:       "number",
+	CustomFieldTypeDate:         "date",
+	CustomFieldTypeSingleSelect: "single_select",
+	CustomFieldTypeMultiSelect:  "multi_select",
+	CustomFieldTypeUser:         "user",
+}
+
+// Name returns the name of the type used by the API
+func (t CustomFieldType) Name() string {
+	return customFieldTypeNames[t]
+}
+
+// CustomFieldTypeFromName returns the type with the given name, 0 if there is none
+func CustomFieldTypeFromName(name string) CustomFieldType {
+	for t, n := range customFieldTypeNames {
+		if n == name {
+			return t
+		}
+	}
+	return 0
+}
+
+// maxCustomFieldValueLength is the maximum length of a value of a custom field
+const maxCustomFieldValueLength = 255
+
+// ErrCustomFieldNotExist represents a "CustomFieldNotExist" kind of error.
+type ErrCustomFieldNotExist struct {
+	ID int64
+}
+
+// IsErrCustomFieldNotExist checks if an error is a ErrCustomFieldNotExist
+func IsErrCustomFieldNotExist(err error) bool {
+	_, ok := err.(ErrCustomFieldNotExist)
+	return ok
+}
+
+func (err ErrCustomFieldNotExist) Error() string {
+	return fmt.Sprintf("custom field does not exist [id: %d]", err.ID)
+}
+
+func (err ErrCustomFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// CustomField is a typed field defined by a repository or an organization
+// that can be set on its issues and pull requests
+type CustomField struct {
+	ID      int64           `xorm:"pk autoincr"`
+	RepoID  int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OrgID   int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name    string          `xorm:"NOT NULL"`
+	Type    CustomFieldType `xorm:"NOT NULL"`
+	Options []string        `xorm:"TEXT JSON"` // the options of the select types
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// IssueCustomFieldValue is a value of a custom field set on an issue. Fields of
+// the CustomFieldTypeMultiSelect type have one row per selected option.
+type IssueCustomFieldValue struct {
+	ID      int64  `xorm:"pk autoincr"`
+	IssueID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	FieldID int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value   string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+
+	Field *CustomField `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(CustomField))
+	db.RegisterModel(new(IssueCustomFieldValue))
+}
+
+// BelongsToOrg returns true if the field is defined by an organization
+func (f *CustomField) BelongsToOrg() bool {
+	return f.OrgID > 0
+}
+
+// validate checks the name, type and options of the field
+func (f *CustomField) validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of a custom field cannot be empty")
+	}
+	if _, ok := customFieldTypeNames[f.Type]; !ok {
+		return util.NewInvalidArgumentErrorf("invalid custom field type %d", f.Type)
+	}
+	if f.Type != CustomFieldTypeSingleSelect && f.Type != CustomFieldTypeMultiSelect {
+		f.Options = nil
+		return nil
+	}
+	if len(f.Options) == 0 {
+		return util.NewInvalidArgumentErrorf("the %s type requires options", f.Type.Name())
+	}
+	for i, option := range f.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || len(option) > maxCustomFieldValueLength {
+			return util.NewInvalidArgumentErrorf("invalid option %q", option)
+		}
+		if slices.Contains(f.Options[:i], option) {
+			return util.NewInvalidArgumentErrorf("duplicate option %q", option)
+		}
+		f.Options[i] = option
+	}
+	return nil
+}
+
+// NormalizeValues checks the values can be set on the field and returns them
+// in the form they are stored. The values of CustomFieldTypeUser fields are user names.
+func (f *CustomField) NormalizeValues(ctx context.Context, values []string) ([]string, error) {
+	if len(values) > 1 && f.Type != CustomFieldTypeMultiSelect {
+		return nil, util.NewInvalidArgumentErrorf("the custom field %q accepts a single value", f.Name)
+	}
+
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		switch f.Type {
+		case CustomFieldTypeText:
+			if value == "" || len(value) > maxCustomFieldValueLength {
+				return nil, util.NewInvalidArgumentErrorf("invalid value %q for the custom field %q", value, f.Name)
+			}
+		case CustomFieldTypeNumber:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, util.NewInvalidArgumentErrorf("invalid number %q for the custom field %q", value, f.Name)
+			}
+			value = strconv.FormatFloat(number, 'f', -1, 64)
+		case CustomFieldTypeDate:
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("invalid date %q for the custom field %q", value, f.Name)
+			}
+		case CustomFieldTypeSingleSelect, CustomFieldTypeMultiSelect:
+			if !slices.Contains(f.Options, value) {
+				return nil, util.NewInvalidArgumentErrorf("%q is not an option of the custom field %q", value, f.Name)
+			}
+		case CustomFieldTypeUser:
+			user, err := user_model.GetUserByName(ctx, value)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					return nil, util.NewInvalidArgumentErrorf("user %q of the custom field %q does not exist", value, f.Name)
+				}
+				return nil, err
+			}
+			value = strconv.FormatInt(user.ID, 10)
+		}
+		if !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized, nil
+}
+
+// NewCustomField creates a custom field
+func NewCustomField(ctx context.Context, field *CustomField) error {
+	if err := field.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, field)
+}
+
+// UpdateCustomField updates the name and options of a custom field. The values
+// of the options that were removed are deleted.
+func UpdateCustomField(ctx context.Context, field *CustomField) error {
+	if err := field.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(field.ID).Cols("name", "options").Update(field); err != nil {
+			return err
+		}
+		if field.Options == nil {
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": field.ID}.And(builder.NotIn("value", field.Options))).
+			Delete(new(IssueCustomFieldValue))
+		return err
+	})
+}
+
+// GetCustomFieldByID returns the custom field with the given ID
+func GetCustomFieldByID(ctx context.Context, id int64) (*CustomField, error) {
+	field, exists, err := db.GetByID[CustomField](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrCustomFieldNotExist{ID: id}
+	}
+	return field, nil
+}
+
+// GetRepoCustomField returns the custom field defined by the repository
+func GetRepoCustomField(ctx context.Context, repoID, id int64) (*CustomField, error) {
+	field, err := GetCustomFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if field.RepoID != repoID {
+		return nil, ErrCustomFieldNotExist{ID: id}
+	}
+	return field, nil
+}
+
+// GetOrgCustomField returns the custom field defined by the organization
+func GetOrgCustomField(ctx context.Context, orgID, id int64) (*CustomField, error) {
+	field, err := GetCustomFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if field.OrgID != orgID {
+		return nil, ErrCustomFieldNotExist{ID: id}
+	}
+	return field, nil
+}
+
+// FindRepoCustomFields returns the custom fields defined by the repository
+func FindRepoCustomFields(ctx context.Context, repoID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("id").Find(&fields)
+}
+
+// FindOrgCustomFields returns the custom fields defined by the organization
+func FindOrgCustomFields(ctx context.Context, orgID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).Where("org_id = ?", orgID).Asc("id").Find(&fields)
+}
+
+// FindCustomFieldsForRepo returns the custom fields that can be set on the
+// issues of the repository: its own and those of its owner
+func FindCustomFieldsForRepo(ctx context.Context, repoID, ownerID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).
+		Where(builder.Eq{"repo_id": repoID}.Or(builder.Eq{"org_id": ownerID}.And(builder.Gt{"org_id": 0}))).
+		Asc("id").
+		Find(&fields)
+}
+
+// GetCustomFieldIssueIDs returns the IDs of the issues the field is set on
+func GetCustomFieldIssueIDs(ctx context.Context, fieldID int64) ([]int64, error) {
+	issueIDs := make([]int64, 0, 10)
+	return issueIDs, db.GetEngine(ctx).Table("issue_custom_field_value").Where("field_id = ?", fieldID).
+		Distinct("issue_id").Find(&issueIDs)
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx context.Context, field *CustomField) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", field.ID).Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[CustomField](ctx, field.ID)
+		return err
+	})
+}
+
+// DeleteCustomFieldsByRepoID deletes the custom fields defined by the repository and their values
+func DeleteCustomFieldsByRepoID(ctx context.Context, repoID int64) error {
+	if _, err := db.GetEngine(ctx).
+		Where(builder.In("field_id", builder.Select("id").From("custom_field").Where(builder.Eq{"repo_id": repoID}))).
+		Delete(new(IssueCustomFieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(CustomField))
+	return err
+}
+
+// DeleteCustomFieldsByOrgID deletes the custom fields defined by the organization and their values
+func DeleteCustomFieldsByOrgID(ctx context.Context, orgID int64) error {
+	if _, err := db.GetEngine(ctx).
+		Where(builder.In("field_id", builder.Select("id").From("custom_field").Where(builder.Eq{"org_id": orgID}))).
+		Delete(new(IssueCustomFieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Delete(new(CustomField))
+	return err
+}
+
+// SetIssueCustomFieldValues replaces the values of the field on the issue by
+// the normalized values, no values removing the field from the issue
+func SetIssueCustomFieldValues(ctx context.Context, issueID int64, field *CustomField, values []string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("issue_id = ? AND field_id = ?", issueID, field.ID).
+			Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		for _, value := range values {
+			if err := db.Insert(ctx, &IssueCustomFieldValue{
+				IssueID: issueID,
+				FieldID: field.ID,
+				Value:   value,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetIssueCustomFieldValues returns the values of the custom fields set on the
+// issues, by issue ID, with their field loaded
+func GetIssueCustomFieldValues(ctx context.Context, issueIDs ...int64) (map[int64][]*IssueCustomFieldValue, error) {
+	if len(issueIDs) == 0 {
+		return map[int64][]*IssueCustomFieldValue{}, nil
+	}
+	values := make([]*IssueCustomFieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Asc("field_id").Asc("id").Find(&values); err != nil {
+		return nil, err
+	}
+
+	fieldIDs := make([]int64, 0, len(values))
+	for _, value := range values {
+		fieldIDs = append(fieldIDs, value.FieldID)
+	}
+	fields := make(map[int64]*CustomField, len(fieldIDs))
+	if err := db.GetEngine(ctx).In("id", fieldIDs).Find(&fields); err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]*IssueCustomFieldValue, len(issueIDs))
+	for _, value := range values {
+		value.Field = fields[value.FieldID]
+		if value.Field != nil {
+			result[value.IssueID] = append(result[value.IssueID], value)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldNormalizeValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, tc := range []struct {
+		field    *issues_model.CustomField
+		values   []string
+		expected []string
+	}{
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeText}, []string{" some text "}, []string{"some text"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeNumber}, []string{"01.50"}, []string{"1.5"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeDate}, []string{"2025-03-01"}, []string{"2025-03-01"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeMultiSelect, Options: []string{"a", "b"}}, []string{"b", "a", "b"}, []string{"b", "a"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeUser}, []string{"user2"}, []string{"2"}},
+	} {
+		values, err := tc.field.NormalizeValues(db.DefaultContext, tc.values)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, values)
+	}
+
+	for _, tc := range []struct {
+		field  *issues_model.CustomField
+		values []string
+	}{
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeText}, []string{""}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeText}, []string{"a", "b"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeNumber}, []string{"one"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeDate}, []string{"01/03/2025"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeSingleSelect, Options: []string{"a"}}, []string{"c"}},
+		{&issues_model.CustomField{Type: issues_model.CustomFieldTypeUser}, []string{"not-a-user"}},
+	} {
+		_, err := tc.field.NormalizeValues(db.DefaultContext, tc.values)
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	}
+}
+
+func TestCustomField(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// select fields need options
+	err := issues_model.NewCustomField(db.DefaultContext, &issues_model.CustomField{RepoID: 1, Name: "Priority", Type: issues_model.CustomFieldTypeSingleSelect})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	priority := &issues_model.CustomField{RepoID: 1, Name: "Priority", Type: issues_model.CustomFieldTypeSingleSelect, Options: []string{"low", "high"}}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, priority))
+	// org3 owns repo 3
+	team := &issues_model.CustomField{OrgID: 3, Name: "Team", Type: issues_model.CustomFieldTypeText}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, team))
+
+	fields, err := issues_model.FindCustomFieldsForRepo(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	if assert.Len(t, fields, 1) {
+		assert.Equal(t, team.ID, fields[0].ID)
+		assert.True(t, fields[0].BelongsToOrg())
+	}
+	_, err = issues_model.GetRepoCustomField(db.DefaultContext, 2, priority.ID)
+	assert.True(t, issues_model.IsErrCustomFieldNotExist(err))
+
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, 1, priority, []string{"high"}))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, 2, priority, []string{"low"}))
+
+	issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+		RepoIDs:      []int64{1},
+		CustomFields: map[int64]string{priority.ID: "high"},
+	})
+	require.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.EqualValues(t, 1, issues[0].ID)
+	}
+
+	values, err := issues_model.GetIssueCustomFieldValues(db.DefaultContext, 1, 2, 3)
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+	if assert.Len(t, values[1], 1) {
+		assert.Equal(t, "high", values[1][0].Value)
+		assert.Equal(t, priority.Name, values[1][0].Field.Name)
+	}
+
+	// removing an option deletes its values
+	priority.Options = []string{"low", "medium"}
+	require.NoError(t, issues_model.UpdateCustomField(db.DefaultContext, priority))
+	issueIDs, err := issues_model.GetCustomFieldIssueIDs(db.DefaultContext, priority.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, issueIDs)
+
+	require.NoError(t, issues_model.DeleteCustomField(db.DefaultContext, priority))
+	unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: priority.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{FieldID: priority.ID})
+
+	require.NoError(t, issues_model.DeleteCustomFieldsByOrgID(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: team.ID})
+}
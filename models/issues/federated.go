@@ -0,0 +1,120 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/validation"
+)
+
+// FederatedIssue links an issue to the ForgeFed ticket it was created from.
+type FederatedIssue struct {
+	ID               int64  `xorm:"pk autoincr"`
+	IssueID          int64  `xorm:"UNIQUE NOT NULL"`
+	FederationHostID int64  `xorm:"INDEX NOT NULL"`
+	ObjectID         string `xorm:"UNIQUE NOT NULL"`
+}
+
+// FederatedComment links a comment to the ActivityPub note it was created from.
+type FederatedComment struct {
+	ID               int64  `xorm:"pk autoincr"`
+	CommentID        int64  `xorm:"UNIQUE NOT NULL"`
+	IssueID          int64  `xorm:"INDEX NOT NULL"`
+	FederationHostID int64  `xorm:"INDEX NOT NULL"`
+	ObjectID         string `xorm:"UNIQUE NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(FederatedIssue))
+	db.RegisterModel(new(FederatedComment))
+}
+
+func (federatedIssue FederatedIssue) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(federatedIssue.IssueID, "IssueID")...)
+	result = append(result, validation.ValidateNotEmpty(federatedIssue.FederationHostID, "FederationHostID")...)
+	result = append(result, validation.ValidateNotEmpty(federatedIssue.ObjectID, "ObjectID")...)
+	return result
+}
+
+func (federatedComment FederatedComment) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(federatedComment.CommentID, "CommentID")...)
+	result = append(result, validation.ValidateNotEmpty(federatedComment.IssueID, "IssueID")...)
+	result = append(result, validation.ValidateNotEmpty(federatedComment.FederationHostID, "FederationHostID")...)
+	result = append(result, validation.ValidateNotEmpty(federatedComment.ObjectID, "ObjectID")...)
+	return result
+}
+
+// CreateFederatedIssue records the ForgeFed ticket the given issue was created from.
+func CreateFederatedIssue(ctx context.Context, federatedIssue *FederatedIssue) error {
+	if valid, err := validation.IsValid(*federatedIssue); !valid {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Insert(federatedIssue)
+	return err
+}
+
+// CreateFederatedComment records the ActivityPub note the given comment was created from.
+func CreateFederatedComment(ctx context.Context, federatedComment *FederatedComment) error {
+	if valid, err := validation.IsValid(*federatedComment); !valid {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Insert(federatedComment)
+	return err
+}
+
+// GetIssueByFederatedObjectID returns the issue created from the ForgeFed ticket with the given ID,
+// or nil if there is none.
+func GetIssueByFederatedObjectID(ctx context.Context, objectID string) (*Issue, error) {
+	federatedIssue := new(FederatedIssue)
+	has, err := db.GetEngine(ctx).Where("object_id=?", objectID).Get(federatedIssue)
+	if err != nil || !has {
+		return nil, err
+	}
+	return GetIssueByID(ctx, federatedIssue.IssueID)
+}
+
+// IsFederatedCommentKnown reports whether a comment was already created from the note with the given ID.
+func IsFederatedCommentKnown(ctx context.Context, objectID string) (bool, error) {
+	return db.GetEngine(ctx).Where("object_id=?", objectID).Exist(new(FederatedComment))
+}
+
+// GetFederatedIssueByIssueID returns the ForgeFed ticket the given issue was created from, if any.
+func GetFederatedIssueByIssueID(ctx context.Context, issueID int64) (*FederatedIssue, error) {
+	federatedIssue := new(FederatedIssue)
+	has, err := db.GetEngine(ctx).Where("issue_id=?", issueID).Get(federatedIssue)
+	if err != nil || !has {
+		return nil, err
+	}
+	return federatedIssue, nil
+}
+
+// APObjectID returns the ID of the issue as a ForgeFed ticket.
+func (issue *Issue) APObjectID() string {
+	return fmt.Sprintf("%vapi/v1/activitypub/repository-id/%d/issues/%d", setting.AppURL, issue.RepoID, issue.Index)
+}
+
+// APObjectID returns the ID of the comment as an ActivityPub note replying to the ticket of its issue.
+// The issue of the comment has to be loaded.
+func (c *Comment) APObjectID() string {
+	return fmt.Sprintf("%s#comment-%d", c.Issue.APObjectID(), c.ID)
+}
+
+// ParseIssueAPObjectID returns the index of the issue of the given repository identified by the given
+// ForgeFed ticket ID, or 0 if the ID does not identify a local issue of this repository.
+func ParseIssueAPObjectID(repoID int64, objectID string) int64 {
+	prefix := fmt.Sprintf("%vapi/v1/activitypub/repository-id/%d/issues/", setting.AppURL, repoID)
+	index, err := strconv.ParseInt(strings.TrimPrefix(objectID, prefix), 10, 64)
+	if !strings.HasPrefix(objectID, prefix) || err != nil {
+		return 0
+	}
+	return index
+}
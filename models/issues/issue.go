@@ -112,7 +112,8 @@ type Issue struct {
 	PullRequest       *PullRequest     `xorm:"-"`
 	NumComments       int
 	Ref               string
-	PinOrder          int `xorm:"DEFAULT 0"`
+	PinOrder          int       `xorm:"DEFAULT 0"`
+	Type              IssueType `xorm:"NOT NULL DEFAULT 0"`
 
 	DeadlineUnix timeutil.TimeStamp `xorm:"INDEX"`
 
@@ -129,6 +130,9 @@ type Issue struct {
 	Reactions           ReactionList             `xorm:"-"`
 	TotalTrackedTime    int64                    `xorm:"-"`
 	Assignees           []*user_model.User       `xorm:"-"`
+	ParentIssue         *Issue                   `xorm:"-"`
+	isParentIssueLoaded bool                     `xorm:"-"`
+	SubIssuesSummary    *SubIssuesSummary        `xorm:"-"`
 
 	// IsLocked limits commenting abilities to users on an issue
 	// with write access
@@ -362,6 +366,8 @@ func (issue *Issue) ResetAttributesLoaded() {
 	issue.isMilestoneLoaded = false
 	issue.isAttachmentsLoaded = false
 	issue.isAssigneeLoaded = false
+	issue.isParentIssueLoaded = false
+	issue.SubIssuesSummary = nil
 }
 
 // GetIsRead loads the `IsRead` field of the issue
@@ -0,0 +1,116 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ReminderType is the reason a user is reminded of an issue
+type ReminderType int
+
+const (
+	// ReminderTypeDueSoon the deadline of the issue is approaching
+	ReminderTypeDueSoon ReminderType = iota + 1
+	// ReminderTypeOverdue the deadline of the issue has passed
+	ReminderTypeOverdue
+	// ReminderTypePersonal the user asked to be reminded of the issue
+	ReminderTypePersonal
+)
+
+// IssueReminder is a reminder a user set for themselves on an issue
+type IssueReminder struct {
+	ID          int64              `xorm:"pk autoincr"`
+	UserID      int64              `xorm:"UNIQUE(reminder) NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE(reminder) NOT NULL"`
+	RemindUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+// IssueDeadlineReminder records that the assignees of an issue were reminded
+// of its deadline. A new reminder is sent when the deadline changes.
+type IssueDeadlineReminder struct {
+	ID           int64              `xorm:"pk autoincr"`
+	IssueID      int64              `xorm:"UNIQUE(reminded) NOT NULL"`
+	Type         ReminderType       `xorm:"UNIQUE(reminded) NOT NULL"`
+	DeadlineUnix timeutil.TimeStamp `xorm:"UNIQUE(reminded) NOT NULL"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueReminder))
+	db.RegisterModel(new(IssueDeadlineReminder))
+}
+
+// SetIssueReminder sets the time the user is reminded of the issue, replacing
+// the previous reminder if any
+func SetIssueReminder(ctx context.Context, userID, issueID int64, remindUnix timeutil.TimeStamp) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		reminder, err := GetIssueReminder(ctx, userID, issueID)
+		if err != nil {
+			return err
+		}
+		if reminder == nil {
+			return db.Insert(ctx, &IssueReminder{
+				UserID:     userID,
+				IssueID:    issueID,
+				RemindUnix: remindUnix,
+			})
+		}
+		reminder.RemindUnix = remindUnix
+		_, err = db.GetEngine(ctx).ID(reminder.ID).Cols("remind_unix").Update(reminder)
+		return err
+	})
+}
+
+// GetIssueReminder returns the reminder the user set on the issue, nil if there is none
+func GetIssueReminder(ctx context.Context, userID, issueID int64) (*IssueReminder, error) {
+	reminder, exists, err := db.Get[IssueReminder](ctx, builder.Eq{"user_id": userID, "issue_id": issueID})
+	if err != nil || !exists {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// DeleteIssueReminder deletes the reminder the user set on the issue
+func DeleteIssueReminder(ctx context.Context, userID, issueID int64) error {
+	_, err := db.GetEngine(ctx).Where("user_id = ? AND issue_id = ?", userID, issueID).Delete(new(IssueReminder))
+	return err
+}
+
+// FindDueIssueReminders returns the reminders due at the given time
+func FindDueIssueReminders(ctx context.Context, now timeutil.TimeStamp) ([]*IssueReminder, error) {
+	reminders := make([]*IssueReminder, 0, 10)
+	return reminders, db.GetEngine(ctx).Where("remind_unix <= ?", now).Asc("remind_unix").Find(&reminders)
+}
+
+// FindIssuesToRemindOfDeadline returns the open issues with a deadline in
+// [from, to) whose assignees were not yet reminded of it with reminderType
+func FindIssuesToRemindOfDeadline(ctx context.Context, reminderType ReminderType, from, to timeutil.TimeStamp) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Where("issue.is_closed = ?", false).
+		And("issue.deadline_unix > 0 AND issue.deadline_unix >= ? AND issue.deadline_unix < ?", from, to).
+		And(builder.NotIn("issue.id", builder.Select("issue_deadline_reminder.issue_id").
+			From("issue_deadline_reminder").
+			Where(builder.Eq{"issue_deadline_reminder.type": reminderType}.
+				And(builder.Expr("issue_deadline_reminder.deadline_unix = issue.deadline_unix"))))).
+		Asc("issue.deadline_unix").
+		Find(&issues)
+}
+
+// RecordIssueDeadlineReminder records that the assignees of the issue were
+// reminded of its current deadline with reminderType
+func RecordIssueDeadlineReminder(ctx context.Context, issue *Issue, reminderType ReminderType) error {
+	return db.Insert(ctx, &IssueDeadlineReminder{
+		IssueID:      issue.ID,
+		Type:         reminderType,
+		DeadlineUnix: issue.DeadlineUnix,
+	})
+}
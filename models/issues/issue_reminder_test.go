@@ -0,0 +1,69 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueReminder(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	reminder, err := issues_model.GetIssueReminder(db.DefaultContext, 2, 1)
+	require.NoError(t, err)
+	assert.Nil(t, reminder)
+
+	require.NoError(t, issues_model.SetIssueReminder(db.DefaultContext, 2, 1, 1000))
+	require.NoError(t, issues_model.SetIssueReminder(db.DefaultContext, 2, 1, 2000))
+	require.NoError(t, issues_model.SetIssueReminder(db.DefaultContext, 4, 1, 3000))
+	reminder, err = issues_model.GetIssueReminder(db.DefaultContext, 2, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2000, reminder.RemindUnix)
+
+	due, err := issues_model.FindDueIssueReminders(db.DefaultContext, 2500)
+	require.NoError(t, err)
+	if assert.Len(t, due, 1) {
+		assert.EqualValues(t, 2, due[0].UserID)
+	}
+
+	require.NoError(t, issues_model.DeleteIssueReminder(db.DefaultContext, 2, 1))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueReminder{UserID: 2, IssueID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueReminder{UserID: 4, IssueID: 1})
+}
+
+func TestFindIssuesToRemindOfDeadline(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 10})
+	from, to := issue.DeadlineUnix-10, issue.DeadlineUnix+10
+
+	find := func(reminderType issues_model.ReminderType, from, to timeutil.TimeStamp) []int64 {
+		issues, err := issues_model.FindIssuesToRemindOfDeadline(db.DefaultContext, reminderType, from, to)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int64{10}, find(issues_model.ReminderTypeDueSoon, from, to))
+	assert.Empty(t, find(issues_model.ReminderTypeDueSoon, to, to+10))
+
+	require.NoError(t, issues_model.RecordIssueDeadlineReminder(db.DefaultContext, issue, issues_model.ReminderTypeDueSoon))
+	assert.Empty(t, find(issues_model.ReminderTypeDueSoon, from, to))
+	assert.Equal(t, []int64{10}, find(issues_model.ReminderTypeOverdue, from, to))
+
+	// a new deadline is reminded of again
+	require.NoError(t, issues_model.UpdateIssueCols(db.DefaultContext, &issues_model.Issue{ID: issue.ID, DeadlineUnix: issue.DeadlineUnix + 5}, "deadline_unix"))
+	assert.Equal(t, []int64{10}, find(issues_model.ReminderTypeDueSoon, from, to))
+}
@@ -51,6 +51,7 @@ type IssuesOptions struct { //nolint
 	// if this issue index (not ID) exists and matches the filters, *and* priorityrepo sort is used, show it first
 	PriorityIssueIndex int64
 	IsArchived         optional.Option[bool]
+	CustomFields       map[int64]string // the issues have these values of the custom fields, by field ID
 
 	// If combined with AllPublic, then private as well as public issues
 	// that matches the criteria will be returned, if AllPublic is false
@@ -180,6 +181,14 @@ func applyMilestoneCondition(sess *xorm.Session, opts *IssuesOptions) {
 	}
 }
 
+func applyCustomFieldsCondition(sess *xorm.Session, opts *IssuesOptions) {
+	for fieldID, value := range opts.CustomFields {
+		sess.In("issue.id", builder.Select("issue_id").
+			From("issue_custom_field_value").
+			Where(builder.Eq{"field_id": fieldID, "value": value}))
+	}
+}
+
 func applyProjectCondition(sess *xorm.Session, opts *IssuesOptions) {
 	if opts.ProjectID > 0 { // specific project
 		sess.Join("INNER", "project_issue", "issue.id = project_issue.issue_id").
@@ -269,6 +278,8 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyProjectColumnCondition(sess, opts)
 
+	applyCustomFieldsCondition(sess, opts)
+
 	if opts.IsPull.Has() {
 		sess.And("issue.is_pull=?", opts.IsPull.Value())
 	}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+)
+
+// IssueType classifies an issue, e.g. an epic whose sub-issues are tasks
+type IssueType int
+
+const (
+	IssueTypeNone IssueType = iota
+	IssueTypeTask
+	IssueTypeBug
+	IssueTypeFeature
+	IssueTypeEpic
+)
+
+var issueTypeNames = map[IssueType]string{
+	IssueTypeNone:    "",
+	IssueTypeTask:    "task",
+	IssueTypeBug:     "bug",
+	IssueTypeFeature: "feature",
+	IssueTypeEpic:    "epic",
+}
+
+// Name returns the name of the type, empty for an issue without a type
+func (t IssueType) Name() string {
+	return issueTypeNames[t]
+}
+
+// IssueTypeFromName returns the type with the given name, the empty name is an issue without a type
+func IssueTypeFromName(name string) (IssueType, bool) {
+	for t, n := range issueTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return IssueTypeNone, false
+}
+
+// ChangeIssueType saves the type of the issue
+func ChangeIssueType(ctx context.Context, issue *Issue) error {
+	return UpdateIssueCols(ctx, issue, "type")
+}
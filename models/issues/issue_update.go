@@ -709,6 +709,17 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
+		// Sub-issues in other repositories of the organization lose their parent
+		_, err = sess.In("parent_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
@@ -734,6 +745,21 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueReminder{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueDeadlineReminder{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueCustomFieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&project_model.ProjectIssue{})
 		if err != nil {
 			return nil, err
@@ -0,0 +1,336 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrSubIssueExists represents a "SubIssueExists" kind of error.
+type ErrSubIssueExists struct {
+	IssueID int64
+}
+
+// IsErrSubIssueExists checks if an error is a ErrSubIssueExists.
+func IsErrSubIssueExists(err error) bool {
+	_, ok := err.(ErrSubIssueExists)
+	return ok
+}
+
+func (err ErrSubIssueExists) Error() string {
+	return fmt.Sprintf("issue already has a parent [issue id: %d]", err.IssueID)
+}
+
+func (err ErrSubIssueExists) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrSubIssueNotExist represents a "SubIssueNotExist" kind of error.
+type ErrSubIssueNotExist struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("issue is not a sub-issue of the parent [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrSubIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrCircularSubIssue represents a "CircularSubIssue" kind of error.
+type ErrCircularSubIssue struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("the parent is the issue or one of its sub-issues [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue makes an issue a child of another issue of the same repository or
+// of another repository of the same organization. An issue has at most one parent.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Position    int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// SubIssuesSummary is the progress of the sub-issues of an issue
+type SubIssuesSummary struct {
+	Total  int64
+	Closed int64
+}
+
+// checkSubIssueRepos checks that the sub-issue is in the repository of the parent or in
+// another repository of the organization owning it
+func checkSubIssueRepos(ctx context.Context, parent, issue *Issue) error {
+	if parent.RepoID == issue.RepoID {
+		return nil
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := parent.Repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+	if !parent.Repo.Owner.IsOrganization() || parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return util.NewInvalidArgumentErrorf("sub-issues must belong to the repository of their parent or to another repository of its organization")
+	}
+	return nil
+}
+
+// AddSubIssue makes the issue the last sub-issue of the parent
+func AddSubIssue(ctx context.Context, doerID int64, parent, issue *Issue) error {
+	if err := checkSubIssueRepos(ctx, parent, issue); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Exist(new(SubIssue))
+		if err != nil {
+			return err
+		} else if exists {
+			return ErrSubIssueExists{IssueID: issue.ID}
+		}
+
+		// walk up from the parent, the issue must not be one of its ancestors
+		for ancestorID := parent.ID; ancestorID != 0; {
+			if ancestorID == issue.ID {
+				return ErrCircularSubIssue{ParentID: parent.ID, IssueID: issue.ID}
+			}
+			if ancestorID, err = getParentIssueID(ctx, ancestorID); err != nil {
+				return err
+			}
+		}
+
+		var position int64
+		if _, err := db.GetEngine(ctx).Table("sub_issue").Where("parent_id = ?", parent.ID).
+			Select("COALESCE(MAX(position), 0)").Get(&position); err != nil {
+			return err
+		}
+
+		return db.Insert(ctx, &SubIssue{
+			ParentID:  parent.ID,
+			IssueID:   issue.ID,
+			Position:  position + 1,
+			CreatorID: doerID,
+		})
+	})
+}
+
+// RemoveSubIssue removes the issue from the sub-issues of the parent
+func RemoveSubIssue(ctx context.Context, parent, issue *Issue) error {
+	n, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, issue.ID).Delete(new(SubIssue))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrSubIssueNotExist{ParentID: parent.ID, IssueID: issue.ID}
+	}
+	return nil
+}
+
+// ReorderSubIssues sets the order of the sub-issues of the parent. issueIDs
+// must contain every sub-issue of the parent.
+func ReorderSubIssues(ctx context.Context, parent *Issue, issueIDs []int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("parent_id = ?", parent.ID).Count(new(SubIssue))
+		if err != nil {
+			return err
+		}
+		if int64(len(issueIDs)) != count {
+			return util.NewInvalidArgumentErrorf("the order must contain the %d sub-issues of the issue", count)
+		}
+
+		for i, issueID := range issueIDs {
+			n, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, issueID).
+				Cols("position").Update(&SubIssue{Position: int64(i + 1)})
+			if err != nil {
+				return err
+			} else if n == 0 {
+				return ErrSubIssueNotExist{ParentID: parent.ID, IssueID: issueID}
+			}
+		}
+		return nil
+	})
+}
+
+func getParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	var parentID int64
+	if _, err := db.GetEngine(ctx).Table("sub_issue").Where("issue_id = ?", issueID).Cols("parent_id").Get(&parentID); err != nil {
+		return 0, err
+	}
+	return parentID, nil
+}
+
+// GetParentIssue returns the parent of the issue, nil if it has none
+func GetParentIssue(ctx context.Context, issueID int64) (*Issue, error) {
+	parentID, err := getParentIssueID(ctx, issueID)
+	if err != nil || parentID == 0 {
+		return nil, err
+	}
+	return GetIssueByID(ctx, parentID)
+}
+
+// GetSubIssues returns the sub-issues of the issue in their order
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", parentID).
+		Asc("sub_issue.position", "sub_issue.id").
+		Find(&issues)
+}
+
+type subIssuesCount struct {
+	ParentID int64
+	Total    int64
+	Closed   int64
+}
+
+// getSubIssuesSummaries returns the progress of the sub-issues of the issues that have some
+func getSubIssuesSummaries(ctx context.Context, parentIDs []int64) (map[int64]*SubIssuesSummary, error) {
+	summaries := make(map[int64]*SubIssuesSummary, len(parentIDs))
+	for len(parentIDs) > 0 {
+		limit := min(len(parentIDs), db.DefaultMaxInSize)
+		var rows []subIssuesCount
+		if err := db.GetEngine(ctx).Table("sub_issue").
+			Join("INNER", "issue", "sub_issue.issue_id = issue.id").
+			Select("sub_issue.parent_id, COUNT(*) AS total, SUM(CASE WHEN issue.is_closed THEN 1 ELSE 0 END) AS closed").
+			In("sub_issue.parent_id", parentIDs[:limit]).
+			GroupBy("sub_issue.parent_id").
+			Find(&rows); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			summaries[row.ParentID] = &SubIssuesSummary{Total: row.Total, Closed: row.Closed}
+		}
+		parentIDs = parentIDs[limit:]
+	}
+	return summaries, nil
+}
+
+// GetSubIssuesSummary returns the number of sub-issues of the issue and how many of them are closed
+func GetSubIssuesSummary(ctx context.Context, parentID int64) (*SubIssuesSummary, error) {
+	summaries, err := getSubIssuesSummaries(ctx, []int64{parentID})
+	if err != nil {
+		return nil, err
+	}
+	if summary, ok := summaries[parentID]; ok {
+		return summary, nil
+	}
+	return &SubIssuesSummary{}, nil
+}
+
+// LoadParentIssue loads the parent of the issue, nil if it has none
+func (issue *Issue) LoadParentIssue(ctx context.Context) (err error) {
+	if issue.isParentIssueLoaded {
+		return nil
+	}
+	if issue.ParentIssue, err = GetParentIssue(ctx, issue.ID); err != nil {
+		return err
+	}
+	if issue.ParentIssue != nil {
+		if err := issue.ParentIssue.LoadRepo(ctx); err != nil {
+			return err
+		}
+	}
+	issue.isParentIssueLoaded = true
+	return nil
+}
+
+// LoadSubIssuesSummary loads the progress of the sub-issues of the issue
+func (issue *Issue) LoadSubIssuesSummary(ctx context.Context) (err error) {
+	if issue.SubIssuesSummary != nil {
+		return nil
+	}
+	issue.SubIssuesSummary, err = GetSubIssuesSummary(ctx, issue.ID)
+	return err
+}
+
+// LoadParentIssues loads the parents of the issues and their repositories
+func (issues IssueList) LoadParentIssues(ctx context.Context) error {
+	issueIDs := issues.getIssueIDs()
+	subIssues := make([]*SubIssue, 0, len(issueIDs))
+	for len(issueIDs) > 0 {
+		limit := min(len(issueIDs), db.DefaultMaxInSize)
+		if err := db.GetEngine(ctx).In("issue_id", issueIDs[:limit]).Find(&subIssues); err != nil {
+			return err
+		}
+		issueIDs = issueIDs[limit:]
+	}
+
+	parentIDs := make(map[int64]int64, len(subIssues))
+	for _, subIssue := range subIssues {
+		parentIDs[subIssue.IssueID] = subIssue.ParentID
+	}
+	parents, err := GetIssuesByIDs(ctx, container.FilterSlice(subIssues, func(subIssue *SubIssue) (int64, bool) {
+		return subIssue.ParentID, true
+	}))
+	if err != nil {
+		return err
+	}
+	if _, err := parents.LoadRepositories(ctx); err != nil {
+		return err
+	}
+	parentsByID := make(map[int64]*Issue, len(parents))
+	for _, parent := range parents {
+		parentsByID[parent.ID] = parent
+	}
+
+	for _, issue := range issues {
+		issue.ParentIssue = parentsByID[parentIDs[issue.ID]]
+		issue.isParentIssueLoaded = true
+	}
+	return nil
+}
+
+// LoadSubIssuesSummaries loads the progress of the sub-issues of the issues
+func (issues IssueList) LoadSubIssuesSummaries(ctx context.Context) error {
+	summaries, err := getSubIssuesSummaries(ctx, issues.getIssueIDs())
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if summary, ok := summaries[issue.ID]; ok {
+			issue.SubIssuesSummary = summary
+		} else {
+			issue.SubIssuesSummary = &SubIssuesSummary{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, issue1, issue2))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, issue1, issue5))
+
+	t.Run("Invalid", func(t *testing.T) {
+		// an issue has a single parent
+		err := issues_model.AddSubIssue(db.DefaultContext, 2, issue5, issue2)
+		assert.True(t, issues_model.IsErrSubIssueExists(err))
+		// issue1 is an ancestor of issue2
+		err = issues_model.AddSubIssue(db.DefaultContext, 2, issue2, issue1)
+		assert.True(t, issues_model.IsErrCircularSubIssue(err))
+		err = issues_model.AddSubIssue(db.DefaultContext, 2, issue1, issue1)
+		assert.True(t, issues_model.IsErrCircularSubIssue(err))
+		// repo1 is owned by a user, issue 6 is in repo3
+		issue6 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+		err = issues_model.AddSubIssue(db.DefaultContext, 2, issue1, issue6)
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		// repo3 and repo5 are owned by org3
+		issue6 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+		issue15 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 15})
+		require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, issue6, issue15))
+
+		parent, err := issues_model.GetParentIssue(db.DefaultContext, issue15.ID)
+		require.NoError(t, err)
+		assert.Equal(t, issue6.ID, parent.ID)
+	})
+
+	subIssues, err := issues_model.GetSubIssues(db.DefaultContext, issue1.ID)
+	require.NoError(t, err)
+	if assert.Len(t, subIssues, 2) {
+		assert.Equal(t, issue2.ID, subIssues[0].ID)
+		assert.Equal(t, issue5.ID, subIssues[1].ID)
+	}
+
+	// issue5 is closed
+	summary, err := issues_model.GetSubIssuesSummary(db.DefaultContext, issue1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.SubIssuesSummary{Total: 2, Closed: 1}, summary)
+
+	require.ErrorIs(t, issues_model.ReorderSubIssues(db.DefaultContext, issue1, []int64{issue5.ID}), util.ErrInvalidArgument)
+	require.NoError(t, issues_model.ReorderSubIssues(db.DefaultContext, issue1, []int64{issue5.ID, issue2.ID}))
+	subIssues, err = issues_model.GetSubIssues(db.DefaultContext, issue1.ID)
+	require.NoError(t, err)
+	if assert.Len(t, subIssues, 2) {
+		assert.Equal(t, issue5.ID, subIssues[0].ID)
+	}
+
+	require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, issue1, issue5))
+	err = issues_model.RemoveSubIssue(db.DefaultContext, issue1, issue5)
+	assert.True(t, issues_model.IsErrSubIssueNotExist(err))
+	parent, err := issues_model.GetParentIssue(db.DefaultContext, issue5.ID)
+	require.NoError(t, err)
+	assert.Nil(t, parent)
+}
@@ -7,12 +7,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"slices"
 	"time"
 
 	"forgejo.org/models/db"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 
 	"xorm.io/builder"
 )
@@ -29,6 +31,27 @@ const (
 	ReportStatusTypeIgnored // 3
 )
 
+var reportStatusTypeNames = map[ReportStatusType]string{
+	ReportStatusTypeOpen:    "open",
+	ReportStatusTypeHandled: "handled",
+	ReportStatusTypeIgnored: "ignored",
+}
+
+// Name returns the name of the status, as used by the API.
+func (t ReportStatusType) Name() string {
+	return reportStatusTypeNames[t]
+}
+
+// ParseReportStatusType returns the status with the given name, or 0 if there is none.
+func ParseReportStatusType(name string) ReportStatusType {
+	for status, statusName := range reportStatusTypeNames {
+		if statusName == name {
+			return status
+		}
+	}
+	return 0
+}
+
 type (
 	// AbuseCategoryType defines the categories in which a user can include the reported content.
 	AbuseCategoryType int
@@ -56,6 +79,28 @@ var AbuseCategoriesTranslationKeys = map[AbuseCategoryType]string{
 	AbuseCategoryTypeOther:          "moderation.abuse_category.other_violations",
 }
 
+var abuseCategoryTypeNames = map[AbuseCategoryType]string{
+	AbuseCategoryTypeSpam:           "spam",
+	AbuseCategoryTypeMalware:        "malware",
+	AbuseCategoryTypeIllegalContent: "illegal_content",
+	AbuseCategoryTypeOther:          "other",
+}
+
+// Name returns the name of the category, as used by the API.
+func (t AbuseCategoryType) Name() string {
+	return abuseCategoryTypeNames[t]
+}
+
+// ParseAbuseCategoryType returns the category with the given name, or 0 if there is none.
+func ParseAbuseCategoryType(name string) AbuseCategoryType {
+	for category, categoryName := range abuseCategoryTypeNames {
+		if categoryName == name {
+			return category
+		}
+	}
+	return 0
+}
+
 // GetAbuseCategoriesList returns a list of pairs with the available abuse category types
 // and their corresponding translation keys
 func GetAbuseCategoriesList() []AbuseCategoryItem {
@@ -96,6 +141,28 @@ func (t ReportedContentType) IsValid() bool {
 	return slices.Contains(allReportedContentTypes, t)
 }
 
+var reportedContentTypeNames = map[ReportedContentType]string{
+	ReportedContentTypeUser:       "user",
+	ReportedContentTypeRepository: "repository",
+	ReportedContentTypeIssue:      "issue",
+	ReportedContentTypeComment:    "comment",
+}
+
+// Name returns the name of the content type, as used by the API.
+func (t ReportedContentType) Name() string {
+	return reportedContentTypeNames[t]
+}
+
+// ParseReportedContentType returns the content type with the given name, or 0 if there is none.
+func ParseReportedContentType(name string) ReportedContentType {
+	for contentType, contentTypeName := range reportedContentTypeNames {
+		if contentTypeName == name {
+			return contentType
+		}
+	}
+	return 0
+}
+
 // AbuseReport represents a report of abusive content.
 type AbuseReport struct {
 	ID     int64            `xorm:"pk autoincr"`
@@ -111,13 +178,34 @@ type AbuseReport struct {
 	// Remarks provided by the reporter.
 	Remarks string `xorm:"VARCHAR(500)"`
 	// The ID of the corresponding shadow-copied content when exists; otherwise null.
-	ShadowCopyID sql.NullInt64      `xorm:"DEFAULT NULL"`
+	ShadowCopyID sql.NullInt64 `xorm:"DEFAULT NULL"`
+	// The ID of the moderator the report is assigned to; 0 when not assigned.
+	AssigneeID   int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
 	CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
 	ResolvedUnix timeutil.TimeStamp `xorm:"DEFAULT NULL"`
 }
 
 var ErrSelfReporting = errors.New("reporting yourself is not allowed")
 
+// ErrAbuseReportNotExist represents a "AbuseReportNotExist" kind of error.
+type ErrAbuseReportNotExist struct {
+	ID int64
+}
+
+// IsErrAbuseReportNotExist checks if an error is a ErrAbuseReportNotExist.
+func IsErrAbuseReportNotExist(err error) bool {
+	_, ok := err.(ErrAbuseReportNotExist)
+	return ok
+}
+
+func (err ErrAbuseReportNotExist) Error() string {
+	return fmt.Sprintf("abuse report does not exist [id: %d]", err.ID)
+}
+
+func (err ErrAbuseReportNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
 func init() {
 	// RegisterModel will create the table if does not already exist
 	// or any missing columns if the table was previously created.
@@ -202,3 +290,75 @@ func updateStatus(ctx context.Context, contentType ReportedContentType, contentI
 
 	return err
 }
+
+// GetReportByID returns the abuse report with the given ID.
+func GetReportByID(ctx context.Context, id int64) (*AbuseReport, error) {
+	report, exist, err := db.GetByID[AbuseReport](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, ErrAbuseReportNotExist{ID: id}
+	}
+	return report, nil
+}
+
+// FindReportsOptions represents the options used to filter abuse reports.
+type FindReportsOptions struct {
+	db.ListOptions
+	Status      ReportStatusType
+	ContentType ReportedContentType
+	ContentID   int64
+	Category    AbuseCategoryType
+	// AssigneeID filters by the moderator the reports are assigned to;
+	// db.NoConditionID (-1) selects the unassigned reports.
+	AssigneeID int64
+}
+
+func (opts FindReportsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.Status > 0 {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	if opts.ContentType > 0 {
+		cond = cond.And(builder.Eq{"content_type": opts.ContentType})
+	}
+	if opts.ContentID > 0 {
+		cond = cond.And(builder.Eq{"content_id": opts.ContentID})
+	}
+	if opts.Category > 0 {
+		cond = cond.And(builder.Eq{"category": opts.Category})
+	}
+	if opts.AssigneeID > 0 {
+		cond = cond.And(builder.Eq{"assignee_id": opts.AssigneeID})
+	} else if opts.AssigneeID == db.NoConditionID {
+		cond = cond.And(builder.Eq{"assignee_id": 0})
+	}
+	return cond
+}
+
+func (opts FindReportsOptions) ToOrders() string {
+	return "created_unix ASC, id ASC"
+}
+
+// CountDistinctReporters returns how many distinct users submitted open reports
+// within the given category for the item with the given type and ID.
+func CountDistinctReporters(ctx context.Context, contentType ReportedContentType, contentID int64, category AbuseCategoryType) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{
+		"status":       ReportStatusTypeOpen,
+		"content_type": contentType,
+		"content_id":   contentID,
+		"category":     category,
+	}).Distinct("reporter_id").Count(new(AbuseReport))
+}
+
+// AssignReports assigns all the open reports linked to the same item (user, repository, issue or comment)
+// to the moderator with the given ID; an ID of 0 removes the assignment.
+func AssignReports(ctx context.Context, contentType ReportedContentType, contentID, assigneeID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{
+		"content_type": contentType,
+		"content_id":   contentID,
+		"status":       ReportStatusTypeOpen,
+	}).Cols("assignee_id").Update(&AbuseReport{AssigneeID: assigneeID})
+
+	return err
+}
@@ -19,6 +19,7 @@ type AbuseReportDetailed struct {
 	AbuseReport        `xorm:"extends"`
 	ReportedTimes      int // only for overview
 	ReporterName       string
+	AssigneeName       string
 	ContentReference   string
 	ShadowCopyDate     timeutil.TimeStamp // only for details
 	ShadowCopyRawValue string             // only for details
@@ -86,7 +87,7 @@ func GetOpenReports(ctx context.Context) ([]*AbuseReportDetailed, error) {
 		identifierEscapeChar = `"`
 	}
 
-	err := db.GetEngine(ctx).SQL(fmt.Sprintf(`SELECT AR.*, ARD.reported_times, U.name AS reporter_name, REFS.ref AS content_reference
+	err := db.GetEngine(ctx).SQL(fmt.Sprintf(`SELECT AR.*, ARD.reported_times, U.name AS reporter_name, A.name AS assignee_name, REFS.ref AS content_reference
 		FROM abuse_report AR
 		INNER JOIN (
 			SELECT min(id) AS id, count(id) AS reported_times
@@ -95,6 +96,7 @@ func GetOpenReports(ctx context.Context) ([]*AbuseReportDetailed, error) {
 			GROUP BY content_type, content_id
 		) ARD ON ARD.id = AR.id
 		LEFT JOIN %[1]suser%[1]s U ON U.id = AR.reporter_id
+		LEFT JOIN %[1]suser%[1]s A ON A.id = AR.assignee_id
 		LEFT JOIN (
 			SELECT %[3]d AS type, id, concat('@', name) AS "ref"
 			FROM %[1]suser%[1]s WHERE id IN (
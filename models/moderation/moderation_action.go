@@ -0,0 +1,145 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ModerationActionType defines the kinds of actions recorded within the audit trail of abuse reports.
+type ModerationActionType int
+
+const (
+	// ModerationActionTypeAssign is recorded when the reports are assigned to a moderator.
+	ModerationActionTypeAssign ModerationActionType = iota + 1 // 1
+	// ModerationActionTypeUnassign is recorded when the assignment of the reports is removed.
+	ModerationActionTypeUnassign // 2
+	// ModerationActionTypeMarkAsHandled is recorded when the reports are marked as handled.
+	ModerationActionTypeMarkAsHandled // 3
+	// ModerationActionTypeMarkAsIgnored is recorded when the reports are marked as ignored.
+	ModerationActionTypeMarkAsIgnored // 4
+	// ModerationActionTypeSuspendAccount is recorded when the reported account (or the poster of the reported content) is suspended.
+	ModerationActionTypeSuspendAccount // 5
+	// ModerationActionTypeDeleteAccount is recorded when the reported account is deleted.
+	ModerationActionTypeDeleteAccount // 6
+	// ModerationActionTypeDeleteRepo is recorded when the reported repository is deleted.
+	ModerationActionTypeDeleteRepo // 7
+	// ModerationActionTypeDeleteIssue is recorded when the reported issue or pull request is deleted.
+	ModerationActionTypeDeleteIssue // 8
+	// ModerationActionTypeDeleteComment is recorded when the reported comment is deleted.
+	ModerationActionTypeDeleteComment // 9
+	// ModerationActionTypeHideRepo is recorded when the reported repository is made private.
+	ModerationActionTypeHideRepo // 10
+)
+
+// llu:TrKeys
+var ModerationActionTypeTranslationKeys = map[ModerationActionType]string{
+	ModerationActionTypeAssign:         "moderation.audit.assign",
+	ModerationActionTypeUnassign:       "moderation.audit.unassign",
+	ModerationActionTypeMarkAsHandled:  "moderation.audit.mark_as_handled",
+	ModerationActionTypeMarkAsIgnored:  "moderation.audit.mark_as_ignored",
+	ModerationActionTypeSuspendAccount: "moderation.audit.suspend_account",
+	ModerationActionTypeDeleteAccount:  "moderation.audit.delete_account",
+	ModerationActionTypeDeleteRepo:     "moderation.audit.delete_repo",
+	ModerationActionTypeDeleteIssue:    "moderation.audit.delete_issue",
+	ModerationActionTypeDeleteComment:  "moderation.audit.delete_comment",
+	ModerationActionTypeHideRepo:       "moderation.audit.hide_repo",
+}
+
+var moderationActionTypeNames = map[ModerationActionType]string{
+	ModerationActionTypeAssign:         "assign",
+	ModerationActionTypeUnassign:       "unassign",
+	ModerationActionTypeMarkAsHandled:  "mark_as_handled",
+	ModerationActionTypeMarkAsIgnored:  "mark_as_ignored",
+	ModerationActionTypeSuspendAccount: "suspend_account",
+	ModerationActionTypeDeleteAccount:  "delete_account",
+	ModerationActionTypeDeleteRepo:     "delete_repo",
+	ModerationActionTypeDeleteIssue:    "delete_issue",
+	ModerationActionTypeDeleteComment:  "delete_comment",
+	ModerationActionTypeHideRepo:       "hide_repo",
+}
+
+// Name returns the name of the action type, as used by the API.
+func (t ModerationActionType) Name() string {
+	return moderationActionTypeNames[t]
+}
+
+// ModerationAction represents an entry within the audit trail of the reports
+// submitted for an item (user, repository, issue or comment).
+type ModerationAction struct {
+	ID int64 `xorm:"pk autoincr"`
+	// Reported content type and ID the action was done for (see AbuseReport).
+	ContentType ReportedContentType `xorm:"INDEX(content) NOT NULL"`
+	ContentID   int64               `xorm:"INDEX(content) NOT NULL"`
+	// The ID of the moderator who did the action; 0 when it was done automatically
+	// because the reports reached one of the configured thresholds.
+	DoerID int64                `xorm:"NOT NULL DEFAULT 0"`
+	Type   ModerationActionType `xorm:"NOT NULL"`
+	// The ID of the user the action targets (the assignee or the suspended account), if any.
+	TargetUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+	Details      string             `xorm:"TEXT"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ModerationAction))
+}
+
+// IsAutomatic reports whether the action was done by Forgejo rather than by a moderator.
+func (a *ModerationAction) IsAutomatic() bool {
+	return a.DoerID == 0
+}
+
+// RecordModerationAction adds a new entry to the audit trail.
+func RecordModerationAction(ctx context.Context, action *ModerationAction) error {
+	_, err := db.GetEngine(ctx).Insert(action)
+	return err
+}
+
+// GetModerationActions returns the audit trail of the item with the given type and ID, oldest first.
+func GetModerationActions(ctx context.Context, contentType ReportedContentType, contentID int64) ([]*ModerationAction, error) {
+	actions := make([]*ModerationAction, 0, 10)
+	return actions, db.GetEngine(ctx).
+		Where(builder.Eq{"content_type": contentType, "content_id": contentID}).
+		Asc("created_unix", "id").
+		Find(&actions)
+}
+
+// HasAutomaticActionSince reports whether the item with the given type and ID was already acted upon
+// automatically since the given time, so that reaching a threshold again does not repeat the action.
+func HasAutomaticActionSince(ctx context.Context, contentType ReportedContentType, contentID int64, since timeutil.TimeStamp) (bool, error) {
+	return db.GetEngine(ctx).
+		Where(builder.Eq{"content_type": contentType, "content_id": contentID, "doer_id": 0}).
+		And(builder.Gte{"created_unix": since}).
+		Exist(new(ModerationAction))
+}
+
+// IsRepositoryHiddenPendingReview reports whether the repository with the given ID was made private
+// automatically because of its abuse reports and the reports are still open. Until a moderator reviews
+// them, only an administrator may make the repository public again.
+func IsRepositoryHiddenPendingReview(ctx context.Context, repoID int64) (bool, error) {
+	firstOpenReport := new(AbuseReport)
+	has, err := db.GetEngine(ctx).
+		Where(builder.Eq{"content_type": ReportedContentTypeRepository, "content_id": repoID, "status": ReportStatusTypeOpen}).
+		Asc("created_unix").
+		Get(firstOpenReport)
+	if err != nil || !has {
+		return false, err
+	}
+
+	return db.GetEngine(ctx).
+		Where(builder.Eq{
+			"content_type": ReportedContentTypeRepository,
+			"content_id":   repoID,
+			"doer_id":      0,
+			"type":         ModerationActionTypeHideRepo,
+		}).
+		And(builder.Gte{"created_unix": firstOpenReport.CreatedUnix}).
+		Exist(new(ModerationAction))
+}
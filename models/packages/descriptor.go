@@ -31,6 +31,7 @@ import (
 	"forgejo.org/modules/packages/rpm"
 	"forgejo.org/modules/packages/rubygems"
 	"forgejo.org/modules/packages/swift"
+	"forgejo.org/modules/packages/terraform"
 	"forgejo.org/modules/packages/vagrant"
 	"forgejo.org/modules/util"
 
@@ -196,6 +197,8 @@ func GetPackageDescriptor(ctx context.Context, pv *PackageVersion) (*PackageDesc
 		metadata = &rubygems.Metadata{}
 	case TypeSwift:
 		metadata = &swift.Metadata{}
+	case TypeTerraform:
+		metadata = &terraform.Metadata{}
 	case TypeVagrant:
 		metadata = &vagrant.Metadata{}
 	default:
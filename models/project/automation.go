@@ -0,0 +1,195 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationTrigger is the event that makes an automation rule move an issue
+type AutomationTrigger int
+
+const (
+	// AutomationTriggerIssueOpened an issue is opened
+	AutomationTriggerIssueOpened AutomationTrigger = iota + 1
+	// AutomationTriggerIssueClosed an issue or pull request is closed
+	AutomationTriggerIssueClosed
+	// AutomationTriggerIssueReopened an issue or pull request is reopened
+	AutomationTriggerIssueReopened
+	// AutomationTriggerPullRequestOpened a pull request, or one closing the issue, is opened
+	AutomationTriggerPullRequestOpened
+	// AutomationTriggerPullRequestMerged a pull request, or one closing the issue, is merged
+	AutomationTriggerPullRequestMerged
+	// AutomationTriggerLabelAdded a label is added to an issue or pull request
+	AutomationTriggerLabelAdded
+)
+
+var automationTriggerNames = map[AutomationTrigger]string{
+	AutomationTriggerIssueOpened:       "issue_opened",
+	AutomationTriggerIssueClosed:       "issue_closed",
+	AutomationTriggerIssueReopened:     "issue_reopened",
+	AutomationTriggerPullRequestOpened: "pull_request_opened",
+	AutomationTriggerPullRequestMerged: "pull_request_merged",
+	AutomationTriggerLabelAdded:        "label_added",
+}
+
+// Name returns the name of the trigger used by the API
+func (t AutomationTrigger) Name() string {
+	return automationTriggerNames[t]
+}
+
+// AutomationTriggerFromName returns the trigger with the given name, 0 if there is none
+func AutomationTriggerFromName(name string) AutomationTrigger {
+	for t, n := range automationTriggerNames {
+		if n == name {
+			return t
+		}
+	}
+	return 0
+}
+
+// ErrAutomationRuleNotExist represents a "AutomationRuleNotExist" kind of error.
+type ErrAutomationRuleNotExist struct {
+	ID        int64
+	ProjectID int64
+}
+
+// IsErrAutomationRuleNotExist checks if an error is a ErrAutomationRuleNotExist
+func IsErrAutomationRuleNotExist(err error) bool {
+	_, ok := err.(ErrAutomationRuleNotExist)
+	return ok
+}
+
+func (err ErrAutomationRuleNotExist) Error() string {
+	return fmt.Sprintf("project automation rule does not exist [id: %d, project_id: %d]", err.ID, err.ProjectID)
+}
+
+func (err ErrAutomationRuleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// AutomationRule moves the issues of a project to a column when TriggerType happens.
+// Issues opened in the repository of the project are added to it.
+type AutomationRule struct {
+	ID          int64             `xorm:"pk autoincr"`
+	ProjectID   int64             `xorm:"INDEX NOT NULL"`
+	TriggerType AutomationTrigger `xorm:"NOT NULL"`
+	LabelID     int64             `xorm:"NOT NULL DEFAULT 0"` // the label of AutomationTriggerLabelAdded
+	ColumnID    int64             `xorm:"INDEX NOT NULL"`
+	CreatorID   int64             `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (AutomationRule) TableName() string {
+	return "project_automation_rule"
+}
+
+func init() {
+	db.RegisterModel(new(AutomationRule))
+}
+
+// validate checks the trigger of the rule and that its column belongs to its project
+func (r *AutomationRule) validate(ctx context.Context) error {
+	if _, ok := automationTriggerNames[r.TriggerType]; !ok {
+		return util.NewInvalidArgumentErrorf("invalid automation trigger %d", r.TriggerType)
+	}
+	if r.TriggerType == AutomationTriggerLabelAdded && r.LabelID == 0 {
+		return util.NewInvalidArgumentErrorf("the %s trigger requires a label", r.TriggerType.Name())
+	}
+	if r.TriggerType != AutomationTriggerLabelAdded {
+		r.LabelID = 0
+	}
+	column, err := GetColumn(ctx, r.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.ProjectID != r.ProjectID {
+		return ErrProjectColumnNotExist{ColumnID: r.ColumnID}
+	}
+	return nil
+}
+
+// NewAutomationRule adds an automation rule to a project
+func NewAutomationRule(ctx context.Context, rule *AutomationRule) error {
+	if err := rule.validate(ctx); err != nil {
+		return err
+	}
+	return db.Insert(ctx, rule)
+}
+
+// UpdateAutomationRule updates the trigger, label and column of an automation rule
+func UpdateAutomationRule(ctx context.Context, rule *AutomationRule) error {
+	if err := rule.validate(ctx); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(rule.ID).Cols("trigger_type", "label_id", "column_id").Update(rule)
+	return err
+}
+
+// GetAutomationRule returns the automation rule of the project
+func GetAutomationRule(ctx context.Context, projectID, id int64) (*AutomationRule, error) {
+	rule, exists, err := db.Get[AutomationRule](ctx, builder.Eq{"id": id, "project_id": projectID})
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrAutomationRuleNotExist{ID: id, ProjectID: projectID}
+	}
+	return rule, nil
+}
+
+// DeleteAutomationRule deletes the automation rule of the project
+func DeleteAutomationRule(ctx context.Context, projectID, id int64) error {
+	n, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", id, projectID).Delete(new(AutomationRule))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrAutomationRuleNotExist{ID: id, ProjectID: projectID}
+	}
+	return nil
+}
+
+// FindAutomationRules returns the automation rules of the project
+func FindAutomationRules(ctx context.Context, projectID int64) ([]*AutomationRule, error) {
+	rules := make([]*AutomationRule, 0, 5)
+	return rules, db.GetEngine(ctx).Where("project_id = ?", projectID).Asc("id").Find(&rules)
+}
+
+// FindAutomationRulesByTrigger returns the automation rules of the open projects
+// matching cond that are triggered by trigger, oldest first
+func FindAutomationRulesByTrigger(ctx context.Context, trigger AutomationTrigger, cond builder.Cond) ([]*AutomationRule, error) {
+	rules := make([]*AutomationRule, 0, 5)
+	return rules, db.GetEngine(ctx).
+		Where(builder.Eq{"project_automation_rule.trigger_type": trigger}).
+		And(builder.In("project_automation_rule.project_id", builder.Select("project.id").From("project").
+			Where(builder.Eq{"project.is_closed": false}.And(cond)))).
+		Asc("project_automation_rule.id").
+		Find(&rules)
+}
+
+func deleteAutomationRulesByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(new(AutomationRule))
+	return err
+}
+
+func deleteAutomationRulesByColumnID(ctx context.Context, columnID int64) error {
+	_, err := db.GetEngine(ctx).Where("column_id = ?", columnID).Delete(new(AutomationRule))
+	return err
+}
+
+func deleteAutomationRulesByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.In("project_id", builder.Select("id").From("project").Where(builder.Eq{"repo_id": repoID}))).
+		Delete(new(AutomationRule))
+	return err
+}
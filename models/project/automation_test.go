@@ -0,0 +1,65 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
+)
+
+func TestAutomationRule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	t.Run("Invalid", func(t *testing.T) {
+		// the label trigger requires a label
+		require.Error(t, NewAutomationRule(db.DefaultContext, &AutomationRule{ProjectID: 1, TriggerType: AutomationTriggerLabelAdded, ColumnID: 2}))
+		// column 4 belongs to project 4
+		err := NewAutomationRule(db.DefaultContext, &AutomationRule{ProjectID: 1, TriggerType: AutomationTriggerIssueClosed, ColumnID: 4})
+		assert.True(t, IsErrProjectColumnNotExist(err))
+	})
+
+	rule := &AutomationRule{ProjectID: 1, TriggerType: AutomationTriggerIssueClosed, LabelID: 1, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomationRule(db.DefaultContext, rule))
+	assert.Zero(t, rule.LabelID)
+
+	rules, err := FindAutomationRulesByTrigger(db.DefaultContext, AutomationTriggerIssueClosed, builder.Eq{"project.repo_id": 1})
+	require.NoError(t, err)
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, rule.ID, rules[0].ID)
+	}
+	rules, err = FindAutomationRulesByTrigger(db.DefaultContext, AutomationTriggerIssueReopened, builder.Eq{"project.repo_id": 1})
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	rule.TriggerType = AutomationTriggerLabelAdded
+	rule.LabelID = 1
+	rule.ColumnID = 2
+	require.NoError(t, UpdateAutomationRule(db.DefaultContext, rule))
+	rule, err = GetAutomationRule(db.DefaultContext, 1, rule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, AutomationTriggerLabelAdded, rule.TriggerType)
+	assert.EqualValues(t, 1, rule.LabelID)
+	assert.EqualValues(t, 2, rule.ColumnID)
+
+	_, err = GetAutomationRule(db.DefaultContext, 2, rule.ID)
+	assert.True(t, IsErrAutomationRuleNotExist(err))
+
+	// deleting the column deletes its rules
+	require.NoError(t, DeleteColumnByID(db.DefaultContext, 2))
+	unittest.AssertNotExistsBean(t, &AutomationRule{ID: rule.ID})
+}
+
+func TestMoveIssueToColumn(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	column := unittest.AssertExistsAndLoadBean(t, &Column{ID: 3, ProjectID: 1})
+	require.NoError(t, MoveIssueToColumn(db.DefaultContext, 1, column))
+	unittest.AssertExistsAndLoadBean(t, &ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 3, Sorting: 1})
+}
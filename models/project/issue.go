@@ -86,6 +86,27 @@ func MoveIssuesOnProjectColumn(ctx context.Context, column *Column, sortedIssueI
 	})
 }
 
+// MoveIssueToColumn moves an issue of the project of the column to the end of the column
+func MoveIssueToColumn(ctx context.Context, issueID int64, column *Column) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		res := struct {
+			MaxSorting int64
+			IssueCount int64
+		}{}
+		if _, err := db.GetEngine(ctx).Select("max(sorting) as max_sorting, count(*) as issue_count").
+			Table("project_issue").
+			Where("project_id=?", column.ProjectID).
+			And("project_board_id=?", column.ID).
+			Get(&res); err != nil {
+			return err
+		}
+
+		_, err := db.GetEngine(ctx).Exec("UPDATE `project_issue` SET project_board_id=?, sorting=? WHERE project_id=? AND issue_id=?",
+			column.ID, util.Iif(res.IssueCount > 0, res.MaxSorting+1, 0), column.ProjectID, issueID)
+		return err
+	})
+}
+
 func (c *Column) moveIssuesToAnotherColumn(ctx context.Context, newColumn *Column) error {
 	if c.ProjectID != newColumn.ProjectID {
 		return errors.New("columns have to be in the same project")
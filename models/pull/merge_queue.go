@@ -0,0 +1,141 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+)
+
+// MergeQueueBranchPrefix is the prefix of the branches the speculative merges of queued pull requests are pushed to
+const MergeQueueBranchPrefix = "merge-queue/"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(s) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(s) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool                  `xorm:"NOT NULL DEFAULT false"`
+	// HeadCommitID is the head of the pull request when it was queued, pushing to the pull request ejects it
+	HeadCommitID string `xorm:"VARCHAR(64)"`
+	// BaseCommitID is the commit the speculative merge was built on top of
+	BaseCommitID string `xorm:"VARCHAR(64)"`
+	// MergeCommitID is the speculative merge commit the checks run against
+	MergeCommitID string             `xorm:"VARCHAR(64)"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// MergeQueueBranchName returns the name of the branch the speculative merge of a queued pull request is pushed to
+func MergeQueueBranchName(baseBranch string, index int64) string {
+	return fmt.Sprintf("%s%s/pr-%d", MergeQueueBranchPrefix, baseBranch, index)
+}
+
+// LoadDoer loads the user who added the pull request to the queue
+func (e *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if e.Doer != nil {
+		return nil
+	}
+	e.Doer, err = user_model.GetPossibleUserByID(ctx, e.DoerID)
+	return err
+}
+
+// ErrAlreadyInMergeQueue represents an error when a pull request is already in the merge queue
+type ErrAlreadyInMergeQueue struct {
+	PullID int64
+}
+
+func (err ErrAlreadyInMergeQueue) Error() string {
+	return fmt.Sprintf("pull request is already in the merge queue [pull_id: %d]", err.PullID)
+}
+
+// IsErrAlreadyInMergeQueue checks if an error is a ErrAlreadyInMergeQueue.
+func IsErrAlreadyInMergeQueue(err error) bool {
+	_, ok := err.(ErrAlreadyInMergeQueue)
+	return ok
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, entry *MergeQueueEntry) error {
+	if exists, _, err := GetMergeQueueEntryByPullID(ctx, entry.PullID); err != nil {
+		return err
+	} else if exists {
+		return ErrAlreadyInMergeQueue{PullID: entry.PullID}
+	}
+
+	_, err := db.GetEngine(ctx).Insert(entry)
+	return err
+}
+
+// GetMergeQueueEntryByPullID gets the merge queue entry of a pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (bool, *MergeQueueEntry, error) {
+	entry := &MergeQueueEntry{}
+	exists, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(entry)
+	if err != nil || !exists {
+		return false, nil, err
+	}
+	return true, entry, nil
+}
+
+// GetMergeQueueEntries returns the entries of the merge queue of a branch, in the order they will be merged
+func GetMergeQueueEntries(ctx context.Context, repoID int64, baseBranch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 5)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ?", repoID, baseBranch).
+		OrderBy("id ASC").
+		Find(&entries)
+}
+
+// GetMergeQueueBaseBranches returns the branches of a repository that have pull requests in their merge queue
+func GetMergeQueueBaseBranches(ctx context.Context, repoID int64) ([]string, error) {
+	branches := make([]string, 0, 2)
+	return branches, db.GetEngine(ctx).Table("pull_merge_queue").
+		Where("repo_id = ?", repoID).
+		Distinct("base_branch").
+		Find(&branches)
+}
+
+// GetMergeQueueEntriesByMergeCommitID returns the entries whose speculative merge is the given commit
+func GetMergeQueueEntriesByMergeCommitID(ctx context.Context, repoID int64, sha string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 1)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND merge_commit_id = ?", repoID, sha).
+		Find(&entries)
+}
+
+// UpdateMergeQueueEntrySpeculativeMerge records the speculative merge that was built for an entry
+func UpdateMergeQueueEntrySpeculativeMerge(ctx context.Context, entry *MergeQueueEntry) error {
+	_, err := db.GetEngine(ctx).ID(entry.ID).Cols("base_commit_id", "merge_commit_id").Update(entry)
+	return err
+}
+
+// DeleteMergeQueueEntry removes a pull request from the merge queue
+func DeleteMergeQueueEntry(ctx context.Context, pullID int64) error {
+	n, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Delete(&MergeQueueEntry{})
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pullID}
+	}
+	return nil
+}
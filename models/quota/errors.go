@@ -3,7 +3,10 @@
 
 package quota
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type ErrRuleAlreadyExists struct {
 	Name string
@@ -125,3 +128,20 @@ func IsErrParseLimitSubjectUnrecognized(err error) bool {
 func (err ErrParseLimitSubjectUnrecognized) Error() string {
 	return fmt.Sprintf("unrecognized quota limit subject: [subject: %s]", err.Subject)
 }
+
+type ErrIncompatibleLimitSubjects struct {
+	Subjects LimitSubjects
+}
+
+func IsErrIncompatibleLimitSubjects(err error) bool {
+	_, ok := err.(ErrIncompatibleLimitSubjects)
+	return ok
+}
+
+func (err ErrIncompatibleLimitSubjects) Error() string {
+	subjects := make([]string, len(err.Subjects))
+	for i, subject := range err.Subjects {
+		subjects[i] = subject.String()
+	}
+	return fmt.Sprintf("quota limit subjects cannot be combined: [subjects: %s]", strings.Join(subjects, ", "))
+}
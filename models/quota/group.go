@@ -5,6 +5,7 @@ package quota
 
 import (
 	"context"
+	"slices"
 
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
@@ -204,14 +205,61 @@ func (gl *GroupList) Evaluate(used Used, forSubject LimitSubject) (pass bool) {
 		return EvaluateDefault(used, forSubject)
 	}
 
+	matched := false
 	for _, group := range *gl {
 		groupMatch, groupAllow := group.Evaluate(used, forSubject)
 		if groupMatch && groupAllow {
 			// evaluation stops as soon as we find a matching group that allows the action
 			return true
 		}
+		matched = matched || groupMatch
 	}
-	return false
+
+	// Count and ceiling subjects are opt-in: unless a rule covers them, they
+	// do not restrict anything.
+	return !matched && forSubject.Kind() != LimitSubjectKindSize
+}
+
+// Group.Ceiling returns whether the group contains a rule for the ceiling
+// subject and if so, the lowest limit among those rules (-1 meaning unlimited)
+func (g *Group) Ceiling(forSubject LimitSubject) (match bool, limit int64) {
+	limit = -1
+	for _, rule := range g.Rules {
+		if !slices.Contains(rule.Subjects, forSubject) {
+			continue
+		}
+		match = true
+		if rule.Limit != -1 && (limit == -1 || rule.Limit < limit) {
+			limit = rule.Limit
+		}
+	}
+	return match, limit
+}
+
+// GroupList.Ceiling returns the largest size a single object may have for the
+// ceiling subject, or -1 if there is no such limit. Like for the other
+// subjects, a single group allowing the object is enough.
+func (gl *GroupList) Ceiling(forSubject LimitSubject) int64 {
+	if gl == nil {
+		return -1
+	}
+
+	matched := false
+	ceiling := int64(-1)
+	for _, group := range *gl {
+		groupMatch, groupLimit := group.Ceiling(forSubject)
+		if !groupMatch {
+			continue
+		}
+		if groupLimit == -1 {
+			return -1
+		}
+		if !matched || groupLimit > ceiling {
+			ceiling = groupLimit
+		}
+		matched = true
+	}
+	return ceiling
 }
 
 func GetGroupByName(ctx context.Context, name string) (*Group, error) {
@@ -25,9 +25,32 @@ const (
 	LimitSubjectSizeAssetsArtifacts
 	LimitSubjectSizeAssetsPackagesAll
 	LimitSubjectSizeWiki
+	LimitSubjectCountReposAll
+	LimitSubjectCountReposPublic
+	LimitSubjectCountReposPrivate
+	LimitSubjectCountPackages
+	LimitSubjectSizeGitObjectMax
 
+	// LimitSubjectFirst and LimitSubjectLast delimit the size subjects, which
+	// are the only ones covered by the default quota.
 	LimitSubjectFirst = LimitSubjectSizeAll
 	LimitSubjectLast  = LimitSubjectSizeWiki
+
+	LimitSubjectCountFirst = LimitSubjectCountReposAll
+	LimitSubjectCountLast  = LimitSubjectCountPackages
+)
+
+// LimitSubjectKind tells what a subject measures, and thus how the limit of a
+// rule is compared against it.
+type LimitSubjectKind int
+
+const (
+	// LimitSubjectKindSize subjects sum the size of everything the user owns
+	LimitSubjectKindSize LimitSubjectKind = iota
+	// LimitSubjectKindCount subjects count the number of items the user owns
+	LimitSubjectKindCount
+	// LimitSubjectKindCeiling subjects limit the size of a single object
+	LimitSubjectKindCeiling
 )
 
 var limitSubjectRepr = map[string]LimitSubject{
@@ -45,6 +68,11 @@ var limitSubjectRepr = map[string]LimitSubject{
 	"size:assets:artifacts":            LimitSubjectSizeAssetsArtifacts,
 	"size:assets:packages:all":         LimitSubjectSizeAssetsPackagesAll,
 	"size:assets:wiki":                 LimitSubjectSizeWiki,
+	"count:repos:all":                  LimitSubjectCountReposAll,
+	"count:repos:public":               LimitSubjectCountReposPublic,
+	"count:repos:private":              LimitSubjectCountReposPrivate,
+	"count:packages":                   LimitSubjectCountPackages,
+	"size:git:object:max":              LimitSubjectSizeGitObjectMax,
 }
 
 func (subject LimitSubject) String() string {
@@ -56,6 +84,17 @@ func (subject LimitSubject) String() string {
 	return "<unknown>"
 }
 
+func (subject LimitSubject) Kind() LimitSubjectKind {
+	switch {
+	case subject >= LimitSubjectCountFirst && subject <= LimitSubjectCountLast:
+		return LimitSubjectKindCount
+	case subject == LimitSubjectSizeGitObjectMax:
+		return LimitSubjectKindCeiling
+	default:
+		return LimitSubjectKindSize
+	}
+}
+
 func (subjects LimitSubjects) GoString() string {
 	return fmt.Sprintf("%T{%+v}", subjects, subjects)
 }
@@ -67,3 +106,27 @@ func ParseLimitSubject(repr string) (LimitSubject, error) {
 	}
 	return result, nil
 }
+
+// ValidateLimitSubjects checks that the subjects can be combined in a single
+// rule: adding up sizes and counts would not make sense, and a ceiling applies
+// to one object at a time, so it cannot be summed with anything else.
+func ValidateLimitSubjects(subjects LimitSubjects) error {
+	for _, subject := range subjects {
+		if subject.Kind() != subjects[0].Kind() {
+			return ErrIncompatibleLimitSubjects{Subjects: subjects}
+		}
+		if subject.Kind() == LimitSubjectKindCeiling && len(subjects) > 1 {
+			return ErrIncompatibleLimitSubjects{Subjects: subjects}
+		}
+	}
+	return nil
+}
+
+// RepoCountSubject returns the subject a new repository counts against,
+// depending on its visibility.
+func RepoCountSubject(isPrivate bool) LimitSubject {
+	if isPrivate {
+		return LimitSubjectCountReposPrivate
+	}
+	return LimitSubjectCountReposPublic
+}
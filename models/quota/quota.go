@@ -35,3 +35,18 @@ func EvaluateForUser(ctx context.Context, userID int64, subject LimitSubject) (b
 	allow := groups.Evaluate(*used, subject)
 	return allow, nil
 }
+
+// GetCeilingForUser returns the largest size a single object may have for the
+// ceiling subject, or -1 if the user is not limited.
+func GetCeilingForUser(ctx context.Context, userID int64, subject LimitSubject) (int64, error) {
+	if !setting.Quota.Enabled {
+		return -1, nil
+	}
+
+	groups, err := GetGroupsForUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return groups.Ceiling(subject), nil
+}
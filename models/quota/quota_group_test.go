@@ -226,3 +226,93 @@ func TestQuotaDefaultGroup(t *testing.T) {
 		})
 	}
 }
+
+// Count and ceiling subjects only restrict users covered by a rule for them,
+// unlike size subjects, which fall back to denying when no rule matches.
+func TestQuotaGroupListCountIsOptIn(t *testing.T) {
+	sizeGroup := quota_model.Group{
+		Rules: []quota_model.Rule{
+			{
+				Limit:    -1,
+				Subjects: quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitLFS},
+			},
+		},
+	}
+	countGroup := quota_model.Group{
+		Rules: []quota_model.Rule{
+			{
+				Limit:    2,
+				Subjects: quota_model.LimitSubjects{quota_model.LimitSubjectCountReposAll},
+			},
+		},
+	}
+
+	used := quota_model.Used{}
+	used.Count.Repos.Public = 1
+	used.Count.Repos.Private = 1
+
+	groups := quota_model.GroupList{&sizeGroup}
+	assert.True(t, groups.Evaluate(used, quota_model.LimitSubjectCountReposPrivate))
+	assert.True(t, groups.Evaluate(used, quota_model.LimitSubjectCountPackages))
+	assert.False(t, groups.Evaluate(used, quota_model.LimitSubjectSizeReposAll))
+
+	groups = quota_model.GroupList{&sizeGroup, &countGroup}
+	assert.False(t, groups.Evaluate(used, quota_model.LimitSubjectCountReposPrivate))
+	assert.False(t, groups.Evaluate(used, quota_model.LimitSubjectCountReposPublic))
+	assert.True(t, groups.Evaluate(used, quota_model.LimitSubjectCountPackages))
+
+	used.Count.Repos.Private = 0
+	assert.True(t, groups.Evaluate(used, quota_model.LimitSubjectCountReposPrivate))
+}
+
+func TestQuotaGroupListCeiling(t *testing.T) {
+	ceilingRule := func(limit int64) quota_model.Rule {
+		return quota_model.Rule{
+			Limit:    limit,
+			Subjects: quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitObjectMax},
+		}
+	}
+	strictGroup := quota_model.Group{
+		Rules: []quota_model.Rule{ceilingRule(2048), ceilingRule(1024)},
+	}
+	lenientGroup := quota_model.Group{
+		Rules: []quota_model.Rule{ceilingRule(4096)},
+	}
+	unlimitedGroup := quota_model.Group{
+		Rules: []quota_model.Rule{ceilingRule(-1)},
+	}
+	otherGroup := quota_model.Group{
+		Rules: []quota_model.Rule{
+			{
+				Limit:    0,
+				Subjects: quota_model.LimitSubjects{quota_model.LimitSubjectSizeAll},
+			},
+		},
+	}
+
+	// Within a group, the strictest rule wins
+	match, limit := strictGroup.Ceiling(quota_model.LimitSubjectSizeGitObjectMax)
+	assert.True(t, match)
+	assert.EqualValues(t, 1024, limit)
+
+	match, _ = otherGroup.Ceiling(quota_model.LimitSubjectSizeGitObjectMax)
+	assert.False(t, match)
+
+	// Across groups, the most lenient group wins
+	testSets := []struct {
+		name   string
+		groups quota_model.GroupList
+		expect int64
+	}{
+		{"none", quota_model.GroupList{}, -1},
+		{"no-match", quota_model.GroupList{&otherGroup}, -1},
+		{"strict", quota_model.GroupList{&otherGroup, &strictGroup}, 1024},
+		{"lenient", quota_model.GroupList{&strictGroup, &lenientGroup}, 4096},
+		{"unlimited", quota_model.GroupList{&strictGroup, &unlimitedGroup}, -1},
+	}
+	for _, testSet := range testSets {
+		t.Run(testSet.name, func(t *testing.T) {
+			assert.Equal(t, testSet.expect, testSet.groups.Ceiling(quota_model.LimitSubjectSizeGitObjectMax))
+		})
+	}
+}
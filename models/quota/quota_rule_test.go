@@ -313,3 +313,60 @@ func TestQuotaRuleSizeAll(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaRuleSubjectKinds(t *testing.T) {
+	assert.Equal(t, quota_model.LimitSubjectKindSize, quota_model.LimitSubjectSizeWiki.Kind())
+	assert.Equal(t, quota_model.LimitSubjectKindCount, quota_model.LimitSubjectCountReposPrivate.Kind())
+	assert.Equal(t, quota_model.LimitSubjectKindCeiling, quota_model.LimitSubjectSizeGitObjectMax.Kind())
+
+	testSets := []struct {
+		name     string
+		subjects quota_model.LimitSubjects
+		valid    bool
+	}{
+		{"sizes", quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitLFS, quota_model.LimitSubjectSizeReposAll}, true},
+		{"counts", quota_model.LimitSubjects{quota_model.LimitSubjectCountReposAll, quota_model.LimitSubjectCountPackages}, true},
+		{"ceiling", quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitObjectMax}, true},
+		{"size-and-count", quota_model.LimitSubjects{quota_model.LimitSubjectSizeAll, quota_model.LimitSubjectCountPackages}, false},
+		{"ceiling-and-size", quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitObjectMax, quota_model.LimitSubjectSizeAll}, false},
+		{"ceiling-twice", quota_model.LimitSubjects{quota_model.LimitSubjectSizeGitObjectMax, quota_model.LimitSubjectSizeGitObjectMax}, false},
+	}
+	for _, testSet := range testSets {
+		t.Run(testSet.name, func(t *testing.T) {
+			err := quota_model.ValidateLimitSubjects(testSet.subjects)
+			if testSet.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, quota_model.IsErrIncompatibleLimitSubjects(err))
+			}
+		})
+	}
+}
+
+func TestQuotaRuleCount(t *testing.T) {
+	rule := quota_model.Rule{
+		Limit: 3,
+		Subjects: quota_model.LimitSubjects{
+			quota_model.LimitSubjectCountReposPublic,
+			quota_model.LimitSubjectCountReposPrivate,
+		},
+	}
+	assert.True(t, rule.IsCount())
+	assert.False(t, rule.IsCeiling())
+
+	used := quota_model.Used{}
+	used.Count.Repos.Public = 1
+	used.Count.Repos.Private = 1
+
+	// A count rule is evaluated before creating a new item, so it allows as
+	// long as there is room for one more.
+	match, allow := rule.Evaluate(used, quota_model.LimitSubjectCountReposPrivate)
+	assert.True(t, match)
+	assert.True(t, allow)
+
+	used.Count.Repos.Private = 2
+	match, allow = rule.Evaluate(used, quota_model.LimitSubjectCountReposPrivate)
+	assert.True(t, match)
+	assert.False(t, allow)
+	assert.True(t, rule.Acceptable(used))
+}
@@ -29,6 +29,8 @@ var subjectToParent = map[LimitSubject]LimitSubject{
 	LimitSubjectSizeAssetsArtifacts:           LimitSubjectSizeAssetsAll,
 	LimitSubjectSizeAssetsPackagesAll:         LimitSubjectSizeAssetsAll,
 	LimitSubjectSizeWiki:                      LimitSubjectSizeAssetsAll,
+	LimitSubjectCountReposPublic:              LimitSubjectCountReposAll,
+	LimitSubjectCountReposPrivate:             LimitSubjectCountReposAll,
 }
 
 func (r *Rule) TableName() string {
@@ -39,6 +41,10 @@ func (r Rule) Acceptable(used Used) bool {
 	if r.Limit == -1 {
 		return true
 	}
+	if r.IsCeiling() {
+		// a ceiling is never exceeded by what is already stored
+		return true
+	}
 
 	return r.Sum(used) <= r.Limit
 }
@@ -51,6 +57,16 @@ func (r Rule) Sum(used Used) int64 {
 	return sum
 }
 
+// IsCount returns whether the rule limits the number of items rather than their size
+func (r Rule) IsCount() bool {
+	return len(r.Subjects) > 0 && r.Subjects[0].Kind() == LimitSubjectKindCount
+}
+
+// IsCeiling returns whether the rule limits the size of single objects
+func (r Rule) IsCeiling() bool {
+	return len(r.Subjects) > 0 && r.Subjects[0].Kind() == LimitSubjectKindCeiling
+}
+
 func (r Rule) Evaluate(used Used, forSubject LimitSubject) (match, allow bool) {
 	if !slices.Contains(r.Subjects, forSubject) {
 		// this rule does not match the subject being tested
@@ -80,6 +96,9 @@ func (r *Rule) Edit(ctx context.Context, limit *int64, subjects *LimitSubjects)
 		cols = append(cols, "limit")
 	}
 	if subjects != nil {
+		if err := ValidateLimitSubjects(*subjects); err != nil {
+			return nil, err
+		}
 		r.Subjects = *subjects
 		cols = append(cols, "subjects")
 	}
@@ -113,6 +132,10 @@ func DoesRuleExist(ctx context.Context, name string) (bool, error) {
 }
 
 func CreateRule(ctx context.Context, name string, limit int64, subjects LimitSubjects) (*Rule, error) {
+	if err := ValidateLimitSubjects(subjects); err != nil {
+		return nil, err
+	}
+
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return nil, err
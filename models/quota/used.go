@@ -15,7 +15,8 @@ import (
 )
 
 type Used struct {
-	Size UsedSize
+	Size  UsedSize
+	Count UsedCount
 }
 
 type UsedSize struct {
@@ -68,6 +69,20 @@ type UsedSizeAssetsPackages struct {
 	All int64
 }
 
+type UsedCount struct {
+	Repos    UsedCountRepos
+	Packages int64
+}
+
+type UsedCountRepos struct {
+	Public  int64
+	Private int64
+}
+
+func (u UsedCountRepos) All() int64 {
+	return u.Public + u.Private
+}
+
 func (u Used) CalculateFor(subject LimitSubject) int64 {
 	switch subject {
 	case LimitSubjectNone:
@@ -98,6 +113,17 @@ func (u Used) CalculateFor(subject LimitSubject) int64 {
 		return u.Size.Assets.Packages.All
 	case LimitSubjectSizeWiki:
 		return 0
+	case LimitSubjectCountReposAll:
+		return u.Count.Repos.All()
+	case LimitSubjectCountReposPublic:
+		return u.Count.Repos.Public
+	case LimitSubjectCountReposPrivate:
+		return u.Count.Repos.Private
+	case LimitSubjectCountPackages:
+		return u.Count.Packages
+	case LimitSubjectSizeGitObjectMax:
+		// ceilings apply to a single object, not to what is already used
+		return 0
 	}
 	return 0
 }
@@ -249,5 +275,26 @@ func GetUsedForUser(ctx context.Context, userID int64) (*Used, error) {
 		return nil, err
 	}
 
+	used.Count.Repos.Private, err = createQueryFor(ctx, userID, "repositories").
+		Where("`repository`.is_private = ?", true).
+		Count()
+	if err != nil {
+		return nil, err
+	}
+
+	used.Count.Repos.Public, err = createQueryFor(ctx, userID, "repositories").
+		Where("`repository`.is_private = ?", false).
+		Count()
+	if err != nil {
+		return nil, err
+	}
+
+	used.Count.Packages, err = db.GetEngine(ctx).
+		Where("owner_id = ?", userID).
+		Count(new(packages_model.Package))
+	if err != nil {
+		return nil, err
+	}
+
 	return &used, nil
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	quota_model "forgejo.org/models/quota"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,8 @@ func TestQuotaUsedGetUsedForUser(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.EqualValues(t, 4096, used.Size.Assets.Artifacts)
+	assert.EqualValues(t, unittest.GetCount(t, &repo_model.Repository{OwnerID: 5, IsPrivate: true}), used.Count.Repos.Private)
+	assert.EqualValues(t, unittest.GetCount(t, &repo_model.Repository{OwnerID: 5}, unittest.Cond("is_private = ?", false)), used.Count.Repos.Public)
 }
 
 func TestQuotaUsedTotals(t *testing.T) {
@@ -52,3 +55,21 @@ func TestQuotaUsedTotals(t *testing.T) {
 	assert.EqualValues(t, 60, used.Size.Assets.All())             // attachments all + artifacts + packages
 	assert.EqualValues(t, 72, used.Size.All())                    // git all + assets all
 }
+
+func TestQuotaUsedCounts(t *testing.T) {
+	used := quota_model.Used{
+		Count: quota_model.UsedCount{
+			Repos: quota_model.UsedCountRepos{
+				Public:  2,
+				Private: 3,
+			},
+			Packages: 5,
+		},
+	}
+
+	assert.EqualValues(t, 5, used.CalculateFor(quota_model.LimitSubjectCountReposAll))
+	assert.EqualValues(t, 2, used.CalculateFor(quota_model.LimitSubjectCountReposPublic))
+	assert.EqualValues(t, 3, used.CalculateFor(quota_model.LimitSubjectCountReposPrivate))
+	assert.EqualValues(t, 5, used.CalculateFor(quota_model.LimitSubjectCountPackages))
+	assert.EqualValues(t, 0, used.CalculateFor(quota_model.LimitSubjectSizeAll))
+}
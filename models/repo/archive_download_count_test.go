@@ -54,6 +54,19 @@ func TestRepoArchiveDownloadCount(t *testing.T) {
 	assert.Equal(t, int64(1), downloadCount.Zip)
 	assert.Equal(t, int64(2), downloadCount.TarGz)
 
+	// Set the TarZst and Bundle counters to 1
+	err = repo_model.CountArchiveDownload(db.DefaultContext, release.RepoID, release.ID, git.TARZST)
+	require.NoError(t, err)
+	err = repo_model.CountArchiveDownload(db.DefaultContext, release.RepoID, release.ID, git.BUNDLE)
+	require.NoError(t, err)
+
+	downloadCount, err = repo_model.GetArchiveDownloadCountForTagName(db.DefaultContext, release.RepoID, release.TagName)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), downloadCount.Zip)
+	assert.Equal(t, int64(2), downloadCount.TarGz)
+	assert.Equal(t, int64(1), downloadCount.TarZst)
+	assert.Equal(t, int64(1), downloadCount.Bundle)
+
 	// Delete the count
 	err = repo_model.DeleteArchiveDownloadCountForRelease(db.DefaultContext, release.ID)
 	require.NoError(t, err)
@@ -62,4 +75,6 @@ func TestRepoArchiveDownloadCount(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), downloadCount.Zip)
 	assert.Equal(t, int64(0), downloadCount.TarGz)
+	assert.Equal(t, int64(0), downloadCount.TarZst)
+	assert.Equal(t, int64(0), downloadCount.Bundle)
 }
@@ -22,6 +22,7 @@ import (
 // It can be:
 //  1. org/user level secret, OwnerID is org/user ID and RepoID is 0
 //  2. repo level secret, OwnerID is 0 and RepoID is repo ID
+//  3. environment level secret, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of an environment of the repo
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find secrets belonging to a specific owner.
@@ -32,12 +33,13 @@ import (
 // Please note that it's not acceptable to have both OwnerID and RepoID to zero, global secrets are not supported.
 // It's for security reasons, admin may be not aware of that the secrets could be stolen by any user when setting them as global.
 type Secret struct {
-	ID          int64
-	OwnerID     int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        []byte             `xorm:"BLOB"` // encrypted data
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	ID            int64
+	OwnerID       int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          []byte             `xorm:"BLOB"` // encrypted data
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
 }
 
 // ErrSecretNotFound represents a "secret not found" error.
@@ -64,12 +66,27 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 		return nil, fmt.Errorf("%w: ownerID and repoID cannot be both zero, global secrets are not supported", util.ErrInvalidArgument)
 	}
 
-	secret := &Secret{
+	return insertEncryptedSecret(ctx, &Secret{
 		OwnerID: ownerID,
 		RepoID:  repoID,
 		Name:    strings.ToUpper(name),
+	}, data)
+}
+
+// InsertEncryptedEnvironmentSecret creates a new secret of an environment of the repository
+func InsertEncryptedEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, data string) (*Secret, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, fmt.Errorf("%w: repoID and environmentID cannot be zero", util.ErrInvalidArgument)
 	}
 
+	return insertEncryptedSecret(ctx, &Secret{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+	}, data)
+}
+
+func insertEncryptedSecret(ctx context.Context, secret *Secret, data string) (*Secret, error) {
 	return secret, db.WithTx(ctx, func(ctx context.Context) error {
 		if err := db.Insert(ctx, secret); err != nil {
 			return err
@@ -87,10 +104,11 @@ func init() {
 
 type FindSecretsOptions struct {
 	db.ListOptions
-	RepoID   int64
-	OwnerID  int64 // it will be ignored if RepoID is set
-	SecretID int64
-	Name     string
+	RepoID        int64
+	OwnerID       int64 // it will be ignored if RepoID is set
+	EnvironmentID int64
+	SecretID      int64
+	Name          string
 }
 
 func (opts FindSecretsOptions) ToConds() builder.Cond {
@@ -103,6 +121,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.SecretID != 0 {
 		cond = cond.And(builder.Eq{"id": opts.SecretID})
@@ -132,15 +151,31 @@ func FetchActionSecrets(ctx context.Context, ownerID, repoID int64) (map[string]
 		return nil, err
 	}
 
+	return secrets, decryptSecrets(secrets, append(ownerSecrets, repoSecrets...))
+}
+
+// FetchEnvironmentSecrets returns the decrypted secrets of an environment of the repository
+func FetchEnvironmentSecrets(ctx context.Context, repoID, environmentID int64) (map[string]string, error) {
+	secrets := map[string]string{}
+
+	envSecrets, err := db.Find[Secret](ctx, FindSecretsOptions{RepoID: repoID, EnvironmentID: environmentID})
+	if err != nil {
+		log.Error("find secrets of environment %v: %v", environmentID, err)
+		return nil, err
+	}
+
+	return secrets, decryptSecrets(secrets, envSecrets)
+}
+
+func decryptSecrets(secrets map[string]string, encrypted []*Secret) error {
 	key := keying.ActionSecret
-	for _, secret := range append(ownerSecrets, repoSecrets...) {
+	for _, secret := range encrypted {
 		v, err := key.Decrypt(secret.Data, keying.ColumnAndID("data", secret.ID))
 		if err != nil {
 			log.Error("unable to decrypt secret[id=%d,name=%q]: %v", secret.ID, secret.Name, err)
-			return nil, err
+			return err
 		}
 		secrets[secret.Name] = string(v)
 	}
-
-	return secrets, nil
+	return nil
 }
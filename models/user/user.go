@@ -646,9 +646,10 @@ var (
 		"-", // used by certain web routes
 		".well-known",
 
-		"api",     // gitea api
-		"metrics", // prometheus metrics api
-		"v2",      // container registry api
+		"api",       // gitea api
+		"metrics",   // prometheus metrics api
+		"v2",        // container registry api
+		"terraform", // terraform registry api, mounted on /api/packages/terraform before the package owners
 
 		"assets",      // static asset files
 		"attachments", // issue attachments
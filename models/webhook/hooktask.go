@@ -56,6 +56,13 @@ type HookTask struct {
 	IsDelivered bool
 	Delivered   timeutil.TimeStampNano
 
+	// Attempts is the number of times the delivery was attempted
+	Attempts int `xorm:"NOT NULL DEFAULT 0"`
+	// NextRetryUnix is when a failed delivery is attempted again, zero if it is not going to be retried
+	NextRetryUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// IsDeadLetter is set when the delivery failed and is not going to be retried, until it is redelivered
+	IsDeadLetter bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"LONGTEXT"`
@@ -170,7 +177,8 @@ func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask,
 	})
 }
 
-// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID
+// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID,
+// the tasks waiting for a retry are skipped until it is due
 func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
 	const batchSize = 100
 
@@ -179,12 +187,71 @@ func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, er
 		Select("id").
 		Table(new(HookTask)).
 		Where("is_delivered=?", false).
+		And("next_retry_unix <= ?", timeutil.TimeStampNow()).
 		And("id > ?", lowerID).
 		Asc("id").
 		Limit(batchSize).
 		Find(&tasks)
 }
 
+// FindDueRetryHookTaskIDs finds the hook tasks whose delivery failed and is due to be retried
+func FindDueRetryHookTaskIDs(ctx context.Context) ([]int64, error) {
+	tasks := make([]int64, 0, 10)
+	return tasks, db.GetEngine(ctx).
+		Select("id").
+		Table(new(HookTask)).
+		Where("is_delivered=?", false).
+		And("next_retry_unix > 0 AND next_retry_unix <= ?", timeutil.TimeStampNow()).
+		Asc("id").
+		Find(&tasks)
+}
+
+// FailedHookTasks returns the dead-lettered hook tasks of a webhook, order by ID desc.
+func FailedHookTasks(ctx context.Context, hookID int64, listOptions db.ListOptions) ([]*HookTask, int64, error) {
+	sess := db.GetEngine(ctx).
+		Where("hook_id=? AND is_dead_letter=?", hookID, true).
+		Desc("id")
+	if !listOptions.IsListAll() {
+		sess = db.SetSessionPagination(sess, &listOptions)
+	}
+	tasks := make([]*HookTask, 0, listOptions.PageSize)
+	count, err := sess.FindAndCount(&tasks)
+	return tasks, count, err
+}
+
+// RedeliverFailedHookTasks copies the dead-lettered hook tasks of a webhook to get re-delivered, the
+// copied tasks are no longer dead-lettered. If uuids is empty, all the dead-lettered tasks are copied.
+func RedeliverFailedHookTasks(ctx context.Context, hookID int64, uuids []string) ([]*HookTask, error) {
+	var replayed []*HookTask
+	return replayed, db.WithTx(ctx, func(ctx context.Context) error {
+		cond := builder.NewCond().And(builder.Eq{"hook_id": hookID, "is_dead_letter": true})
+		if len(uuids) > 0 {
+			cond = cond.And(builder.In("uuid", uuids))
+		}
+		tasks := make([]*HookTask, 0, 10)
+		if err := db.GetEngine(ctx).Where(cond).Asc("id").Find(&tasks); err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if _, err := db.GetEngine(ctx).ID(task.ID).Cols("is_dead_letter").Update(&HookTask{IsDeadLetter: false}); err != nil {
+				return err
+			}
+			t, err := CreateHookTask(ctx, &HookTask{
+				HookID:         task.HookID,
+				PayloadContent: task.PayloadContent,
+				EventType:      task.EventType,
+				PayloadVersion: task.PayloadVersion,
+			})
+			if err != nil {
+				return err
+			}
+			replayed = append(replayed, t)
+		}
+		return nil
+	})
+}
+
 func MarkTaskDelivered(ctx context.Context, task *HookTask) (bool, error) {
 	count, err := db.GetEngine(ctx).ID(task.ID).Where("is_delivered = ?", false).Cols("is_delivered").Update(&HookTask{
 		ID:          task.ID,
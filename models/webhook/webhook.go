@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"forgejo.org/models/db"
 	"forgejo.org/modules/json"
@@ -134,10 +135,17 @@ type Webhook struct {
 	Type                      webhook_module.HookType   `xorm:"VARCHAR(16) 'type'"`
 	Meta                      string                    `xorm:"TEXT"` // store hook-specific attributes
 	LastStatus                webhook_module.HookStatus // Last delivery status
+	// ConsecutiveFailures is the number of deliveries that failed in a row, reset by a successful delivery
+	ConsecutiveFailures int `xorm:"NOT NULL DEFAULT 0"`
 
 	// HeaderAuthorizationEncrypted should be accessed using HeaderAuthorization() and SetHeaderAuthorization()
 	HeaderAuthorizationEncrypted []byte `xorm:"BLOB"`
 
+	// PreviousSecret still signs the deliveries until PreviousSecretExpiresUnix, so that the
+	// receivers can be updated to the new secret without rejecting deliveries in between
+	PreviousSecret            string             `xorm:"TEXT"`
+	PreviousSecretExpiresUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 }
@@ -154,6 +162,34 @@ func (w *Webhook) AfterLoad() {
 	}
 }
 
+// SetSecret changes the secret of the webhook. The replaced secret remains valid for the
+// overlap duration, it is discarded right away if the overlap is zero.
+func (w *Webhook) SetSecret(secret string, overlap time.Duration) {
+	if secret == w.Secret {
+		return
+	}
+	if w.Secret != "" && overlap > 0 {
+		w.PreviousSecret = w.Secret
+		w.PreviousSecretExpiresUnix = timeutil.TimeStamp(time.Now().Add(overlap).Unix())
+	} else {
+		w.PreviousSecret = ""
+		w.PreviousSecretExpiresUnix = 0
+	}
+	w.Secret = secret
+}
+
+// ValidSecrets returns the secrets the deliveries are signed with, the current secret first
+func (w *Webhook) ValidSecrets() []string {
+	secrets := make([]string, 0, 2)
+	if w.Secret != "" {
+		secrets = append(secrets, w.Secret)
+	}
+	if w.PreviousSecret != "" && w.PreviousSecretExpiresUnix > timeutil.TimeStampNow() {
+		secrets = append(secrets, w.PreviousSecret)
+	}
+	return secrets
+}
+
 // History returns history of webhook by given conditions.
 func (w *Webhook) History(ctx context.Context, page int) ([]*HookTask, error) {
 	return HookTasks(ctx, w.ID, page)
@@ -316,6 +352,18 @@ func (w *Webhook) HasActionRunSuccessEvent() bool {
 		(w.ChooseEvents && w.ActionRunSuccess)
 }
 
+// HasStatusEvent returns if hook enabled commit status event.
+func (w *Webhook) HasStatusEvent() bool {
+	return w.SendEverything ||
+		(w.ChooseEvents && w.Status)
+}
+
+// HasWorkflowJobEvent returns if hook enabled workflow job event.
+func (w *Webhook) HasWorkflowJobEvent() bool {
+	return w.SendEverything ||
+		(w.ChooseEvents && w.WorkflowJob)
+}
+
 // HasPullRequestReviewRequestEvent returns true if hook enabled pull request review request event.
 func (w *Webhook) HasPullRequestReviewRequestEvent() bool {
 	return w.SendEverything ||
@@ -357,6 +405,8 @@ func (w *Webhook) EventCheckers() []struct {
 		{w.HasActionRunFailureEvent, webhook_module.HookEventActionRunFailure},
 		{w.HasActionRunRecoverEvent, webhook_module.HookEventActionRunRecover},
 		{w.HasActionRunSuccessEvent, webhook_module.HookEventActionRunSuccess},
+		{w.HasStatusEvent, webhook_module.HookEventStatus},
+		{w.HasWorkflowJobEvent, webhook_module.HookEventWorkflowJob},
 	}
 }
 
@@ -512,6 +562,39 @@ func UpdateWebhookLastStatus(ctx context.Context, w *Webhook) error {
 	return err
 }
 
+// IncreaseWebhookConsecutiveFailures counts a failed delivery of the webhook and returns the
+// number of deliveries that failed in a row
+func IncreaseWebhookConsecutiveFailures(ctx context.Context, w *Webhook) (int, error) {
+	if _, err := db.GetEngine(ctx).ID(w.ID).Incr("consecutive_failures").NoAutoTime().Update(new(Webhook)); err != nil {
+		return 0, err
+	}
+	if _, err := db.GetEngine(ctx).ID(w.ID).Cols("consecutive_failures").Get(w); err != nil {
+		return 0, err
+	}
+	return w.ConsecutiveFailures, nil
+}
+
+// ResetWebhookConsecutiveFailures resets the count of failed deliveries after a successful one
+func ResetWebhookConsecutiveFailures(ctx context.Context, w *Webhook) error {
+	if w.ConsecutiveFailures == 0 {
+		return nil
+	}
+	w.ConsecutiveFailures = 0
+	_, err := db.GetEngine(ctx).ID(w.ID).Cols("consecutive_failures").NoAutoTime().Update(w)
+	return err
+}
+
+// DisableWebhook deactivates the webhook after too many failed deliveries, it returns false if
+// the webhook was already inactive
+func DisableWebhook(ctx context.Context, w *Webhook) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(w.ID).Where("is_active=?", true).Cols("is_active").Update(&Webhook{IsActive: false})
+	if err != nil {
+		return false, err
+	}
+	w.IsActive = false
+	return n > 0, nil
+}
+
 // DeleteWebhookByID uses argument bean as query condition,
 // ID must be specified and do not assign unnecessary fields.
 func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
@@ -124,7 +124,7 @@ func TestCreateWebhook(t *testing.T) {
 			RepoID:      3,
 			URL:         "https://www.example.com/unit_test",
 			ContentType: ContentTypeJSON,
-			Events:      `{"push_only":false,"send_everything":false,"choose_events":true,"events":{"create":true,"delete":true,"fork":true,"issues":true,"issue_assign":true,"issue_label":true,"issue_milestone":true,"issue_comment":true,"push":true,"pull_request":true,"pull_request_assign":true,"pull_request_label":true,"pull_request_milestone":true,"pull_request_comment":true,"pull_request_review":true,"pull_request_sync":true,"pull_request_review_request":true,"wiki":true,"repository":true,"release":true,"package":true,"action_run_failure":true,"action_run_recover":true,"action_run_success":true}}`,
+			Events:      `{"push_only":false,"send_everything":false,"choose_events":true,"events":{"create":true,"delete":true,"fork":true,"issues":true,"issue_assign":true,"issue_label":true,"issue_milestone":true,"issue_comment":true,"push":true,"pull_request":true,"pull_request_assign":true,"pull_request_label":true,"pull_request_milestone":true,"pull_request_comment":true,"pull_request_review":true,"pull_request_sync":true,"pull_request_review_request":true,"wiki":true,"repository":true,"release":true,"package":true,"action_run_failure":true,"action_run_recover":true,"action_run_success":true,"status":true,"workflow_job":true}}`,
 		}
 		unittest.AssertNotExistsBean(t, hook)
 		require.NoError(t, CreateWebhook(db.DefaultContext, hook, ""))
@@ -159,6 +159,8 @@ func TestCreateWebhook(t *testing.T) {
 			string(webhook_module.HookEventActionRunFailure),
 			string(webhook_module.HookEventActionRunRecover),
 			string(webhook_module.HookEventActionRunSuccess),
+			string(webhook_module.HookEventStatus),
+			string(webhook_module.HookEventWorkflowJob),
 		},
 			hookFromDb.EventsArray())
 	})
@@ -312,6 +314,100 @@ func TestUpdateHookTask(t *testing.T) {
 	unittest.AssertExistsAndLoadBean(t, hook)
 }
 
+func TestFindDueRetryHookTaskIDs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	hookTask, err := CreateHookTask(db.DefaultContext, &HookTask{
+		HookID:         3,
+		Attempts:       1,
+		NextRetryUnix:  timeutil.TimeStampNow().Add(3600),
+		PayloadVersion: 2,
+	})
+	require.NoError(t, err)
+
+	ids, err := FindDueRetryHookTaskIDs(db.DefaultContext)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, hookTask.ID)
+	ids, err = FindUndeliveredHookTaskIDs(db.DefaultContext, 0)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, hookTask.ID)
+
+	hookTask.NextRetryUnix = timeutil.TimeStampNow().Add(-1)
+	require.NoError(t, UpdateHookTask(db.DefaultContext, hookTask))
+
+	ids, err = FindDueRetryHookTaskIDs(db.DefaultContext)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{hookTask.ID}, ids)
+	ids, err = FindUndeliveredHookTaskIDs(db.DefaultContext, 0)
+	require.NoError(t, err)
+	assert.Contains(t, ids, hookTask.ID)
+}
+
+func TestRedeliverFailedHookTasks(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	failed := make([]*HookTask, 2)
+	for i := range failed {
+		hookTask, err := CreateHookTask(db.DefaultContext, &HookTask{
+			HookID:         3,
+			EventType:      webhook_module.HookEventPush,
+			PayloadContent: "payload",
+			IsDelivered:    true,
+			IsDeadLetter:   true,
+			Attempts:       4,
+			PayloadVersion: 2,
+		})
+		require.NoError(t, err)
+		failed[i] = hookTask
+	}
+
+	tasks, count, err := FailedHookTasks(db.DefaultContext, 3, db.ListOptions{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, tasks, 2) {
+		assert.Equal(t, failed[1].ID, tasks[0].ID)
+	}
+
+	replayed, err := RedeliverFailedHookTasks(db.DefaultContext, 3, []string{failed[0].UUID})
+	require.NoError(t, err)
+	if assert.Len(t, replayed, 1) {
+		assert.NotEqual(t, failed[0].UUID, replayed[0].UUID)
+		assert.Equal(t, "payload", replayed[0].PayloadContent)
+		assert.False(t, replayed[0].IsDelivered)
+		assert.Zero(t, replayed[0].Attempts)
+	}
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &HookTask{ID: failed[0].ID}).IsDeadLetter)
+
+	replayed, err = RedeliverFailedHookTasks(db.DefaultContext, 3, nil)
+	require.NoError(t, err)
+	assert.Len(t, replayed, 1)
+
+	_, count, err = FailedHookTasks(db.DefaultContext, 3, db.ListOptions{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestWebhookConsecutiveFailures(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	hook := unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1})
+	hook.IsActive = true
+	require.NoError(t, UpdateWebhook(db.DefaultContext, hook))
+
+	for i := 1; i <= 2; i++ {
+		failures, err := IncreaseWebhookConsecutiveFailures(db.DefaultContext, hook)
+		require.NoError(t, err)
+		assert.Equal(t, i, failures)
+	}
+	require.NoError(t, ResetWebhookConsecutiveFailures(db.DefaultContext, hook))
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1}).ConsecutiveFailures)
+
+	disabled, err := DisableWebhook(db.DefaultContext, hook)
+	require.NoError(t, err)
+	assert.True(t, disabled)
+	disabled, err = DisableWebhook(db.DefaultContext, hook)
+	require.NoError(t, err)
+	assert.False(t, disabled)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1}).IsActive)
+}
+
 func TestCleanupHookTaskTable_PerWebhook_DeletesDelivered(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	hookTask := &HookTask{
@@ -411,3 +507,25 @@ func TestCleanupHookTaskTable_OlderThan_LeavesTaskEarlierThanAgeToDelete(t *test
 	require.NoError(t, CleanupHookTaskTable(t.Context(), OlderThan, 168*time.Hour, 0))
 	unittest.AssertExistsAndLoadBean(t, hookTask)
 }
+
+func TestWebhookSetSecret(t *testing.T) {
+	w := &Webhook{}
+	w.SetSecret("first", time.Hour)
+	assert.Equal(t, []string{"first"}, w.ValidSecrets())
+	assert.Empty(t, w.PreviousSecret)
+
+	w.SetSecret("second", time.Hour)
+	assert.Equal(t, []string{"second", "first"}, w.ValidSecrets())
+	assert.Greater(t, w.PreviousSecretExpiresUnix, timeutil.TimeStampNow())
+
+	// saving the form without changing the secret keeps the rotation going
+	w.SetSecret("second", time.Hour)
+	assert.Equal(t, []string{"second", "first"}, w.ValidSecrets())
+
+	w.PreviousSecretExpiresUnix = timeutil.TimeStampNow() - 1
+	assert.Equal(t, []string{"second"}, w.ValidSecrets())
+
+	w.SetSecret("third", 0)
+	assert.Equal(t, []string{"third"}, w.ValidSecrets())
+	assert.Empty(t, w.PreviousSecret)
+}
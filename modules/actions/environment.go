@@ -0,0 +1,47 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// JobEnvironments returns the deployment environments of the jobs of the workflow by job id.
+// The `environment` of a job is either a name or a mapping with a name. The names given by an
+// expression are ignored: they are only known once the job runs, too late to protect it.
+func JobEnvironments(content []byte) (map[string]string, error) {
+	var workflow struct {
+		Jobs map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, err
+	}
+
+	environments := make(map[string]string, len(workflow.Jobs))
+	for id, job := range workflow.Jobs {
+		var name string
+		switch job.Environment.Kind {
+		case yaml.ScalarNode:
+			name = job.Environment.Value
+		case yaml.MappingNode:
+			var environment struct {
+				Name string `yaml:"name"`
+			}
+			if err := job.Environment.Decode(&environment); err != nil {
+				return nil, err
+			}
+			name = environment.Name
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || strings.Contains(name, "${{") {
+			continue
+		}
+		environments[id] = name
+	}
+	return environments, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobEnvironments(t *testing.T) {
+	environments, err := JobEnvironments([]byte(`
+on: push
+jobs:
+  build:
+    runs-on: docker
+    steps:
+      - run: make
+  staging:
+    runs-on: docker
+    environment: staging
+    steps:
+      - run: make deploy
+  production:
+    runs-on: docker
+    environment:
+      name: production
+      url: https://example.com
+    steps:
+      - run: make deploy
+  matrix:
+    runs-on: docker
+    environment: ${{ matrix.environment }}
+    steps:
+      - run: make deploy
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"staging":    "staging",
+		"production": "production",
+	}, environments)
+}
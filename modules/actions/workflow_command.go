@@ -0,0 +1,56 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	WorkflowCommandError   = "error"
+	WorkflowCommandWarning = "warning"
+	WorkflowCommandNotice  = "notice"
+	// The runners do not upload the file of $GITHUB_STEP_SUMMARY, they forward
+	// its content with this command instead, escaped like any workflow command value.
+	WorkflowCommandStepSummary = "step-summary"
+)
+
+// A command is a whole line, which the runner may prefix with the icon it logs commands
+// with: ❗ for errors, 🚧 for warnings and ❓ for the commands it does not handle itself.
+var workflowCommandPattern = regexp.MustCompile(`^(?:\s*[❗🚧❓]\s*)?::(error|warning|notice|step-summary)(?: ([^:]*))?::(.*)$`)
+
+// WorkflowCommand is a command written by a step in its log, like `::error file=main.go,line=3::message`
+type WorkflowCommand struct {
+	Name       string
+	Properties map[string]string
+	Value      string
+}
+
+var (
+	workflowCommandValueUnescaper    = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%")
+	workflowCommandPropertyUnescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+)
+
+// ParseWorkflowCommand returns the annotation or summary command found in a log line, if any
+func ParseWorkflowCommand(line string) (*WorkflowCommand, bool) {
+	matches := workflowCommandPattern.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+	if matches == nil {
+		return nil, false
+	}
+
+	cmd := &WorkflowCommand{
+		Name:       matches[1],
+		Properties: make(map[string]string),
+		Value:      workflowCommandValueUnescaper.Replace(matches[3]),
+	}
+	for _, property := range strings.Split(matches[2], ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(property), "=")
+		if !ok || key == "" {
+			continue
+		}
+		cmd.Properties[key] = workflowCommandPropertyUnescaper.Replace(value)
+	}
+	return cmd, true
+}
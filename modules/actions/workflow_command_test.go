@@ -0,0 +1,62 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkflowCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want *WorkflowCommand
+	}{
+		{
+			line: "::error file=main.go,line=3,col=5,title=Build%3A failed::undefined: x",
+			want: &WorkflowCommand{
+				Name:       WorkflowCommandError,
+				Properties: map[string]string{"file": "main.go", "line": "3", "col": "5", "title": "Build: failed"},
+				Value:      "undefined: x",
+			},
+		},
+		{
+			line: "  ❗  ::warning::deprecated%0Asince 2.0",
+			want: &WorkflowCommand{
+				Name:       WorkflowCommandWarning,
+				Properties: map[string]string{},
+				Value:      "deprecated\nsince 2.0",
+			},
+		},
+		{
+			line: "::step-summary::# Results%0A%0A100%25 passed\n",
+			want: &WorkflowCommand{
+				Name:       WorkflowCommandStepSummary,
+				Properties: map[string]string{},
+				Value:      "# Results\n\n100% passed",
+			},
+		},
+		{
+			line: "  ❓  ::notice title=Coverage::87%25",
+			want: &WorkflowCommand{
+				Name:       WorkflowCommandNotice,
+				Properties: map[string]string{"title": "Coverage"},
+				Value:      "87%",
+			},
+		},
+		{line: "::set-output name=x::y"},
+		{line: "an error happened"},
+		{line: "use std::error::Error;"},
+		{line: "  ❗  use std::error::Error;"},
+		{line: "error: expected one of `::`, found `::warning::`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := ParseWorkflowCommand(tt.line)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
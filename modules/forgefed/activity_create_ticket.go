@@ -0,0 +1,78 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed
+
+import (
+	"time"
+
+	"forgejo.org/modules/validation"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// ForgeCreateTicket activity data type, used to open a ticket in the issue tracker of a repository
+// swagger:model
+type ForgeCreateTicket struct {
+	// swagger:ignore
+	ap.Activity
+	// swagger:ignore
+	Ticket *Ticket
+}
+
+func NewForgeCreateTicketFromAp(activity ap.Activity) (ForgeCreateTicket, error) {
+	result := ForgeCreateTicket{}
+	result.Activity = activity
+	ticket, err := ToTicket(activity.Object)
+	if err != nil {
+		return ForgeCreateTicket{}, err
+	}
+	result.Ticket = ticket
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeCreateTicket{}, err
+	}
+	return result, nil
+}
+
+func NewForgeCreateTicket(actorIRI string, ticket *Ticket, published time.Time) (ForgeCreateTicket, error) {
+	result := ForgeCreateTicket{}
+	result.ID = ap.IRI(ticket.ID.String() + "/create")
+	result.Type = ap.CreateType
+	result.Actor = ap.IRI(actorIRI)
+	result.Published = published
+	result.StartTime = published
+	result.To = ticket.To
+	result.Object = ticket
+	result.Ticket = ticket
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeCreateTicket{}, err
+	}
+	return result, nil
+}
+
+func (create ForgeCreateTicket) MarshalJSON() ([]byte, error) {
+	return create.Activity.MarshalJSON()
+}
+
+func (create ForgeCreateTicket) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(string(create.Type), "type")...)
+	result = append(result, validation.ValidateOneOf(string(create.Type), []any{"Create"}, "type")...)
+	result = append(result, validation.ValidateIDExists(create.Actor, "actor")...)
+
+	if create.Ticket == nil {
+		return append(result, "Ticket should not be nil.")
+	}
+	result = append(result, validation.ValidateOneOf(string(create.Ticket.Type), []any{"Ticket"}, "object.type")...)
+	result = append(result, validation.ValidateNotEmpty(create.Ticket.ID.String(), "object.id")...)
+	result = append(result, validation.ValidateNotEmpty(create.Ticket.Summary.String(), "object.summary")...)
+	result = append(result, validation.ValidateMaxLen(create.Ticket.Summary.String(), 255, "object.summary")...)
+	result = append(result, validation.ValidateIDExists(create.Ticket.Context, "object.context")...)
+	result = append(result, validation.ValidateIDExists(create.Ticket.AttributedTo, "object.attributedTo")...)
+	if create.Actor != nil && create.Ticket.AttributedTo != nil &&
+		create.Actor.GetLink() != create.Ticket.AttributedTo.GetLink() {
+		result = append(result, "The ticket has to be attributed to the actor of the activity.")
+	}
+
+	return result
+}
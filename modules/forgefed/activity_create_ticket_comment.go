@@ -0,0 +1,78 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed
+
+import (
+	"time"
+
+	"forgejo.org/modules/validation"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// ForgeCreateTicketComment activity data type, used to comment on a ticket.
+// The comment is a Note replying to the ticket.
+// swagger:model
+type ForgeCreateTicketComment struct {
+	// swagger:ignore
+	ap.Activity
+	// swagger:ignore
+	Note *ap.Object
+}
+
+func NewForgeCreateTicketCommentFromAp(activity ap.Activity) (ForgeCreateTicketComment, error) {
+	result := ForgeCreateTicketComment{}
+	result.Activity = activity
+	note, err := ap.ToObject(activity.Object)
+	if err != nil {
+		return ForgeCreateTicketComment{}, err
+	}
+	result.Note = note
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeCreateTicketComment{}, err
+	}
+	return result, nil
+}
+
+func NewForgeCreateTicketComment(actorIRI string, note *ap.Object, published time.Time) (ForgeCreateTicketComment, error) {
+	result := ForgeCreateTicketComment{}
+	result.ID = ap.IRI(note.ID.String() + "/create")
+	result.Type = ap.CreateType
+	result.Actor = ap.IRI(actorIRI)
+	result.Published = published
+	result.StartTime = published
+	result.To = note.To
+	result.Object = note
+	result.Note = note
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeCreateTicketComment{}, err
+	}
+	return result, nil
+}
+
+func (create ForgeCreateTicketComment) MarshalJSON() ([]byte, error) {
+	return create.Activity.MarshalJSON()
+}
+
+func (create ForgeCreateTicketComment) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(string(create.Type), "type")...)
+	result = append(result, validation.ValidateOneOf(string(create.Type), []any{"Create"}, "type")...)
+	result = append(result, validation.ValidateIDExists(create.Actor, "actor")...)
+
+	if create.Note == nil {
+		return append(result, "Note should not be nil.")
+	}
+	result = append(result, validation.ValidateOneOf(string(create.Note.Type), []any{"Note"}, "object.type")...)
+	result = append(result, validation.ValidateNotEmpty(create.Note.ID.String(), "object.id")...)
+	result = append(result, validation.ValidateNotEmpty(create.Note.Content.String(), "object.content")...)
+	result = append(result, validation.ValidateIDExists(create.Note.InReplyTo, "object.inReplyTo")...)
+	result = append(result, validation.ValidateIDExists(create.Note.AttributedTo, "object.attributedTo")...)
+	if create.Actor != nil && create.Note.AttributedTo != nil &&
+		create.Actor.GetLink() != create.Note.AttributedTo.GetLink() {
+		result = append(result, "The note has to be attributed to the actor of the activity.")
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed_test
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/modules/forgefed"
+	"forgejo.org/modules/validation"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ticketActorIRI = "https://repo.prod.meissa.de/api/v1/activitypub/user-id/1"
+	ticketRepoIRI  = "https://codeberg.org/api/v1/activitypub/repository-id/1"
+	ticketIRI      = "https://codeberg.org/api/v1/activitypub/repository-id/1/issues/1"
+)
+
+func validTicket() *forgefed.Ticket {
+	ticket := forgefed.TicketNew(ticketIRI)
+	ticket.AttributedTo = ap.IRI(ticketActorIRI)
+	ticket.Context = ap.IRI(ticketRepoIRI)
+	ticket.Summary = ap.DefaultNaturalLanguageValue("A bug")
+	ticket.Content = ap.DefaultNaturalLanguageValue("<p>Something is broken</p>")
+	return ticket
+}
+
+func Test_NewForgeCreateTicket(t *testing.T) {
+	sut, err := forgefed.NewForgeCreateTicket(ticketActorIRI, validTicket(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, ap.CreateType, sut.Type)
+	assert.Equal(t, ticketIRI+"/create", sut.ID.String())
+
+	got, err := sut.MarshalJSON()
+	require.NoError(t, err)
+
+	activity := ap.Activity{}
+	require.NoError(t, activity.UnmarshalJSON(got))
+	roundtrip, err := forgefed.NewForgeCreateTicketFromAp(activity)
+	require.NoError(t, err)
+	assert.Equal(t, "A bug", roundtrip.Ticket.Summary.String())
+	assert.Equal(t, ticketRepoIRI, roundtrip.Ticket.Context.GetLink().String())
+}
+
+func Test_ForgeCreateTicketValidation(t *testing.T) {
+	sut, err := forgefed.NewForgeCreateTicket(ticketActorIRI, validTicket(), time.Now())
+	require.NoError(t, err)
+	valid, _ := validation.IsValid(sut)
+	assert.True(t, valid)
+
+	withoutSummary := validTicket()
+	withoutSummary.Summary = nil
+	_, err = forgefed.NewForgeCreateTicket(ticketActorIRI, withoutSummary, time.Now())
+	require.Error(t, err)
+
+	withoutContext := validTicket()
+	withoutContext.Context = nil
+	_, err = forgefed.NewForgeCreateTicket(ticketActorIRI, withoutContext, time.Now())
+	require.Error(t, err)
+
+	// the ticket has to be attributed to the actor of the activity
+	_, err = forgefed.NewForgeCreateTicket("https://repo.prod.meissa.de/api/v1/activitypub/user-id/2", validTicket(), time.Now())
+	require.Error(t, err)
+
+	// only tickets are accepted
+	note := ap.ObjectNew(ap.NoteType)
+	note.ID = ticketIRI
+	_, err = forgefed.NewForgeCreateTicketFromAp(ap.Activity{Type: ap.CreateType, Actor: ap.IRI(ticketActorIRI), Object: note})
+	require.Error(t, err)
+}
+
+func Test_ForgeCreateTicketCommentValidation(t *testing.T) {
+	note := ap.ObjectNew(ap.NoteType)
+	note.ID = ticketIRI + "#comment-1"
+	note.AttributedTo = ap.IRI(ticketActorIRI)
+	note.InReplyTo = ap.IRI(ticketIRI)
+	note.Content = ap.DefaultNaturalLanguageValue("<p>Same here</p>")
+
+	sut, err := forgefed.NewForgeCreateTicketComment(ticketActorIRI, note, time.Now())
+	require.NoError(t, err)
+	valid, _ := validation.IsValid(sut)
+	assert.True(t, valid)
+
+	note.InReplyTo = nil
+	_, err = forgefed.NewForgeCreateTicketComment(ticketActorIRI, note, time.Now())
+	require.Error(t, err)
+}
+
+func Test_ForgeResolveTicket(t *testing.T) {
+	resolve, err := forgefed.NewForgeResolveTicket(ticketActorIRI, ticketIRI, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, forgefed.ResolveType, resolve.Type)
+
+	undo, err := forgefed.NewForgeUndoResolveTicket(ticketActorIRI, ticketIRI, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, ap.UndoType, undo.Type)
+	got, err := undo.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"type":"Resolve"`)
+
+	_, err = forgefed.NewForgeResolveTicket(ticketActorIRI, ticketIRI, time.Time{})
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed
+
+import (
+	"time"
+
+	"forgejo.org/modules/validation"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+const (
+	ResolveType ap.ActivityVocabularyType = "Resolve"
+)
+
+// ForgeResolveTicket activity data type, used to mark a ticket as resolved (closed)
+// swagger:model
+type ForgeResolveTicket struct {
+	// swagger:ignore
+	ap.Activity
+}
+
+func NewForgeResolveTicket(actorIRI, ticketIRI string, startTime time.Time) (ForgeResolveTicket, error) {
+	result := ForgeResolveTicket{}
+	result.Type = ResolveType
+	result.Actor = ap.IRI(actorIRI)
+	result.Object = ap.IRI(ticketIRI)
+	result.StartTime = startTime
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeResolveTicket{}, err
+	}
+	return result, nil
+}
+
+func (resolve ForgeResolveTicket) MarshalJSON() ([]byte, error) {
+	return resolve.Activity.MarshalJSON()
+}
+
+func (resolve ForgeResolveTicket) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(string(resolve.Type), "type")...)
+	result = append(result, validation.ValidateOneOf(string(resolve.Type), []any{"Resolve"}, "type")...)
+	result = append(result, validation.ValidateIDExists(resolve.Actor, "actor")...)
+	result = append(result, validation.ValidateIDExists(resolve.Object, "object")...)
+	if resolve.StartTime.IsZero() {
+		result = append(result, "StartTime was invalid.")
+	}
+	return result
+}
+
+// ForgeUndoResolveTicket activity data type, used to mark a resolved ticket as unresolved (reopened)
+// swagger:model
+type ForgeUndoResolveTicket struct {
+	// swagger:ignore
+	ap.Activity
+}
+
+func NewForgeUndoResolveTicket(actorIRI, ticketIRI string, startTime time.Time) (ForgeUndoResolveTicket, error) {
+	result := ForgeUndoResolveTicket{}
+	result.Type = ap.UndoType
+	result.Actor = ap.IRI(actorIRI)
+	result.StartTime = startTime
+
+	resolve := ap.Activity{}
+	resolve.Type = ResolveType
+	resolve.Actor = ap.IRI(actorIRI)
+	resolve.Object = ap.IRI(ticketIRI)
+	result.Object = &resolve
+
+	if valid, err := validation.IsValid(result); !valid {
+		return ForgeUndoResolveTicket{}, err
+	}
+	return result, nil
+}
+
+func (undo ForgeUndoResolveTicket) MarshalJSON() ([]byte, error) {
+	return undo.Activity.MarshalJSON()
+}
+
+func (undo ForgeUndoResolveTicket) Validate() []string {
+	var result []string
+	result = append(result, validation.ValidateNotEmpty(string(undo.Type), "type")...)
+	result = append(result, validation.ValidateOneOf(string(undo.Type), []any{"Undo"}, "type")...)
+	result = append(result, validation.ValidateIDExists(undo.Actor, "actor")...)
+	if undo.StartTime.IsZero() {
+		result = append(result, "StartTime was invalid.")
+	}
+
+	if undo.Object == nil {
+		result = append(result, "object should not be empty.")
+	} else if activity, ok := undo.Object.(*ap.Activity); !ok {
+		result = append(result, "object is not of type Activity")
+	} else {
+		result = append(result, validation.ValidateOneOf(string(activity.Type), []any{"Resolve"}, "object.type")...)
+		result = append(result, validation.ValidateIDExists(activity.Object, "object.object")...)
+	}
+	return result
+}
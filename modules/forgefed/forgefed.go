@@ -10,12 +10,21 @@ import (
 
 const ForgeFedNamespaceURI = "https://forgefed.org/ns"
 
+func init() {
+	// let the go-ap/activitypub package decode the ForgeFed types nested in activities
+	ap.ItemTyperFunc = GetItemByType
+	ap.JSONItemUnmarshal = JSONUnmarshalerFn
+	ap.IsNotEmpty = NotEmpty
+}
+
 // GetItemByType instantiates a new ForgeFed object if the type matches
 // otherwise it defaults to existing activitypub package typer function.
 func GetItemByType(typ ap.ActivityVocabularyType) (ap.Item, error) {
 	switch typ {
 	case RepositoryType:
 		return RepositoryNew(""), nil
+	case TicketType:
+		return TicketNew(""), nil
 	default:
 		return ap.GetItemByType(typ)
 	}
@@ -29,6 +38,10 @@ func JSONUnmarshalerFn(typ ap.ActivityVocabularyType, val *fastjson.Value, i ap.
 		return OnRepository(i, func(r *Repository) error {
 			return JSONLoadRepository(val, r)
 		})
+	case TicketType:
+		return OnTicket(i, func(t *Ticket) error {
+			return JSONLoadTicket(val, t)
+		})
 	default:
 		return nil
 	}
@@ -46,6 +59,12 @@ func NotEmpty(i ap.Item) bool {
 			return false
 		}
 		return ap.NotEmpty(r.Actor)
+	case TicketType:
+		t, err := ToTicket(i)
+		if err != nil {
+			return false
+		}
+		return ap.NotEmpty(t.Object)
 	default:
 		return ap.NotEmpty(i)
 	}
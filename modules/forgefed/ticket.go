@@ -0,0 +1,102 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed
+
+import (
+	"reflect"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/valyala/fastjson"
+)
+
+const (
+	TicketType ap.ActivityVocabularyType = "Ticket"
+)
+
+// Ticket is an item in a project's issue tracker, see https://forgefed.org/vocabulary.html#type-ticket
+// The title of the ticket is carried by the summary, its description by the content (HTML) and,
+// if available, by the source (usually markdown). The context of the ticket is the repository
+// whose issue tracker it belongs to.
+type Ticket struct {
+	ap.Object
+	// IsResolved Whether the work on this ticket is done
+	IsResolved bool `jsonld:"isResolved,omitempty"`
+}
+
+// TicketNew initializes a Ticket type object
+func TicketNew(id ap.ID) *Ticket {
+	o := ap.ObjectNew(TicketType)
+	o.ID = id
+	return &Ticket{Object: *o}
+}
+
+func (t Ticket) MarshalJSON() ([]byte, error) {
+	b, err := t.Object.MarshalJSON()
+	if len(b) == 0 || err != nil {
+		return nil, err
+	}
+
+	b = b[:len(b)-1]
+	if t.IsResolved {
+		ap.JSONWriteBoolProp(&b, "isResolved", t.IsResolved)
+	}
+	ap.JSONWrite(&b, '}')
+	return b, nil
+}
+
+func JSONLoadTicket(val *fastjson.Value, t *Ticket) error {
+	if err := ap.OnObject(&t.Object, func(o *ap.Object) error {
+		return ap.JSONLoadObject(val, o)
+	}); err != nil {
+		return err
+	}
+
+	t.IsResolved = val.GetBool("isResolved")
+	return nil
+}
+
+func (t *Ticket) UnmarshalJSON(data []byte) error {
+	p := fastjson.Parser{}
+	val, err := p.ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	return JSONLoadTicket(val, t)
+}
+
+// ToTicket tries to convert the it Item to a Ticket.
+func ToTicket(it ap.Item) (*Ticket, error) {
+	switch i := it.(type) {
+	case *Ticket:
+		return i, nil
+	case Ticket:
+		return &i, nil
+	case *ap.Object:
+		return &Ticket{Object: *i}, nil
+	case ap.Object:
+		return &Ticket{Object: i}, nil
+	default:
+		typ := reflect.TypeOf(new(Ticket))
+		if reflect.TypeOf(it).ConvertibleTo(typ) {
+			if i, ok := reflect.ValueOf(it).Convert(typ).Interface().(*Ticket); ok {
+				return i, nil
+			}
+		}
+	}
+	return nil, ap.ErrorInvalidType[ap.Object](it)
+}
+
+type withTicketFn func(*Ticket) error
+
+// OnTicket calls function fn on it Item if it can be asserted to type *Ticket
+func OnTicket(it ap.Item, fn withTicketFn) error {
+	if it == nil {
+		return nil
+	}
+	ob, err := ToTicket(it)
+	if err != nil {
+		return err
+	}
+	return fn(ob)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgefed_test
+
+import (
+	"testing"
+
+	"forgejo.org/modules/forgefed"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TicketMarshalJSON(t *testing.T) {
+	ticket := forgefed.TicketNew("https://example.com/api/v1/activitypub/repository-id/1/issues/1")
+	ticket.Summary = ap.DefaultNaturalLanguageValue("A bug")
+	ticket.Context = ap.IRI("https://example.com/api/v1/activitypub/repository-id/1")
+
+	got, err := ticket.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"type":"Ticket"`)
+	assert.Contains(t, string(got), `"summary":"A bug"`)
+	assert.NotContains(t, string(got), "isResolved")
+
+	ticket.IsResolved = true
+	got, err = ticket.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"isResolved":true`)
+
+	got, err = forgefed.Ticket{}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func Test_TicketUnmarshalJSON(t *testing.T) {
+	data := []byte(`{"id":"https://example.com/tickets/1","type":"Ticket","summary":"A bug",` +
+		`"context":"https://example.com/api/v1/activitypub/repository-id/1","isResolved":true}`)
+
+	ticket := forgefed.Ticket{}
+	require.NoError(t, ticket.UnmarshalJSON(data))
+	assert.Equal(t, forgefed.TicketType, ticket.Type)
+	assert.Equal(t, "https://example.com/tickets/1", ticket.ID.String())
+	assert.Equal(t, "A bug", ticket.Summary.String())
+	assert.Equal(t, "https://example.com/api/v1/activitypub/repository-id/1", ticket.Context.GetLink().String())
+	assert.True(t, ticket.IsResolved)
+}
+
+func Test_TicketWithinActivity(t *testing.T) {
+	data := []byte(`{"type":"Create","actor":"https://example.com/api/v1/activitypub/user-id/1",` +
+		`"object":{"id":"https://example.com/tickets/1","type":"Ticket","summary":"A bug","isResolved":true}}`)
+
+	activity := ap.Activity{}
+	require.NoError(t, activity.UnmarshalJSON(data))
+	require.NotNil(t, activity.Object)
+	assert.Equal(t, forgefed.TicketType, activity.Object.GetType())
+
+	ticket, err := forgefed.ToTicket(activity.Object)
+	require.NoError(t, err)
+	assert.Equal(t, "A bug", ticket.Summary.String())
+	assert.True(t, ticket.IsResolved)
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"forgejo.org/modules/zstd"
 )
 
 // ArchiveType archive types
@@ -23,6 +25,8 @@ const (
 	TARGZ
 	// BUNDLE bundle archive type
 	BUNDLE
+	// TARZST tar zstd archive type
+	TARZST
 )
 
 // String converts an ArchiveType to string
@@ -34,6 +38,8 @@ func (a ArchiveType) String() string {
 		return "tar.gz"
 	case BUNDLE:
 		return "bundle"
+	case TARZST:
+		return "tar.zst"
 	}
 	return "unknown"
 }
@@ -46,6 +52,8 @@ func ToArchiveType(s string) ArchiveType {
 		return TARGZ
 	case "bundle":
 		return BUNDLE
+	case "tar.zst":
+		return TARZST
 	}
 	return 0
 }
@@ -60,7 +68,20 @@ func (repo *Repository) CreateArchive(ctx context.Context, format ArchiveType, t
 	if usePrefix {
 		cmd.AddOptionFormat("--prefix=%s", filepath.Base(strings.TrimSuffix(repo.Path, ".git"))+"/")
 	}
-	cmd.AddOptionFormat("--format=%s", format.String())
+	var zstdWriter *zstd.Writer
+	if format == TARZST {
+		// git archive does not know about zstd without a tar.zst.command configured,
+		// so the tar stream is compressed here
+		var err error
+		zstdWriter, err = zstd.NewWriter(target)
+		if err != nil {
+			return err
+		}
+		target = zstdWriter
+		cmd.AddArguments("--format=tar")
+	} else {
+		cmd.AddOptionFormat("--format=%s", format.String())
+	}
 	cmd.AddDynamicArguments(commitID)
 
 	// Avoid LFS hooks getting installed because of /etc/gitconfig, which can break pull requests.
@@ -74,7 +95,13 @@ func (repo *Repository) CreateArchive(ctx context.Context, format ArchiveType, t
 		Env:    env,
 	})
 	if err != nil {
+		if zstdWriter != nil {
+			_ = zstdWriter.Close()
+		}
 		return ConcatenateError(err, stderr.String())
 	}
+	if zstdWriter != nil {
+		return zstdWriter.Close()
+	}
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"forgejo.org/modules/indexer/code/bleve"
 	"forgejo.org/modules/indexer/code/elasticsearch"
 	"forgejo.org/modules/indexer/code/internal"
+	"forgejo.org/modules/indexer/code/meilisearch"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,3 +206,25 @@ func TestESIndexAndSearch(t *testing.T) {
 
 	testIndexer("elastic_search", t, indexer)
 }
+
+func TestMeilisearchIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	u := os.Getenv("TEST_INDEXER_CODE_MEILISEARCH_URL")
+	if u == "" {
+		t.SkipNow()
+		return
+	}
+
+	indexer := meilisearch.NewIndexer(u, os.Getenv("TEST_INDEXER_CODE_MEILISEARCH_KEY"), "gitea_codes")
+	if _, err := indexer.Init(t.Context()); err != nil {
+		if indexer != nil {
+			indexer.Close()
+		}
+		assert.FailNow(t, "Unable to init meilisearch indexer", "error: %v", err)
+	}
+
+	defer indexer.Close()
+
+	testIndexer("meilisearch", t, indexer)
+}
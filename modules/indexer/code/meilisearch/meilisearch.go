@@ -0,0 +1,436 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/analyze"
+	"forgejo.org/modules/charset"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/indexer/code/internal"
+	indexer_internal "forgejo.org/modules/indexer/internal"
+	inner_meilisearch "forgejo.org/modules/indexer/internal/meilisearch"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/typesniffer"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	repoIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+
+	// maxBatchSize is the number of documents sent to meilisearch in one request
+	maxBatchSize = 50
+
+	// maxLanguages is the number of languages returned in the search stats, like the other indexers
+	maxLanguages = 10
+)
+
+// ErrMalformedResponse is never expected as we initialize the indexer ourself and so define the types.
+var ErrMalformedResponse = errors.New("meilisearch returned unexpected malformed content")
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		SearchableAttributes: []string{
+			"content",
+		},
+		DisplayedAttributes: []string{
+			"id",
+			"repo_id",
+			"content",
+			"commit_id",
+			"filename",
+			"language",
+			"updated_at",
+		},
+		FilterableAttributes: []string{
+			"repo_id",
+			"filename_tree",
+			"language",
+		},
+		SortableAttributes: []string{
+			"updated_at",
+		},
+		// The defaults only allow typos on words of five characters or more,
+		// which is too strict for identifiers found in source code.
+		TypoTolerance: &meilisearch.TypoTolerance{
+			Enabled: true,
+			MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{
+				OneTypo:  4,
+				TwoTypos: 8,
+			},
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, repoIndexerLatestVersion, settings)
+	indexer := &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+	return indexer
+}
+
+// repoIndexerDocument is the document stored in meilisearch for every indexed file.
+type repoIndexerDocument struct {
+	ID           string   `json:"id"`
+	RepoID       int64    `json:"repo_id"`
+	Content      string   `json:"content"`
+	CommitID     string   `json:"commit_id"`
+	Filename     string   `json:"filename"`
+	FilenameTree []string `json:"filename_tree,omitempty"`
+	Language     string   `json:"language"`
+	UpdatedAt    int64    `json:"updated_at"`
+
+	// MatchesPosition is only filled in search results
+	MatchesPosition map[string][]matchPosition `json:"_matchesPosition,omitempty"`
+}
+
+// matchPosition is the byte range of a match, as returned by meilisearch
+type matchPosition struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// documentID returns an identifier for a file of a repository.
+// Meilisearch only allows alphanumeric characters, hyphens and underscores in
+// document identifiers, so the file name cannot be used as is.
+func documentID(repoID int64, filename string) string {
+	sum := sha256.Sum256([]byte(internal.FilenameIndexerID(repoID, filename)))
+	return indexer_internal.Base36(repoID) + "_" + hex.EncodeToString(sum[:])
+}
+
+// filenameTree returns all the hierarchies of a path, the same way the
+// path_hierarchy tokenizer does for the other indexers, so that a filename
+// filter matches both files and directories.
+func filenameTree(filename string) []string {
+	filename = strings.Trim(filename, "/")
+	tree := make([]string, 0, 8)
+	for i, c := range filename {
+		if c == '/' {
+			tree = append(tree, filename[:i])
+		}
+	}
+	return append(tree, filename)
+}
+
+func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserError, batchReader *bufio.Reader, sha string, update internal.FileUpdate, repo *repo_model.Repository) (*repoIndexerDocument, bool, error) {
+	// Ignore vendored files in code search
+	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+		return nil, false, nil
+	}
+
+	size := update.Size
+	var err error
+	if !update.Sized {
+		var stdout string
+		stdout, _, err = git.NewCommand(ctx, "cat-file", "-s").AddDynamicArguments(update.BlobSha).RunStdString(&git.RunOpts{Dir: repo.RepoPath()})
+		if err != nil {
+			return nil, false, err
+		}
+		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
+			return nil, false, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+	}
+
+	if size > setting.Indexer.MaxIndexerFileSize {
+		return nil, true, nil
+	}
+
+	if _, err := batchWriter.Write([]byte(update.BlobSha + "\n")); err != nil {
+		return nil, false, err
+	}
+
+	_, _, size, err = git.ReadBatchLine(batchReader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fileContents, err := io.ReadAll(io.LimitReader(batchReader, size))
+	if err != nil {
+		return nil, false, err
+	} else if !typesniffer.DetectContentType(fileContents, update.Filename).IsText() {
+		// FIXME: UTF-16 files will probably fail here
+		return nil, false, nil
+	}
+
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, false, err
+	}
+
+	return &repoIndexerDocument{
+		ID:           documentID(repo.ID, update.Filename),
+		RepoID:       repo.ID,
+		Content:      string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
+		CommitID:     sha,
+		Filename:     update.Filename,
+		FilenameTree: filenameTree(update.Filename),
+		Language:     analyze.GetCodeLanguage(update.Filename, fileContents),
+		UpdatedAt:    int64(timeutil.TimeStampNow()),
+	}, false, nil
+}
+
+// Index will save the index data
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+	docs := make([]*repoIndexerDocument, 0, len(changes.Updates))
+	deletes := make([]string, 0, len(changes.RemovedFilenames))
+	if len(changes.Updates) > 0 {
+		r, err := gitrepo.OpenRepository(ctx, repo)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		batch, err := r.NewBatch(ctx)
+		if err != nil {
+			return err
+		}
+		defer batch.Close()
+
+		for _, update := range changes.Updates {
+			doc, remove, err := b.addUpdate(ctx, batch.Writer, batch.Reader, sha, update, repo)
+			if err != nil {
+				return err
+			}
+			if remove {
+				deletes = append(deletes, documentID(repo.ID, update.Filename))
+			} else if doc != nil {
+				docs = append(docs, doc)
+			}
+		}
+		batch.Close()
+	}
+
+	for _, filename := range changes.RemovedFilenames {
+		deletes = append(deletes, documentID(repo.ID, filename))
+	}
+
+	index := b.inner.Client.Index(b.inner.VersionedIndexName())
+	for i := 0; i < len(docs); i += maxBatchSize {
+		if _, err := index.AddDocuments(docs[i:min(i+maxBatchSize, len(docs))], nil); err != nil {
+			return err
+		}
+	}
+	if len(deletes) > 0 {
+		if _, err := index.DeleteDocuments(deletes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete entries by repoId
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	_, err := b.inner.Client.Index(b.inner.VersionedIndexName()).
+		DeleteDocumentsByFilter(inner_meilisearch.NewFilterEq("repo_id", repoID).Statement())
+	return err
+}
+
+// Search searches for codes and language stats by given conditions.
+func (b *Indexer) Search(_ context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	query := inner_meilisearch.FilterAnd{}
+	if len(opts.RepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("repo_id", opts.RepoIDs...))
+	}
+	if filename := strings.Trim(opts.Filename, "/"); len(filename) > 0 {
+		query.And(inner_meilisearch.NewFilterEqString("filename_tree", filename))
+	}
+
+	// Save for reuse without language filter
+	facetQuery := query
+	if len(opts.Language) > 0 {
+		query.And(inner_meilisearch.NewFilterEqString("language", opts.Language))
+	}
+
+	keyword, matchingStrategy := buildKeyword(opts.Keyword, opts.Mode)
+	skip, limit := indexer_internal.ParsePaginator(opts.Paginator, maxTotalHits)
+
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).
+		Search(keyword, &meilisearch.SearchRequest{
+			Filter:              query.Statement(),
+			Limit:               int64(limit),
+			Offset:              int64(skip),
+			Facets:              []string{"language"},
+			ShowMatchesPosition: true,
+			MatchingStrategy:    matchingStrategy,
+		})
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	hits, err := convertHits(searchRes, opts)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	if len(opts.Language) > 0 {
+		// Use separate query to go get all language counts.
+		// If set limit to 0, it will be 20 by default, so set limit to 1 to make the cost as low as possible.
+		facetRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).
+			Search(keyword, &meilisearch.SearchRequest{
+				Filter:               facetQuery.Statement(),
+				Limit:                1,
+				Facets:               []string{"language"},
+				AttributesToRetrieve: []string{"id"},
+				MatchingStrategy:     matchingStrategy,
+			})
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		searchRes.FacetDistribution = facetRes.FacetDistribution
+	}
+
+	languages, err := convertLanguages(searchRes)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return searchRes.EstimatedTotalHits, hits, languages, nil
+}
+
+// buildKeyword converts the keyword to the meilisearch query syntax for the given search mode.
+func buildKeyword(keyword string, mode internal.CodeSearchMode) (string, meilisearch.MatchingStrategy) {
+	switch mode {
+	case internal.CodeSearchModeFuzzy:
+		return keyword, meilisearch.All
+	case internal.CodeSearchModeUnion:
+		// phrases are not typo tolerant, quote every word to only match them exactly
+		return doubleQuoteKeyword(keyword), meilisearch.Last
+	default:
+		// to make it a phrase search, we have to quote the keyword
+		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
+		keyword = strings.ReplaceAll(keyword, `"`, "")
+		if strings.TrimSpace(keyword) == "" {
+			return "", meilisearch.All
+		}
+		return `"` + keyword + `"`, meilisearch.All
+	}
+}
+
+func doubleQuoteKeyword(k string) string {
+	kp := strings.Split(k, " ")
+	parts := 0
+	for i := range kp {
+		part := strings.Trim(kp[i], "\"")
+		if part != "" {
+			kp[parts] = fmt.Sprintf(`"%s"`, part)
+			parts++
+		}
+	}
+	return strings.Join(kp[:parts], " ")
+}
+
+// matchRange returns the byte range of the content to highlight.
+// In exact mode the range covers all the words of the phrase, otherwise only the first match.
+func matchRange(positions []matchPosition, keyword string, mode internal.CodeSearchMode) (int, int) {
+	if len(positions) == 0 {
+		return -1, -1
+	}
+	slices.SortFunc(positions, func(a, b matchPosition) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	start := positions[0].Start
+	end := start + positions[0].Length
+	if mode == internal.CodeSearchModeExact {
+		for _, pos := range positions[1:] {
+			if pos.Start >= start+len(keyword) {
+				break
+			}
+			end = max(end, pos.Start+pos.Length)
+		}
+	}
+	return start, end
+}
+
+func convertHits(searchRes *meilisearch.SearchResponse, opts *internal.SearchOptions) ([]*internal.SearchResult, error) {
+	docs := make([]*repoIndexerDocument, 0, len(searchRes.Hits))
+	if err := searchRes.Hits.DecodeInto(&docs); err != nil {
+		return nil, ErrMalformedResponse
+	}
+
+	hits := make([]*internal.SearchResult, 0, len(docs))
+	for _, doc := range docs {
+		startIndex, endIndex := matchRange(doc.MatchesPosition["content"], opts.Keyword, opts.Mode)
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      doc.RepoID,
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+			Filename:    doc.Filename,
+			Content:     doc.Content,
+			CommitID:    doc.CommitID,
+			UpdatedUnix: timeutil.TimeStamp(doc.UpdatedAt),
+			Language:    doc.Language,
+			Color:       enry.GetColor(doc.Language),
+		})
+	}
+	return hits, nil
+}
+
+func convertLanguages(searchRes *meilisearch.SearchResponse) ([]*internal.SearchResultLanguages, error) {
+	languages := make([]*internal.SearchResultLanguages, 0, maxLanguages)
+	if searchRes.FacetDistribution == nil {
+		return languages, nil
+	}
+
+	// the facet distribution is decoded through JSON to not depend on how the client exposes it
+	raw, err := json.Marshal(searchRes.FacetDistribution)
+	if err != nil {
+		return nil, err
+	}
+	var facets map[string]map[string]int
+	if err := json.Unmarshal(raw, &facets); err != nil {
+		return nil, ErrMalformedResponse
+	}
+
+	for language, count := range facets["language"] {
+		if len(language) == 0 {
+			continue
+		}
+		languages = append(languages, &internal.SearchResultLanguages{
+			Language: language,
+			Color:    enry.GetColor(language),
+			Count:    count,
+		})
+	}
+	slices.SortFunc(languages, func(a, b *internal.SearchResultLanguages) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Language, b.Language)
+	})
+	if len(languages) > maxLanguages {
+		languages = languages[:maxLanguages]
+	}
+	return languages, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"testing"
+
+	"forgejo.org/modules/indexer/code/internal"
+
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilenameTree(t *testing.T) {
+	assert.Equal(t, []string{"README.md"}, filenameTree("README.md"))
+	assert.Equal(t, []string{
+		"modules",
+		"modules/indexer",
+		"modules/indexer/code",
+		"modules/indexer/code/search.go",
+	}, filenameTree("/modules/indexer/code/search.go"))
+}
+
+func TestDocumentID(t *testing.T) {
+	id := documentID(1, "some dir/file.go")
+	assert.Regexp(t, "^[a-zA-Z0-9_-]+$", id)
+	assert.NotEqual(t, id, documentID(2, "some dir/file.go"))
+	assert.NotEqual(t, id, documentID(1, "some dir/file2.go"))
+}
+
+func TestBuildKeyword(t *testing.T) {
+	kw, strategy := buildKeyword("Description for", internal.CodeSearchModeExact)
+	assert.Equal(t, `"Description for"`, kw)
+	assert.Equal(t, meilisearch.All, strategy)
+
+	kw, strategy = buildKeyword(`Description "for`, internal.CodeSearchModeUnion)
+	assert.Equal(t, `"Description" "for"`, kw)
+	assert.Equal(t, meilisearch.Last, strategy)
+
+	kw, strategy = buildKeyword("reppo1", internal.CodeSearchModeFuzzy)
+	assert.Equal(t, "reppo1", kw)
+	assert.Equal(t, meilisearch.All, strategy)
+}
+
+func TestMatchRange(t *testing.T) {
+	start, end := matchRange(nil, "Description", internal.CodeSearchModeExact)
+	assert.Equal(t, -1, start)
+	assert.Equal(t, -1, end)
+
+	positions := []matchPosition{{Start: 21, Length: 3}, {Start: 9, Length: 11}}
+	start, end = matchRange(positions, "Description for", internal.CodeSearchModeExact)
+	assert.Equal(t, 9, start)
+	assert.Equal(t, 24, end)
+
+	positions = []matchPosition{{Start: 21, Length: 3}, {Start: 9, Length: 11}}
+	start, end = matchRange(positions, "Description for", internal.CodeSearchModeUnion)
+	assert.Equal(t, 9, start)
+	assert.Equal(t, 20, end)
+}
+
+func TestConvertHits(t *testing.T) {
+	_, err := convertHits(&meilisearch.SearchResponse{
+		Hits: meilisearch.Hits{
+			meilisearch.Hit{"repo_id": []byte(`"aa"`)},
+		},
+	}, &internal.SearchOptions{})
+	require.ErrorIs(t, err, ErrMalformedResponse)
+
+	hits, err := convertHits(&meilisearch.SearchResponse{
+		Hits: meilisearch.Hits{
+			meilisearch.Hit{
+				"id":               []byte(`"1_abc"`),
+				"repo_id":          []byte("1"),
+				"content":          []byte(`"# repo1\n\nDescription for repo1"`),
+				"commit_id":        []byte(`"65f1bf27bc3bf70f64657658635e66094edbcb4d"`),
+				"filename":         []byte(`"README.md"`),
+				"language":         []byte(`"Markdown"`),
+				"updated_at":       []byte("1700000000"),
+				"_matchesPosition": []byte(`{"content":[{"start":9,"length":11}]}`),
+			},
+		},
+	}, &internal.SearchOptions{Keyword: "Description"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, int64(1), hits[0].RepoID)
+	assert.Equal(t, "README.md", hits[0].Filename)
+	assert.Equal(t, "Markdown", hits[0].Language)
+	assert.Equal(t, 9, hits[0].StartIndex)
+	assert.Equal(t, 20, hits[0].EndIndex)
+}
@@ -84,6 +84,18 @@ func (f FilterEq) Statement() string {
 	return string(f)
 }
 
+// NewFilterEqString creates a new FilterEq comparing against a string value.
+// The value is quoted, with double quotes and backslashes escaped.
+func NewFilterEqString(field, value string) FilterEq {
+	return FilterEq(fmt.Sprintf("%s = %s", field, quoteFilterValue(value)))
+}
+
+func quoteFilterValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
 type FilterNot string
 
 func NewFilterNot(filter Filter) FilterNot {
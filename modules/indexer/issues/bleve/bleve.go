@@ -24,7 +24,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 5
+	issueIndexerLatestVersion = 6
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -68,6 +68,10 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	numberFieldMapping.Store = false
 	numberFieldMapping.IncludeInAll = false
 
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	keywordFieldMapping.Store = false
+	keywordFieldMapping.IncludeInAll = false
+
 	docMapping.AddFieldMappingsAt("is_public", boolFieldMapping)
 
 	docMapping.AddFieldMappingsAt("index", numberFieldMapping)
@@ -88,6 +92,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("reviewed_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("review_requested_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("subscriber_ids", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("custom_fields", keywordFieldMapping)
 	docMapping.AddFieldMappingsAt("updated_unix", numberFieldMapping)
 
 	docMapping.AddFieldMappingsAt("created_unix", numberFieldMapping)
@@ -257,6 +262,12 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 	}
 
+	for fieldID, value := range options.CustomFields {
+		customFieldQuery := bleve.NewTermQuery(internal.CustomFieldToken(fieldID, value))
+		customFieldQuery.SetField("custom_fields")
+		filters = append(filters, customFieldQuery)
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		filters = append(filters, inner_bleve.NumericRangeInclusiveQuery(
 			options.UpdatedAfterUnix,
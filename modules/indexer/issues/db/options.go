@@ -71,6 +71,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		IssueIDs:           nil,
 		UpdatedAfterUnix:   options.UpdatedAfterUnix.Value(),
 		UpdatedBeforeUnix:  options.UpdatedBeforeUnix.Value(),
+		CustomFields:       options.CustomFields,
 		PriorityRepoID:     0,
 		IsArchived:         optional.None[bool](),
 		Org:                nil,
@@ -76,6 +76,8 @@ func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.Iss
 		searchOpt.UpdatedBeforeUnix = optional.Some(opts.UpdatedBeforeUnix)
 	}
 
+	searchOpt.CustomFields = opts.CustomFields
+
 	searchOpt.Paginator = opts.Paginator
 
 	switch opts.SortType {
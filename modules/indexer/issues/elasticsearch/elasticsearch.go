@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 2
+	issueIndexerLatestVersion = 3
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -74,6 +74,7 @@ const (
 			"reviewed_ids": { "type": "long", "index": true },
 			"review_requested_ids": { "type": "long", "index": true },
 			"subscriber_ids": { "type": "long", "index": true },
+			"custom_fields": { "type": "keyword", "index": true },
 			"updated_unix": { "type": "long", "index": true },
 
 			"created_unix": { "type": "long", "index": true },
@@ -251,6 +252,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(elastic.NewTermQuery("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	for fieldID, value := range options.CustomFields {
+		query.Must(elastic.NewTermQuery("custom_fields", internal.CustomFieldToken(fieldID, value)))
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		q := elastic.NewRangeQuery("updated_unix")
 		if options.UpdatedAfterUnix.Has() {
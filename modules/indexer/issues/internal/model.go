@@ -4,6 +4,8 @@
 package internal
 
 import (
+	"strconv"
+
 	"forgejo.org/models/db"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/timeutil"
@@ -35,6 +37,7 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	CustomFields       []string           `json:"custom_fields"` // formatted with CustomFieldToken
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -45,6 +48,11 @@ type IndexerData struct {
 	CommentCount int64              `json:"comment_count"`
 }
 
+// CustomFieldToken returns the keyword indexed for the value of a custom field
+func CustomFieldToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
 // Match represents on search result
 type Match struct {
 	ID    int64   `json:"id"`
@@ -103,6 +111,8 @@ type SearchOptions struct {
 
 	SubscriberID optional.Option[int64] // subscriber of the issues
 
+	CustomFields map[int64]string // values of custom fields the issues have, by field ID
+
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
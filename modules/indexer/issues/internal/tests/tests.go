@@ -558,6 +558,25 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "CustomFields",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CustomFields: map[int64]string{1: "option1", 2: "2025-01-01"},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, data[v.ID].CustomFields, "1:option1")
+				assert.Contains(t, data[v.ID].CustomFields, "2:2025-01-01")
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return slices.Contains(v.CustomFields, "1:option1") && slices.Contains(v.CustomFields, "2:2025-01-01")
+			}), result.Total)
+		},
+	},
 	{
 		Name: "updated",
 		SearchOptions: &internal.SearchOptions{
@@ -839,6 +858,10 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 			for i := range subscriberIDs {
 				subscriberIDs[i] = int64(i) + 1 // SubscriberID should not be 0
 			}
+			customFields := []string{internal.CustomFieldToken(1, fmt.Sprintf("option%d", id%3))}
+			if id%2 == 0 {
+				customFields = append(customFields, internal.CustomFieldToken(2, "2025-01-01"))
+			}
 
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
@@ -861,6 +884,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				CustomFields:       customFields,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
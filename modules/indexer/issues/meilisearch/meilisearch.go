@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 3
+	issueIndexerLatestVersion = 4
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -72,6 +72,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"reviewed_ids",
 			"review_requested_ids",
 			"subscriber_ids",
+			"custom_fields",
 			"updated_unix",
 		},
 		SortableAttributes: []string{
@@ -201,6 +202,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterEq("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	for fieldID, value := range options.CustomFields {
+		query.And(inner_meilisearch.NewFilterEqString("custom_fields", internal.CustomFieldToken(fieldID, value)))
+	}
+
 	if options.UpdatedAfterUnix.Has() {
 		query.And(inner_meilisearch.NewFilterGte("updated_unix", options.UpdatedAfterUnix.Value()))
 	}
@@ -87,6 +87,15 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		return nil, false, err
 	}
 
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	customFields := make([]string, 0, len(customFieldValues[issue.ID]))
+	for _, value := range customFieldValues[issue.ID] {
+		customFields = append(customFields, internal.CustomFieldToken(value.FieldID, value.Value))
+	}
+
 	var projectID int64
 	if issue.Project != nil {
 		projectID = issue.Project.ID
@@ -113,6 +122,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		CustomFields:       customFields,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
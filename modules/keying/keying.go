@@ -13,6 +13,14 @@
 // XChaCha-Poly1305 (per draft-irtf-cfrg-xchacha-01) is used as AEAD to encrypt
 // and decrypt messages. A new fresh random nonce is generated for every
 // encryption. The nonce gets prepended to the ciphertext.
+//
+// Ciphertexts are versioned envelopes: a random data key encrypts the
+// plaintext and is itself wrapped with the subkey of the main key. The
+// envelope records the ID of the main key it was wrapped with, so that the main
+// key can be rotated. Previous main keys are kept in a key ring to decrypt the
+// data that wasn't re-encrypted yet. Ciphertexts of the unversioned format
+// (nonce followed by the ciphertext, sealed directly with the subkey) are still
+// decrypted with every key of the ring.
 package keying
 
 import (
@@ -41,6 +49,8 @@ var (
 	MigrateTask = deriveKey("migrate_repo_task")
 	// Used for the `webhook` table.
 	Webhook = deriveKey("webhook")
+	// Used for the `login_source` table.
+	AuthSource = deriveKey("auth_source")
 )
 
 var (
@@ -48,39 +58,103 @@ var (
 	hash = sha256.New
 	// The AEAD used for encryption/decryption.
 	aead = chacha20poly1305.NewX
-	// The pseudorandom key generated by HKDF-Extract.
-	prk atomic.Value
+	// The main key new data is encrypted with.
+	current atomic.Pointer[ringKey]
+	// The previous main keys, only used to decrypt data.
+	previous atomic.Pointer[[]*ringKey]
 )
 
-// Set the main IKM for this module.
-func Init(ikm []byte) {
+var errUnknownKey = errors.New("keying: ciphertext was encrypted with an unknown key")
+
+// A main key of the key ring.
+type ringKey struct {
+	// Identifies the key in the envelopes it wrapped.
+	id []byte
+	// The pseudorandom key generated by HKDF-Extract.
+	prk []byte
+}
+
+func newRingKey(ikm []byte) *ringKey {
 	// Salt is intentionally left empty, it's not useful to Forgejo's use case.
-	buf, err := hkdf.Extract(hash, ikm, nil)
+	prk, err := hkdf.Extract(hash, ikm, nil)
 	if err != nil {
 		panic(err)
 	}
-	if ok := prk.CompareAndSwap(nil, buf); ok {
+	id, err := hkdf.Expand(hash, prk, "key id", keyIDSize)
+	if err != nil {
+		panic(err)
+	}
+	return &ringKey{id: id, prk: prk}
+}
+
+// Set the main IKM for this module.
+func Init(ikm []byte) {
+	key := newRingKey(ikm)
+	if ok := current.CompareAndSwap(nil, key); ok {
 		return
 	}
-	// prk was already set
-	old := prk.Load().([]byte)
-	if bytes.Equal(old, buf) {
+	// the main key was already set
+	if bytes.Equal(current.Load().prk, key.prk) {
 		return
 	}
 	panic("main IKM cannot be updated at runtime")
 }
 
+// Set the previous main IKMs. Data encrypted with them can still be decrypted,
+// new data is always encrypted with the main IKM.
+func SetPreviousKeys(ikms [][]byte) {
+	keys := make([]*ringKey, 0, len(ikms))
+	for _, ikm := range ikms {
+		keys = append(keys, newRingKey(ikm))
+	}
+	previous.Store(&keys)
+}
+
+func currentKey() *ringKey {
+	key := current.Load()
+	if key == nil {
+		panic("keying: not initialized")
+	}
+	return key
+}
+
+// Returns the main key followed by the previous main keys.
+func ringKeys() []*ringKey {
+	keys := []*ringKey{currentKey()}
+	if previousKeys := previous.Load(); previousKeys != nil {
+		keys = append(keys, *previousKeys...)
+	}
+	return keys
+}
+
+func findKey(id []byte) *ringKey {
+	for _, key := range ringKeys() {
+		if bytes.Equal(key.id, id) {
+			return key
+		}
+	}
+	return nil
+}
+
 const (
 	aeadKeySize   = chacha20poly1305.KeySize
 	aeadNonceSize = chacha20poly1305.NonceSizeX
+
+	envelopeMagic   = 0xfe
+	envelopeVersion = 0x01
+	keyIDSize       = 4
+	// magic, version and ID of the main key.
+	envelopeHeaderSize = 2 + keyIDSize
+	// nonce and sealed data key.
+	wrappedKeySize = aeadNonceSize + aeadKeySize + chacha20poly1305.Overhead
 )
 
 // Derive *the* key for a given context, this is a deterministic function.
 // The same key will be provided for the same context.
 func deriveKey(context string) Context {
-	// wrap another sync.Once to prevent panic on initialization (prk would be nil)
-	return Context{sync.OnceValue(func() cipher.AEAD {
-		return expandPRK(prk.Load().([]byte), context)
+	// wrap another sync.Once to prevent panic on initialization (the main key would be nil)
+	return Context{context, sync.OnceValue(func() cipher.AEAD {
+		return expandPRK(currentKey().prk, context)
 	})}
 }
 
@@ -101,9 +175,19 @@ func expandPRK(prk []byte, context string) cipher.AEAD {
 }
 
 type Context struct {
+	context string
+	// The AEAD derived from the main key.
 	aead func() cipher.AEAD
 }
 
+// Returns the AEAD of this context derived from the given main key.
+func (k Context) aeadFor(key *ringKey) cipher.AEAD {
+	if key == currentKey() {
+		return k.aead()
+	}
+	return expandPRK(key.prk, k.context)
+}
+
 // Encrypts the specified plaintext with some additional data that is tied to
 // this plaintext. The additional data can be seen as the context in which the
 // data is being encrypted for, this is different than the context for which the
@@ -114,24 +198,101 @@ type Context struct {
 // appended to the ciphertext and may be publicly known, it must be available
 // when decryping the ciphertext.
 func (k Context) Encrypt(plaintext, additionalData []byte) []byte {
-	nonce := make([]byte, aeadNonceSize)
-	_, _ = crand.Read(nonce) // never returns an error
+	return k.encrypt(currentKey(), plaintext, additionalData)
+}
+
+func (k Context) encrypt(key *ringKey, plaintext, additionalData []byte) []byte {
+	dataKey := make([]byte, aeadKeySize)
+	_, _ = crand.Read(dataKey) // never returns an error
+	dataAEAD, err := aead(dataKey)
+	if err != nil {
+		panic(err)
+	}
 
-	// Returns the ciphertext of this plaintext.
-	return k.aead().Seal(nonce, nonce, plaintext, additionalData)
+	header := append([]byte{envelopeMagic, envelopeVersion}, key.id...)
+	// The header is authenticated with the data key, so that it can't be swapped.
+	wrapAdditionalData := append(bytes.Clone(header), additionalData...)
+
+	ciphertext := seal(k.aeadFor(key), header, dataKey, wrapAdditionalData)
+	return seal(dataAEAD, ciphertext, plaintext, additionalData)
 }
 
 // Decrypts the ciphertext and authenticates it against the given additional
 // data that was given when it was encrypted. It returns an error if the
 // authentication failed.
 func (k Context) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	if !isEnvelope(ciphertext) {
+		return k.decryptUnversioned(ciphertext, additionalData)
+	}
+
+	plaintext, err := k.decryptEnvelope(ciphertext, additionalData)
+	if err != nil {
+		// a ciphertext of the unversioned format may start like an envelope by chance
+		if plaintext, unversionedErr := k.decryptUnversioned(ciphertext, additionalData); unversionedErr == nil {
+			return plaintext, nil
+		}
+		return nil, err
+	}
+	return plaintext, nil
+}
+
+func (k Context) decryptEnvelope(ciphertext, additionalData []byte) ([]byte, error) {
+	header, ciphertext := ciphertext[:envelopeHeaderSize], ciphertext[envelopeHeaderSize:]
+
+	key := findKey(header[2:])
+	if key == nil {
+		return nil, errUnknownKey
+	}
+
+	dataKey, err := open(k.aeadFor(key), ciphertext[:wrappedKeySize], append(bytes.Clone(header), additionalData...))
+	if err != nil {
+		return nil, err
+	}
+	dataAEAD, err := aead(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataAEAD, ciphertext[wrappedKeySize:], additionalData)
+}
+
+func (k Context) decryptUnversioned(ciphertext, additionalData []byte) (plaintext []byte, err error) {
+	for _, key := range ringKeys() {
+		if plaintext, err = open(k.aeadFor(key), ciphertext, additionalData); err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, err
+}
+
+// Seals the plaintext with a fresh random nonce, the nonce and the ciphertext
+// are appended to dst.
+func seal(e cipher.AEAD, dst, plaintext, additionalData []byte) []byte {
+	nonce := make([]byte, aeadNonceSize)
+	_, _ = crand.Read(nonce) // never returns an error
+
+	return e.Seal(append(dst, nonce...), nonce, plaintext, additionalData)
+}
+
+func open(e cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
 	if len(ciphertext) <= aeadNonceSize {
 		return nil, errors.New("keying: ciphertext is too short")
 	}
 
 	nonce, ciphertext := ciphertext[:aeadNonceSize], ciphertext[aeadNonceSize:]
 
-	return k.aead().Open(nil, nonce, ciphertext, additionalData)
+	return e.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func isEnvelope(ciphertext []byte) bool {
+	return len(ciphertext) >= envelopeHeaderSize+wrappedKeySize+aeadNonceSize+chacha20poly1305.Overhead &&
+		ciphertext[0] == envelopeMagic && ciphertext[1] == envelopeVersion
+}
+
+// Reports whether the ciphertext is an envelope wrapped with the main key. Any
+// other ciphertext has to be re-encrypted before the previous main keys can be
+// removed from the key ring. It doesn't authenticate the ciphertext.
+func IsCurrentKey(ciphertext []byte) bool {
+	return isEnvelope(ciphertext) && bytes.Equal(ciphertext[2:envelopeHeaderSize], currentKey().id)
 }
 
 // ColumnAndID generates a context that can be used as additional context for
@@ -105,6 +105,57 @@ func TestKeying(t *testing.T) {
 	})
 }
 
+func TestKeyRing(t *testing.T) {
+	Init([]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07})
+	previousIKM := []byte{0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x00}
+	previousKey := newRingKey(previousIKM)
+	defer SetPreviousKeys(nil)
+
+	key := deriveKey("TESTING KEY RING")
+	plainText := []byte("Forgejo is run by [Redacted]")
+	additionalData := []byte{0x05, 0x06}
+
+	oldCipherText := key.encrypt(previousKey, plainText, additionalData)
+	assert.False(t, IsCurrentKey(oldCipherText))
+	unversionedCipherText := seal(key.aeadFor(previousKey), nil, plainText, additionalData)
+	assert.False(t, IsCurrentKey(unversionedCipherText))
+
+	t.Run("Unknown key", func(t *testing.T) {
+		SetPreviousKeys(nil)
+
+		_, err := key.Decrypt(oldCipherText, additionalData)
+		require.ErrorIs(t, err, errUnknownKey)
+		_, err = key.Decrypt(unversionedCipherText, additionalData)
+		require.Error(t, err)
+	})
+
+	t.Run("Previous key", func(t *testing.T) {
+		SetPreviousKeys([][]byte{previousIKM})
+
+		convertedPlainText, err := key.Decrypt(oldCipherText, additionalData)
+		require.NoError(t, err)
+		assert.Equal(t, plainText, convertedPlainText)
+
+		convertedPlainText, err = key.Decrypt(unversionedCipherText, additionalData)
+		require.NoError(t, err)
+		assert.Equal(t, plainText, convertedPlainText)
+	})
+
+	t.Run("Current key", func(t *testing.T) {
+		cipherText := key.Encrypt(plainText, additionalData)
+		assert.True(t, IsCurrentKey(cipherText))
+
+		convertedPlainText, err := key.Decrypt(cipherText, additionalData)
+		require.NoError(t, err)
+		assert.Equal(t, plainText, convertedPlainText)
+
+		// the header can't be tampered with
+		cipherText[2] = ^cipherText[2]
+		_, err = key.Decrypt(cipherText, additionalData)
+		require.Error(t, err)
+	})
+}
+
 func TestKeyingColumnAndID(t *testing.T) {
 	assert.Equal(t, []byte{0x74, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, ColumnAndID("table", math.MinInt64))
 	assert.Equal(t, []byte{0x74, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, ColumnAndID("table", -1))
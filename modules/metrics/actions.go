@@ -0,0 +1,153 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// the jobs of autoscaled runners wait and run for seconds to hours
+var actionsDurationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600}
+
+var (
+	actionsJobQueueDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    namespace + "actions_job_queue_duration_seconds",
+		Help:    "Time the Actions jobs waited for a runner",
+		Buckets: actionsDurationBuckets,
+	}, []string{"labels"})
+	actionsJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    namespace + "actions_job_duration_seconds",
+		Help:    "Time the Actions jobs ran on a runner",
+		Buckets: actionsDurationBuckets,
+	}, []string{"labels", "status"})
+	actionsTaskFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    namespace + "actions_task_fetch_duration_seconds",
+		Help:    "Time taken to answer the runners fetching a task",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"result"})
+)
+
+// the runs-on of the jobs come from the workflows, only so many of them are
+// kept apart and the others share actionsOtherLabels
+const (
+	maxActionsLabels   = 100
+	actionsOtherLabels = "other"
+)
+
+var (
+	actionsLabelsMu   sync.Mutex
+	actionsLabelsSeen = make(container.Set[string])
+)
+
+// actionsLabels returns the labels of a job as a single, stable, metric label value
+func actionsLabels(runsOn []string) string {
+	labels := slices.Clone(runsOn)
+	slices.Sort(labels)
+	value := strings.Join(labels, ",")
+
+	actionsLabelsMu.Lock()
+	defer actionsLabelsMu.Unlock()
+	if !actionsLabelsSeen.Contains(value) {
+		if len(actionsLabelsSeen) >= maxActionsLabels {
+			return actionsOtherLabels
+		}
+		actionsLabelsSeen.Add(value)
+	}
+	return value
+}
+
+// ObserveActionsJobQueued records how long a job waited before a runner picked it
+func ObserveActionsJobQueued(runsOn []string, waited time.Duration) {
+	actionsJobQueueDuration.WithLabelValues(actionsLabels(runsOn)).Observe(waited.Seconds())
+}
+
+// ObserveActionsJobDone records how long a job ran before it ended with the given status
+func ObserveActionsJobDone(runsOn []string, status string, ran time.Duration) {
+	actionsJobDuration.WithLabelValues(actionsLabels(runsOn), status).Observe(ran.Seconds())
+}
+
+// ObserveActionsTaskFetch records how long it took to answer a runner fetching a task
+func ObserveActionsTaskFetch(picked bool, took time.Duration) {
+	result := "none"
+	if picked {
+		result = "task"
+	}
+	actionsTaskFetchDuration.WithLabelValues(result).Observe(took.Seconds())
+}
+
+// ActionsCollector implements the prometheus.Collector interface and
+// exposes the Actions jobs and runners metrics for prometheus
+type ActionsCollector struct {
+	Jobs    *prometheus.Desc
+	Runners *prometheus.Desc
+}
+
+// NewActionsCollector returns a new ActionsCollector with all prometheus.Desc initialized
+func NewActionsCollector() ActionsCollector {
+	return ActionsCollector{
+		Jobs: prometheus.NewDesc(
+			namespace+"actions_jobs",
+			"Number of Actions jobs",
+			[]string{"status"}, nil,
+		),
+		Runners: prometheus.NewDesc(
+			namespace+"actions_runners",
+			"Number of Actions runners",
+			[]string{"owner", "state"}, nil,
+		),
+	}
+}
+
+// Describe returns all possible prometheus.Desc
+func (c ActionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Jobs
+	ch <- c.Runners
+	actionsJobQueueDuration.Describe(ch)
+	actionsJobDuration.Describe(ch)
+	actionsTaskFetchDuration.Describe(ch)
+}
+
+// Collect returns the metrics with values
+func (c ActionsCollector) Collect(ch chan<- prometheus.Metric) {
+	jobs, err := actions_model.CountRunJobsByStatus(db.DefaultContext)
+	if err != nil {
+		log.Error("CountRunJobsByStatus: %v", err)
+	}
+	for status, count := range jobs {
+		ch <- prometheus.MustNewConstMetric(
+			c.Jobs,
+			prometheus.GaugeValue,
+			float64(count),
+			status.String(),
+		)
+	}
+
+	runners, err := actions_model.CountRunnersByOwnerAndState(db.DefaultContext)
+	if err != nil {
+		log.Error("CountRunnersByOwnerAndState: %v", err)
+	}
+	for _, runner := range runners {
+		ch <- prometheus.MustNewConstMetric(
+			c.Runners,
+			prometheus.GaugeValue,
+			float64(runner.Count),
+			runner.OwnerName,
+			runner.State,
+		)
+	}
+
+	actionsJobQueueDuration.Collect(ch)
+	actionsJobDuration.Collect(ch)
+	actionsTaskFetchDuration.Collect(ch)
+}
@@ -56,6 +56,9 @@ type RedisClient interface {
 	SRem(ctx context.Context, key string, members ...any) *redis.IntCmd
 	SIsMember(ctx context.Context, key string, member any) *redis.BoolCmd
 
+	// redis.StreamCmdable
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+
 	// redis.Cmdable
 	DBSize(ctx context.Context) *redis.IntCmd
 	FlushDB(ctx context.Context) *redis.StatusCmd
@@ -0,0 +1,171 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+)
+
+var (
+	ErrInvalidName      = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidArchive   = util.NewInvalidArgumentErrorf("module archive is invalid")
+	ErrInvalidFilename  = util.NewInvalidArgumentErrorf("provider filename is invalid")
+	ErrInvalidManifest  = util.NewInvalidArgumentErrorf("provider manifest is invalid")
+	ErrReadmeTooLarge   = util.NewInvalidArgumentErrorf("README file is too large")
+	ErrManifestTooLarge = util.NewInvalidArgumentErrorf("provider manifest is too large")
+
+	// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#module-addresses
+	namePattern = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?\z`)
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#provider-addresses
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z]+\z`)
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	PropertyOS        = "terraform.os"
+	PropertyArch      = "terraform.arch"
+	PropertyProtocols = "terraform.protocols"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+
+	// DefaultProtocol is the plugin protocol assumed when a provider has no manifest
+	DefaultProtocol = "5.0"
+
+	maxReadmeSize   = 1 * 1024 * 1024
+	maxManifestSize = 64 * 1024
+)
+
+// Metadata represents the metadata of a Terraform module or provider
+type Metadata struct {
+	Kind   string `json:"kind"`
+	Readme string `json:"readme,omitempty"`
+}
+
+// IsValidModuleName checks if the name and target system of a module are valid
+func IsValidModuleName(name, system string) bool {
+	return namePattern.MatchString(name) && namePattern.MatchString(system)
+}
+
+// IsValidProviderType checks if the type of a provider is valid
+func IsValidProviderType(providerType string) bool {
+	return providerTypePattern.MatchString(providerType)
+}
+
+// ModulePackageName returns the package name used to store a module
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// ModuleArchiveName returns the filename of a module archive
+func ModuleArchiveName(name, system, version string) string {
+	return name + "-" + system + "-" + version + ".tar.gz"
+}
+
+// ProviderFilePrefix returns the prefix every file of a provider release has
+func ProviderFilePrefix(providerType, version string) string {
+	return "terraform-provider-" + providerType + "_" + version + "_"
+}
+
+// ShasumsName returns the filename of the checksum file of a provider release
+func ShasumsName(providerType, version string) string {
+	return ProviderFilePrefix(providerType, version) + "SHA256SUMS"
+}
+
+// ManifestName returns the filename of the manifest of a provider release
+func ManifestName(providerType, version string) string {
+	return ProviderFilePrefix(providerType, version) + "manifest.json"
+}
+
+// ParseModuleArchive validates a gzip compressed module archive and extracts its README
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.EqualFold(path.Clean(hd.Name), "README.md") {
+			if hd.Size > maxReadmeSize {
+				return nil, ErrReadmeTooLarge
+			}
+
+			data, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(data)
+		}
+	}
+
+	return m, nil
+}
+
+// ParseProviderArchiveName extracts the target platform from the filename of a provider archive.
+// The expected format is terraform-provider-TYPE_VERSION_OS_ARCH.zip
+func ParseProviderArchiveName(providerType, version, filename string) (string, string, error) {
+	prefix := ProviderFilePrefix(providerType, version)
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, ".zip") {
+		return "", "", ErrInvalidFilename
+	}
+
+	goos, goarch, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".zip"), "_")
+	if !ok || !platformPattern.MatchString(goos) || !platformPattern.MatchString(goarch) {
+		return "", "", ErrInvalidFilename
+	}
+	return goos, goarch, nil
+}
+
+// ParseManifest reads the plugin protocol versions from a provider manifest
+// https://developer.hashicorp.com/terraform/registry/providers/publishing#terraform-registry-manifest-file
+func ParseManifest(r io.Reader) ([]string, error) {
+	var manifest struct {
+		Version  int `json:"version"`
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+
+	lr := &io.LimitedReader{R: r, N: maxManifestSize + 1}
+	if err := json.NewDecoder(lr).Decode(&manifest); err != nil {
+		if lr.N <= 0 {
+			return nil, ErrManifestTooLarge
+		}
+		return nil, ErrInvalidManifest
+	}
+
+	if manifest.Version != 1 || len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(strings.NewReader("not an archive"))
+		assert.Nil(t, metadata)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("MissingReadme", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{"main.tf": ""}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Empty(t, metadata.Readme)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{
+			"main.tf":           "",
+			"./README.md":       "# Module",
+			"modules/README.md": "# Submodule",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, "# Module", metadata.Readme)
+	})
+}
+
+func TestParseProviderArchiveName(t *testing.T) {
+	cases := []struct {
+		Filename string
+		OS       string
+		Arch     string
+		Valid    bool
+	}{
+		{"terraform-provider-test_1.0.0_linux_amd64.zip", "linux", "amd64", true},
+		{"terraform-provider-test_1.0.0_darwin_arm64.zip", "darwin", "arm64", true},
+		{"terraform-provider-test_1.0.0_linux_amd64.tar.gz", "", "", false},
+		{"terraform-provider-test_1.0.1_linux_amd64.zip", "", "", false},
+		{"terraform-provider-other_1.0.0_linux_amd64.zip", "", "", false},
+		{"terraform-provider-test_1.0.0_linux.zip", "", "", false},
+		{"terraform-provider-test_1.0.0_linux_amd_64.zip", "", "", false},
+	}
+
+	for _, c := range cases {
+		goos, goarch, err := ParseProviderArchiveName("test", "1.0.0", c.Filename)
+		if c.Valid {
+			require.NoError(t, err, c.Filename)
+		} else {
+			require.ErrorIs(t, err, ErrInvalidFilename, c.Filename)
+		}
+		assert.Equal(t, c.OS, goos, c.Filename)
+		assert.Equal(t, c.Arch, goarch, c.Filename)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		protocols, err := ParseManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["5.0","6.0"]}}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{
+			`{"version":2,"metadata":{"protocol_versions":["5.0"]}}`,
+			`{"version":1,"metadata":{}}`,
+			`not json`,
+		} {
+			_, err := ParseManifest(strings.NewReader(content))
+			require.ErrorIs(t, err, ErrInvalidManifest, content)
+		}
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		_, err := ParseManifest(strings.NewReader(`{"version":1,"padding":"` + strings.Repeat("a", maxManifestSize) + `"}`))
+		require.ErrorIs(t, err, ErrManifestTooLarge)
+	})
+}
+
+func TestIsValidName(t *testing.T) {
+	assert.True(t, IsValidModuleName("consul", "aws"))
+	assert.True(t, IsValidModuleName("my_module-1", "azurerm"))
+	assert.False(t, IsValidModuleName("-consul", "aws"))
+	assert.False(t, IsValidModuleName("consul", "a/ws"))
+
+	assert.True(t, IsValidProviderType("random"))
+	assert.True(t, IsValidProviderType("my-provider"))
+	assert.False(t, IsValidProviderType("Random"))
+	assert.False(t, IsValidProviderType("my_provider"))
+}
@@ -36,6 +36,7 @@ type PushTrigger string
 const (
 	PushTriggerPRMergeToBase    PushTrigger = "pr-merge-to-base"
 	PushTriggerPRUpdateWithBase PushTrigger = "pr-update-with-base"
+	PushTriggerMergeQueue       PushTrigger = "merge-queue"
 )
 
 // InternalPushingEnvironment returns an os environment to switch off hooks on push
@@ -0,0 +1,64 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"path/filepath"
+
+	"forgejo.org/modules/log"
+)
+
+// Firehose settings
+var Firehose = struct {
+	Enabled bool
+	// Sink is where the events are published: redis, nats or file
+	Sink string
+	// ConnStr is the connection string of the redis or nats server
+	ConnStr string
+	// Topic is the redis stream or the nats subject the events are published to
+	Topic string
+	// MaxLength caps the approximate length of the redis stream, zero means no limit
+	MaxLength int64
+	// FilePath is the file the events are appended to
+	FilePath string
+	// Source is the CloudEvents source attribute of the events
+	Source string
+}{
+	Enabled: false,
+	Sink:    "file",
+	Topic:   "forgejo.events",
+}
+
+func loadFirehoseFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("firehose")
+	Firehose.Enabled = sec.Key("ENABLED").MustBool(false)
+	Firehose.Sink = sec.Key("SINK").In("file", []string{"file", "redis", "nats"})
+	Firehose.ConnStr = sec.Key("CONN_STR").MustString("")
+	Firehose.Topic = sec.Key("TOPIC").MustString("forgejo.events")
+	Firehose.MaxLength = sec.Key("MAX_LENGTH").MustInt64(0)
+	Firehose.FilePath = sec.Key("FILE_PATH").MustString("")
+	Firehose.Source = sec.Key("SOURCE").MustString(AppURL)
+
+	if !Firehose.Enabled {
+		return
+	}
+	switch Firehose.Sink {
+	case "file":
+		if Firehose.FilePath == "" {
+			Firehose.FilePath = "firehose/events.jsonl"
+		}
+		if !filepath.IsAbs(Firehose.FilePath) {
+			Firehose.FilePath = filepath.Join(AppDataPath, Firehose.FilePath)
+		}
+	case "redis":
+		if Firehose.ConnStr == "" {
+			Firehose.ConnStr = "redis://127.0.0.1:6379/0"
+		}
+	case "nats":
+		if Firehose.ConnStr == "" {
+			Firehose.ConnStr = "nats://127.0.0.1:4222"
+		}
+	}
+	log.Info("Firehose enabled, publishing events to %s", Firehose.Sink)
+}
@@ -29,6 +29,7 @@ var Indexer = struct {
 	RepoType               string
 	RepoPath               string
 	RepoConnStr            string
+	RepoConnAuth           string
 	RepoIndexerName        string
 	MaxIndexerFileSize     int64
 	IncludePatterns        []Glob
@@ -47,6 +48,7 @@ var Indexer = struct {
 	RepoType:               "bleve",
 	RepoPath:               "indexers/repos.bleve",
 	RepoConnStr:            "",
+	RepoConnAuth:           "",
 	RepoIndexerName:        "gitea_codes",
 	MaxIndexerFileSize:     1024 * 1024,
 	ExcludeVendored:        true,
@@ -75,14 +77,7 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.IssueConnStr = sec.Key("ISSUE_INDEXER_CONN_STR").MustString(Indexer.IssueConnStr)
 
 	if Indexer.IssueType == "meilisearch" {
-		u, err := url.Parse(Indexer.IssueConnStr)
-		if err != nil {
-			log.Warn("Failed to parse ISSUE_INDEXER_CONN_STR: %v", err)
-			u = &url.URL{}
-		}
-		Indexer.IssueConnAuth, _ = u.User.Password()
-		u.User = nil
-		Indexer.IssueConnStr = u.String()
+		Indexer.IssueConnStr, Indexer.IssueConnAuth = splitMeilisearchConnStr("ISSUE_INDEXER_CONN_STR", Indexer.IssueConnStr)
 	}
 
 	Indexer.IssueIndexerName = sec.Key("ISSUE_INDEXER_NAME").MustString(Indexer.IssueIndexerName)
@@ -96,6 +91,9 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 		Indexer.RepoPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.RepoPath))
 	}
 	Indexer.RepoConnStr = sec.Key("REPO_INDEXER_CONN_STR").MustString("")
+	if Indexer.RepoType == "meilisearch" {
+		Indexer.RepoConnStr, Indexer.RepoConnAuth = splitMeilisearchConnStr("REPO_INDEXER_CONN_STR", Indexer.RepoConnStr)
+	}
 	Indexer.RepoIndexerName = sec.Key("REPO_INDEXER_NAME").MustString("gitea_codes")
 
 	Indexer.IncludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_INCLUDE").MustString(""))
@@ -105,6 +103,19 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
 }
 
+// splitMeilisearchConnStr extracts the API key from the password part of a meilisearch
+// connection string, since the client expects the URL and the key separately.
+func splitMeilisearchConnStr(key, connStr string) (string, string) {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		log.Warn("Failed to parse %s: %v", key, err)
+		u = &url.URL{}
+	}
+	auth, _ := u.User.Password()
+	u.User = nil
+	return u.String(), auth
+}
+
 // IndexerGlobFromString parses a comma separated list of patterns and returns a glob.Glob slice suited for repo indexing
 func IndexerGlobFromString(globstr string) []Glob {
 	extarr := make([]Glob, 0, 10)
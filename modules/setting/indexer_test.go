@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type indexerMatchList struct {
@@ -69,3 +70,20 @@ func checkGlobMatch(t *testing.T, globstr string, list []indexerMatchList) {
 		}
 	}
 }
+
+func TestMeilisearchConnStr(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[indexer]
+ISSUE_INDEXER_TYPE = meilisearch
+ISSUE_INDEXER_CONN_STR = http://:issuekey@localhost:7700
+REPO_INDEXER_TYPE = meilisearch
+REPO_INDEXER_CONN_STR = http://:repokey@localhost:7700
+`)
+	require.NoError(t, err)
+	loadIndexerFrom(cfg)
+
+	assert.Equal(t, "http://localhost:7700", Indexer.IssueConnStr)
+	assert.Equal(t, "issuekey", Indexer.IssueConnAuth)
+	assert.Equal(t, "http://localhost:7700", Indexer.RepoConnStr)
+	assert.Equal(t, "repokey", Indexer.RepoConnAuth)
+}
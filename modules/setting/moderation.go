@@ -12,6 +12,13 @@ import (
 var Moderation = struct {
 	Enabled                bool          `ini:"ENABLED"`
 	KeepResolvedReportsFor time.Duration `ini:"KEEP_RESOLVED_REPORTS_FOR"`
+
+	// Number of distinct users who must report the same content within a category
+	// before it is automatically acted upon, pending review; 0 disables the escalation.
+	SpamReportsThreshold           int `ini:"SPAM_REPORTS_THRESHOLD"`
+	MalwareReportsThreshold        int `ini:"MALWARE_REPORTS_THRESHOLD"`
+	IllegalContentReportsThreshold int `ini:"ILLEGAL_CONTENT_REPORTS_THRESHOLD"`
+	OtherReportsThreshold          int `ini:"OTHER_REPORTS_THRESHOLD"`
 }{
 	Enabled: false,
 }
@@ -18,6 +18,7 @@ var (
 	// Security settings
 	InstallLock                        bool
 	SecretKey                          string
+	PreviousSecretKeys                 []string
 	InternalToken                      string // internal access token
 	LogInRememberDays                  int
 	GlobalTwoFactorRequirement         TwoFactorRequirementType
@@ -112,6 +113,18 @@ func loadSecurityFrom(rootCfg ConfigProvider) {
 	}
 	keying.Init([]byte(SecretKey))
 
+	// the previous secret keys are only used to decrypt the data that was not re-encrypted with the secret key yet,
+	// they are separated by newlines because a secret key may contain any other character
+	PreviousSecretKeys = nil
+	previousIKMs := make([][]byte, 0, 2)
+	for _, key := range strings.Split(loadSecret(sec, "PREVIOUS_SECRET_KEYS_URI", "PREVIOUS_SECRET_KEYS"), "\n") {
+		if key = strings.TrimSpace(key); key != "" && key != SecretKey {
+			PreviousSecretKeys = append(PreviousSecretKeys, key)
+			previousIKMs = append(previousIKMs, []byte(key))
+		}
+	}
+	keying.SetPreviousKeys(previousIKMs)
+
 	GlobalTwoFactorRequirement = NewTwoFactorRequirementType(sec.Key("GLOBAL_TWO_FACTOR_REQUIREMENT").String())
 
 	CookieRememberName = sec.Key("COOKIE_REMEMBER_NAME").MustString("gitea_incredible")
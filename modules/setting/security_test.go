@@ -0,0 +1,40 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+
+	"forgejo.org/modules/keying"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPreviousSecretKeys(t *testing.T) {
+	defer func() {
+		PreviousSecretKeys = nil
+		keying.SetPreviousKeys(nil)
+	}()
+
+	cfg, err := NewConfigProviderFromData(`
+[security]
+PREVIOUS_SECRET_KEYS = """first
+sec,ond
+
+"""
+`)
+	require.NoError(t, err)
+	loadSecurityFrom(cfg)
+	// a secret key may contain commas
+	assert.Equal(t, []string{"first", "sec,ond"}, PreviousSecretKeys)
+
+	cfg, err = NewConfigProviderFromData(`
+[security]
+PREVIOUS_SECRET_KEYS = only, one
+`)
+	require.NoError(t, err)
+	loadSecurityFrom(cfg)
+	assert.Equal(t, []string{"only, one"}, PreviousSecretKeys)
+}
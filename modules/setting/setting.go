@@ -218,6 +218,7 @@ func LoadSettings() {
 	loadMailsFrom(CfgProvider)
 	loadProxyFrom(CfgProvider)
 	loadWebhookFrom(CfgProvider)
+	loadFirehoseFrom(CfgProvider)
 	loadMigrationsFrom(CfgProvider)
 	loadIndexerFrom(CfgProvider)
 	loadTaskFrom(CfgProvider)
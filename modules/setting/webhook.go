@@ -5,29 +5,49 @@ package setting
 
 import (
 	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"forgejo.org/modules/log"
 )
 
 // Webhook settings
 var Webhook = struct {
-	QueueLength        int
-	DeliverTimeout     int
-	SkipTLSVerify      bool
-	AllowedHostList    string
-	PagingNum          int
-	ProxyURL           string
-	ProxyURLFixed      *url.URL
-	ProxyHosts         []string
-	PayloadCommitLimit int
+	QueueLength          int
+	DeliverTimeout       int
+	SkipTLSVerify        bool
+	AllowedHostList      string
+	PagingNum            int
+	ProxyURL             string
+	ProxyURLFixed        *url.URL
+	ProxyHosts           []string
+	PayloadCommitLimit   int
+	MaxRetries           int
+	RetryBackoff         time.Duration
+	MaxRetryBackoff      time.Duration
+	DisableAfterFailures int
+	// SecretRotationOverlap is how long the replaced secret of a webhook still signs the deliveries
+	SecretRotationOverlap time.Duration
+	// SigningAlgorithm is the algorithm of the instance key signing the deliveries, empty to not sign them
+	SigningAlgorithm      string
+	SigningPrivateKeyFile string
 }{
-	QueueLength:        1000,
-	DeliverTimeout:     5,
-	SkipTLSVerify:      false,
-	PagingNum:          10,
-	ProxyURL:           "",
-	ProxyHosts:         []string{},
-	PayloadCommitLimit: 15,
+	QueueLength:          1000,
+	DeliverTimeout:       5,
+	SkipTLSVerify:        false,
+	PagingNum:            10,
+	ProxyURL:             "",
+	ProxyHosts:           []string{},
+	PayloadCommitLimit:   15,
+	MaxRetries:           3,
+	RetryBackoff:         time.Minute,
+	MaxRetryBackoff:      time.Hour,
+	DisableAfterFailures: 0,
+
+	SecretRotationOverlap: 24 * time.Hour,
+	SigningAlgorithm:      "",
+	SigningPrivateKeyFile: "webhook/signing_private.pem",
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -48,4 +68,19 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
 	Webhook.PayloadCommitLimit = sec.Key("PAYLOAD_COMMIT_LIMIT").MustInt(15)
+	Webhook.MaxRetries = sec.Key("MAX_RETRIES").MustInt(3)
+	Webhook.RetryBackoff = sec.Key("RETRY_BACKOFF").MustDuration(time.Minute)
+	Webhook.MaxRetryBackoff = sec.Key("MAX_RETRY_BACKOFF").MustDuration(time.Hour)
+	Webhook.DisableAfterFailures = sec.Key("DISABLE_AFTER_FAILURES").MustInt(0)
+	Webhook.SecretRotationOverlap = sec.Key("SECRET_ROTATION_OVERLAP").MustDuration(24 * time.Hour)
+	Webhook.SigningAlgorithm = strings.ToLower(sec.Key("SIGNING_ALGORITHM").MustString(""))
+	switch Webhook.SigningAlgorithm {
+	case "", "ed25519", "rsa":
+	default:
+		log.Fatal("Webhook SIGNING_ALGORITHM must be ed25519 or rsa, got %q", Webhook.SigningAlgorithm)
+	}
+	Webhook.SigningPrivateKeyFile = sec.Key("SIGNING_PRIVATE_KEY_FILE").MustString("webhook/signing_private.pem")
+	if !filepath.IsAbs(Webhook.SigningPrivateKeyFile) {
+		Webhook.SigningPrivateKeyFile = filepath.Join(AppDataPath, Webhook.SigningPrivateKeyFile)
+	}
 }
@@ -28,6 +28,64 @@ type ActionRunJob struct {
 	Status string `json:"status"`
 }
 
+// ActionQueuedJob represents a job waiting for a runner, with the runners which can pick it
+type ActionQueuedJob struct {
+	// the action run job id
+	ID int64 `json:"id"`
+	// the action run id
+	RunID int64 `json:"run_id"`
+	// the repository id
+	RepoID int64 `json:"repo_id"`
+	// the owner id
+	OwnerID int64 `json:"owner_id"`
+	// the action run job name
+	Name string `json:"name"`
+	// the labels requested by the job
+	RunsOn []string `json:"runs_on"`
+	// when the job started waiting for a runner
+	// swagger:strfmt date-time
+	QueuedSince time.Time `json:"queued_since"`
+	// the number of seconds the job has been waiting for a runner
+	TimeInQueue int64 `json:"time_in_queue"`
+	// the labels offered by the online runners which can be used by the repository of the job
+	OnlineLabels []string `json:"online_labels"`
+	// the labels requested by the job which no online runner offers
+	MissingLabels []string `json:"missing_labels"`
+	// the number of online runners which have all the labels requested by the job
+	MatchingOnlineRunners int `json:"matching_online_runners"`
+	// the number of registered runners, online or not, which have all the labels requested by the job
+	MatchingRunners int `json:"matching_runners"`
+	// whether an online runner can pick the job, only an offline one, or none
+	// enum: runner_available,no_online_runner,no_matching_runner
+	Diagnosis string `json:"diagnosis"`
+}
+
+// ActionWorkflowJob represents a job of an action run, as sent by the workflow_job webhook
+type ActionWorkflowJob struct {
+	// the action run job id
+	ID int64 `json:"id"`
+	// the id of the action run the job belongs to
+	RunID int64 `json:"run_id"`
+	// the action run job name
+	Name string `json:"name"`
+	// the attempt of the job, it is incremented every time the job is rerun
+	Attempt int64 `json:"attempt"`
+	// the commit sha the job runs on
+	HeadSHA string `json:"head_sha"`
+	// the action run job labels to run on
+	RunsOn []string `json:"runs_on"`
+	// the action run job latest task id
+	TaskID int64 `json:"task_id"`
+	// the action run job status
+	Status string `json:"status"`
+	// when the job was started
+	Started time.Time `json:"started,omitempty"`
+	// when the job was stopped
+	Stopped time.Time `json:"stopped,omitempty"`
+	// the url of this job
+	HTMLURL string `json:"html_url"`
+}
+
 // ActionRun represents an action run
 // swagger:model
 type ActionRun struct {
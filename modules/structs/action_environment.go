@@ -0,0 +1,65 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// ActionEnvironment represents a deployment environment of a repository
+type ActionEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// the number of minutes the jobs wait before they start
+	WaitTimer int64 `json:"wait_timer"`
+	// the users who must approve the jobs before they start, nobody if empty
+	Reviewers []*User `json:"reviewers"`
+	// the glob patterns of the branches whose runs can deploy, all branches if empty
+	BranchPatterns []string `json:"branch_patterns"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateOrUpdateActionEnvironmentOption options to create or update a deployment environment
+type CreateOrUpdateActionEnvironmentOption struct {
+	// the number of minutes the jobs wait before they start, at most 43200 (30 days)
+	WaitTimer int64 `json:"wait_timer"`
+	// the user names of the users who must approve the jobs before they start
+	Reviewers []string `json:"reviewers"`
+	// the glob patterns of the branches whose runs can deploy, all branches if empty
+	BranchPatterns []string `json:"branch_patterns"`
+}
+
+// ActionDeployment represents an attempt of a job to deploy to an environment
+type ActionDeployment struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	RunID       int64  `json:"run_id"`
+	JobID       int64  `json:"job_id"`
+	// the attempt of the job the deployment is for
+	Attempt   int64  `json:"attempt"`
+	Ref       string `json:"ref"`
+	CommitSHA string `json:"sha"`
+	// enum: waiting,approved,queued,rejected
+	Status string `json:"status"`
+	// the status of the job
+	JobStatus string `json:"job_status"`
+	// the user who approved or rejected the deployment
+	Reviewer *User `json:"reviewer"`
+	// swagger:strfmt date-time
+	StartAfter time.Time `json:"start_after"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// ReviewActionDeploymentOption options to approve or reject a deployment
+type ReviewActionDeploymentOption struct {
+	// required: true
+	// enum: approve,reject
+	Action string `json:"action" binding:"Required;In(approve,reject)"`
+}
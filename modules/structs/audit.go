@@ -0,0 +1,28 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AuditEvent represents an entry of the audit log
+type AuditEvent struct {
+	ID     int64  `json:"id"`
+	Action string `json:"action"`
+	// the user who caused the event, it is null if the user was deleted
+	Doer     *User  `json:"doer"`
+	DoerID   int64  `json:"doer_id"`
+	DoerName string `json:"doer_name"`
+	// the user or organization the event belongs to, zero for instance wide events
+	OwnerID int64 `json:"owner_id"`
+	// the repository the event belongs to, zero if it does not belong to a repository
+	RepoID     int64  `json:"repo_id"`
+	TargetType string `json:"target_type"`
+	TargetID   int64  `json:"target_id"`
+	TargetName string `json:"target_name"`
+	Detail     string `json:"detail"`
+	// the address the change was made from, only shown to administrators
+	IPAddress string `json:"ip_address,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
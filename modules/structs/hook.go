@@ -65,6 +65,30 @@ type EditHookOption struct {
 	Active              *bool             `json:"active"`
 }
 
+// HookDelivery represents a delivery of a hook
+type HookDelivery struct {
+	ID    int64  `json:"id"`
+	UUID  string `json:"uuid"`
+	Event string `json:"event"`
+	// number of times the delivery was attempted
+	Attempts int `json:"attempts"`
+	// HTTP status of the last response, 0 if no response was received
+	StatusCode int `json:"status_code"`
+	// error of the last attempt or body of the last response
+	Response string `json:"response"`
+	// swagger:strfmt date-time
+	Delivered time.Time `json:"delivered_at"`
+}
+
+// HookDeliveryList represents a list of hook deliveries
+type HookDeliveryList []*HookDelivery
+
+// RedeliverHookDeliveriesOption options to redeliver the failed deliveries of a hook
+type RedeliverHookDeliveriesOption struct {
+	// UUIDs of the deliveries to redeliver, all the failed deliveries are redelivered if empty
+	UUIDs []string `json:"uuids"`
+}
+
 // Payloader payload is some part of one hook
 type Payloader interface {
 	JSONPayload() ([]byte, error)
@@ -119,6 +143,8 @@ var (
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
 	_ Payloader = &ActionPayload{}
+	_ Payloader = &CommitStatusPayload{}
+	_ Payloader = &WorkflowJobPayload{}
 )
 
 // _________                        __
@@ -517,3 +543,51 @@ type ActionPayload struct {
 func (p *ActionPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
+
+// CommitStatusPayload payload for commit status webhooks
+type CommitStatusPayload struct {
+	ID          int64             `json:"id"`
+	SHA         string            `json:"sha"`
+	Context     string            `json:"context"`
+	State       CommitStatusState `json:"state"`
+	TargetURL   string            `json:"target_url"`
+	Description string            `json:"description"`
+	Commit      *PayloadCommit    `json:"commit,omitempty"`
+	Repo        *Repository       `json:"repository"`
+	Sender      *User             `json:"sender"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// JSONPayload return payload information
+func (p *CommitStatusPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookWorkflowJobAction is the transition of a job of an action run
+type HookWorkflowJobAction string
+
+const (
+	// HookWorkflowJobQueued the job waits for a runner
+	HookWorkflowJobQueued HookWorkflowJobAction = "queued"
+	// HookWorkflowJobWaiting the job waits for the jobs it needs
+	HookWorkflowJobWaiting HookWorkflowJobAction = "waiting"
+	// HookWorkflowJobInProgress the job was picked by a runner
+	HookWorkflowJobInProgress HookWorkflowJobAction = "in_progress"
+	// HookWorkflowJobCompleted the job is done, its status is the conclusion
+	HookWorkflowJobCompleted HookWorkflowJobAction = "completed"
+)
+
+// WorkflowJobPayload payload for workflow job webhooks
+type WorkflowJobPayload struct {
+	Action      HookWorkflowJobAction `json:"action"`
+	WorkflowJob *ActionWorkflowJob    `json:"workflow_job"`
+	Run         *ActionRun            `json:"run"`
+	Repo        *Repository           `json:"repository"`
+	Sender      *User                 `json:"sender"`
+}
+
+// JSONPayload return payload information
+func (p *WorkflowJobPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
@@ -80,6 +80,14 @@ type Issue struct {
 	Repo        *RepositoryMeta  `json:"repository"`
 
 	PinOrder int `json:"pin_order"`
+
+	// the type of the issue, empty if it has none
+	// enum: ["task", "bug", "feature", "epic"]
+	IssueType string `json:"issue_type"`
+	// the issue this issue is a sub-issue of
+	Parent *IssueMeta `json:"parent,omitempty"`
+	// the progress of the sub-issues of the issue
+	SubIssues *SubIssuesSummary `json:"sub_issues,omitempty"`
 }
 
 // CreateIssueOption options to create one issue
@@ -98,6 +106,9 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// the type of the issue
+	// enum: ["task", "bug", "feature", "epic"]
+	IssueType string `json:"issue_type"`
 }
 
 // EditIssueOption options for editing an issue
@@ -115,6 +126,60 @@ type EditIssueOption struct {
 	RemoveDeadline *bool      `json:"unset_due_date"`
 	// swagger:strfmt date-time
 	Updated *time.Time `json:"updated_at"`
+	// the type of the issue, empty to remove it
+	// enum: ["", "task", "bug", "feature", "epic"]
+	IssueType *string `json:"issue_type"`
+}
+
+// BulkEditIssuesFilter selects the issues and pull requests of a bulk edit
+type BulkEditIssuesFilter struct {
+	// list of issue and pull request numbers
+	Indexes []int64 `json:"indexes"`
+	// enum: ["open", "closed", "all"]
+	State string `json:"state"`
+	// enum: ["issues", "pulls"]
+	Type string `json:"type"`
+	// list of label names
+	Labels []string `json:"labels"`
+	// list of milestone names
+	Milestones []string `json:"milestones"`
+	// username of the poster
+	CreatedBy string `json:"created_by"`
+	// username of an assignee
+	AssignedBy string `json:"assigned_by"`
+}
+
+// BulkEditIssuesChanges changes applied by a bulk edit, unset fields are left unchanged
+type BulkEditIssuesChanges struct {
+	// list of label ids to add
+	AddLabels []int64 `json:"add_labels"`
+	// list of label ids to remove
+	RemoveLabels []int64 `json:"remove_labels"`
+	// milestone id, 0 removes the milestone
+	Milestone *int64 `json:"milestone"`
+	// list of usernames to assign
+	AddAssignees []string `json:"add_assignees"`
+	// list of usernames to unassign
+	RemoveAssignees []string `json:"remove_assignees"`
+	// enum: ["open", "closed"]
+	State *string `json:"state"`
+	// project column id, 0 removes from the project
+	ProjectColumn *int64 `json:"project_column"`
+}
+
+// BulkEditIssuesOption options for editing issues and pull requests in bulk
+type BulkEditIssuesOption struct {
+	Filter  BulkEditIssuesFilter  `json:"filter"`
+	Changes BulkEditIssuesChanges `json:"changes"`
+	// only report the issues and pull requests that would be changed
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkEditIssuesResult issues and pull requests changed by a bulk edit
+type BulkEditIssuesResult struct {
+	// list of the changed issue and pull request numbers
+	Indexes []int64 `json:"indexes"`
+	DryRun  bool    `json:"dry_run"`
 }
 
 // EditDeadlineOption options for creating a deadline
@@ -267,3 +332,16 @@ type IssueMeta struct {
 	Owner string `json:"owner"`
 	Name  string `json:"repo"`
 }
+
+// SubIssuesSummary the number of sub-issues of an issue and how many of them are closed
+type SubIssuesSummary struct {
+	Total  int64 `json:"total"`
+	Closed int64 `json:"closed"`
+}
+
+// ReorderSubIssuesOption options for ordering the sub-issues of an issue
+type ReorderSubIssuesOption struct {
+	// every sub-issue of the issue, in their new order
+	// required: true
+	SubIssues []IssueMeta `json:"sub_issues" binding:"Required"`
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// CustomField is a typed field of the issues and pull requests of a repository or an organization
+type CustomField struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: ["text", "number", "date", "single_select", "multi_select", "user"]
+	Type string `json:"type"`
+	// the options of the single_select and multi_select types
+	Options    []string `json:"options,omitempty"`
+	IsOrgField bool     `json:"is_org_field"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateCustomFieldOption options for creating a custom field
+type CreateCustomFieldOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required"`
+	// required: true
+	// enum: ["text", "number", "date", "single_select", "multi_select", "user"]
+	Type string `json:"type" binding:"Required"`
+	// the options of the single_select and multi_select types
+	Options []string `json:"options"`
+}
+
+// EditCustomFieldOption options for editing a custom field. The type of a field cannot be changed.
+type EditCustomFieldOption struct {
+	Name *string `json:"name"`
+	// the options of the single_select and multi_select types, the values of the removed options are deleted
+	Options []string `json:"options"`
+}
+
+// IssueCustomFieldValue the values of a custom field set on an issue
+type IssueCustomFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	// enum: ["text", "number", "date", "single_select", "multi_select", "user"]
+	Type string `json:"type"`
+	// the values of user fields are user names
+	Values []string `json:"values"`
+}
+
+// IssueCustomFieldValueOption the values to set for a custom field
+type IssueCustomFieldValueOption struct {
+	// required: true
+	FieldID int64 `json:"field_id" binding:"Required"`
+	// the values of user fields are user names, no values remove the field from the issue
+	Values []string `json:"values"`
+}
+
+// SetIssueCustomFieldsOption options for setting the custom fields of an issue
+type SetIssueCustomFieldsOption struct {
+	// the fields to change, the other fields are left unchanged
+	Fields []IssueCustomFieldValueOption `json:"fields"`
+}
@@ -11,3 +11,51 @@ type BlockedUser struct {
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 }
+
+// AbuseReport represents a report of abusive content
+type AbuseReport struct {
+	ID int64 `json:"id"`
+	// enum: ["open", "handled", "ignored"]
+	Status string `json:"status"`
+	// enum: ["user", "repository", "issue", "comment"]
+	ContentType string `json:"content_type"`
+	// ID of the reported user, repository, issue or comment
+	ContentID int64 `json:"content_id"`
+	// enum: ["spam", "malware", "illegal_content", "other"]
+	Category string `json:"category"`
+	Remarks  string `json:"remarks"`
+	Reporter *User  `json:"reporter"`
+	// Moderator the report is assigned to, if any
+	Assignee *User `json:"assignee"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Resolved *time.Time `json:"resolved_at"`
+}
+
+// ModerationAction represents an entry within the audit trail of abuse reports
+type ModerationAction struct {
+	ID int64 `json:"id"`
+	// enum: ["assign", "unassign", "mark_as_handled", "mark_as_ignored", "suspend_account", "delete_account", "delete_repo", "delete_issue", "delete_comment", "hide_repo"]
+	Action string `json:"action"`
+	// Moderator who did the action; null when it was done automatically because a threshold was reached
+	Doer *User `json:"doer"`
+	// Assignee or suspended user, if any
+	TargetUser *User  `json:"target_user"`
+	Details    string `json:"details"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// AssignAbuseReportOption options for assigning the reports of some content to a moderator
+type AssignAbuseReportOption struct {
+	// Name of the moderator to assign the reports to; empty to remove the assignment
+	Assignee string `json:"assignee"`
+}
+
+// ResolveAbuseReportOption options for resolving the reports of some content
+type ResolveAbuseReportOption struct {
+	// required: true
+	// enum: ["handled", "ignored"]
+	Status string `json:"status" binding:"Required;In(handled,ignored)"`
+}
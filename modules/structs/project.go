@@ -0,0 +1,42 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// ProjectAutomationRule moves the issues of a project to a column when its trigger happens
+type ProjectAutomationRule struct {
+	ID        int64 `json:"id"`
+	ProjectID int64 `json:"project_id"`
+	// enum: ["issue_opened", "issue_closed", "issue_reopened", "pull_request_opened", "pull_request_merged", "label_added"]
+	Trigger string `json:"trigger"`
+	// the label of the label_added trigger
+	LabelID  int64 `json:"label_id,omitempty"`
+	ColumnID int64 `json:"column_id"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateProjectAutomationRuleOption options for creating a project automation rule
+type CreateProjectAutomationRuleOption struct {
+	// required: true
+	// enum: ["issue_opened", "issue_closed", "issue_reopened", "pull_request_opened", "pull_request_merged", "label_added"]
+	Trigger string `json:"trigger" binding:"Required"`
+	// the label of the label_added trigger
+	LabelID int64 `json:"label_id"`
+	// required: true
+	ColumnID int64 `json:"column_id" binding:"Required"`
+}
+
+// EditProjectAutomationRuleOption options for editing a project automation rule
+type EditProjectAutomationRuleOption struct {
+	// enum: ["issue_opened", "issue_closed", "issue_reopened", "pull_request_opened", "pull_request_merged", "label_added"]
+	Trigger  *string `json:"trigger"`
+	LabelID  *int64  `json:"label_id"`
+	ColumnID *int64  `json:"column_id"`
+}
@@ -11,7 +11,23 @@ type QuotaInfo struct {
 
 // QuotaUsed represents the quota usage of a user
 type QuotaUsed struct {
-	Size QuotaUsedSize `json:"size"`
+	Size  QuotaUsedSize  `json:"size"`
+	Count QuotaUsedCount `json:"count"`
+}
+
+// QuotaUsedCount represents the count-based quota usage of a user
+type QuotaUsedCount struct {
+	Repos QuotaUsedCountRepos `json:"repos"`
+	// Number of packages owned by the user
+	Packages int64 `json:"packages"`
+}
+
+// QuotaUsedCountRepos represents the count-based repository quota usage of a user
+type QuotaUsedCountRepos struct {
+	// Number of the user's public repositories
+	Public int64 `json:"public"`
+	// Number of the user's private repositories
+	Private int64 `json:"private"`
 }
 
 // QuotaUsedSize represents the size-based quota usage of a user
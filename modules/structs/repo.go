@@ -328,6 +328,7 @@ const (
 	CodebaseService                        // 8 codebase service
 	ForgejoService                         // 9 forgejo service
 	PagureService                          // 10 pagure service
+	BitbucketService                       // 11 bitbucket cloud or server service
 )
 
 // Name represents the service type's name
@@ -357,6 +358,8 @@ func (gt GitServiceType) Title() string {
 		return "Forgejo"
 	case PagureService:
 		return "Pagure"
+	case BitbucketService:
+		return "Bitbucket"
 	case PlainGitService:
 		return "Git"
 	}
@@ -375,7 +378,7 @@ type MigrateRepoOptions struct {
 	// required: true
 	RepoName string `json:"repo_name" binding:"Required;AlphaDashDot;MaxSize(100)"`
 
-	// enum: ["git", "github", "gitea", "gitlab", "gogs", "onedev", "gitbucket", "codebase"]
+	// enum: ["git", "github", "gitea", "gitlab", "gogs", "onedev", "gitbucket", "codebase", "bitbucket"]
 	Service      string `json:"service"`
 	AuthUsername string `json:"auth_username"`
 	AuthPassword string `json:"auth_password"`
@@ -398,7 +401,7 @@ type MigrateRepoOptions struct {
 // TokenAuth represents whether a service type supports token-based auth
 func (gt GitServiceType) TokenAuth() bool {
 	switch gt {
-	case GithubService, GiteaService, GitlabService, ForgejoService:
+	case GithubService, GiteaService, GitlabService, ForgejoService, BitbucketService:
 		return true
 	}
 	return false
@@ -416,6 +419,7 @@ var SupportedFullGitService = []GitServiceType{
 	GitBucketService,
 	CodebaseService,
 	PagureService,
+	BitbucketService,
 }
 
 // RepoTransfer represents a pending repo transfer
@@ -80,4 +80,6 @@ type ActionRunner struct {
 	Labels []string `json:"labels"`
 	// Description provides optional details about this runner.
 	Description string `json:"description"`
+	// Ephemeral indicates whether this runner picks a single task and is deleted once it is done.
+	Ephemeral bool `json:"ephemeral"`
 }
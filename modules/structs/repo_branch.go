@@ -20,34 +20,46 @@ type Branch struct {
 	EffectiveBranchProtectionName string         `json:"effective_branch_protection_name"`
 }
 
+// StatusCheckSource pins a required status check context to the only source allowed to report it
+type StatusCheckSource struct {
+	// pattern of status_check_contexts the source is pinned for
+	Context string `json:"context"`
+	// file name of the Actions workflow that must report the status, e.g. build.yml
+	Workflow string `json:"workflow,omitempty"`
+	// name of the user whose token must be used to report the status
+	Username string `json:"username,omitempty"`
+}
+
 // BranchProtection represents a branch protection for a repository
 type BranchProtection struct {
 	// Deprecated: true
-	BranchName                    string   `json:"branch_name"`
-	RuleName                      string   `json:"rule_name"`
-	EnablePush                    bool     `json:"enable_push"`
-	EnablePushWhitelist           bool     `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       bool     `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             bool     `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             int64    `json:"required_approvals"`
-	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 bool     `json:"apply_to_admins"`
+	BranchName                    string               `json:"branch_name"`
+	RuleName                      string               `json:"rule_name"`
+	EnablePush                    bool                 `json:"enable_push"`
+	EnablePushWhitelist           bool                 `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string             `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string             `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       bool                 `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool                 `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string             `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string             `json:"merge_whitelist_teams"`
+	EnableStatusCheck             bool                 `json:"enable_status_check"`
+	StatusCheckContexts           []string             `json:"status_check_contexts"`
+	StatusCheckSources            []*StatusCheckSource `json:"status_check_sources"`
+	RequiredApprovals             int64                `json:"required_approvals"`
+	EnableApprovalsWhitelist      bool                 `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string             `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string             `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        bool                 `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool                 `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool                 `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool                 `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool                 `json:"ignore_stale_approvals"`
+	RequireSignedCommits          bool                 `json:"require_signed_commits"`
+	ProtectedFilePatterns         string               `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       string               `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 bool                 `json:"apply_to_admins"`
+	EnableMergeQueue              bool                 `json:"enable_merge_queue"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -57,56 +69,60 @@ type BranchProtection struct {
 // CreateBranchProtectionOption options for creating a branch protection
 type CreateBranchProtectionOption struct {
 	// Deprecated: true
-	BranchName                    string   `json:"branch_name"`
-	RuleName                      string   `json:"rule_name"`
-	EnablePush                    bool     `json:"enable_push"`
-	EnablePushWhitelist           bool     `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       bool     `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             bool     `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             int64    `json:"required_approvals"`
-	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 bool     `json:"apply_to_admins"`
+	BranchName                    string               `json:"branch_name"`
+	RuleName                      string               `json:"rule_name"`
+	EnablePush                    bool                 `json:"enable_push"`
+	EnablePushWhitelist           bool                 `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string             `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string             `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       bool                 `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool                 `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string             `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string             `json:"merge_whitelist_teams"`
+	EnableStatusCheck             bool                 `json:"enable_status_check"`
+	StatusCheckContexts           []string             `json:"status_check_contexts"`
+	StatusCheckSources            []*StatusCheckSource `json:"status_check_sources"`
+	RequiredApprovals             int64                `json:"required_approvals"`
+	EnableApprovalsWhitelist      bool                 `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string             `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string             `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        bool                 `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool                 `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool                 `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool                 `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool                 `json:"ignore_stale_approvals"`
+	RequireSignedCommits          bool                 `json:"require_signed_commits"`
+	ProtectedFilePatterns         string               `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       string               `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 bool                 `json:"apply_to_admins"`
+	EnableMergeQueue              bool                 `json:"enable_merge_queue"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
 type EditBranchProtectionOption struct {
-	EnablePush                    *bool    `json:"enable_push"`
-	EnablePushWhitelist           *bool    `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       *bool    `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          *bool    `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             *bool    `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             *int64   `json:"required_approvals"`
-	EnableApprovalsWhitelist      *bool    `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        *bool    `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests *bool    `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals"`
-	RequireSignedCommits          *bool    `json:"require_signed_commits"`
-	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 *bool    `json:"apply_to_admins"`
+	EnablePush                    *bool                `json:"enable_push"`
+	EnablePushWhitelist           *bool                `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string             `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string             `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       *bool                `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          *bool                `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string             `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string             `json:"merge_whitelist_teams"`
+	EnableStatusCheck             *bool                `json:"enable_status_check"`
+	StatusCheckContexts           []string             `json:"status_check_contexts"`
+	StatusCheckSources            []*StatusCheckSource `json:"status_check_sources"`
+	RequiredApprovals             *int64               `json:"required_approvals"`
+	EnableApprovalsWhitelist      *bool                `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string             `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string             `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        *bool                `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests *bool                `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         *bool                `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         *bool                `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          *bool                `json:"ignore_stale_approvals"`
+	RequireSignedCommits          *bool                `json:"require_signed_commits"`
+	ProtectedFilePatterns         *string              `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       *string              `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 *bool                `json:"apply_to_admins"`
+	EnableMergeQueue              *bool                `json:"enable_merge_queue"`
 }
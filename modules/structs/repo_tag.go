@@ -45,8 +45,10 @@ type CreateTagOption struct {
 
 // TagArchiveDownloadCount counts how many times a archive was downloaded
 type TagArchiveDownloadCount struct {
-	Zip   int64 `json:"zip"`
-	TarGz int64 `json:"tar_gz"`
+	Zip    int64 `json:"zip"`
+	TarGz  int64 `json:"tar_gz"`
+	TarZst int64 `json:"tar_zst"`
+	Bundle int64 `json:"bundle"`
 }
 
 // TagProtection represents a tag protection
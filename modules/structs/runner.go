@@ -15,6 +15,12 @@ type RegisterRunnerOptions struct {
 	//
 	// required: false
 	Description string `json:"description"`
+
+	// Whether the runner is ephemeral. An ephemeral runner picks a single task and is deleted
+	// once the task is done, which invalidates its token.
+	//
+	// required: false
+	Ephemeral bool `json:"ephemeral"`
 }
 
 // RegisterRunnerResponse contains the details of the just registered runner.
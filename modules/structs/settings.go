@@ -36,3 +36,13 @@ type GeneralAttachmentSettings struct {
 	MaxSize      int64  `json:"max_size"`
 	MaxFiles     int    `json:"max_files"`
 }
+
+// WebhookSigningKey is the public key of the instance the webhook deliveries are signed with
+type WebhookSigningKey struct {
+	// KeyID is sent in the Webhook-Key-Id header of the deliveries signed with this key
+	KeyID string `json:"key_id"`
+	// enum: ["ed25519", "rsa"]
+	Algorithm string `json:"algorithm"`
+	// PublicKey in the PEM encoded PKIX format
+	PublicKey string `json:"public_key"`
+}
@@ -30,6 +30,8 @@ type HookEvents struct {
 	ActionRunFailure         bool `json:"action_run_failure"`
 	ActionRunRecover         bool `json:"action_run_recover"`
 	ActionRunSuccess         bool `json:"action_run_success"`
+	Status                   bool `json:"status"`
+	WorkflowJob              bool `json:"workflow_job"`
 }
 
 // HookEvent represents events that will deliver a hook.
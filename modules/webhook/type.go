@@ -37,6 +37,8 @@ const (
 	HookEventActionRunFailure          HookEventType = "action_run_failure"
 	HookEventActionRunRecover          HookEventType = "action_run_recover"
 	HookEventActionRunSuccess          HookEventType = "action_run_success"
+	HookEventStatus                    HookEventType = "status"
+	HookEventWorkflowJob               HookEventType = "workflow_job"
 )
 
 // Event returns the HookEventType as an event string
@@ -75,6 +77,10 @@ func (h HookEventType) Event() string {
 		return "action_run_recover"
 	case HookEventActionRunSuccess:
 		return "action_run_success"
+	case HookEventStatus:
+		return "status"
+	case HookEventWorkflowJob:
+		return "workflow_job"
 	}
 	return ""
 }
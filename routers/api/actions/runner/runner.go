@@ -8,12 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	actions_model "forgejo.org/models/actions"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/actions"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/metrics"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	actions_service "forgejo.org/services/actions"
@@ -79,6 +81,7 @@ func (s *Service) Register(
 		RepoID:      runnerToken.RepoID,
 		Version:     req.Msg.Version,
 		AgentLabels: labels,
+		Ephemeral:   req.Msg.Ephemeral,
 	}
 	runner.GenerateToken()
 
@@ -95,12 +98,13 @@ func (s *Service) Register(
 
 	res := connect.NewResponse(&runnerv1.RegisterResponse{
 		Runner: &runnerv1.Runner{
-			Id:      runner.ID,
-			Uuid:    runner.UUID,
-			Token:   runner.Token,
-			Name:    runner.Name,
-			Version: runner.Version,
-			Labels:  runner.AgentLabels,
+			Id:        runner.ID,
+			Uuid:      runner.UUID,
+			Token:     runner.Token,
+			Name:      runner.Name,
+			Version:   runner.Version,
+			Labels:    runner.AgentLabels,
+			Ephemeral: runner.Ephemeral,
 		},
 	})
 
@@ -120,12 +124,13 @@ func (s *Service) Declare(
 
 	return connect.NewResponse(&runnerv1.DeclareResponse{
 		Runner: &runnerv1.Runner{
-			Id:      runner.ID,
-			Uuid:    runner.UUID,
-			Token:   runner.Token,
-			Name:    runner.Name,
-			Version: runner.Version,
-			Labels:  runner.AgentLabels,
+			Id:        runner.ID,
+			Uuid:      runner.UUID,
+			Token:     runner.Token,
+			Name:      runner.Name,
+			Version:   runner.Version,
+			Labels:    runner.AgentLabels,
+			Ephemeral: runner.Ephemeral,
 		},
 	}), nil
 }
@@ -136,6 +141,7 @@ func (s *Service) FetchTask(
 	req *connect.Request[runnerv1.FetchTaskRequest],
 ) (*connect.Response[runnerv1.FetchTaskResponse], error) {
 	runner := GetRunner(ctx)
+	start := time.Now()
 
 	var task *runnerv1.Task
 	tasksVersion := req.Msg.TasksVersion // task version from runner
@@ -186,6 +192,7 @@ func (s *Service) FetchTask(
 		TasksVersion:    latestVersion,
 		AdditionalTasks: additionalTasks,
 	})
+	metrics.ObserveActionsTaskFetch(task != nil, time.Since(start))
 	return res, nil
 }
 
@@ -253,6 +260,13 @@ func (s *Service) UpdateTask(
 		}
 	}
 
+	// the logs of the task are sent before its final state, an ephemeral runner has nothing left to do
+	if task.Status.IsDone() {
+		if err := actions_model.DeleteEphemeralRunner(ctx, runner); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("delete ephemeral runner: %w", err))
+		}
+	}
+
 	return connect.NewResponse(&runnerv1.UpdateTaskResponse{
 		State: &runnerv1.TaskState{
 			Id:     req.Msg.State.Id,
@@ -317,5 +331,12 @@ func (s *Service) UpdateLog(
 		remove()
 	}
 
+	// once the log length is saved: the runner resends the rows it was not acknowledged,
+	// which would otherwise add their summaries and annotations twice
+	if err := actions_service.ProcessWorkflowCommands(ctx, task, rows); err != nil {
+		// the log is saved, the summaries and annotations are not worth failing the runner
+		log.Error("process workflow commands of task %d: %v", task.ID, err)
+	}
+
 	return res, nil
 }
@@ -36,6 +36,7 @@ import (
 	"forgejo.org/routers/api/packages/rpm"
 	"forgejo.org/routers/api/packages/rubygems"
 	"forgejo.org/routers/api/packages/swift"
+	"forgejo.org/routers/api/packages/terraform"
 	"forgejo.org/routers/api/packages/vagrant"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/context"
@@ -140,6 +141,19 @@ func CommonRoutes() *web.Route {
 		&chef.Auth{},
 	})
 
+	// The Terraform registry protocols expect the namespace (the owner) after a fixed base path
+	// announced by the service discovery, see routers/web/web.go
+	r.Group("/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Get("/{version}/download", terraform.DownloadModule)
+		})
+		r.Group("/providers/v1/{username}/{provider}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.DownloadProvider)
+		})
+	}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+
 	r.Group("/{username}", func() {
 		r.Group("/alpine", func() {
 			r.Get("/key", alpine.GetRepositoryKey)
@@ -747,6 +761,16 @@ func CommonRoutes() *web.Route {
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadModule)
+				r.Get("/{filename}", terraform.DownloadModuleArchive)
+			})
+			r.Group("/providers/{provider}/{version}/{filename}", func() {
+				r.Get("", terraform.DownloadProviderFile)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadProviderFile)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
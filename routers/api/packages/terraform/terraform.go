@@ -0,0 +1,537 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	packages_module "forgejo.org/modules/packages"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/routers/api/packages/helper"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	terraform_service "forgejo.org/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, struct {
+			Errors []string `json:"errors"`
+		}{
+			Errors: []string{
+				message,
+			},
+		})
+	})
+}
+
+// ServiceDiscovery returns the locations of the module and provider registry
+// It must be served at /.well-known/terraform.json
+func ServiceDiscovery(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, map[string]string{
+		"modules.v1":   setting.AppURL + "api/packages/terraform/modules/v1/",
+		"providers.v1": setting.AppURL + "api/packages/terraform/providers/v1/",
+	})
+}
+
+func ownerURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+type moduleVersions struct {
+	Modules []*moduleVersionList `json:"modules"`
+}
+
+type moduleVersionList struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+// EnumerateModuleVersions lists all available versions of a module
+func EnumerateModuleVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(ctx.Params("name"), ctx.Params("system")))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, &moduleVersion{Version: pv.Version})
+	}
+
+	ctx.JSON(http.StatusOK, &moduleVersions{
+		Modules: []*moduleVersionList{
+			{Versions: versions},
+		},
+	})
+}
+
+// DownloadModule points the client to the archive of a module version
+func DownloadModule(ctx *context.Context) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system), ctx.Params("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf(
+		"%s/modules/%s/%s/%s/%s",
+		ownerURL(ctx),
+		url.PathEscape(name),
+		url.PathEscape(system),
+		url.PathEscape(pv.Version),
+		url.PathEscape(terraform_module.ModuleArchiveName(name, system, pv.Version)),
+	))
+	ctx.Status(http.StatusNoContent)
+}
+
+// UploadModule publishes a new module version from a gzip compressed tar archive
+func UploadModule(ctx *context.Context) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+	if !terraform_module.IsValidModuleName(name, system) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	packageVersion := ctx.Params("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ModulePackageName(name, system),
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: terraform_module.ModuleArchiveName(name, system, packageVersion),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadModuleArchive serves the archive of a module version
+func DownloadModuleArchive(ctx *context.Context) {
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(ctx.Params("name"), ctx.Params("system")),
+			Version:     ctx.Params("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.Params("filename"),
+		},
+	)
+	if err != nil {
+		if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+type providerVersions struct {
+	Versions []*providerVersion `json:"versions"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerPackage struct {
+	Protocols           []string     `json:"protocols"`
+	OS                  string       `json:"os"`
+	Arch                string       `json:"arch"`
+	Filename            string       `json:"filename"`
+	DownloadURL         string       `json:"download_url"`
+	ShasumsURL          string       `json:"shasums_url"`
+	ShasumsSignatureURL string       `json:"shasums_signature_url"`
+	Shasum              string       `json:"shasum"`
+	SigningKeys         *signingKeys `json:"signing_keys"`
+}
+
+type signingKeys struct {
+	GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+}
+
+type gpgPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+func protocols(pd *packages_model.PackageDescriptor) []string {
+	if p := pd.VersionProperties.GetByName(terraform_module.PropertyProtocols); p != "" {
+		return strings.Split(p, ",")
+	}
+	return []string{terraform_module.DefaultProtocol}
+}
+
+// EnumerateProviderVersions lists all available versions of a provider with their platforms
+func EnumerateProviderVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, ctx.Params("provider"))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			if goos := pfd.Properties.GetByName(terraform_module.PropertyOS); goos != "" {
+				platforms = append(platforms, &providerPlatform{
+					OS:   goos,
+					Arch: pfd.Properties.GetByName(terraform_module.PropertyArch),
+				})
+			}
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: protocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &providerVersions{
+		Versions: versions,
+	})
+}
+
+func getProviderDescriptor(ctx *context.Context) *packages_model.PackageDescriptor {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, ctx.Params("provider"), ctx.Params("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return nil
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+	return pd
+}
+
+// DownloadProvider returns the download location and the signing key of a provider version for a platform
+func DownloadProvider(ctx *context.Context) {
+	pd := getProviderDescriptor(ctx)
+	if pd == nil {
+		return
+	}
+
+	goos := ctx.Params("os")
+	goarch := ctx.Params("arch")
+
+	var archive *packages_model.PackageFileDescriptor
+	for _, pfd := range pd.Files {
+		if pfd.Properties.GetByName(terraform_module.PropertyOS) == goos && pfd.Properties.GetByName(terraform_module.PropertyArch) == goarch {
+			archive = pfd
+			break
+		}
+	}
+	if archive == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	keyID, publicKey, err := terraform_service.GetPublicKey(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s", ownerURL(ctx), url.PathEscape(pd.Package.Name), url.PathEscape(pd.Version.Version))
+	shasumsName := terraform_module.ShasumsName(pd.Package.Name, pd.Version.Version)
+
+	ctx.JSON(http.StatusOK, &providerPackage{
+		Protocols:           protocols(pd),
+		OS:                  goos,
+		Arch:                goarch,
+		Filename:            archive.File.Name,
+		DownloadURL:         versionURL + "/" + url.PathEscape(archive.File.Name),
+		ShasumsURL:          versionURL + "/" + url.PathEscape(shasumsName),
+		ShasumsSignatureURL: versionURL + "/" + url.PathEscape(shasumsName+".sig"),
+		Shasum:              archive.Blob.HashSHA256,
+		SigningKeys: &signingKeys{
+			GPGPublicKeys: []*gpgPublicKey{
+				{
+					KeyID:      keyID,
+					ASCIIArmor: publicKey,
+				},
+			},
+		},
+	})
+}
+
+// UploadProviderFile adds a platform archive or the manifest to a provider version
+func UploadProviderFile(ctx *context.Context) {
+	providerType := ctx.Params("provider")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	packageVersion := ctx.Params("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	filename := ctx.Params("filename")
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	var protocolVersions []string
+	properties := map[string]string{}
+	if filename == terraform_module.ManifestName(providerType, packageVersion) {
+		protocolVersions, err = terraform_module.ParseManifest(buf)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		goos, goarch, err := terraform_module.ParseProviderArchiveName(providerType, packageVersion, filename)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		properties[terraform_module.PropertyOS] = goos
+		properties[terraform_module.PropertyArch] = goarch
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerType,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata: &terraform_module.Metadata{
+				Kind: terraform_module.KindProvider,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:    ctx.Doer,
+			Data:       buf,
+			Properties: properties,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if protocolVersions != nil {
+		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, terraform_module.PropertyProtocols, strings.Join(protocolVersions, ",")); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadProviderFile serves a file of a provider version.
+// The SHA256SUMS file and its signature are generated from the uploaded platform archives.
+func DownloadProviderFile(ctx *context.Context) {
+	providerType := ctx.Params("provider")
+	packageVersion := ctx.Params("version")
+	filename := ctx.Params("filename")
+
+	shasumsName := terraform_module.ShasumsName(providerType, packageVersion)
+	if filename == shasumsName || filename == shasumsName+".sig" {
+		pd := getProviderDescriptor(ctx)
+		if pd == nil {
+			return
+		}
+
+		content := terraform_service.BuildShasums(pd)
+		if filename != shasumsName {
+			var err error
+			content, err = terraform_service.SignShasums(ctx, ctx.Package.Owner.ID, content)
+			if err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		ctx.ServeContent(bytes.NewReader(content), &context.ServeHeaderOptions{
+			Filename: filename,
+		})
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        providerType,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+	)
+	if err != nil {
+		if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
@@ -8,12 +8,16 @@ import (
 	"net/http"
 	"strings"
 
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unit"
 	"forgejo.org/modules/activitypub"
 	"forgejo.org/modules/forgefed"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
 	"forgejo.org/services/federation"
 
 	ap "github.com/go-ap/activitypub"
@@ -53,6 +57,64 @@ func Repository(ctx *context.APIContext) {
 	response(ctx, repo)
 }
 
+// RepositoryTicket function returns an issue of a repo as a ForgeFed Ticket
+func RepositoryTicket(ctx *context.APIContext) {
+	// swagger:operation GET /activitypub/repository-id/{repository-id}/issues/{index} activitypub activitypubRepositoryTicket
+	// ---
+	// summary: Returns an issue of a repo as a ForgeFed Ticket
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: repository-id
+	//   in: path
+	//   description: repository ID of the repo
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActivityPub"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	repository := ctx.Repo.Repository
+	if err := repository.LoadOwner(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadOwner", err)
+		return
+	}
+	if repository.IsPrivate || repository.Owner.Visibility != structs.VisibleTypePublic || !repository.UnitEnabled(ctx, unit.TypeIssues) {
+		ctx.NotFound()
+		return
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if issue.IsPull {
+		ctx.NotFound()
+		return
+	}
+
+	ticket, err := convert.ToForgeTicket(ctx, issue, issue.APObjectID())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToForgeTicket", err)
+		return
+	}
+	response(ctx, ticket)
+}
+
 // PersonInbox function handles the incoming data for a repository inbox
 func RepositoryInbox(ctx *context.APIContext) {
 	// swagger:operation POST /activitypub/repository-id/{repository-id}/inbox activitypub activitypubRepositoryInbox
@@ -74,12 +136,14 @@ func RepositoryInbox(ctx *context.APIContext) {
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
+	//   "406":
+	//     "$ref": "#/responses/error"
 
 	repository := ctx.Repo.Repository
 	log.Info("RepositoryInbox: repo: %v", repository)
 	form := web.GetForm(ctx)
 	activity := form.(*ap.Activity)
-	result, err := federation.ProcessRepositoryInbox(ctx, activity, repository.ID)
+	result, err := federation.ProcessRepositoryInbox(ctx, activity, repository.ID, signatureKeyID(ctx))
 	if err != nil {
 		ctx.Error(federation.HTTPStatus(err), "Processing Repository Inbox failed", result)
 		return
@@ -14,6 +14,14 @@ import (
 	"github.com/42wim/httpsig"
 )
 
+const signatureKeyIDKey = "HTTPSignatureKeyID"
+
+// signatureKeyID returns the ID of the key the request is signed with, empty if the signatures are not enforced
+func signatureKeyID(ctx *app_context.APIContext) string {
+	keyID, _ := ctx.Data[signatureKeyIDKey].(string)
+	return keyID
+}
+
 func verifyHTTPSignature(ctx app_context.APIContext) (authenticated bool, err error) {
 	if !setting.Federation.SignatureEnforced {
 		return true, nil
@@ -44,6 +52,8 @@ func verifyHTTPSignature(ctx app_context.APIContext) (authenticated bool, err er
 		log.Debug("For %q verification failed: %v", r.URL.Path, err)
 		return false, err
 	}
+	// the inboxes check that the actor of an activity owns the key it is signed with
+	ctx.Data[signatureKeyIDKey] = v.KeyId()
 	return true, nil
 }
 
@@ -0,0 +1,67 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListAuditEvents lists the audit events of the instance
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /admin/audit_events admin adminListAuditEvents
+	// ---
+	// summary: List the audit events of the instance
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: action
+	//   in: query
+	//   description: only list the events of this action
+	//   type: string
+	// - name: user
+	//   in: query
+	//   description: only list the events caused by or concerning this user or organization
+	//   type: string
+	// - name: repo_id
+	//   in: query
+	//   description: only list the events of this repository
+	//   type: integer
+	//   format: int64
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := audit_model.FindEventsOptions{RepoID: ctx.FormInt64("repo_id")}
+	if name := ctx.FormTrim("user"); name != "" {
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.NotFound()
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+		opts.UserID = u.ID
+	}
+
+	shared.ListAuditEvents(ctx, opts)
+}
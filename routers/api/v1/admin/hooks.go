@@ -175,3 +175,83 @@ func DeleteHook(ctx *context.APIContext) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListHookFailedDeliveries lists the failed deliveries of a global (system) or default webhook
+func ListHookFailedDeliveries(ctx *context.APIContext) {
+	// swagger:operation GET /admin/hooks/{id}/failed_deliveries admin adminListHookFailedDeliveries
+	// ---
+	// summary: List the deliveries of a hook that failed and are not going to be retried
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSystemOrDefaultWebhook", err)
+		}
+		return
+	}
+
+	utils.ListFailedHookDeliveries(ctx, hook)
+}
+
+// RedeliverHookFailedDeliveries redelivers the failed deliveries of a global (system) or default webhook
+func RedeliverHookFailedDeliveries(ctx *context.APIContext) {
+	// swagger:operation POST /admin/hooks/{id}/failed_deliveries/redeliver admin adminRedeliverHookFailedDeliveries
+	// ---
+	// summary: Redeliver the failed deliveries of a hook
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RedeliverHookDeliveriesOption"
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSystemOrDefaultWebhook", err)
+		}
+		return
+	}
+
+	utils.RedeliverFailedHookDeliveries(ctx, hook, web.GetForm(ctx).(*api.RedeliverHookDeliveriesOption))
+}
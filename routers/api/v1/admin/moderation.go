@@ -0,0 +1,335 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	moderation_service "forgejo.org/services/moderation"
+)
+
+// ListAbuseReports lists the abuse reports
+func ListAbuseReports(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports admin adminListAbuseReports
+	// ---
+	// summary: List abuse reports
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: status
+	//   in: query
+	//   description: status of the reports, open by default
+	//   type: string
+	//   enum: [open, handled, ignored]
+	// - name: type
+	//   in: query
+	//   description: type of the reported content
+	//   type: string
+	//   enum: [user, repository, issue, comment]
+	// - name: content_id
+	//   in: query
+	//   description: ID of the reported content, used together with type
+	//   type: integer
+	//   format: int64
+	// - name: category
+	//   in: query
+	//   description: abuse category of the reports
+	//   type: string
+	//   enum: [spam, malware, illegal_content, other]
+	// - name: assignee
+	//   in: query
+	//   description: name of the moderator the reports are assigned to, or "none" for the unassigned reports
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := moderation_model.FindReportsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Status:      moderation_model.ReportStatusTypeOpen,
+		ContentID:   ctx.FormInt64("content_id"),
+	}
+	if status := ctx.FormString("status"); status != "" {
+		if opts.Status = moderation_model.ParseReportStatusType(status); opts.Status == 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "invalid status")
+			return
+		}
+	}
+	if contentType := ctx.FormString("type"); contentType != "" {
+		if opts.ContentType = moderation_model.ParseReportedContentType(contentType); opts.ContentType == 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "invalid type")
+			return
+		}
+	}
+	if category := ctx.FormString("category"); category != "" {
+		if opts.Category = moderation_model.ParseAbuseCategoryType(category); opts.Category == 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "invalid category")
+			return
+		}
+	}
+	if assignee := ctx.FormString("assignee"); assignee == "none" {
+		opts.AssigneeID = db.NoConditionID
+	} else if assignee != "" {
+		u, err := user_model.GetUserByName(ctx, assignee)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+		opts.AssigneeID = u.ID
+	}
+
+	reports, total, err := db.FindAndCount[moderation_model.AbuseReport](ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindReports", err)
+		return
+	}
+
+	apiReports, err := convert.ToAbuseReports(ctx, reports, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToAbuseReports", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiReports)
+}
+
+// getAbuseReport loads the abuse report with the ID given within the path
+func getAbuseReport(ctx *context.APIContext) *moderation_model.AbuseReport {
+	report, err := moderation_model.GetReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if moderation_model.IsErrAbuseReportNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetReportByID", err)
+		}
+		return nil
+	}
+	return report
+}
+
+// respondWithAbuseReport reloads the given report and writes it as response
+func respondWithAbuseReport(ctx *context.APIContext, id int64) {
+	report, err := moderation_model.GetReportByID(ctx, id)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReportByID", err)
+		return
+	}
+
+	apiReports, err := convert.ToAbuseReports(ctx, []*moderation_model.AbuseReport{report}, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToAbuseReports", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiReports[0])
+}
+
+// GetAbuseReport gets an abuse report
+func GetAbuseReport(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports/{id} admin adminGetAbuseReport
+	// ---
+	// summary: Get an abuse report
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	report := getAbuseReport(ctx)
+	if report == nil {
+		return
+	}
+	respondWithAbuseReport(ctx, report.ID)
+}
+
+// AssignAbuseReport assigns the open reports of the reported content to a moderator
+func AssignAbuseReport(ctx *context.APIContext) {
+	// swagger:operation POST /admin/moderation/reports/{id}/assign admin adminAssignAbuseReport
+	// ---
+	// summary: Assign the open reports of the content targeted by an abuse report to a moderator
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/AssignAbuseReportOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.AssignAbuseReportOption)
+
+	report := getAbuseReport(ctx)
+	if report == nil {
+		return
+	}
+
+	var assignee *user_model.User
+	if form.Assignee != "" {
+		var err error
+		assignee, err = user_model.GetUserByName(ctx, form.Assignee)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+	}
+
+	if err := moderation_service.AssignReports(ctx, ctx.Doer, report.ContentType, report.ContentID, assignee); err != nil {
+		if errors.Is(err, moderation_service.ErrAssigneeNotModerator) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "AssignReports", err)
+		}
+		return
+	}
+
+	respondWithAbuseReport(ctx, report.ID)
+}
+
+// ResolveAbuseReport marks the open reports of the reported content as handled or ignored
+func ResolveAbuseReport(ctx *context.APIContext) {
+	// swagger:operation POST /admin/moderation/reports/{id}/resolve admin adminResolveAbuseReport
+	// ---
+	// summary: Mark the reports of the content targeted by an abuse report as handled or ignored
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ResolveAbuseReportOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.ResolveAbuseReportOption)
+
+	report := getAbuseReport(ctx)
+	if report == nil {
+		return
+	}
+
+	reportAction := moderation_service.ReportActionMarkAsHandled
+	if form.Status == "ignored" {
+		reportAction = moderation_service.ReportActionMarkAsIgnored
+	}
+
+	if err := moderation_service.ResolveReports(ctx, ctx.Doer, report.ContentType, report.ContentID, reportAction); err != nil {
+		ctx.Error(http.StatusInternalServerError, "ResolveReports", err)
+		return
+	}
+
+	respondWithAbuseReport(ctx, report.ID)
+}
+
+// ListAbuseReportActions lists the audit trail of the content targeted by an abuse report
+func ListAbuseReportActions(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports/{id}/actions admin adminListAbuseReportActions
+	// ---
+	// summary: List the moderation actions done for the content targeted by an abuse report
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationActionList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	report := getAbuseReport(ctx)
+	if report == nil {
+		return
+	}
+
+	actions, err := moderation_model.GetModerationActions(ctx, report.ContentType, report.ContentID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetModerationActions", err)
+		return
+	}
+
+	apiActions, err := convert.ToModerationActions(ctx, actions, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToModerationActions", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiActions)
+}
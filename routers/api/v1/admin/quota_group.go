@@ -120,7 +120,7 @@ func CreateQuotaGroup(ctx *context.APIContext) {
 	if err != nil {
 		if quota_model.IsErrGroupAlreadyExists(err) {
 			ctx.Error(http.StatusConflict, "", err)
-		} else if quota_model.IsErrParseLimitSubjectUnrecognized(err) {
+		} else if quota_model.IsErrParseLimitSubjectUnrecognized(err) || quota_model.IsErrIncompatibleLimitSubjects(err) {
 			ctx.Error(http.StatusUnprocessableEntity, "", err)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "quota_model.CreateGroup", err)
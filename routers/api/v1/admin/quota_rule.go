@@ -97,6 +97,8 @@ func CreateQuotaRule(ctx *context.APIContext) {
 	if err != nil {
 		if quota_model.IsErrRuleAlreadyExists(err) {
 			ctx.Error(http.StatusConflict, "", err)
+		} else if quota_model.IsErrIncompatibleLimitSubjects(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "quota_model.CreateRule", err)
 		}
@@ -179,7 +181,11 @@ func EditQuotaRule(ctx *context.APIContext) {
 
 	rule, err := ctx.QuotaRule.Edit(ctx, form.Limit, subjects)
 	if err != nil {
-		ctx.Error(http.StatusInternalServerError, "quota_model.rule.Edit", err)
+		if quota_model.IsErrIncompatibleLimitSubjects(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "quota_model.rule.Edit", err)
+		}
 		return
 	}
 
@@ -64,6 +64,30 @@ func GetActionRunJobs(ctx *context.APIContext) {
 	shared.GetActionRunJobs(ctx, 0, 0)
 }
 
+// ListQueuedJobs lists the jobs of the instance waiting for a runner
+func ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/queue admin adminListActionQueuedJobs
+	// ---
+	// summary: List all the action jobs waiting for a runner, with the runners which can pick them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	shared.ListQueuedJobs(ctx, 0, 0)
+}
+
 // SearchActionRunJobs returns a list of actions jobs filtered by the provided parameters
 //
 // Deprecated: This operation has been deprecated in Forgejo 15. Use GetActionRunJobs instead.
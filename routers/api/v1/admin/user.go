@@ -255,6 +255,7 @@ func EditUser(ctx *context.APIContext) {
 		AllowCreateOrganization: optional.FromPtr(form.AllowCreateOrganization),
 		IsRestricted:            optional.FromPtr(form.Restricted),
 		KeepEmailPrivate:        optional.FromPtr(form.HideEmail),
+		Doer:                    ctx.Doer,
 	}
 
 	if err := user_service.UpdateUser(ctx, ctx.ContextUser, opts); err != nil {
@@ -864,6 +864,8 @@ func Routes() *web.Route {
 				m.Delete("/{runner_id}", reqToken(), reqChecker, act.DeleteRunner)
 				m.Get("/jobs", reqToken(), reqChecker, act.SearchActionRunJobs)
 			})
+
+			m.Get("/queue", reqToken(), reqChecker, act.ListQueuedJobs)
 		})
 	}
 
@@ -902,6 +904,7 @@ func Routes() *web.Route {
 						activitypub.ReqHTTPSignature(),
 						activitypub.RepositoryInbox)
 					m.Get("/outbox", activitypub.ReqHTTPSignature(), activitypub.RepositoryOutbox)
+					m.Get("/issues/{index}", activitypub.ReqHTTPSignature(), activitypub.RepositoryTicket)
 				}, context.RepositoryIDAssignmentAPI())
 			}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryActivityPub))
 		}
@@ -926,6 +929,7 @@ func Routes() *web.Route {
 				m.Get("/api", settings.GetGeneralAPISettings)
 				m.Get("/attachment", settings.GetGeneralAttachmentSettings)
 				m.Get("/repository", settings.GetGeneralRepoSettings)
+				m.Get("/webhook_signing_key", settings.GetWebhookSigningKey)
 			})
 		})
 
@@ -985,6 +989,7 @@ func Routes() *web.Route {
 		// Users (requires user scope)
 		m.Group("/user", func() {
 			m.Get("", user.GetAuthenticatedUser)
+			m.Get("/audit_events", user.ListAuditEvents)
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
 					m.Get("", user.GetQuota)
@@ -1029,6 +1034,8 @@ func Routes() *web.Route {
 					m.Delete("/{runner_id}", reqToken(), user.DeleteRunner)
 					m.Get("/jobs", reqToken(), user.SearchActionRunJobs)
 				})
+
+				m.Get("/queue", reqToken(), user.ListQueuedJobs)
 			})
 
 			m.Get("/followers", user.ListMyFollowers)
@@ -1095,6 +1102,8 @@ func Routes() *web.Route {
 				m.Combo("/{id}").Get(user.GetHook).
 					Patch(bind(api.EditHookOption{}), user.EditHook).
 					Delete(user.DeleteHook)
+				m.Get("/{id}/failed_deliveries", user.ListHookFailedDeliveries)
+				m.Post("/{id}/failed_deliveries/redeliver", bind(api.RedeliverHookDeliveriesOption{}), user.RedeliverHookFailedDeliveries)
 			}, reqWebhooksEnabled())
 
 			m.Group("", func() {
@@ -1164,6 +1173,8 @@ func Routes() *web.Route {
 							Patch(bind(api.EditHookOption{}), repo.EditHook).
 							Delete(repo.DeleteHook)
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
+						m.Get("/failed_deliveries", repo.ListHookFailedDeliveries)
+						m.Post("/failed_deliveries/redeliver", bind(api.RedeliverHookDeliveriesOption{}), repo.RedeliverHookFailedDeliveries)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
 				m.Group("/collaborators", func() {
@@ -1245,6 +1256,28 @@ func Routes() *web.Route {
 							m.Post("/dispatches", reqToken(), reqRepoWriter(unit.TypeActions), mustNotBeArchived, bind(api.DispatchWorkflowOption{}), repo.DispatchWorkflow)
 						})
 					})
+
+					m.Group("/environments", func() {
+						m.Get("", repo.ListActionEnvironments)
+						m.Group("/{environment_name}", func() {
+							m.Combo("").Get(repo.GetActionEnvironment).
+								Put(reqToken(), reqAdmin(), bind(api.CreateOrUpdateActionEnvironmentOption{}), repo.CreateOrUpdateActionEnvironment).
+								Delete(reqToken(), reqAdmin(), repo.DeleteActionEnvironment)
+							m.Get("/deployments", repo.ListActionDeployments)
+							m.Group("", func() {
+								m.Get("/secrets", repo.ListActionEnvironmentSecrets)
+								m.Combo("/secrets/{secretname}").
+									Put(bind(api.CreateOrUpdateSecretOption{}), repo.CreateOrUpdateActionEnvironmentSecret).
+									Delete(repo.DeleteActionEnvironmentSecret)
+								m.Get("/variables", repo.ListActionEnvironmentVariables)
+								m.Combo("/variables/{variablename}").
+									Post(bind(api.CreateVariableOption{}), repo.CreateActionEnvironmentVariable).
+									Put(bind(api.UpdateVariableOption{}), repo.UpdateActionEnvironmentVariable).
+									Delete(repo.DeleteActionEnvironmentVariable)
+							}, reqToken(), reqAdmin())
+						})
+					})
+					m.Post("/deployments/{deployment_id}/review", reqToken(), reqRepoWriter(unit.TypeActions), mustNotBeArchived, bind(api.ReviewActionDeploymentOption{}), repo.ReviewActionDeployment)
 				}, reqRepoReader(unit.TypeActions), context.ReferencesGitRepo(true))
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.ListDeployKeys).
@@ -1324,6 +1357,7 @@ func Routes() *web.Route {
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
+						m.Delete("/merge_queue", reqToken(), mustNotBeArchived, repo.RemoveFromMergeQueue)
 						m.Group("/reviews", func() {
 							m.Combo("").
 								Get(repo.ListPullReviews).
@@ -1407,6 +1441,7 @@ func Routes() *web.Route {
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
+				m.Get("/audit_events", reqToken(), reqAdmin(), repo.ListAuditEvents)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
 				m.Group("/avatar", func() {
 					m.Post("", bind(api.UpdateRepoAvatarOption{}), repo.UpdateAvatar)
@@ -1441,6 +1476,7 @@ func Routes() *web.Route {
 					m.Combo("").Get(repo.ListIssues).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Post("/bulk", reqToken(), mustNotBeArchived, bind(api.BulkEditIssuesOption{}), repo.BulkEditIssues)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1474,6 +1510,12 @@ func Routes() *web.Route {
 								Delete(repo.DeleteIssueCommentDeprecated)
 						})
 						m.Get("/timeline", repo.ListIssueCommentsAndTimeline)
+						m.Combo("/custom_fields").Get(repo.GetIssueCustomFields).
+							Put(reqToken(), mustNotBeArchived, bind(api.SetIssueCustomFieldsOption{}), repo.SetIssueCustomFields)
+						m.Combo("/sub_issues").Get(repo.ListSubIssues).
+							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+							Patch(reqToken(), mustNotBeArchived, bind(api.ReorderSubIssuesOption{}), repo.ReorderSubIssues).
+							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
 						m.Group("/labels", func() {
 							m.Combo("").Get(repo.ListIssueLabels).
 								Post(reqToken(), bind(api.IssueLabelsOption{}), repo.AddIssueLabels).
@@ -1536,6 +1578,13 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditLabelOption{}), repo.EditLabel).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteLabel)
 				})
+				m.Group("/custom_fields", func() {
+					m.Combo("").Get(repo.ListCustomFields).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), mustNotBeArchived, bind(api.CreateCustomFieldOption{}), repo.CreateCustomField)
+					m.Combo("/{id}").
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), mustNotBeArchived, bind(api.EditCustomFieldOption{}), repo.EditCustomField).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), mustNotBeArchived, repo.DeleteCustomField)
+				})
 				m.Group("/milestones", func() {
 					m.Combo("").Get(repo.ListMilestones).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateMilestoneOption{}), repo.CreateMilestone)
@@ -1543,6 +1592,13 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Group("/projects/{id}/automation", func() {
+					m.Combo("").Get(repo.ListProjectAutomationRules).
+						Post(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.CreateProjectAutomationRuleOption{}), repo.CreateProjectAutomationRule)
+					m.Combo("/{rule}").Get(repo.GetProjectAutomationRule).
+						Patch(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.EditProjectAutomationRuleOption{}), repo.EditProjectAutomationRule).
+						Delete(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, repo.DeleteProjectAutomationRule)
+				}, reqRepoReader(unit.TypeProjects))
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 
@@ -1605,18 +1661,28 @@ func Routes() *web.Route {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Group("/custom_fields", func() {
+				m.Get("", org.ListCustomFields)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateCustomFieldOption{}), org.CreateCustomField)
+				m.Combo("/{id}").
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditCustomFieldOption{}), org.EditCustomField).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteCustomField)
+			})
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
 				m.Combo("/{id}").Get(org.GetHook).
 					Patch(bind(api.EditHookOption{}), org.EditHook).
 					Delete(org.DeleteHook)
+				m.Get("/{id}/failed_deliveries", org.ListHookFailedDeliveries)
+				m.Post("/{id}/failed_deliveries/redeliver", bind(api.RedeliverHookDeliveriesOption{}), org.RedeliverHookFailedDeliveries)
 			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
 			m.Group("/avatar", func() {
 				m.Post("", bind(api.UpdateUserAvatarOption{}), org.UpdateAvatar)
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Get("/audit_events", reqToken(), reqOrgOwnership(), org.ListAuditEvents)
 
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
@@ -1663,6 +1729,7 @@ func Routes() *web.Route {
 				m.Post("/{task}", admin.PostCronTask)
 			})
 			m.Get("/orgs", admin.GetAllOrgs)
+			m.Get("/audit_events", admin.ListAuditEvents)
 			m.Group("/users", func() {
 				m.Get("", admin.SearchUsers)
 				m.Post("", bind(api.CreateUserOption{}), admin.CreateUser)
@@ -1703,6 +1770,8 @@ func Routes() *web.Route {
 				m.Combo("/{id}").Get(admin.GetHook).
 					Patch(bind(api.EditHookOption{}), admin.EditHook).
 					Delete(admin.DeleteHook)
+				m.Get("/{id}/failed_deliveries", admin.ListHookFailedDeliveries)
+				m.Post("/{id}/failed_deliveries/redeliver", bind(api.RedeliverHookDeliveriesOption{}), admin.RedeliverHookFailedDeliveries)
 			})
 			m.Group("/actions/runners", func() {
 				m.Combo("").
@@ -1713,10 +1782,22 @@ func Routes() *web.Route {
 				m.Delete("/{runner_id}", admin.DeleteRunner)
 				m.Get("/jobs", admin.GetActionRunJobs)
 			})
+			m.Get("/actions/queue", admin.ListQueuedJobs)
 			m.Group("/runners", func() {
 				m.Get("/registration-token", admin.GetRegistrationToken) //nolint:staticcheck
 				m.Get("/jobs", admin.SearchActionRunJobs)                //nolint:staticcheck
 			})
+			if setting.Moderation.Enabled {
+				m.Group("/moderation/reports", func() {
+					m.Get("", admin.ListAbuseReports)
+					m.Group("/{id}", func() {
+						m.Get("", admin.GetAbuseReport)
+						m.Post("/assign", bind(api.AssignAbuseReportOption{}), admin.AssignAbuseReport)
+						m.Post("/resolve", bind(api.ResolveAbuseReportOption{}), admin.ResolveAbuseReport)
+						m.Get("/actions", admin.ListAbuseReportActions)
+					})
+				})
+			}
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
 					m.Group("/rules", func() {
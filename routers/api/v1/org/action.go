@@ -213,6 +213,35 @@ func (Action) SearchActionRunJobs(ctx *context.APIContext) {
 	shared.GetActionRunJobs(ctx, ctx.Org.Organization.ID, 0)
 }
 
+// ListQueuedJobs lists the jobs of the organization's repositories waiting for a runner
+func (Action) ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/queue organization orgListActionQueuedJobs
+	// ---
+	// summary: List the action jobs of the organization's repositories waiting for a runner, with the runners which can pick them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	shared.ListQueuedJobs(ctx, ctx.Org.Organization.ID, 0)
+}
+
 // ListVariables list org-level variables
 func (Action) ListVariables(ctx *context.APIContext) {
 	// swagger:operation GET /orgs/{org}/actions/variables organization getOrgVariablesList
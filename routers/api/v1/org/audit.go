@@ -0,0 +1,46 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListAuditEvents lists the audit events of an organization and of its repositories
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/audit_events organization orgListAuditEvents
+	// ---
+	// summary: List the audit events of an organization and of its repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only list the events of this action
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListAuditEvents(ctx, audit_model.FindEventsOptions{OwnerID: ctx.Org.Organization.ID})
+}
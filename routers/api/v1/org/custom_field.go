@@ -0,0 +1,164 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListCustomFields list the custom fields of an organization
+func ListCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/custom_fields organization orgListCustomFields
+	// ---
+	// summary: List an organization's custom fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, err := issues_model.FindOrgCustomFields(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOrgCustomFields", err)
+		return
+	}
+	apiFields := make([]*api.CustomField, 0, len(fields))
+	for _, field := range fields {
+		apiFields = append(apiFields, convert.ToAPICustomField(field))
+	}
+	ctx.JSON(http.StatusOK, apiFields)
+}
+
+// CreateCustomField create a custom field for an organization
+func CreateCustomField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/custom_fields organization orgCreateCustomField
+	// ---
+	// summary: Create a custom field for the issues of an organization's repositories
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateCustomFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateCustomFieldOption)
+	field := &issues_model.CustomField{
+		OrgID:   ctx.Org.Organization.ID,
+		Name:    form.Name,
+		Type:    issues_model.CustomFieldTypeFromName(form.Type),
+		Options: form.Options,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		utils.HandleCustomFieldError(ctx, "NewCustomField", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPICustomField(field))
+}
+
+// EditCustomField edit a custom field of an organization
+func EditCustomField(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/custom_fields/{id} organization orgEditCustomField
+	// ---
+	// summary: Edit a custom field of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCustomFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	field, err := issues_model.GetOrgCustomField(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		utils.HandleCustomFieldError(ctx, "GetOrgCustomField", err)
+		return
+	}
+	utils.EditCustomField(ctx, field, web.GetForm(ctx).(*api.EditCustomFieldOption))
+}
+
+// DeleteCustomField delete a custom field of an organization
+func DeleteCustomField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/custom_fields/{id} organization orgDeleteCustomField
+	// ---
+	// summary: Delete a custom field of an organization and its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field, err := issues_model.GetOrgCustomField(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		utils.HandleCustomFieldError(ctx, "GetOrgCustomField", err)
+		return
+	}
+	if err := issue_service.DeleteCustomField(ctx, ctx.Doer, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteCustomField", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
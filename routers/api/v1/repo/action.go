@@ -674,6 +674,40 @@ func (Action) SearchActionRunJobs(ctx *context.APIContext) {
 	shared.GetActionRunJobs(ctx, 0, ctx.Repo.Repository.ID)
 }
 
+// ListQueuedJobs lists the jobs of the repository waiting for a runner
+func (Action) ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/queue repository repoListActionQueuedJobs
+	// ---
+	// summary: List the repository's action jobs waiting for a runner, with the runners which can pick them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	shared.ListQueuedJobs(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 var _ actions_service.API = new(Action)
 
 // Action implements actions_service.API
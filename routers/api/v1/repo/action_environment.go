@@ -0,0 +1,862 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	secret_model "forgejo.org/models/secret"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	actions_service "forgejo.org/services/actions"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	secrets_service "forgejo.org/services/secrets"
+)
+
+// ListActionEnvironments list the deployment environments of a repository
+func ListActionEnvironments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments repository repoListActionEnvironments
+	// ---
+	// summary: List the deployment environments of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	envs, err := actions_model.GetEnvironmentsByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetEnvironmentsByRepoID", err)
+		return
+	}
+
+	apiEnvs := make([]*api.ActionEnvironment, 0, len(envs))
+	for _, env := range envs {
+		apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToActionEnvironment", err)
+			return
+		}
+		apiEnvs = append(apiEnvs, apiEnv)
+	}
+	ctx.JSON(http.StatusOK, apiEnvs)
+}
+
+// GetActionEnvironment get a deployment environment of a repository
+func GetActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoGetActionEnvironment
+	// ---
+	// summary: Get a deployment environment of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondActionEnvironment(ctx, http.StatusOK, env)
+}
+
+// CreateOrUpdateActionEnvironment create or update a deployment environment of a repository
+func CreateOrUpdateActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoCreateOrUpdateActionEnvironment
+	// ---
+	// summary: Create or update a deployment environment of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateActionEnvironmentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "201":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrUpdateActionEnvironmentOption)
+
+	reviewerIDs, err := user_model.GetUserIDsByNames(ctx, form.Reviewers, false)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetUserIDsByNames", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetUserIDsByNames", err)
+		}
+		return
+	}
+
+	name := ctx.Params("environment_name")
+	env, err := actions_model.GetEnvironmentByName(ctx, ctx.Repo.Repository.ID, name)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.Error(http.StatusInternalServerError, "GetEnvironmentByName", err)
+		return
+	}
+
+	status := http.StatusOK
+	if env == nil {
+		status = http.StatusCreated
+		env = &actions_model.ActionEnvironment{RepoID: ctx.Repo.Repository.ID, Name: name}
+	}
+	env.WaitTimer = form.WaitTimer
+	env.ReviewerIDs = reviewerIDs
+	env.BranchPatterns = form.BranchPatterns
+
+	if status == http.StatusCreated {
+		err = actions_model.CreateEnvironment(ctx, env)
+	} else {
+		err = actions_model.UpdateEnvironment(ctx, env)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateOrUpdateEnvironment", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateOrUpdateEnvironment", err)
+		}
+		return
+	}
+	respondActionEnvironment(ctx, status, env)
+}
+
+// DeleteActionEnvironment delete a deployment environment of a repository
+func DeleteActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoDeleteActionEnvironment
+	// ---
+	// summary: Delete a deployment environment of a repository, with its secrets, variables and deployments
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.DeleteEnvironment(ctx, env); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "DeleteEnvironment", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteEnvironment", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentSecrets list the secrets of a deployment environment
+func ListActionEnvironmentSecrets(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets repository repoListActionEnvironmentSecrets
+	// ---
+	// summary: List the secrets of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	secrets, count, err := db.FindAndCount[secret_model.Secret](ctx, &secret_model.FindSecretsOptions{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		ListOptions:   utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiSecrets := make([]*api.Secret, len(secrets))
+	for k, v := range secrets {
+		apiSecrets[k] = &api.Secret{
+			Name:    v.Name,
+			Created: v.CreatedUnix.AsTime(),
+		}
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiSecrets)
+}
+
+// CreateOrUpdateActionEnvironmentSecret create or update a secret of a deployment environment
+func CreateOrUpdateActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository repoUpdateActionEnvironmentSecret
+	// ---
+	// summary: Create or update a secret of a deployment environment
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateSecretOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a secret
+	//   "204":
+	//     description: response when updating a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
+
+	_, created, err := secrets_service.CreateOrUpdateEnvironmentSecret(ctx, env.RepoID, env.ID, ctx.Params("secretname"), opt.Data)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "CreateOrUpdateEnvironmentSecret", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateOrUpdateEnvironmentSecret", err)
+		}
+		return
+	}
+
+	if created {
+		ctx.Status(http.StatusCreated)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteActionEnvironmentSecret delete a secret of a deployment environment
+func DeleteActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository repoDeleteActionEnvironmentSecret
+	// ---
+	// summary: Delete a secret of a deployment environment
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: response when deleting a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := secrets_service.DeleteEnvironmentSecretByName(ctx, env.RepoID, env.ID, ctx.Params("secretname")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "DeleteEnvironmentSecretByName", err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusNotFound, "DeleteEnvironmentSecretByName", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteEnvironmentSecretByName", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentVariables list the variables of a deployment environment
+func ListActionEnvironmentVariables(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/variables repository repoListActionEnvironmentVariables
+	// ---
+	// summary: List the variables of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/VariableList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	vars, count, err := db.FindAndCount[actions_model.ActionVariable](ctx, &actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		ListOptions:   utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindVariables", err)
+		return
+	}
+
+	variables := make([]*api.ActionVariable, len(vars))
+	for i, v := range vars {
+		variables[i] = &api.ActionVariable{
+			OwnerID: v.OwnerID,
+			RepoID:  v.RepoID,
+			Name:    v.Name,
+			Data:    v.Data,
+		}
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, variables)
+}
+
+// CreateActionEnvironmentVariable create a variable of a deployment environment
+func CreateActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository repoCreateActionEnvironmentVariable
+	// ---
+	// summary: Create a variable of a deployment environment
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateVariableOption"
+	// responses:
+	//   "204":
+	//     description: response when creating a variable
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.CreateVariableOption)
+	variableName := ctx.Params("variablename")
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          variableName,
+	})
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.Error(http.StatusInternalServerError, "GetVariable", err)
+		return
+	}
+	if v != nil && v.ID > 0 {
+		ctx.Error(http.StatusConflict, "VariableNameAlreadyExists", util.NewAlreadyExistErrorf("variable name %s already exists", variableName))
+		return
+	}
+
+	if _, err := actions_service.CreateEnvironmentVariable(ctx, env.RepoID, env.ID, variableName, opt.Value); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "CreateEnvironmentVariable", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateEnvironmentVariable", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UpdateActionEnvironmentVariable update a variable of a deployment environment
+func UpdateActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository repoUpdateActionEnvironmentVariable
+	// ---
+	// summary: Update a variable of a deployment environment
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdateVariableOption"
+	// responses:
+	//   "204":
+	//     description: response when updating a variable
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.UpdateVariableOption)
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          ctx.Params("variablename"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusNotFound, "GetVariable", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetVariable", err)
+		}
+		return
+	}
+
+	if opt.Name == "" {
+		opt.Name = ctx.Params("variablename")
+	}
+	if _, err := actions_service.UpdateVariable(ctx, v.ID, 0, env.RepoID, opt.Name, opt.Value); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "UpdateVariable", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateVariable", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteActionEnvironmentVariable delete a variable of a deployment environment
+func DeleteActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository repoDeleteActionEnvironmentVariable
+	// ---
+	// summary: Delete a variable of a deployment environment
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: response when deleting a variable
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          ctx.Params("variablename"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusNotFound, "GetVariable", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetVariable", err)
+		}
+		return
+	}
+
+	if _, err := actions_model.DeleteVariable(ctx, v.ID, 0, env.RepoID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteVariable", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionDeployments list the deployments to a deployment environment
+func ListActionDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/deployments repository repoListActionDeployments
+	// ---
+	// summary: List the deployments to a deployment environment, the most recent first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: status
+	//   in: query
+	//   description: status of the deployments
+	//   type: string
+	//   enum: [waiting, approved, queued, rejected]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	env := getActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	opts := actions_model.FindDeploymentsOptions{
+		EnvironmentID: env.ID,
+		ListOptions:   utils.GetListOptions(ctx),
+	}
+	if status := ctx.FormString("status"); status != "" {
+		var ok bool
+		if opts.Status, ok = actions_model.DeploymentStatusFromString(status); !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", "invalid deployment status")
+			return
+		}
+	}
+
+	deployments, count, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindDeployments", err)
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if err := deployment.LoadJob(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadJob", err)
+			return
+		}
+		apiDeployments = append(apiDeployments, convert.ToActionDeployment(ctx, deployment, env, ctx.Doer))
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// ReviewActionDeployment approve or reject a deployment
+func ReviewActionDeployment(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/deployments/{deployment_id}/review repository repoReviewActionDeployment
+	// ---
+	// summary: Approve or reject a deployment waiting for a reviewer of its environment
+	// description: The job of a rejected deployment fails.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: deployment_id
+	//   in: path
+	//   description: id of the deployment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewActionDeploymentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeployment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.ReviewActionDeploymentOption)
+
+	deployment, err := actions_model.GetDeploymentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64("deployment_id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetDeploymentByID", err)
+		}
+		return
+	}
+	env, has, err := db.GetByID[actions_model.ActionEnvironment](ctx, deployment.EnvironmentID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetEnvironmentByID", err)
+		return
+	} else if !has {
+		ctx.NotFound()
+		return
+	}
+	if !env.IsReviewer(ctx.Doer.ID) {
+		ctx.Error(http.StatusForbidden, "", "Not a reviewer of the environment")
+		return
+	}
+
+	if err := actions_service.ReviewDeployment(ctx, deployment, ctx.Doer.ID, form.Action == "approve"); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ReviewDeployment", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ReviewDeployment", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionDeployment(ctx, deployment, env, ctx.Doer))
+}
+
+func getActionEnvironment(ctx *context.APIContext) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByName(ctx, ctx.Repo.Repository.ID, ctx.Params("environment_name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetEnvironmentByName", err)
+		}
+		return nil
+	}
+	return env
+}
+
+func respondActionEnvironment(ctx *context.APIContext, status int, env *actions_model.ActionEnvironment) {
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToActionEnvironment", err)
+		return
+	}
+	ctx.JSON(status, apiEnv)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListAuditEvents lists the audit events of a repository
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/audit_events repository repoListAuditEvents
+	// ---
+	// summary: List the audit events of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only list the events of this action
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListAuditEvents(ctx, audit_model.FindEventsOptions{RepoID: ctx.Repo.Repository.ID})
+}
@@ -22,6 +22,7 @@ import (
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
+	"forgejo.org/services/mergequeue"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
 )
@@ -638,6 +639,15 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "GetUserIDsByNames", err)
 		return
 	}
+	statusCheckSources, err := toStatusCheckSources(ctx, form.StatusCheckSources)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "User does not exist", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+		return
+	}
 	var whitelistTeams, mergeWhitelistTeams, approvalsWhitelistTeams []int64
 	if repo.Owner.IsOrganization() {
 		whitelistTeams, err = organization.GetTeamIDsByNames(ctx, repo.OwnerID, form.PushWhitelistTeams, false)
@@ -678,6 +688,7 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		WhitelistDeployKeys:           form.EnablePush && form.EnablePushWhitelist && form.PushWhitelistDeployKeys,
 		EnableStatusCheck:             form.EnableStatusCheck,
 		StatusCheckContexts:           form.StatusCheckContexts,
+		StatusCheckSources:            statusCheckSources,
 		EnableApprovalsWhitelist:      form.EnableApprovalsWhitelist,
 		RequiredApprovals:             requiredApprovals,
 		BlockOnRejectedReviews:        form.BlockOnRejectedReviews,
@@ -689,9 +700,14 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
 		ApplyToAdmins:                 form.ApplyToAdmins,
+		EnableMergeQueue:              form.EnableMergeQueue,
+	}
+	if err := protectBranch.CheckStatusCheckSources(); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "CheckStatusCheckSources", err)
+		return
 	}
 
-	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
+	err = repo_service.UpdateProtectBranch(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
 		TeamIDs:          whitelistTeams,
 		MergeUserIDs:     mergeWhitelistUsers,
@@ -752,6 +768,26 @@ func CreateBranchProtection(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToBranchProtection(ctx, bp, repo))
 }
 
+// toStatusCheckSources converts the sources the required status checks are pinned to
+func toStatusCheckSources(ctx *context.APIContext, sources []*api.StatusCheckSource) ([]*git_model.StatusCheckSource, error) {
+	result := make([]*git_model.StatusCheckSource, 0, len(sources))
+	for _, source := range sources {
+		statusCheckSource := &git_model.StatusCheckSource{
+			Context:  source.Context,
+			Workflow: source.Workflow,
+		}
+		if source.Username != "" {
+			user, err := user_model.GetUserByName(ctx, source.Username)
+			if err != nil {
+				return nil, err
+			}
+			statusCheckSource.UserID = user.ID
+		}
+		result = append(result, statusCheckSource)
+	}
+	return result, nil
+}
+
 // EditBranchProtection edits a branch protection for a repo
 func EditBranchProtection(ctx *context.APIContext) {
 	// swagger:operation PATCH /repos/{owner}/{repo}/branch_protections/{name} repository repoEditBranchProtection
@@ -836,6 +872,22 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.StatusCheckContexts = form.StatusCheckContexts
 	}
 
+	if form.StatusCheckSources != nil {
+		protectBranch.StatusCheckSources, err = toStatusCheckSources(ctx, form.StatusCheckSources)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "User does not exist", err)
+				return
+			}
+			ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			return
+		}
+	}
+	if err := protectBranch.CheckStatusCheckSources(); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "CheckStatusCheckSources", err)
+		return
+	}
+
 	if form.RequiredApprovals != nil && *form.RequiredApprovals >= 0 {
 		protectBranch.RequiredApprovals = *form.RequiredApprovals
 	}
@@ -880,6 +932,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.ApplyToAdmins = *form.ApplyToAdmins
 	}
 
+	if form.EnableMergeQueue != nil {
+		protectBranch.EnableMergeQueue = *form.EnableMergeQueue
+	}
+
 	var whitelistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
@@ -966,7 +1022,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		}
 	}
 
-	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
+	err = repo_service.UpdateProtectBranch(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
 		TeamIDs:          whitelistTeams,
 		MergeUserIDs:     mergeWhitelistUsers,
@@ -1020,6 +1076,11 @@ func EditBranchProtection(ctx *context.APIContext) {
 		}
 	}
 
+	if err := mergequeue.StartMergeQueueChecks(ctx, repo.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "StartMergeQueueChecks", err)
+		return
+	}
+
 	// Reload from db to ensure get all whitelists
 	bp, err := git_model.GetProtectedBranchRuleByName(ctx, repo.ID, bpName)
 	if err != nil {
@@ -1075,7 +1136,7 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
-	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, bp.ID); err != nil {
+	if err := repo_service.DeleteProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, bp); err != nil {
 		ctx.Error(http.StatusInternalServerError, "DeleteProtectedBranch", err)
 		return
 	}
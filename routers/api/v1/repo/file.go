@@ -265,6 +265,7 @@ func GetArchive(ctx *context.APIContext) {
 	// - application/octet-stream
 	// - application/zip
 	// - application/gzip
+	// - application/zstd
 	// parameters:
 	// - name: owner
 	//   in: path
@@ -366,6 +367,9 @@ func download(ctx *context.APIContext, archiveName string, archiver *repo_model.
 	case git.TARGZ:
 		// Per RFC6713.
 		contentType = "application/gzip"
+	case git.TARZST:
+		// Per RFC8878.
+		contentType = "application/zstd"
 	}
 
 	ctx.ServeContent(fr, &context.ServeHeaderOptions{
@@ -140,6 +140,9 @@ func CreateFork(ctx *context.APIContext) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, forker.ID, forker.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(repo.IsPrivate), forker.ID, forker.Name) {
+		return
+	}
 
 	var name string
 	if form.Name == nil {
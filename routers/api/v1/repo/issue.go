@@ -24,6 +24,7 @@ import (
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -400,6 +401,13 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: custom_fields
+	//   in: query
+	//   description: "custom field values formatted as field_id:value, with user names as the values of user fields. Fetch only issues that have all of them"
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -419,6 +427,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -520,13 +530,18 @@ func ListIssues(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
+	customFields := customFieldsForFilter(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	searchOpt := &issue_indexer.SearchOptions{
-		Paginator: &listOptions,
-		RepoIDs:   []int64{ctx.Repo.Repository.ID},
-		IsPull:    isPull,
-		IsClosed:  isClosed,
-		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
+		Paginator:    &listOptions,
+		RepoIDs:      []int64{ctx.Repo.Repository.ID},
+		IsPull:       isPull,
+		IsClosed:     isClosed,
+		CustomFields: customFields,
+		SortBy:       issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
 		ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
@@ -706,6 +721,12 @@ func CreateIssue(ctx *context.APIContext) {
 	var err error
 	if ctx.Repo.CanWrite(unit.TypeIssues) {
 		issue.MilestoneID = form.Milestone
+		issueType, ok := issues_model.IssueTypeFromName(form.IssueType)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("unknown issue type: %q", form.IssueType))
+			return
+		}
+		issue.Type = issueType
 		assigneeIDs, err = issues_model.MakeIDsFromAPIAssigneesToAdd(ctx, form.Assignee, form.Assignees)
 		if err != nil {
 			if user_model.IsErrUserNotExist(err) {
@@ -739,7 +760,7 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
-	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs); err != nil {
+	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs, 0); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
 			return
@@ -810,6 +831,8 @@ func EditIssue(ctx *context.APIContext) {
 	//     "$ref": "#/responses/notFound"
 	//   "412":
 	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditIssueOption)
 	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
@@ -867,6 +890,21 @@ func EditIssue(ctx *context.APIContext) {
 			return
 		}
 	}
+	if form.IssueType != nil && canWrite {
+		issueType, ok := issues_model.IssueTypeFromName(*form.IssueType)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("unknown issue type: %q", *form.IssueType))
+			return
+		}
+		if err := issue_service.ChangeIssueType(ctx, issue, issueType); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "ChangeIssueType", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "ChangeIssueType", err)
+			}
+			return
+		}
+	}
 
 	// Update or remove the deadline, only if set and allowed
 	if (form.Deadline != nil || form.RemoveDeadline != nil) && canWrite {
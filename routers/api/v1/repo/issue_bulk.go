@@ -0,0 +1,318 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+
+	"xorm.io/builder"
+)
+
+// BulkEditIssues edit the issues and pull requests of a repository matching a filter
+func BulkEditIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/bulk issue issueBulkEdit
+	// ---
+	// summary: Edit the issues and pull requests matching a filter in a single transaction
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/BulkEditIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BulkEditIssuesResult"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "412":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+	form := web.GetForm(ctx).(*api.BulkEditIssuesOption)
+
+	searchOpt, ok := bulkEditSearchOptions(ctx, &form.Filter)
+	if !ok {
+		return
+	}
+	opts, ok := bulkEditOptions(ctx, &form.Changes)
+	if !ok {
+		return
+	}
+
+	var conds []builder.Cond
+	if len(form.Filter.Indexes) > 0 {
+		conds = append(conds, builder.In("issue.`index`", form.Filter.Indexes))
+	}
+	issueIDs, total, err := issues_model.IssueIDs(ctx, searchOpt, conds...)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IssueIDs", err)
+		return
+	}
+	if total > int64(setting.API.MaxResponseItems) {
+		ctx.Error(http.StatusUnprocessableEntity, "TooManyIssues",
+			fmt.Sprintf("the filter matches %d issues, more than the maximum of %d", total, setting.API.MaxResponseItems))
+		return
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssuesByIDs", err)
+		return
+	}
+
+	changed, err := issue_service.BulkEdit(ctx, ctx.Doer, issues, opts, form.DryRun)
+	if err != nil {
+		switch {
+		case models.IsErrPullRequestHasMerged(err):
+			ctx.Error(http.StatusPreconditionFailed, "MergedPRState", "cannot change state of a pull request, it was already merged")
+		case issues_model.IsErrDependenciesLeft(err):
+			ctx.Error(http.StatusPreconditionFailed, "DependenciesLeft", "cannot close an issue because it still has open dependencies")
+		case errors.Is(err, user_model.ErrBlockedByUser):
+			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "BulkEdit", err)
+		}
+		return
+	}
+
+	indexes := make([]int64, 0, len(changed))
+	for _, issue := range changed {
+		indexes = append(indexes, issue.Index)
+	}
+	ctx.JSON(http.StatusOK, &api.BulkEditIssuesResult{
+		Indexes: indexes,
+		DryRun:  form.DryRun,
+	})
+}
+
+// bulkEditSearchOptions converts the filter of a bulk edit to search options,
+// restricted to the issues and pull requests the doer can write to.
+func bulkEditSearchOptions(ctx *context.APIContext, filter *api.BulkEditIssuesFilter) (*issues_model.IssuesOptions, bool) {
+	canWriteIssues := ctx.Repo.CanWriteIssuesOrPulls(false)
+	canWritePulls := ctx.Repo.CanWriteIssuesOrPulls(true)
+
+	var isPull optional.Option[bool]
+	switch filter.Type {
+	case "issues":
+		canWritePulls = false
+	case "pulls":
+		canWriteIssues = false
+	case "":
+	default:
+		ctx.Error(http.StatusUnprocessableEntity, "InvalidType", fmt.Sprintf("invalid type %q", filter.Type))
+		return nil, false
+	}
+	switch {
+	case canWriteIssues && canWritePulls:
+	case canWriteIssues:
+		isPull = optional.Some(false)
+	case canWritePulls:
+		isPull = optional.Some(true)
+	default:
+		ctx.Status(http.StatusForbidden)
+		return nil, false
+	}
+
+	var isClosed optional.Option[bool]
+	switch filter.State {
+	case "closed":
+		isClosed = optional.Some(true)
+	case "all":
+	case "", "open":
+		isClosed = optional.Some(false)
+	default:
+		ctx.Error(http.StatusUnprocessableEntity, "InvalidState", fmt.Sprintf("invalid state %q", filter.State))
+		return nil, false
+	}
+
+	searchOpt := &issues_model.IssuesOptions{
+		Paginator: &db.ListOptions{
+			Page:     1,
+			PageSize: setting.API.MaxResponseItems,
+		},
+		RepoIDs:            []int64{ctx.Repo.Repository.ID},
+		IsClosed:           isClosed,
+		IsPull:             isPull,
+		IncludedLabelNames: filter.Labels,
+		IncludeMilestones:  filter.Milestones,
+		SortType:           "oldest",
+	}
+	for _, f := range []struct {
+		name string
+		id   *int64
+	}{
+		{filter.CreatedBy, &searchOpt.PosterID},
+		{filter.AssignedBy, &searchOpt.AssigneeID},
+	} {
+		if f.name == "" {
+			continue
+		}
+		user, err := user_model.GetUserByName(ctx, f.name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetUserByName", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return nil, false
+		}
+		*f.id = user.ID
+	}
+
+	return searchOpt, true
+}
+
+// bulkEditOptions validates the changes of a bulk edit against the repository.
+func bulkEditOptions(ctx *context.APIContext, changes *api.BulkEditIssuesChanges) (*issue_service.BulkEditOptions, bool) {
+	repo := ctx.Repo.Repository
+	opts := &issue_service.BulkEditOptions{}
+
+	getLabels := func(labelIDs []int64) ([]*issues_model.Label, bool) {
+		if len(labelIDs) == 0 {
+			return nil, true
+		}
+		labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetLabelsByIDs", err)
+			return nil, false
+		}
+		if len(labels) != len(labelIDs) {
+			ctx.Error(http.StatusUnprocessableEntity, "InvalidLabels", "some labels do not exist")
+			return nil, false
+		}
+		for _, label := range labels {
+			if label.RepoID != repo.ID && label.OrgID != repo.OwnerID {
+				ctx.Error(http.StatusUnprocessableEntity, "InvalidLabels", fmt.Sprintf("label %d does not belong to this repository", label.ID))
+				return nil, false
+			}
+		}
+		return labels, true
+	}
+	var ok bool
+	if opts.AddLabels, ok = getLabels(changes.AddLabels); !ok {
+		return nil, false
+	}
+	if opts.RemoveLabels, ok = getLabels(changes.RemoveLabels); !ok {
+		return nil, false
+	}
+
+	if changes.Milestone != nil {
+		if *changes.Milestone > 0 {
+			if _, err := issues_model.GetMilestoneByRepoID(ctx, repo.ID, *changes.Milestone); err != nil {
+				if issues_model.IsErrMilestoneNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "GetMilestoneByRepoID", err)
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetMilestoneByRepoID", err)
+				}
+				return nil, false
+			}
+		}
+		opts.MilestoneID = optional.Some(*changes.Milestone)
+	}
+
+	getUsers := func(names []string, checkAssignable bool) ([]*user_model.User, bool) {
+		users := make([]*user_model.User, 0, len(names))
+		for _, name := range names {
+			user, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("Assignee does not exist: [name: %s]", name))
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+				}
+				return nil, false
+			}
+			if checkAssignable {
+				valid, err := access_model.CanBeAssigned(ctx, user, repo, false)
+				if err != nil {
+					ctx.Error(http.StatusInternalServerError, "canBeAssigned", err)
+					return nil, false
+				}
+				if !valid {
+					ctx.Error(http.StatusUnprocessableEntity, "canBeAssigned", fmt.Sprintf("%s cannot be assigned to issues of this repository", name))
+					return nil, false
+				}
+			}
+			users = append(users, user)
+		}
+		return users, true
+	}
+	if opts.AddAssignees, ok = getUsers(changes.AddAssignees, true); !ok {
+		return nil, false
+	}
+	if opts.RemoveAssignees, ok = getUsers(changes.RemoveAssignees, false); !ok {
+		return nil, false
+	}
+
+	if changes.State != nil {
+		switch api.StateType(*changes.State) {
+		case api.StateOpen:
+			opts.IsClosed = optional.Some(false)
+		case api.StateClosed:
+			opts.IsClosed = optional.Some(true)
+		default:
+			ctx.Error(http.StatusUnprocessableEntity, "InvalidState", fmt.Sprintf("invalid state %q", *changes.State))
+			return nil, false
+		}
+	}
+
+	if changes.ProjectColumn != nil {
+		if *changes.ProjectColumn == 0 {
+			opts.ProjectColumn = optional.Some[*project_model.Column](nil)
+		} else {
+			column, err := project_model.GetColumn(ctx, *changes.ProjectColumn)
+			if err != nil {
+				if project_model.IsErrProjectColumnNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "GetColumn", err)
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+				}
+				return nil, false
+			}
+			project, err := project_model.GetProjectByID(ctx, column.ProjectID)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+				return nil, false
+			}
+			if !project.CanBeAccessedByOwnerRepo(repo.OwnerID, repo) {
+				ctx.Error(http.StatusUnprocessableEntity, "InvalidProjectColumn", "the project column does not belong to this repository")
+				return nil, false
+			}
+			opts.ProjectColumn = optional.Some(column)
+		}
+	}
+
+	return opts, true
+}
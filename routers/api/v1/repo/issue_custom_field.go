@@ -0,0 +1,366 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListCustomFields list the custom fields of a repository
+func ListCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/custom_fields issue issueListCustomFields
+	// ---
+	// summary: List the custom fields of a repository, including those of its owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, err := issues_model.FindCustomFieldsForRepo(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindCustomFieldsForRepo", err)
+		return
+	}
+	apiFields := make([]*api.CustomField, 0, len(fields))
+	for _, field := range fields {
+		apiFields = append(apiFields, convert.ToAPICustomField(field))
+	}
+	ctx.JSON(http.StatusOK, apiFields)
+}
+
+// CreateCustomField create a custom field for a repository
+func CreateCustomField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/custom_fields issue issueCreateCustomField
+	// ---
+	// summary: Create a custom field for a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateCustomFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateCustomFieldOption)
+	field := &issues_model.CustomField{
+		RepoID:  ctx.Repo.Repository.ID,
+		Name:    form.Name,
+		Type:    issues_model.CustomFieldTypeFromName(form.Type),
+		Options: form.Options,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		utils.HandleCustomFieldError(ctx, "NewCustomField", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPICustomField(field))
+}
+
+// EditCustomField edit a custom field of a repository
+func EditCustomField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/custom_fields/{id} issue issueEditCustomField
+	// ---
+	// summary: Edit a custom field of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCustomFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	field, err := issues_model.GetRepoCustomField(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		utils.HandleCustomFieldError(ctx, "GetRepoCustomField", err)
+		return
+	}
+	utils.EditCustomField(ctx, field, web.GetForm(ctx).(*api.EditCustomFieldOption))
+}
+
+// DeleteCustomField delete a custom field of a repository
+func DeleteCustomField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/custom_fields/{id} issue issueDeleteCustomField
+	// ---
+	// summary: Delete a custom field of a repository and its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	field, err := issues_model.GetRepoCustomField(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		utils.HandleCustomFieldError(ctx, "GetRepoCustomField", err)
+		return
+	}
+	if err := issue_service.DeleteCustomField(ctx, ctx.Doer, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteCustomField", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetIssueCustomFields get the values of the custom fields of an issue
+func GetIssueCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/custom_fields issue issueGetIssueCustomFields
+	// ---
+	// summary: Get the values of the custom fields of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	respondIssueCustomFields(ctx, issue)
+}
+
+// SetIssueCustomFields set the values of custom fields of an issue
+func SetIssueCustomFields(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/custom_fields issue issueSetIssueCustomFields
+	// ---
+	// summary: Set the values of custom fields of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueCustomFieldsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.SetIssueCustomFieldsOption)
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	changes := make([]issue_service.CustomFieldValues, 0, len(form.Fields))
+	for _, option := range form.Fields {
+		field, err := issues_model.GetCustomFieldByID(ctx, option.FieldID)
+		if err != nil {
+			if issues_model.IsErrCustomFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetCustomFieldByID", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetCustomFieldByID", err)
+			}
+			return
+		}
+		changes = append(changes, issue_service.CustomFieldValues{Field: field, Values: option.Values})
+	}
+	if err := issue_service.SetCustomFields(ctx, ctx.Doer, issue, changes); err != nil {
+		if issues_model.IsErrCustomFieldNotExist(err) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetCustomFields", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetCustomFields", err)
+		}
+		return
+	}
+	respondIssueCustomFields(ctx, issue)
+}
+
+func respondIssueCustomFields(ctx *context.APIContext, issue *issues_model.Issue) {
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueCustomFieldValues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueCustomFieldValues(ctx, values[issue.ID]))
+}
+
+// customFieldsForFilter parses the custom_fields query parameter of the issue
+// lists, formatted as field_id:value, into custom field values by field ID
+func customFieldsForFilter(ctx *context.APIContext) map[int64]string {
+	filters := ctx.FormStrings("custom_fields")
+	if len(filters) == 0 {
+		return nil
+	}
+
+	customFields := make(map[int64]string, len(filters))
+	for _, filter := range filters {
+		id, value, ok := strings.Cut(filter, ":")
+		fieldID, err := strconv.ParseInt(id, 10, 64)
+		if !ok || err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "InvalidCustomField", fmt.Sprintf("invalid custom field filter %q", filter))
+			return nil
+		}
+		field, err := issues_model.GetCustomFieldByID(ctx, fieldID)
+		if err == nil && field.RepoID != ctx.Repo.Repository.ID && (!field.BelongsToOrg() || field.OrgID != ctx.Repo.Repository.OwnerID) {
+			err = issues_model.ErrCustomFieldNotExist{ID: fieldID}
+		}
+		if err != nil {
+			if issues_model.IsErrCustomFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetCustomFieldByID", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetCustomFieldByID", err)
+			}
+			return nil
+		}
+		values, err := field.NormalizeValues(ctx, []string{value})
+		if err != nil {
+			utils.HandleCustomFieldError(ctx, "NormalizeValues", err)
+			return nil
+		}
+		customFields[fieldID] = values[0]
+	}
+	return customFields
+}
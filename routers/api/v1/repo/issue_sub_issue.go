@@ -0,0 +1,341 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListSubIssues list the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the sub-issues of an issue, in their order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	parent := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(parent.IsPull) {
+		ctx.NotFound()
+		return
+	}
+	listSubIssues(ctx, parent)
+}
+
+// AddSubIssue make an issue a sub-issue of another issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Add a sub-issue to an issue
+	// description: The sub-issue must belong to the repository of the issue or to another repository of its organization, and have no parent.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueForWrite(ctx, web.GetForm(ctx).(*api.IssueMeta))
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.AddSubIssue(ctx, ctx.Doer.ID, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueExists(err):
+			ctx.Error(http.StatusConflict, "AddSubIssue", err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "AddSubIssue", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "AddSubIssue", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// RemoveSubIssue remove a sub-issue from an issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Remove a sub-issue from an issue
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueForWrite(ctx, web.GetForm(ctx).(*api.IssueMeta))
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.RemoveSubIssue(ctx, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RemoveSubIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ReorderSubIssues set the order of the sub-issues of an issue
+func ReorderSubIssues(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueReorderSubIssues
+	// ---
+	// summary: Set the order of the sub-issues of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReorderSubIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.ReorderSubIssuesOption)
+	parent := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	issueIDs := make([]int64, 0, len(form.SubIssues))
+	for i := range form.SubIssues {
+		subIssue := getSubIssueFromMeta(ctx, &form.SubIssues[i])
+		if ctx.Written() {
+			return
+		}
+		issueIDs = append(issueIDs, subIssue.ID)
+	}
+
+	if err := issues_model.ReorderSubIssues(ctx, parent, issueIDs); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ReorderSubIssues", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ReorderSubIssues", err)
+		}
+		return
+	}
+	listSubIssues(ctx, parent)
+}
+
+func listSubIssues(ctx *context.APIContext, parent *issues_model.Issue) {
+	subIssues, err := issues_model.GetSubIssues(ctx, parent.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSubIssues", err)
+		return
+	}
+
+	// sub-issues in other repositories are only listed if they can be read
+	repoPerms := map[int64]access_model.Permission{ctx.Repo.Repository.ID: ctx.Repo.Permission}
+	readable := make(issues_model.IssueList, 0, len(subIssues))
+	for _, subIssue := range subIssues {
+		perm, ok := repoPerms[subIssue.RepoID]
+		if !ok {
+			if err := subIssue.LoadRepo(ctx); err != nil {
+				ctx.Error(http.StatusInternalServerError, "LoadRepo", err)
+				return
+			}
+			perm, err = access_model.GetUserRepoPermission(ctx, subIssue.Repo, ctx.Doer)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+				return
+			}
+			repoPerms[subIssue.RepoID] = perm
+		}
+		if perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+			readable = append(readable, subIssue)
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, readable))
+}
+
+// getSubIssueFromMeta returns the issue of the form, in the current repository
+// if the form has no repository
+func getSubIssueFromMeta(ctx *context.APIContext, form *api.IssueMeta) *issues_model.Issue {
+	repo := ctx.Repo.Repository
+	if (form.Owner != "" || form.Name != "") && (form.Owner != repo.OwnerName || form.Name != repo.Name) {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, form.Owner, form.Name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("IsErrRepoNotExist", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return nil
+		}
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, form.Index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("IsErrIssueNotExist", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil
+	}
+	issue.Repo = repo
+	return issue
+}
+
+// getSubIssueForWrite returns the issue of the path and the issue of the form,
+// checking the doer can write both
+func getSubIssueForWrite(ctx *context.APIContext, form *api.IssueMeta) (*issues_model.Issue, *issues_model.Issue) {
+	parent := getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return nil, nil
+	}
+
+	subIssue := getSubIssueFromMeta(ctx, form)
+	if ctx.Written() {
+		return nil, nil
+	}
+	subIssuePerm := getPermissionForRepo(ctx, subIssue.Repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !subIssuePerm.CanReadIssuesOrPulls(subIssue.IsPull) {
+		ctx.NotFound()
+		return nil, nil
+	}
+	if subIssue.Repo.IsArchived || !subIssuePerm.CanWriteIssuesOrPulls(subIssue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not writer of the repository of the sub-issue")
+		return nil, nil
+	}
+	return parent, subIssue
+}
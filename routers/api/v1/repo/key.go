@@ -238,7 +238,7 @@ func CreateDeployKey(ctx *context.APIContext) {
 		return
 	}
 
-	key, err := asymkey_model.AddDeployKey(ctx, ctx.Repo.Repository.ID, form.Title, content, form.ReadOnly)
+	key, err := asymkey_service.AddDeployKey(ctx, ctx.Doer, ctx.Repo.Repository, form.Title, content, form.ReadOnly)
 	if err != nil {
 		HandleAddKeyError(ctx, err)
 		return
@@ -287,6 +287,5 @@ func DeleteDeploykey(ctx *context.APIContext) {
 		}
 		return
 	}
-
 	ctx.Status(http.StatusNoContent)
 }
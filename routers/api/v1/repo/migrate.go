@@ -90,6 +90,9 @@ func Migrate(ctx *context.APIContext) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, repoOwner.ID, repoOwner.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(form.Private || setting.Repository.ForcePrivate), repoOwner.ID, repoOwner.Name) {
+		return
+	}
 
 	if !ctx.Doer.IsAdmin {
 		if !repoOwner.IsOrganization() && ctx.Doer.ID != repoOwner.ID {
@@ -0,0 +1,357 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListProjectAutomationRules list the automation rules of a project
+func ListProjectAutomationRules(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/automation repository repoListProjectAutomationRules
+	// ---
+	// summary: List the automation rules of a repository project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationRuleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	rules, err := project_model.FindAutomationRules(ctx, project.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAutomationRules", err)
+		return
+	}
+	apiRules := make([]*api.ProjectAutomationRule, 0, len(rules))
+	for _, rule := range rules {
+		apiRules = append(apiRules, convert.ToAPIProjectAutomationRule(rule))
+	}
+	ctx.JSON(http.StatusOK, apiRules)
+}
+
+// GetProjectAutomationRule get an automation rule of a project
+func GetProjectAutomationRule(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/automation/{rule} repository repoGetProjectAutomationRule
+	// ---
+	// summary: Get an automation rule of a repository project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: rule
+	//   in: path
+	//   description: id of the automation rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rule := getProjectAutomationRule(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectAutomationRule(rule))
+}
+
+// CreateProjectAutomationRule create an automation rule for a project
+func CreateProjectAutomationRule(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/automation repository repoCreateProjectAutomationRule
+	// ---
+	// summary: Create an automation rule for a repository project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectAutomationRuleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectAutomationRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateProjectAutomationRuleOption)
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	rule := &project_model.AutomationRule{
+		ProjectID:   project.ID,
+		TriggerType: project_model.AutomationTriggerFromName(form.Trigger),
+		LabelID:     form.LabelID,
+		ColumnID:    form.ColumnID,
+		CreatorID:   ctx.Doer.ID,
+	}
+	if !validateProjectAutomationRule(ctx, rule) {
+		return
+	}
+	if err := project_model.NewAutomationRule(ctx, rule); err != nil {
+		handleProjectAutomationRuleError(ctx, "NewAutomationRule", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIProjectAutomationRule(rule))
+}
+
+// EditProjectAutomationRule edit an automation rule of a project
+func EditProjectAutomationRule(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/automation/{rule} repository repoEditProjectAutomationRule
+	// ---
+	// summary: Edit an automation rule of a repository project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: rule
+	//   in: path
+	//   description: id of the automation rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectAutomationRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationRule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.EditProjectAutomationRuleOption)
+	rule := getProjectAutomationRule(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Trigger != nil {
+		rule.TriggerType = project_model.AutomationTriggerFromName(*form.Trigger)
+	}
+	if form.LabelID != nil {
+		rule.LabelID = *form.LabelID
+	}
+	if form.ColumnID != nil {
+		rule.ColumnID = *form.ColumnID
+	}
+	if !validateProjectAutomationRule(ctx, rule) {
+		return
+	}
+	if err := project_model.UpdateAutomationRule(ctx, rule); err != nil {
+		handleProjectAutomationRuleError(ctx, "UpdateAutomationRule", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectAutomationRule(rule))
+}
+
+// DeleteProjectAutomationRule delete an automation rule of a project
+func DeleteProjectAutomationRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/automation/{rule} repository repoDeleteProjectAutomationRule
+	// ---
+	// summary: Delete an automation rule of a repository project
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: rule
+	//   in: path
+	//   description: id of the automation rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	rule := getProjectAutomationRule(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteAutomationRule(ctx, rule.ProjectID, rule.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteAutomationRule", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// getRepoProject returns the project of the repository from the id parameter
+func getRepoProject(ctx *context.APIContext) *project_model.Project {
+	project, err := project_model.GetProjectForRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetProjectForRepoByID", err)
+		}
+		return nil
+	}
+	return project
+}
+
+// getProjectAutomationRule returns the automation rule from the rule parameter
+// of the project from the id parameter
+func getProjectAutomationRule(ctx *context.APIContext) *project_model.AutomationRule {
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	rule, err := project_model.GetAutomationRule(ctx, project.ID, ctx.ParamsInt64(":rule"))
+	if err != nil {
+		if project_model.IsErrAutomationRuleNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAutomationRule", err)
+		}
+		return nil
+	}
+	return rule
+}
+
+// validateProjectAutomationRule checks the trigger and the label of the rule
+func validateProjectAutomationRule(ctx *context.APIContext, rule *project_model.AutomationRule) bool {
+	if rule.TriggerType == 0 {
+		ctx.Error(http.StatusUnprocessableEntity, "InvalidTrigger", "invalid trigger")
+		return false
+	}
+	if rule.TriggerType != project_model.AutomationTriggerLabelAdded {
+		return true
+	}
+
+	label, err := issues_model.GetLabelByID(ctx, rule.LabelID)
+	if err != nil {
+		if issues_model.IsErrLabelNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetLabelByID", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetLabelByID", err)
+		}
+		return false
+	}
+	repo := ctx.Repo.Repository
+	if label.RepoID != repo.ID && label.OrgID != repo.OwnerID {
+		ctx.Error(http.StatusUnprocessableEntity, "InvalidLabel", fmt.Sprintf("label %d does not belong to this repository", label.ID))
+		return false
+	}
+	return true
+}
+
+func handleProjectAutomationRuleError(ctx *context.APIContext, name string, err error) {
+	if project_model.IsErrProjectColumnNotExist(err) || errors.Is(err, util.ErrInvalidArgument) {
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	} else {
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
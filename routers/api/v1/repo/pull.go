@@ -38,6 +38,7 @@ import (
 	"forgejo.org/services/forms"
 	"forgejo.org/services/gitdiff"
 	issue_service "forgejo.org/services/issue"
+	"forgejo.org/services/mergequeue"
 	notify_service "forgejo.org/services/notify"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
@@ -877,6 +878,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "405":
@@ -987,6 +990,35 @@ func MergePullRequest(ctx *context.APIContext) {
 		message += "\n\n" + form.MergeMessageField
 	}
 
+	// admins forcing the merge bypass the merge queue like they bypass the other branch protections
+	if !form.ForceMerge {
+		inMergeQueue, err := mergequeue.IsEnabled(ctx, pr.BaseRepoID, pr.BaseBranch)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "IsEnabled", err)
+			return
+		}
+		if inMergeQueue {
+			headCommitID := form.HeadCommitID
+			if headCommitID == "" {
+				headCommitID, err = ctx.Repo.GitRepo.GetRefCommitID(pr.GetGitRefName())
+				if err != nil {
+					ctx.Error(http.StatusInternalServerError, "GetRefCommitID", err)
+					return
+				}
+			}
+			if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), headCommitID, message, form.DeleteBranchAfterMerge); err != nil {
+				if pull_model.IsErrAlreadyInMergeQueue(err) {
+					ctx.Error(http.StatusConflict, "AddToMergeQueue", err)
+					return
+				}
+				ctx.Error(http.StatusInternalServerError, "AddToMergeQueue", err)
+				return
+			}
+			ctx.Status(http.StatusAccepted)
+			return
+		}
+	}
+
 	if form.MergeWhenChecksSucceed {
 		scheduled, err := automerge.ScheduleAutoMerge(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, form.DeleteBranchAfterMerge)
 		if err != nil {
@@ -1402,6 +1434,84 @@ func CancelScheduledAutoMerge(ctx *context.APIContext) {
 	}
 }
 
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch
+func RemoveFromMergeQueue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoRemoveFromMergeQueue
+	// ---
+	// summary: Remove the given pull request from the merge queue of its base branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	pull, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+			return
+		}
+		ctx.InternalServerError(err)
+		return
+	}
+
+	exist, _, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	if !exist {
+		ctx.NotFound()
+		return
+	}
+	if err := pull.LoadIssue(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	// the author of the pull request may take it out of the queue as well as those allowed to merge it
+	if ctx.Doer.ID != pull.Issue.PosterID {
+		allowed, err := pull_service.IsUserAllowedToMerge(ctx, pull, ctx.Repo.Permission, ctx.Doer)
+		if err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		if !allowed {
+			ctx.Error(http.StatusForbidden, "No permission to remove", "user has no permission to remove the pull request from the merge queue")
+			return
+		}
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, pull); err != nil {
+		ctx.InternalServerError(err)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
 // GetPullRequestCommits gets all commits associated with a given PR
 func GetPullRequestCommits(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/commits repository repoGetPullRequestCommits
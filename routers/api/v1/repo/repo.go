@@ -14,6 +14,7 @@ import (
 
 	activities_model "forgejo.org/models/activities"
 	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -241,6 +242,10 @@ func Search(ctx *context.APIContext) {
 
 // CreateUserRepo create a repository for a user
 func CreateUserRepo(ctx *context.APIContext, owner *user_model.User, opt api.CreateRepoOption) {
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(opt.Private || setting.Repository.ForcePrivate), owner.ID, owner.Name) {
+		return
+	}
+
 	if opt.AutoInit && opt.Readme == "" {
 		opt.Readme = "Default"
 	}
@@ -429,6 +434,9 @@ func Generate(ctx *context.APIContext) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, ctxUser.ID, ctxUser.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(opts.Private), ctxUser.ID, ctxUser.Name) {
+		return
+	}
 
 	repo, err := repo_service.GenerateRepository(ctx, ctx.Doer, ctxUser, ctx.Repo.Repository, opts)
 	if err != nil {
@@ -771,6 +779,18 @@ func updateBasicProperties(ctx *context.APIContext, opts api.EditRepoOption) err
 			ctx.Error(http.StatusUnprocessableEntity, "Force Private enabled", err)
 			return err
 		}
+		if visibilityChanged && !*opts.Private && !ctx.Doer.IsAdmin {
+			hidden, err := moderation_model.IsRepositoryHiddenPendingReview(ctx, repo.ID)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "IsRepositoryHiddenPendingReview", err)
+				return err
+			}
+			if hidden {
+				err := errors.New("the repository is private pending the review of its abuse reports")
+				ctx.Error(http.StatusUnprocessableEntity, "Hidden pending review", err)
+				return err
+			}
+		}
 
 		repo.IsPrivate = *opts.Private
 	}
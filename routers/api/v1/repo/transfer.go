@@ -82,6 +82,9 @@ func Transfer(ctx *context.APIContext) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, newOwner.ID, newOwner.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(ctx.Repo.Repository.IsPrivate), newOwner.ID, newOwner.Name) {
+		return
+	}
 
 	var teams []*organization.Team
 	if opts.TeamIDs != nil {
@@ -246,6 +249,9 @@ func acceptOrRejectRepoTransfer(ctx *context.APIContext, accept bool) error {
 		if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, recipient.ID, recipient.Name) {
 			return nil
 		}
+		if !ctx.CheckQuota(quota_model.RepoCountSubject(ctx.Repo.Repository.IsPrivate), recipient.ID, recipient.Name) {
+			return nil
+		}
 
 		return repo_service.TransferOwnership(ctx, repoTransfer.Doer, repoTransfer.Recipient, ctx.Repo.Repository, repoTransfer.Teams)
 	}
@@ -9,6 +9,7 @@ import (
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/services/context"
+	webhook_service "forgejo.org/services/webhook"
 )
 
 // GetGeneralUISettings returns instance's global settings for ui
@@ -84,3 +85,27 @@ func GetGeneralAttachmentSettings(ctx *context.APIContext) {
 		MaxSize:      setting.Attachment.MaxSize,
 	})
 }
+
+// GetWebhookSigningKey returns the public key the webhook deliveries are signed with
+func GetWebhookSigningKey(ctx *context.APIContext) {
+	// swagger:operation GET /settings/webhook_signing_key settings getWebhookSigningKey
+	// ---
+	// summary: Get the public key the webhook deliveries are signed with
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WebhookSigningKey"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	key, err := webhook_service.SigningKey()
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "SigningKey", err)
+		return
+	}
+	if key == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, key)
+}
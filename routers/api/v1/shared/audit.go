@@ -0,0 +1,39 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListAuditEvents writes the audit events matching the options, filtered by the action query parameter
+func ListAuditEvents(ctx *context.APIContext, opts audit_model.FindEventsOptions) {
+	opts.ListOptions = utils.GetListOptions(ctx)
+	opts.Action = audit_model.Action(ctx.FormTrim("action"))
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiEvents := make([]*api.AuditEvent, len(events))
+	for i, event := range events {
+		if err := event.LoadDoer(ctx); err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		apiEvents[i] = convert.ToAuditEvent(ctx, event, ctx.Doer)
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiEvents)
+}
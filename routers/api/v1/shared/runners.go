@@ -79,6 +79,38 @@ func fromRunJobModelToResponse(job []*actions_model.ActionRunJob, labels []strin
 	return res
 }
 
+// ListQueuedJobs lists the jobs waiting for a runner, the oldest first, with the runners which can pick them
+// ownerID == 0 and repoID == 0 means all the jobs of the instance
+// ownerID == 0 and repoID != 0 means the jobs of the given repo
+// ownerID != 0 and repoID == 0 means the jobs of the repos of the given user/org
+// Access rights are checked at the API route level
+func ListQueuedJobs(ctx *context.APIContext, ownerID, repoID int64) {
+	listOptions := utils.GetListOptions(ctx)
+	jobs, total, err := db.FindAndCount[actions_model.ActionRunJob](ctx, actions_model.FindQueuedJobsOptions{
+		ListOptions: listOptions,
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindQueuedJobs", err)
+		return
+	}
+
+	queuedJobs, err := actions_model.DiagnoseQueuedJobs(ctx, jobs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "DiagnoseQueuedJobs", err)
+		return
+	}
+	res := make([]*structs.ActionQueuedJob, 0, len(queuedJobs))
+	for _, q := range queuedJobs {
+		res = append(res, convert.ToActionQueuedJob(q))
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // ListRunners lists runners for api route validated ownerID and repoID
 // ownerID == 0 and repoID == 0 means all runners including global runners, does not appear in sql where clause
 // ownerID == 0 and repoID != 0 means all runners for the given repo
@@ -162,6 +194,7 @@ func RegisterRunner(ctx *context.APIContext, ownerID, repoID int64) {
 		OwnerID:     ownerID,
 		RepoID:      repoID,
 		Description: options.Description,
+		Ephemeral:   options.Ephemeral,
 	}
 	runner.GenerateToken()
 	if err := actions_model.CreateRunner(ctx, runner); err != nil {
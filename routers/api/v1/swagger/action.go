@@ -83,3 +83,38 @@ type swaggerRegisterRunnerResponse struct {
 	// in: body
 	Body api.RegisterRunnerResponse `json:"body"`
 }
+
+// ActionEnvironment represents a deployment environment
+// swagger:response ActionEnvironment
+type swaggerActionEnvironment struct {
+	// in: body
+	Body api.ActionEnvironment `json:"body"`
+}
+
+// ActionEnvironmentList is a list of deployment environments
+// swagger:response ActionEnvironmentList
+type swaggerActionEnvironmentList struct {
+	// in: body
+	Body []api.ActionEnvironment `json:"body"`
+}
+
+// ActionDeployment represents a deployment of a job to an environment
+// swagger:response ActionDeployment
+type swaggerActionDeployment struct {
+	// in: body
+	Body api.ActionDeployment `json:"body"`
+}
+
+// ActionDeploymentList is a list of deployments
+// swagger:response ActionDeploymentList
+type swaggerActionDeploymentList struct {
+	// in: body
+	Body []api.ActionDeployment `json:"body"`
+}
+
+// ActionQueuedJobList is a list of jobs waiting for a runner
+// swagger:response ActionQueuedJobList
+type swaggerActionQueuedJobList struct {
+	// in: body
+	Body []api.ActionQueuedJob `json:"body"`
+}
@@ -91,6 +91,13 @@ type swaggerIssueDeadline struct {
 	Body api.IssueDeadline `json:"body"`
 }
 
+// BulkEditIssuesResult
+// swagger:response BulkEditIssuesResult
+type swaggerBulkEditIssuesResult struct {
+	// in:body
+	Body api.BulkEditIssuesResult `json:"body"`
+}
+
 // IssueTemplates
 // swagger:response IssueTemplates
 type swaggerIssueTemplates struct {
@@ -125,3 +132,24 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// CustomField
+// swagger:response CustomField
+type swaggerResponseCustomField struct {
+	// in:body
+	Body api.CustomField `json:"body"`
+}
+
+// CustomFieldList
+// swagger:response CustomFieldList
+type swaggerResponseCustomFieldList struct {
+	// in:body
+	Body []api.CustomField `json:"body"`
+}
+
+// IssueCustomFieldValueList
+// swagger:response IssueCustomFieldValueList
+type swaggerResponseIssueCustomFieldValueList struct {
+	// in:body
+	Body []api.IssueCustomFieldValue `json:"body"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package swagger
+
+import (
+	api "forgejo.org/modules/structs"
+)
+
+// AbuseReport
+// swagger:response AbuseReport
+type swaggerResponseAbuseReport struct {
+	// in:body
+	Body api.AbuseReport `json:"body"`
+}
+
+// AbuseReportList
+// swagger:response AbuseReportList
+type swaggerResponseAbuseReportList struct {
+	// in:body
+	Body []api.AbuseReport `json:"body"`
+}
+
+// ModerationActionList
+// swagger:response ModerationActionList
+type swaggerResponseModerationActionList struct {
+	// in:body
+	Body []api.ModerationAction `json:"body"`
+}
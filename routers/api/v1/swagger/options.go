@@ -34,6 +34,8 @@ type swaggerParameterBodies struct {
 	CreateHookOption api.CreateHookOption
 	// in:body
 	EditHookOption api.EditHookOption
+	// in:body
+	RedeliverHookDeliveriesOption api.RedeliverHookDeliveriesOption
 
 	// in:body
 	EditGitHookOption api.EditGitHookOption
@@ -44,6 +46,8 @@ type swaggerParameterBodies struct {
 	EditIssueOption api.EditIssueOption
 	// in:body
 	EditDeadlineOption api.EditDeadlineOption
+	// in:body
+	BulkEditIssuesOption api.BulkEditIssuesOption
 
 	// in:body
 	CreateIssueCommentOption api.CreateIssueCommentOption
@@ -242,4 +246,33 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	RegisterRunnerOptions api.RegisterRunnerOptions
+
+	// in:body
+	AssignAbuseReportOption api.AssignAbuseReportOption
+
+	// in:body
+	ResolveAbuseReportOption api.ResolveAbuseReportOption
+
+	// in:body
+	CreateProjectAutomationRuleOption api.CreateProjectAutomationRuleOption
+	// in:body
+	EditProjectAutomationRuleOption api.EditProjectAutomationRuleOption
+
+	// in:body
+	CreateCustomFieldOption api.CreateCustomFieldOption
+
+	// in:body
+	EditCustomFieldOption api.EditCustomFieldOption
+
+	// in:body
+	SetIssueCustomFieldsOption api.SetIssueCustomFieldsOption
+
+	// in:body
+	ReorderSubIssuesOption api.ReorderSubIssuesOption
+
+	// in:body
+	CreateOrUpdateActionEnvironmentOption api.CreateOrUpdateActionEnvironmentOption
+
+	// in:body
+	ReviewActionDeploymentOption api.ReviewActionDeploymentOption
 }
@@ -112,6 +112,13 @@ type swaggerResponseHookList struct {
 	Body []api.Hook `json:"body"`
 }
 
+// HookDeliveryList
+// swagger:response HookDeliveryList
+type swaggerResponseHookDeliveryList struct {
+	// in:body
+	Body []api.HookDelivery `json:"body"`
+}
+
 // GitHook
 // swagger:response GitHook
 type swaggerResponseGitHook struct {
@@ -476,3 +483,17 @@ type swaggerActionRun struct {
 	// in:body
 	Body api.ActionRun `json:"body"`
 }
+
+// ProjectAutomationRule
+// swagger:response ProjectAutomationRule
+type swaggerProjectAutomationRule struct {
+	// in:body
+	Body api.ProjectAutomationRule `json:"body"`
+}
+
+// ProjectAutomationRuleList
+// swagger:response ProjectAutomationRuleList
+type swaggerProjectAutomationRuleList struct {
+	// in:body
+	Body []api.ProjectAutomationRule `json:"body"`
+}
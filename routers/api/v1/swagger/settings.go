@@ -32,3 +32,10 @@ type swaggerResponseGeneralAttachmentSettings struct {
 	// in:body
 	Body api.GeneralAttachmentSettings `json:"body"`
 }
+
+// WebhookSigningKey
+// swagger:response WebhookSigningKey
+type swaggerResponseWebhookSigningKey struct {
+	// in:body
+	Body api.WebhookSigningKey `json:"body"`
+}
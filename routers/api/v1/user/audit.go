@@ -0,0 +1,39 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListAuditEvents lists the audit events caused by or concerning the authenticated user
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /user/audit_events user userListAuditEvents
+	// ---
+	// summary: List the audit events caused by or concerning the authenticated user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: action
+	//   in: query
+	//   description: only list the events of this action
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+
+	shared.ListAuditEvents(ctx, audit_model.FindEventsOptions{UserID: ctx.Doer.ID})
+}
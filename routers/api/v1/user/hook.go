@@ -177,3 +177,73 @@ func DeleteHook(ctx *context.APIContext) {
 		ctx.ParamsInt64("id"),
 	)
 }
+
+// ListHookFailedDeliveries lists the failed deliveries of hook of the authenticated user
+func ListHookFailedDeliveries(ctx *context.APIContext) {
+	// swagger:operation GET /user/hooks/{id}/failed_deliveries user userListHookFailedDeliveries
+	// ---
+	// summary: List the deliveries of a hook that failed and are not going to be retried
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.Doer.ID, ctx.ParamsInt64("id"))
+	if err != nil {
+		return
+	}
+
+	utils.ListFailedHookDeliveries(ctx, hook)
+}
+
+// RedeliverHookFailedDeliveries redelivers the failed deliveries of hook of the authenticated user
+func RedeliverHookFailedDeliveries(ctx *context.APIContext) {
+	// swagger:operation POST /user/hooks/{id}/failed_deliveries/redeliver user userRedeliverHookFailedDeliveries
+	// ---
+	// summary: Redeliver the failed deliveries of a hook
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RedeliverHookDeliveriesOption"
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.Doer.ID, ctx.ParamsInt64("id"))
+	if err != nil {
+		return
+	}
+
+	utils.RedeliverFailedHookDeliveries(ctx, hook, web.GetForm(ctx).(*api.RedeliverHookDeliveriesOption))
+}
@@ -49,6 +49,32 @@ func SearchActionRunJobs(ctx *context.APIContext) {
 	shared.GetActionRunJobs(ctx, ctx.Doer.ID, 0)
 }
 
+// ListQueuedJobs lists the jobs of the user's repositories waiting for a runner
+func ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /user/actions/queue user userListActionQueuedJobs
+	// ---
+	// summary: List the action jobs of the user's repositories waiting for a runner, with the runners which can pick them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	shared.ListQueuedJobs(ctx, ctx.Doer.ID, 0)
+}
+
 // ListRunners returns the user's runners
 func ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /user/actions/runners user getUserRunners
@@ -0,0 +1,43 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// EditCustomField applies the options to a custom field of a repository or an organization
+func EditCustomField(ctx *context.APIContext, field *issues_model.CustomField, form *api.EditCustomFieldOption) {
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if err := issue_service.UpdateCustomField(ctx, ctx.Doer, field); err != nil {
+		HandleCustomFieldError(ctx, "UpdateCustomField", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPICustomField(field))
+}
+
+// HandleCustomFieldError writes the response of an error returned for a custom field
+func HandleCustomFieldError(ctx *context.APIContext, name string, err error) {
+	switch {
+	case issues_model.IsErrCustomFieldNotExist(err):
+		ctx.NotFound()
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	default:
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
@@ -214,6 +214,8 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 				ActionRunFailure:         util.SliceContainsString(form.Events, string(webhook_module.HookEventActionRunFailure), true),
 				ActionRunRecover:         util.SliceContainsString(form.Events, string(webhook_module.HookEventActionRunRecover), true),
 				ActionRunSuccess:         util.SliceContainsString(form.Events, string(webhook_module.HookEventActionRunSuccess), true),
+				Status:                   util.SliceContainsString(form.Events, string(webhook_module.HookEventStatus), true),
+				WorkflowJob:              util.SliceContainsString(form.Events, string(webhook_module.HookEventWorkflowJob), true),
 			},
 			BranchFilter: form.BranchFilter,
 		},
@@ -341,6 +343,9 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 			}
 			w.ContentType = webhook.ToHookContentType(ct)
 		}
+		if secret, ok := form.Config["secret"]; ok {
+			w.SetSecret(secret, setting.Webhook.SecretRotationOverlap)
+		}
 
 		if w.Type == webhook_module.SLACK {
 			if channel, ok := form.Config["channel"]; ok {
@@ -374,6 +379,8 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.Repository = util.SliceContainsString(form.Events, string(webhook_module.HookEventRepository), true)
 	w.Wiki = util.SliceContainsString(form.Events, string(webhook_module.HookEventWiki), true)
 	w.Release = util.SliceContainsString(form.Events, string(webhook_module.HookEventRelease), true)
+	w.Status = util.SliceContainsString(form.Events, string(webhook_module.HookEventStatus), true)
+	w.WorkflowJob = util.SliceContainsString(form.Events, string(webhook_module.HookEventWorkflowJob), true)
 	w.BranchFilter = form.BranchFilter
 
 	w.SetHeaderAuthorization(form.AuthorizationHeader)
@@ -423,3 +430,35 @@ func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListFailedHookDeliveries lists the deliveries of a webhook that failed and are not going to be retried
+func ListFailedHookDeliveries(ctx *context.APIContext, w *webhook.Webhook) {
+	tasks, count, err := webhook.FailedHookTasks(ctx, w.ID, GetListOptions(ctx))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FailedHookTasks", err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, len(tasks))
+	for i, task := range tasks {
+		deliveries[i] = webhook_service.ToHookDelivery(task)
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverFailedHookDeliveries queues the failed deliveries of a webhook for another delivery
+func RedeliverFailedHookDeliveries(ctx *context.APIContext, w *webhook.Webhook, form *api.RedeliverHookDeliveriesOption) {
+	tasks, err := webhook_service.RedeliverFailedHookTasks(ctx, w, form.UUIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RedeliverFailedHookTasks", err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, len(tasks))
+	for i, task := range tasks {
+		deliveries[i] = webhook_service.ToHookDelivery(task)
+	}
+	ctx.JSON(http.StatusAccepted, deliveries)
+}
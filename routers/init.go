@@ -40,12 +40,15 @@ import (
 	"forgejo.org/services/cron"
 	federation_service "forgejo.org/services/federation"
 	feed_service "forgejo.org/services/feed"
+	"forgejo.org/services/firehose"
 	indexer_service "forgejo.org/services/indexer"
 	"forgejo.org/services/mailer"
 	mailer_incoming "forgejo.org/services/mailer/incoming"
 	markup_service "forgejo.org/services/markup"
+	"forgejo.org/services/mergequeue"
 	migrations_service "forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
+	project_service "forgejo.org/services/project"
 	pull_service "forgejo.org/services/pull"
 	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
@@ -154,8 +157,11 @@ func InitWebInstalled(ctx context.Context) {
 
 	mirror_service.InitSyncMirrors()
 	mustInit(webhook.Init)
+	mustInit(firehose.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(mergequeue.Init)
+	mustInit(project_service.Init)
 	mustInit(task.Init)
 	mustInit(migrations_service.Init)
 	eventsource.GetManager().Init()
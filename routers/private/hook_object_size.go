@@ -0,0 +1,47 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"forgejo.org/modules/git"
+)
+
+// findObjectLargerThan returns the first blob reachable from newCommitID, and
+// not from any existing reference, whose size exceeds the limit. It returns an
+// empty object id if there is none.
+func findObjectLargerThan(ctx context.Context, repoPath string, env []string, newCommitID string, limit int64) (string, int64, error) {
+	// blob:limit=<n> omits the blobs of n bytes or more, and --filter-print-omitted
+	// lists them prefixed by a tilde, so the repository does not need to be walked twice.
+	stdout, _, err := git.NewCommand(ctx, "rev-list", "--objects", "--no-object-names", "--filter-print-omitted").
+		AddOptionFormat("--filter=blob:limit=%d", limit+1).
+		AddDynamicArguments(newCommitID).
+		AddArguments("--not", "--all").
+		RunStdString(&git.RunOpts{Dir: repoPath, Env: env})
+	if err != nil {
+		return "", 0, err
+	}
+
+	for line := range strings.SplitSeq(stdout, "\n") {
+		oid, omitted := strings.CutPrefix(strings.TrimSpace(line), "~")
+		if !omitted || oid == "" {
+			continue
+		}
+
+		size, _, err := git.NewCommand(ctx, "cat-file", "-s").AddDynamicArguments(oid).RunStdString(&git.RunOpts{Dir: repoPath, Env: env})
+		if err != nil {
+			return "", 0, err
+		}
+		objectSize, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+		return oid, objectSize, nil
+	}
+	return "", 0, nil
+}
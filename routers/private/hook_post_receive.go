@@ -13,6 +13,7 @@ import (
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
+	moderation_model "forgejo.org/models/moderation"
 	pull_model "forgejo.org/models/pull"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
@@ -182,7 +183,20 @@ func HookPostReceive(ctx *app_context.PrivateContext) {
 			wasEmpty = repo.IsEmpty
 		}
 
-		repo.IsPrivate = opts.GetGitPushOptions().GetBool(pushoptions.RepoPrivate, repo.IsPrivate)
+		isPrivate := opts.GetGitPushOptions().GetBool(pushoptions.RepoPrivate, repo.IsPrivate)
+		if repo.IsPrivate && !isPrivate {
+			// the repository stays private until a moderator reviews its abuse reports
+			hidden, err := moderation_model.IsRepositoryHiddenPendingReview(ctx, repo.ID)
+			if err != nil {
+				log.Error("IsRepositoryHiddenPendingReview: %s/%s Error: %v", ownerName, repoName, err)
+				ctx.JSON(http.StatusInternalServerError, private.HookPostReceiveResult{
+					Err: fmt.Sprintf("IsRepositoryHiddenPendingReview: %s/%s Error: %v", ownerName, repoName, err),
+				})
+				return
+			}
+			isPrivate = hidden
+		}
+		repo.IsPrivate = isPrivate
 		repo.IsTemplate = opts.GetGitPushOptions().GetBool(pushoptions.RepoTemplate, repo.IsTemplate)
 		if err := repo_model.UpdateRepositoryCols(ctx, repo, "is_private", "is_template"); err != nil {
 			log.Error("Failed to Update: %s/%s Error: %v", ownerName, repoName, err)
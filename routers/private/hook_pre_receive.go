@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"forgejo.org/models"
 	asymkey_model "forgejo.org/models/asymkey"
@@ -15,12 +16,15 @@ import (
 	issues_model "forgejo.org/models/issues"
 	perm_model "forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
+	pull_model "forgejo.org/models/pull"
 	quota_model "forgejo.org/models/quota"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/private"
+	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
 	app_context "forgejo.org/services/context"
@@ -50,6 +54,8 @@ type preReceiveContext struct {
 	opts *private.HookOptions
 
 	isOverQuota bool
+	// objectSizeLimit is the maximum size of a single pushed object, or -1 if unlimited
+	objectSizeLimit int64
 
 	branchName string
 }
@@ -147,6 +153,7 @@ func (ctx *preReceiveContext) assertPushOptions() bool {
 func (ctx *preReceiveContext) checkQuota() error {
 	if !setting.Quota.Enabled {
 		ctx.isOverQuota = false
+		ctx.objectSizeLimit = -1
 		return nil
 	}
 
@@ -165,6 +172,15 @@ func (ctx *preReceiveContext) checkQuota() error {
 	}
 
 	ctx.isOverQuota = !ok
+
+	ctx.objectSizeLimit, err = quota_model.GetCeilingForUser(ctx, ctx.Repo.Repository.OwnerID, quota_model.LimitSubjectSizeGitObjectMax)
+	if err != nil {
+		log.Error("quota_model.GetCeilingForUser: %v", err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			UserMsg: "Error checking user quota",
+		})
+		return err
+	}
 	return nil
 }
 
@@ -174,6 +190,32 @@ func (ctx *preReceiveContext) quotaExceeded() {
 	})
 }
 
+// assertObjectSize returns true if none of the objects introduced by newCommitID
+// exceed the per-object size limit of the repository owner
+func (ctx *preReceiveContext) assertObjectSize(newCommitID string) bool {
+	if ctx.objectSizeLimit < 0 || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	oid, size, err := findObjectLargerThan(ctx, ctx.Repo.Repository.RepoPath(), ctx.env, newCommitID, ctx.objectSizeLimit)
+	if err != nil {
+		log.Error("Unable to check the size of the objects pushed to %-v: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check the size of the pushed objects: %v", err),
+		})
+		return false
+	}
+	if oid == "" {
+		return true
+	}
+
+	ctx.JSON(http.StatusRequestEntityTooLarge, private.Response{
+		UserMsg: fmt.Sprintf("Quota exceeded: object %s is %s, which is larger than the maximum allowed size of %s per object",
+			oid, base.FileSize(size), base.FileSize(ctx.objectSizeLimit)),
+	})
+	return false
+}
+
 // HookPreReceive checks whether a individual commit is acceptable
 func HookPreReceive(ctx *app_context.PrivateContext) {
 	opts := web.GetForm(ctx).(*private.HookOptions)
@@ -200,6 +242,10 @@ func HookPreReceive(ctx *app_context.PrivateContext) {
 		newCommitID := opts.NewCommitIDs[i]
 		refFullName := opts.RefFullNames[i]
 
+		if !ourCtx.assertObjectSize(newCommitID) {
+			return
+		}
+
 		switch {
 		case refFullName.IsBranch():
 			preReceiveBranch(ourCtx, oldCommitID, newCommitID, refFullName)
@@ -251,6 +297,12 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	// The merge queue force pushes the speculative merges of the queued pull requests to branches of
+	// their own, which the rules protecting them with a wildcard must not block
+	if ctx.opts.PushTrigger == repo_module.PushTriggerMergeQueue && strings.HasPrefix(branchName, pull_model.MergeQueueBranchPrefix) {
+		protectBranch = nil
+	}
+
 	// Allow pushes to non-protected branches
 	if protectBranch == nil {
 		// ...unless the user is over quota, and the operation is not a delete
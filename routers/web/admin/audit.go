@@ -0,0 +1,77 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+const (
+	tplAuditEvents base.TplName = "admin/audit"
+)
+
+// AuditEvents shows the audit log of the instance
+func AuditEvents(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.audit")
+	ctx.Data["PageIsAdminAudit"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	action := ctx.FormTrim("action")
+	userName := ctx.FormTrim("q")
+
+	opts := audit_model.FindEventsOptions{
+		ListOptions: db.ListOptions{
+			PageSize: setting.UI.Admin.NoticePagingNum,
+			Page:     page,
+		},
+		Action: audit_model.Action(action),
+	}
+	if userName != "" {
+		u, err := user_model.GetUserByName(ctx, userName)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		if u == nil {
+			// an unknown user has no events, rather than every event
+			opts.UserID = -1
+		} else {
+			opts.UserID = u.ID
+		}
+	}
+
+	var events []*audit_model.Event
+	var total int64
+	if opts.UserID >= 0 {
+		var err error
+		events, total, err = db.FindAndCount[audit_model.Event](ctx, opts)
+		if err != nil {
+			ctx.ServerError("FindAndCount", err)
+			return
+		}
+	}
+
+	ctx.Data["Events"] = events
+	ctx.Data["Total"] = total
+	ctx.Data["Actions"] = audit_model.Actions
+	ctx.Data["Action"] = action
+	ctx.Data["UserName"] = userName
+
+	pager := context.NewPagination(int(total), setting.UI.Admin.NoticePagingNum, page, 5)
+	pager.AddParamString("action", action)
+	pager.AddParamString("q", userName)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplAuditEvents)
+}
@@ -15,6 +15,7 @@ import (
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
 	"forgejo.org/services/context"
 	issue_service "forgejo.org/services/issue"
 	moderation_service "forgejo.org/services/moderation"
@@ -90,6 +91,20 @@ func AbuseReportDetails(ctx *context.Context) {
 
 	ctx.Data["GetShadowCopyMap"] = moderation_service.GetShadowCopyMap
 
+	if reports[0].AssigneeID > 0 {
+		assignee, err := user.GetPossibleUserByID(ctx, reports[0].AssigneeID)
+		if err != nil && !user.IsErrUserNotExist(err) {
+			ctx.ServerError("Failed to load the assignee", err)
+			return
+		}
+		ctx.Data["Assignee"] = assignee
+	}
+
+	if err := loadModerationActions(ctx, contentType, ctx.ParamsInt64(":id")); err != nil {
+		ctx.ServerError("Failed to load the audit trail", err)
+		return
+	}
+
 	if err = setReportedContentDetails(ctx, reports[0]); err != nil {
 		if user.IsErrUserNotExist(err) || issues.IsErrCommentNotExist(err) || issues.IsErrIssueNotExist(err) || repo_model.IsErrRepoNotExist(err) {
 			ctx.Data["ContentReference"] = ctx.Tr("admin.moderation.deleted_content_ref", reports[0].ContentType, reports[0].ContentID)
@@ -105,6 +120,33 @@ func AbuseReportDetails(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplModerationReportDetails)
 }
 
+// loadModerationActions adds the audit trail of the reports for the given content into context data,
+// together with the users who did the actions and the users targeted by them.
+func loadModerationActions(ctx *context.Context, contentType moderation.ReportedContentType, contentID int64) error {
+	actions, err := moderation.GetModerationActions(ctx, contentType, contentID)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]int64, 0, len(actions)*2)
+	for _, action := range actions {
+		userIDs = append(userIDs, action.DoerID, action.TargetUserID)
+	}
+	users, err := user.GetPossibleUserByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	usersMap := make(map[int64]*user.User, len(users))
+	for _, u := range users {
+		usersMap[u.ID] = u
+	}
+
+	ctx.Data["ModerationActions"] = actions
+	ctx.Data["ModerationActionUsers"] = usersMap
+	ctx.Data["ModerationActionTypes"] = moderation.ModerationActionTypeTranslationKeys
+	return nil
+}
+
 // setReportedContentDetails adds some values into context data for the given report
 // (icon name, a reference, the URL and in case of issues and comments also the poster name and URL).
 func setReportedContentDetails(ctx *context.Context, report *moderation.AbuseReportDetailed) error {
@@ -238,10 +280,8 @@ func updateReportStatus(ctx *context.Context, contentType moderation.ReportedCon
 	var err error
 
 	switch reportAction {
-	case moderation_service.ReportActionMarkAsHandled:
-		err = moderation.MarkAsHandled(ctx, contentType, contentID)
-	case moderation_service.ReportActionMarkAsIgnored:
-		err = moderation.MarkAsIgnored(ctx, contentType, contentID)
+	case moderation_service.ReportActionMarkAsHandled, moderation_service.ReportActionMarkAsIgnored:
+		err = moderation_service.ResolveReports(ctx, ctx.Doer, contentType, contentID, reportAction)
 	default:
 		return
 	}
@@ -293,6 +333,7 @@ func suspendAccount(ctx *context.Context, contentType moderation.ReportedContent
 		ctx.Error(http.StatusInternalServerError, fmt.Sprintf("Failed to suspend the user: %s", err.Error()))
 		return
 	}
+	recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeSuspendAccount, reportedUser.ID)
 
 	if reportAction != moderation_service.ReportActionNone {
 		// TODO: currently not implemented
@@ -331,12 +372,14 @@ func deleteAccount(ctx *context.Context, contentType moderation.ReportedContentT
 			return
 		}
 		log.Trace("Organization deleted by admin (%s): %s", ctx.Doer.Name, reportedOrg.Name)
+		recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeDeleteAccount, 0)
 	} else {
 		if err = user_service.DeleteUser(ctx, reportedUser, true); err != nil {
 			ctx.Error(http.StatusInternalServerError, fmt.Sprintf("Failed to delete the user: %s", err.Error()))
 			return
 		}
 		log.Trace("Account deleted by admin (%s): %s", ctx.Doer.Name, reportedUser.Name)
+		recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeDeleteAccount, 0)
 	}
 
 	// TODO: when deleting content maybe we should always mark the reports as handled (does it makes sense to keep them open?!)
@@ -358,6 +401,7 @@ func deleteRepository(ctx *context.Context, contentType moderation.ReportedConte
 		return
 	}
 	log.Trace("Repository deleted: %s", repo.FullName())
+	recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeDeleteRepo, 0)
 
 	updateReportStatus(ctx, contentType, contentID, reportAction)
 
@@ -376,6 +420,7 @@ func deleteIssue(ctx *context.Context, contentType moderation.ReportedContentTyp
 		ctx.Error(http.StatusInternalServerError, fmt.Sprintf("Failed to delete the issue: %s", err.Error()))
 		return
 	}
+	recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeDeleteIssue, 0)
 
 	updateReportStatus(ctx, contentType, contentID, reportAction)
 
@@ -394,9 +439,50 @@ func deleteComment(ctx *context.Context, contentType moderation.ReportedContentT
 		ctx.Error(http.StatusInternalServerError, fmt.Sprintf("Failed to delete the comment: %s", err.Error()))
 		return
 	}
+	recordAction(ctx, contentType, contentID, moderation.ModerationActionTypeDeleteComment, 0)
 
 	updateReportStatus(ctx, contentType, contentID, reportAction)
 
 	ctx.Flash.Success(ctx.Tr("moderation.comment.deletion_success"), true)
 	ctx.HTML(http.StatusOK, tplAlert)
 }
+
+// recordAction adds the action done for the reported content to the audit trail of its reports;
+// a failure is only logged since the action itself was already done.
+func recordAction(ctx *context.Context, contentType moderation.ReportedContentType, contentID int64, actionType moderation.ModerationActionType, targetUserID int64) {
+	if err := moderation_service.RecordAction(ctx, ctx.Doer, contentType, contentID, actionType, targetUserID); err != nil {
+		log.Error("Failed to record the moderation action %d for content with type %d and ID %d: %v", actionType, contentType, contentID, err)
+	}
+}
+
+// AssignReports assigns the open reports of some content to the doer or removes their assignment.
+func AssignReports(ctx *context.Context) {
+	contentID := ctx.FormInt64("content_id")
+	if contentID <= 0 {
+		ctx.Error(http.StatusBadRequest, "Invalid parameter: content_id")
+		return
+	}
+
+	contentType := moderation.ReportedContentType(ctx.FormInt64("content_type"))
+	if !contentType.IsValid() {
+		ctx.Error(http.StatusBadRequest, "Invalid parameter: content_type")
+		return
+	}
+
+	var assignee *user.User
+	if ctx.FormBool("assign") {
+		assignee = ctx.Doer
+	}
+
+	if err := moderation_service.AssignReports(ctx, ctx.Doer, contentType, contentID, assignee); err != nil {
+		ctx.ServerError("AssignReports", err)
+		return
+	}
+
+	if assignee != nil {
+		ctx.Flash.Success(ctx.Tr("moderation.report.assign_success"))
+	} else {
+		ctx.Flash.Success(ctx.Tr("moderation.report.unassign_success"))
+	}
+	ctx.Redirect(fmt.Sprintf("%s/admin/moderation/reports/type/%d/id/%d", setting.AppSubURL, contentType, contentID))
+}
@@ -26,6 +26,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/explore"
 	user_setting "forgejo.org/routers/web/user/setting"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -455,6 +456,7 @@ func EditUserPost(ctx *context.Context) {
 		Visibility:              optional.Some(form.Visibility),
 		Language:                optional.Some(form.Language),
 		KeepEmailPrivate:        optional.Some(form.HideEmail),
+		Doer:                    ctx.Doer,
 	}
 
 	if err := user_service.UpdateUser(ctx, u, opts); err != nil {
@@ -473,7 +475,7 @@ func EditUserPost(ctx *context.Context) {
 			ctx.ServerError("auth.GetTwoFactorByUID", err)
 			return
 		} else if tf != nil {
-			if err := auth.DeleteTwoFactorByID(ctx, tf.ID, u.ID); err != nil {
+			if err := auth_service.DeleteTwoFactor(ctx, ctx.Doer, u, tf); err != nil {
 				ctx.ServerError("auth.DeleteTwoFactorByID", err)
 				return
 			}
@@ -110,7 +110,7 @@ func CreatePost(ctx *context.Context) {
 		Remarks:     form.Remarks,
 	}
 
-	if err := moderation.ReportAbuse(ctx, &report); err != nil {
+	if err := moderation_service.ReportAbuse(ctx, &report); err != nil {
 		if errors.Is(err, moderation.ErrSelfReporting) {
 			ctx.Flash.Error(ctx.Tr("moderation.reporting_failed", err))
 			ctx.Redirect(ctx.Doer.DashboardLink())
@@ -18,10 +18,12 @@ import (
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/templates"
 	"forgejo.org/modules/web"
 	shared_user "forgejo.org/routers/web/shared/user"
 	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 )
 
@@ -384,8 +386,26 @@ func ViewProject(ctx *context.Context) {
 		}
 	}
 
-	project.RenderedContent = templates.RenderMarkdownToHtml(ctx, project.Description)
 	ctx.Data["LinkedPRs"] = linkedPrsMap
+
+	issueIDs := make([]int64, 0, 10)
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, issueIDs...)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+	customFieldsMap := make(map[int64][]*api.IssueCustomFieldValue, len(customFieldValues))
+	for issueID, values := range customFieldValues {
+		customFieldsMap[issueID] = convert.ToAPIIssueCustomFieldValues(ctx, values)
+	}
+	ctx.Data["CustomFields"] = customFieldsMap
+
+	project.RenderedContent = templates.RenderMarkdownToHtml(ctx, project.Description)
 	ctx.Data["PageIsViewProjects"] = true
 	ctx.Data["CanWriteProjects"] = canWriteProjects(ctx)
 	ctx.Data["Project"] = project
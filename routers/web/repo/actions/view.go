@@ -28,6 +28,8 @@ import (
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/storage"
 	"forgejo.org/modules/templates"
@@ -183,10 +185,20 @@ type ViewRunInfo struct {
 }
 
 type ViewCurrentJob struct {
-	Title       string          `json:"title"`
-	Details     []template.HTML `json:"details"`
-	Steps       []*ViewJobStep  `json:"steps"`
-	AllAttempts []*TaskAttempt  `json:"allAttempts"`
+	Title       string            `json:"title"`
+	Details     []template.HTML   `json:"details"`
+	Steps       []*ViewJobStep    `json:"steps"`
+	AllAttempts []*TaskAttempt    `json:"allAttempts"`
+	Summary     template.HTML     `json:"summary"`
+	Annotations []*ViewAnnotation `json:"annotations"`
+}
+
+type ViewAnnotation struct {
+	Level   string `json:"level"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Path    string `json:"path"`
+	Link    string `json:"link"`
 }
 
 type ViewLogs struct {
@@ -360,10 +372,20 @@ func getViewResponse(ctx *app_context.Context, req *ViewRequest, runIndex, jobIn
 	}
 
 	resp.State.CurrentJob.Title = current.Name
-	resp.State.CurrentJob.Details = statusDiagnostics(current.Status, current, ctx.Locale)
+	var queued *actions_model.QueuedJob
+	if current.Status.IsWaiting() {
+		queuedJobs, err := actions_model.DiagnoseQueuedJobs(ctx, []*actions_model.ActionRunJob{current})
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, err.Error())
+			return nil
+		}
+		queued = queuedJobs[0]
+	}
+	resp.State.CurrentJob.Details = statusDiagnostics(current.Status, current, queued, ctx.Locale)
 
-	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0) // marshal to '[]' instead of 'null' in json
-	resp.Logs.StepsLog = make([]*ViewStepLog, 0)          // marshal to '[]' instead of 'null' in json
+	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0)          // marshal to '[]' instead of 'null' in json
+	resp.State.CurrentJob.Annotations = make([]*ViewAnnotation, 0) // marshal to '[]' instead of 'null' in json
+	resp.Logs.StepsLog = make([]*ViewStepLog, 0)                   // marshal to '[]' instead of 'null' in json
 	// As noted above with TaskID; task will be nil when the job hasn't be picked yet...
 	if task != nil {
 		taskAttempts, err := task.GetAllAttempts(ctx)
@@ -377,7 +399,7 @@ func getViewResponse(ctx *app_context.Context, req *ViewRequest, runIndex, jobIn
 				Number:            actionTask.Attempt,
 				Started:           templates.TimeSince(actionTask.Started),
 				Status:            actionTask.Status.String(),
-				StatusDiagnostics: statusDiagnostics(actionTask.Status, task.Job, ctx.Locale),
+				StatusDiagnostics: statusDiagnostics(actionTask.Status, task.Job, nil, ctx.Locale),
 			}
 		}
 		resp.State.CurrentJob.AllAttempts = allAttempts
@@ -391,6 +413,34 @@ func getViewResponse(ctx *app_context.Context, req *ViewRequest, runIndex, jobIn
 			})
 		}
 
+		summary, err := actions_model.GetTaskSummary(ctx, task.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, err.Error())
+			return nil
+		}
+		if summary != "" {
+			resp.State.CurrentJob.Summary, err = markdown.RenderString(&markup.RenderContext{
+				Links: markup.Links{
+					Base: ctx.Repo.RepoLink,
+				},
+				Metas: metas,
+				Ctx:   ctx,
+			}, summary)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, err.Error())
+				return nil
+			}
+		}
+
+		annotations, err := actions_model.GetTaskAnnotations(ctx, task.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, err.Error())
+			return nil
+		}
+		for _, a := range annotations {
+			resp.State.CurrentJob.Annotations = append(resp.State.CurrentJob.Annotations, toViewAnnotation(run, a))
+		}
+
 		for _, cursor := range req.LogCursors {
 			if !cursor.Expanded {
 				continue
@@ -461,6 +511,26 @@ func getViewResponse(ctx *app_context.Context, req *ViewRequest, runIndex, jobIn
 	return resp
 }
 
+func toViewAnnotation(run *actions_model.ActionRun, a *actions_model.ActionTaskAnnotation) *ViewAnnotation {
+	view := &ViewAnnotation{
+		Level:   string(a.Level),
+		Title:   a.Title,
+		Message: a.Message,
+		Path:    a.Path,
+	}
+	if a.Path != "" {
+		view.Link = fmt.Sprintf("%s/src/commit/%s/%s", run.Repo.Link(), url.PathEscape(a.CommitSHA), util.PathEscapeSegments(a.Path))
+		if start, end := a.Lines(); start > 0 {
+			view.Path = fmt.Sprintf("%s:%d", a.Path, start)
+			view.Link += fmt.Sprintf("#L%d", start)
+			if end > start {
+				view.Link += fmt.Sprintf("-L%d", end)
+			}
+		}
+	}
+	return view
+}
+
 // When used with the JS `linkAction` handler (typically a <button> with class="link-action" and a data-url), will cause
 // the browser to redirect to the target page.
 type redirectObject struct {
@@ -588,6 +658,7 @@ func rerunJob(ctx *app_context.Context, job *actions_model.ActionRunJob, shouldB
 	}
 
 	actions_service.CreateCommitStatus(ctx, job)
+	actions_service.NotifyWorkflowJobStatusUpdate(ctx, job)
 	return nil
 }
 
@@ -649,6 +720,7 @@ func Cancel(ctx *app_context.Context) {
 		return
 	}
 
+	var cancelled []*actions_model.ActionRunJob
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		for _, job := range jobs {
 			status := job.Status
@@ -665,11 +737,20 @@ func Cancel(ctx *app_context.Context) {
 				if n == 0 {
 					return errors.New("job has changed, try again")
 				}
+				cancelled = append(cancelled, job)
 				continue
 			}
 			if err := actions_service.StopTask(ctx, job.TaskID, actions_model.StatusCancelled); err != nil {
 				return err
 			}
+			// StopTask updated the job in the database, not this copy of it
+			job, err := actions_model.GetRunJobByID(ctx, job.ID)
+			if err != nil {
+				return err
+			}
+			if job.Status != status {
+				cancelled = append(cancelled, job)
+			}
 		}
 		return nil
 	}); err != nil {
@@ -677,7 +758,8 @@ func Cancel(ctx *app_context.Context) {
 		return
 	}
 
-	actions_service.CreateCommitStatus(ctx, jobs...)
+	actions_service.CreateCommitStatus(ctx, cancelled...)
+	actions_service.NotifyWorkflowJobStatusUpdate(ctx, cancelled...)
 
 	ctx.JSON(http.StatusOK, struct{}{})
 }
@@ -973,8 +1055,9 @@ func disableOrEnableWorkflowFile(ctx *app_context.Context, isEnable bool) {
 }
 
 // statusDiagnostics returns optional diagnostic information to display to the user. It should help the user understand
-// what the current Status means and whether an action needs to be performed, for example, approving a job.
-func statusDiagnostics(status actions_model.Status, job *actions_model.ActionRunJob, lang translation.Locale) []template.HTML {
+// what the current Status means and whether an action needs to be performed, for example, approving a job. queued is
+// how the runners match the job when it is waiting, nil otherwise.
+func statusDiagnostics(status actions_model.Status, job *actions_model.ActionRunJob, queued *actions_model.QueuedJob, lang translation.Locale) []template.HTML {
 	// Initialize as empty container for it to be serialized to an empty JSON array, not `null`.
 	diagnostics := []template.HTML{}
 
@@ -982,6 +1065,9 @@ func statusDiagnostics(status actions_model.Status, job *actions_model.ActionRun
 	case actions_model.StatusWaiting:
 		joinedLabels := strings.Join(job.RunsOn, ", ")
 		diagnostics = append(diagnostics, lang.TrPluralString(len(job.RunsOn), "actions.status.diagnostics.waiting", joinedLabels))
+		if queued != nil && queued.HasNoMatchingRunner() {
+			diagnostics = append(diagnostics, template.HTML(lang.TrString("actions.status.diagnostics.no_matching_runner")))
+		}
 	default:
 		diagnostics = append(diagnostics, template.HTML(status.LocaleString(lang)))
 	}
@@ -214,6 +214,7 @@ func baseExpectedViewResponse() *ViewResponse {
 						StatusDiagnostics: []template.HTML{"actions.status.success"},
 					},
 				},
+				Annotations: []*ViewAnnotation{},
 			},
 		},
 		Logs: ViewLogs{
@@ -573,6 +574,7 @@ func TestActionsViewStatusDiagnostics(t *testing.T) {
 		name     string
 		status   actions_model.Status
 		job      actions_model.ActionRunJob
+		queued   *actions_model.QueuedJob
 		expected []template.HTML
 	}{
 		{
@@ -608,6 +610,23 @@ func TestActionsViewStatusDiagnostics(t *testing.T) {
 				"Need approval to run workflows for fork pull request.",
 			},
 		},
+		{
+			name:   "Waiting without matching runner",
+			status: actions_model.StatusWaiting,
+			job:    actions_model.ActionRunJob{RunsOn: []string{"freebsd"}, Run: &actions_model.ActionRun{NeedApproval: false}},
+			queued: &actions_model.QueuedJob{MatchingRunners: 0},
+			expected: []template.HTML{
+				"Waiting for a runner with the following label: freebsd",
+				"No registered runner has all of these labels. The job will be canceled if none is registered in time.",
+			},
+		},
+		{
+			name:     "Waiting with an offline matching runner",
+			status:   actions_model.StatusWaiting,
+			job:      actions_model.ActionRunJob{RunsOn: []string{"freebsd"}, Run: &actions_model.ActionRun{NeedApproval: false}},
+			queued:   &actions_model.QueuedJob{MatchingRunners: 1},
+			expected: []template.HTML{"Waiting for a runner with the following label: freebsd"},
+		},
 		{
 			name:     "Running",
 			status:   actions_model.StatusRunning,
@@ -648,7 +667,7 @@ func TestActionsViewStatusDiagnostics(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			assert.Equal(t, testCase.expected, statusDiagnostics(testCase.status, &testCase.job, english))
+			assert.Equal(t, testCase.expected, statusDiagnostics(testCase.status, &testCase.job, testCase.queued, english))
 		})
 	}
 }
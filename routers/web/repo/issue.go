@@ -55,6 +55,7 @@ import (
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 	issue_service "forgejo.org/services/issue"
+	"forgejo.org/services/mergequeue"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
 
@@ -1261,7 +1262,13 @@ func NewIssuePost(ctx *context.Context) {
 		Ref:         form.Ref,
 	}
 
-	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs); err != nil {
+	if projectID > 0 && !ctx.Repo.CanRead(unit.TypeProjects) {
+		// User must also be able to see the project.
+		ctx.Error(http.StatusBadRequest, "user hasn't permissions to read projects")
+		return
+	}
+
+	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs, projectID); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			if issue.IsPull {
 				ctx.JSONError(ctx.Tr("repo.pulls.blocked_by_user"))
@@ -1277,18 +1284,6 @@ func NewIssuePost(ctx *context.Context) {
 		return
 	}
 
-	if projectID > 0 {
-		if !ctx.Repo.CanRead(unit.TypeProjects) {
-			// User must also be able to see the project.
-			ctx.Error(http.StatusBadRequest, "user hasn't permissions to read projects")
-			return
-		}
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
-			ctx.ServerError("IssueAssignOrRemoveProject", err)
-			return
-		}
-	}
-
 	log.Trace("Issue created: %d/%d", repo.ID, issue.ID)
 	if ctx.FormString("redirect_after_creation") == "project" && projectID > 0 {
 		project, err := project_model.GetProjectByID(ctx, projectID)
@@ -1506,6 +1501,13 @@ func ViewIssue(ctx *context.Context) {
 		}
 	}
 	ctx.Data["IssueWatch"] = iw
+	if ctx.Doer != nil {
+		ctx.Data["IssueReminder"], err = issues_model.GetIssueReminder(ctx, ctx.Doer.ID, issue.ID)
+		if err != nil {
+			ctx.ServerError("GetIssueReminder", err)
+			return
+		}
+	}
 	issue.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
@@ -2029,6 +2031,13 @@ func ViewIssue(ctx *context.Context) {
 			ctx.ServerError("GetScheduledMergeByPullID", err)
 			return
 		}
+
+		// Check if the pr is waiting in the merge queue
+		ctx.Data["MergeQueuePosition"], ctx.Data["MergeQueueLength"], err = mergequeue.GetMergeQueuePosition(ctx, pull)
+		if err != nil {
+			ctx.ServerError("GetMergeQueuePosition", err)
+			return
+		}
 	}
 
 	// Get Dependencies
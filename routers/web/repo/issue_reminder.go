@@ -0,0 +1,53 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/context"
+)
+
+// IssueReminder sets the reminder of the signed in user on an issue, or
+// removes it when no date is given
+func IssueReminder(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.IsSigned || !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	defer ctx.Redirect(issue.Link())
+
+	remindAt := ctx.FormString("remind_at")
+	if remindAt == "" {
+		if err := issues_model.DeleteIssueReminder(ctx, ctx.Doer.ID, issue.ID); err != nil {
+			ctx.ServerError("DeleteIssueReminder", err)
+		}
+		return
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", remindAt, time.Local)
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.issues.reminder.invalid_date"))
+		return
+	}
+	// the reminder is sent in the morning of the chosen day
+	date = date.Add(9 * time.Hour)
+	if !date.After(time.Now()) {
+		ctx.Flash.Error(ctx.Tr("repo.issues.reminder.invalid_date"))
+		return
+	}
+
+	if err := issues_model.SetIssueReminder(ctx, ctx.Doer.ID, issue.ID, timeutil.TimeStamp(date.Unix())); err != nil {
+		ctx.ServerError("SetIssueReminder", err)
+	}
+}
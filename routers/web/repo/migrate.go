@@ -176,6 +176,9 @@ func MigratePost(ctx *context.Context) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, ctxUser.ID, ctxUser.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(form.Private || setting.Repository.ForcePrivate), ctxUser.ID, ctxUser.Name) {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tpl)
@@ -21,9 +21,11 @@ import (
 	"forgejo.org/modules/markup/markdown"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 )
 
@@ -363,6 +365,23 @@ func ViewProject(ctx *context.Context) {
 	}
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 
+	issueIDs := make([]int64, 0, 10)
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, issueIDs...)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+	customFieldsMap := make(map[int64][]*api.IssueCustomFieldValue, len(customFieldValues))
+	for issueID, values := range customFieldValues {
+		customFieldsMap[issueID] = convert.ToAPIIssueCustomFieldValues(ctx, values)
+	}
+	ctx.Data["CustomFields"] = customFieldsMap
+
 	project.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
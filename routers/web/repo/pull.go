@@ -52,6 +52,7 @@ import (
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/gitdiff"
+	"forgejo.org/services/mergequeue"
 	notify_service "forgejo.org/services/notify"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
@@ -254,6 +255,9 @@ func ForkPost(ctx *context.Context) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, ctxUser.ID, ctxUser.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(forkRepo.IsPrivate), ctxUser.ID, ctxUser.Name) {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplFork)
@@ -695,12 +699,20 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 	}
 
 	if pb != nil && pb.EnableStatusCheck {
+		// a status reported for a required context by another source must not hide the one of the pinned source
+		sourceCommitStatuses, err := git_model.GetLatestCommitStatusPerSource(ctx, repo.ID, sha)
+		if err != nil {
+			ctx.ServerError("GetLatestCommitStatusPerSource", err)
+			return nil
+		}
+
 		var missingRequiredChecks []string
 		for _, requiredContext := range pb.StatusCheckContexts {
 			contextFound := false
 			matchesRequiredContext := createRequiredContextMatcher(requiredContext)
-			for _, presentStatus := range commitStatuses {
-				if matchesRequiredContext(presentStatus.Context) {
+			source := pb.GetStatusCheckSource(requiredContext)
+			for _, presentStatus := range sourceCommitStatuses {
+				if matchesRequiredContext(presentStatus.Context) && (source == nil || source.Matches(presentStatus)) {
 					contextFound = true
 					break
 				}
@@ -728,7 +740,7 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 			}
 			return false
 		}
-		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts)
+		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(sourceCommitStatuses, pb.StatusCheckContexts, pb.StatusCheckSources)
 	}
 
 	ctx.Data["HeadBranchMovedOn"] = headBranchSha != sha
@@ -1116,6 +1128,19 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 		}
 	}
 
+	if ctx.Repo.CanRead(unit.TypeActions) {
+		annotations, err := actions_model.FindPullRequestAnnotations(ctx, ctx.Repo.Repository.ID, endCommitID)
+		if err != nil {
+			ctx.ServerError("FindPullRequestAnnotations", err)
+			return
+		}
+		if err := annotations.LoadTasks(ctx); err != nil {
+			ctx.ServerError("LoadTasks", err)
+			return
+		}
+		diff.LoadAnnotations(annotations)
+	}
+
 	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pull.BaseRepoID, pull.BaseBranch)
 	if err != nil {
 		ctx.ServerError("LoadProtectedBranch", err)
@@ -1403,6 +1428,37 @@ func MergePullRequest(ctx *context.Context) {
 		message += "\n\n" + form.MergeMessageField
 	}
 
+	// admins forcing the merge bypass the merge queue like they bypass the other branch protections
+	if !form.ForceMerge {
+		inMergeQueue, err := mergequeue.IsEnabled(ctx, pr.BaseRepoID, pr.BaseBranch)
+		if err != nil {
+			ctx.ServerError("IsEnabled", err)
+			return
+		}
+		if inMergeQueue {
+			headCommitID := form.HeadCommitID
+			if headCommitID == "" {
+				headCommitID, err = ctx.Repo.GitRepo.GetRefCommitID(pr.GetGitRefName())
+				if err != nil {
+					ctx.ServerError("GetRefCommitID", err)
+					return
+				}
+			}
+			if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), headCommitID, message, form.DeleteBranchAfterMerge); err != nil {
+				if pull_model.IsErrAlreadyInMergeQueue(err) {
+					ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue.already_queued"))
+					ctx.JSONRedirect(issue.Link())
+					return
+				}
+				ctx.ServerError("AddToMergeQueue", err)
+				return
+			}
+			ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.newly_queued"))
+			ctx.JSONRedirect(issue.Link())
+			return
+		}
+	}
+
 	if form.MergeWhenChecksSucceed {
 		// delete all scheduled auto merges
 		_ = pull_model.DeleteScheduledAutoMerge(ctx, pr.ID)
@@ -1551,6 +1607,38 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch
+func RemoveFromMergeQueue(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	// the author of the pull request may take it out of the queue as well as those allowed to merge it
+	if issue.PosterID != ctx.Doer.ID {
+		allowed, err := pull_service.IsUserAllowedToMerge(ctx, issue.PullRequest, ctx.Repo.Permission, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("IsUserAllowedToMerge", err)
+			return
+		} else if !allowed {
+			ctx.NotFound("IsUserAllowedToMerge", nil)
+			return
+		}
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, issue.PullRequest); err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue.not_queued"))
+			ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
+			return
+		}
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.removed"))
+	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	if issues_model.StopwatchExists(ctx, user.ID, issue.ID) {
 		if err := issues_model.CreateOrStopIssueStopwatch(ctx, user, issue); err != nil {
@@ -244,6 +244,9 @@ func CreatePost(ctx *context.Context) {
 	if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, ctxUser.ID, ctxUser.Name) {
 		return
 	}
+	if !ctx.CheckQuota(quota_model.RepoCountSubject(form.Private || setting.Repository.ForcePrivate), ctxUser.ID, ctxUser.Name) {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplCreate)
@@ -399,6 +402,9 @@ func acceptOrRejectRepoTransfer(ctx *context.Context, accept bool) (bool, error)
 		if !ctx.CheckQuota(quota_model.LimitSubjectSizeReposAll, ctx.Doer.ID, ctx.Doer.Name) {
 			return false, nil
 		}
+		if !ctx.CheckQuota(quota_model.RepoCountSubject(ctx.Repo.Repository.IsPrivate), ctx.Doer.ID, ctx.Doer.Name) {
+			return false, nil
+		}
 
 		if ctx.Repo.GitRepo != nil {
 			ctx.Repo.GitRepo.Close()
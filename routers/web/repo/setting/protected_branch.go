@@ -15,11 +15,14 @@ import (
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/repo"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+	"forgejo.org/services/mergequeue"
 	pull_service "forgejo.org/services/pull"
 	"forgejo.org/services/repository"
 
@@ -81,6 +84,7 @@ func SettingsProtectedBranch(c *context.Context) {
 	c.Data["merge_whitelist_users"] = strings.Join(base.Int64sToStrings(rule.MergeWhitelistUserIDs), ",")
 	c.Data["approvals_whitelist_users"] = strings.Join(base.Int64sToStrings(rule.ApprovalsWhitelistUserIDs), ",")
 	c.Data["status_check_contexts"] = strings.Join(rule.StatusCheckContexts, "\n")
+	c.Data["status_check_sources"] = formatStatusCheckSources(c, rule.StatusCheckSources)
 	contexts, _ := git_model.FindRepoRecentCommitStatusContexts(c, c.Repo.Repository.ID, 7*24*time.Hour) // Find last week status check contexts
 	c.Data["recent_status_checks"] = contexts
 
@@ -100,6 +104,62 @@ func SettingsProtectedBranch(c *context.Context) {
 	c.HTML(http.StatusOK, tplProtectedBranch)
 }
 
+// formatStatusCheckSources formats the sources the required status checks are pinned to, one per line as
+// "pattern => workflow:build.yml" or "pattern => user:name"
+func formatStatusCheckSources(ctx *context.Context, sources []*git_model.StatusCheckSource) string {
+	lines := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source.Workflow != "" {
+			lines = append(lines, source.Context+" => workflow:"+source.Workflow)
+			continue
+		}
+		user, err := user_model.GetPossibleUserByID(ctx, source.UserID)
+		if err != nil {
+			log.Error("GetPossibleUserByID[%d]: %v", source.UserID, err)
+			continue
+		}
+		lines = append(lines, source.Context+" => user:"+user.Name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseStatusCheckSources parses the lines written by formatStatusCheckSources, it returns the first line
+// that could not be parsed, if any
+func parseStatusCheckSources(ctx *context.Context, text string) ([]*git_model.StatusCheckSource, string) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+	sources := make([]*git_model.StatusCheckSource, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		idx := strings.LastIndex(trimmed, "=>")
+		if idx < 0 {
+			return nil, trimmed
+		}
+		source := &git_model.StatusCheckSource{Context: strings.TrimSpace(trimmed[:idx])}
+		kind, value, ok := strings.Cut(strings.TrimSpace(trimmed[idx+2:]), ":")
+		value = strings.TrimSpace(value)
+		if !ok || source.Context == "" || value == "" {
+			return nil, trimmed
+		}
+		switch kind {
+		case "workflow":
+			source.Workflow = value
+		case "user":
+			user, err := user_model.GetUserByName(ctx, value)
+			if err != nil {
+				return nil, trimmed
+			}
+			source.UserID = user.ID
+		default:
+			return nil, trimmed
+		}
+		sources = append(sources, source)
+	}
+	return sources, ""
+}
+
 // SettingsProtectedBranchPost updates the protected branch settings
 func SettingsProtectedBranchPost(ctx *context.Context) {
 	f := web.GetForm(ctx).(*forms.ProtectBranchForm)
@@ -215,8 +275,22 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 		protectBranch.StatusCheckContexts = validPatterns
+
+		sources, invalidLine := parseStatusCheckSources(ctx, f.StatusCheckSources)
+		if invalidLine != "" {
+			ctx.Flash.Error(ctx.Tr("repo.settings.protect_invalid_status_check_source", invalidLine))
+			ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, url.QueryEscape(protectBranch.RuleName)))
+			return
+		}
+		protectBranch.StatusCheckSources = sources
+		if err := protectBranch.CheckStatusCheckSources(); err != nil {
+			ctx.Flash.Error(ctx.Tr("repo.settings.protect_invalid_status_check_source", err.Error()))
+			ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, url.QueryEscape(protectBranch.RuleName)))
+			return
+		}
 	} else {
 		protectBranch.StatusCheckContexts = nil
+		protectBranch.StatusCheckSources = nil
 	}
 
 	protectBranch.RequiredApprovals = f.RequiredApprovals
@@ -238,8 +312,9 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
 	protectBranch.ApplyToAdmins = f.ApplyToAdmins
+	protectBranch.EnableMergeQueue = f.EnableMergeQueue
 
-	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
+	err = repository.UpdateProtectBranch(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
 		TeamIDs:          whitelistTeams,
 		MergeUserIDs:     mergeWhitelistUsers,
@@ -265,6 +340,11 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		}
 	}
 
+	if err := mergequeue.StartMergeQueueChecks(ctx, ctx.Repo.Repository.ID); err != nil {
+		ctx.ServerError("StartMergeQueueChecks", err)
+		return
+	}
+
 	ctx.Flash.Success(ctx.Tr("repo.settings.update_protect_branch_success", protectBranch.RuleName))
 	ctx.Redirect(fmt.Sprintf("%s/settings/branches?rule_name=%s", ctx.Repo.RepoLink, protectBranch.RuleName))
 }
@@ -291,7 +371,7 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		return
 	}
 
-	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, ruleID); err != nil {
+	if err := repository.DeleteProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, rule); err != nil {
 		ctx.Flash.Error(ctx.Tr("repo.settings.remove_protected_branch_failed", rule.RuleName))
 		ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
 		return
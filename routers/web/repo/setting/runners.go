@@ -127,6 +127,13 @@ func Runners(ctx *context.Context) {
 		opts.WithAvailable = true
 	}
 	actions_shared.RunnersList(ctx, opts)
+	if ctx.Written() {
+		return
+	}
+	actions_shared.QueuedJobsList(ctx, opts.OwnerID, opts.RepoID)
+	if ctx.Written() {
+		return
+	}
 
 	ctx.HTML(http.StatusOK, rCtx.RunnersTemplate)
 }
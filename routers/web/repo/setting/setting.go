@@ -16,6 +16,7 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
 	"forgejo.org/models/organization"
 	quota_model "forgejo.org/models/quota"
 	repo_model "forgejo.org/models/repo"
@@ -383,6 +384,17 @@ func SettingsPost(ctx *context.Context) {
 			ctx.RenderWithErr(ctx.Tr("form.repository_force_private"), tplSettingsOptions, form)
 			return
 		}
+		if visibilityChanged && !form.Private && !ctx.Doer.IsAdmin {
+			hidden, err := moderation_model.IsRepositoryHiddenPendingReview(ctx, repo.ID)
+			if err != nil {
+				ctx.ServerError("IsRepositoryHiddenPendingReview", err)
+				return
+			}
+			if hidden {
+				ctx.RenderWithErr(ctx.Tr("moderation.repo.hidden_pending_review"), tplSettingsOptions, form)
+				return
+			}
+		}
 
 		repo.IsPrivate = form.Private
 		if err := repo_service.UpdateRepository(ctx, repo, visibilityChanged); err != nil {
@@ -904,6 +916,13 @@ func SettingsPost(ctx *context.Context) {
 			ctx.ServerError("quota_model.EvaluateForUser", err)
 			return
 		}
+		if ok {
+			ok, err = quota_model.EvaluateForUser(ctx, newOwner.ID, quota_model.RepoCountSubject(repo.IsPrivate))
+			if err != nil {
+				ctx.ServerError("quota_model.EvaluateForUser", err)
+				return
+			}
+		}
 		if !ok {
 			ctx.RenderWithErr(ctx.Tr("repo.settings.transfer_quota_exceeded", newOwner.Name), tplSettingsOptions, &form)
 			return
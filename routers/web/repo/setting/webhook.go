@@ -178,6 +178,8 @@ func ParseHookEvent(form forms.WebhookCoreForm) *webhook_module.HookEvent {
 			ActionRunFailure:         form.ActionFailure,
 			ActionRunRecover:         form.ActionRecover,
 			ActionRunSuccess:         form.ActionSuccess,
+			Status:                   form.Status,
+			WorkflowJob:              form.WorkflowJob,
 		},
 		BranchFilter: form.BranchFilter,
 	}
@@ -291,7 +293,7 @@ func WebhookUpdate(ctx *context.Context) {
 	// pre-fill the form with the submitted data
 	w.URL = fields.URL
 	w.ContentType = fields.ContentType
-	w.Secret = fields.Secret
+	w.SetSecret(fields.Secret, setting.Webhook.SecretRotationOverlap)
 	w.HookEvent = ParseHookEvent(fields.WebhookCoreForm)
 	w.IsActive = fields.Active
 	w.HTTPMethod = fields.HTTPMethod
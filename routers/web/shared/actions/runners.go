@@ -55,6 +55,36 @@ func RunnersList(ctx *context.Context, opts actions_model.FindRunnerOptions) {
 	ctx.Data["Page"] = pager
 }
 
+// QueuedJobsList prepares data for the list of the jobs waiting for a runner
+func QueuedJobsList(ctx *context.Context, ownerID, repoID int64) {
+	jobs, count, err := db.FindAndCount[actions_model.ActionRunJob](ctx, actions_model.FindQueuedJobsOptions{
+		ListOptions: db.ListOptions{
+			Page:     1,
+			PageSize: 50,
+		},
+		OwnerID: ownerID,
+		RepoID:  repoID,
+	})
+	if err != nil {
+		ctx.ServerError("FindQueuedJobs", err)
+		return
+	}
+
+	if err := actions_model.ActionJobList(jobs).LoadRuns(ctx, true); err != nil {
+		ctx.ServerError("LoadRuns", err)
+		return
+	}
+
+	queuedJobs, err := actions_model.DiagnoseQueuedJobs(ctx, jobs)
+	if err != nil {
+		ctx.ServerError("DiagnoseQueuedJobs", err)
+		return
+	}
+
+	ctx.Data["QueuedJobs"] = queuedJobs
+	ctx.Data["QueuedJobsTotal"] = count
+}
+
 // RunnerDetails prepares data for runners edit page
 func RunnerDetails(ctx *context.Context, page int, runnerID, ownerID, repoID int64) {
 	runner, err := actions_model.GetRunnerByID(ctx, runnerID)
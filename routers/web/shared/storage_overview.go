@@ -54,6 +54,16 @@ func StorageOverview(ctx *context.Context, userID int64, tpl base.TplName) {
 			return ctx.Locale.Tr("settings.quota.sizes.assets.packages.all")
 		case quota_model.LimitSubjectSizeWiki:
 			return ctx.Locale.Tr("settings.quota.sizes.wiki")
+		case quota_model.LimitSubjectCountReposAll:
+			return ctx.Locale.Tr("settings.quota.counts.repos.all")
+		case quota_model.LimitSubjectCountReposPublic:
+			return ctx.Locale.Tr("settings.quota.counts.repos.public")
+		case quota_model.LimitSubjectCountReposPrivate:
+			return ctx.Locale.Tr("settings.quota.counts.repos.private")
+		case quota_model.LimitSubjectCountPackages:
+			return ctx.Locale.Tr("settings.quota.counts.packages")
+		case quota_model.LimitSubjectSizeGitObjectMax:
+			return ctx.Locale.Tr("settings.quota.sizes.git.object_max")
 		default:
 			panic("unrecognized subject: " + subject.String())
 		}
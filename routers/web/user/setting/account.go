@@ -7,6 +7,7 @@ package setting
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"forgejo.org/models"
@@ -199,6 +200,16 @@ func EmailPost(ctx *context.Context) {
 		ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		return
 	}
+	// Set Due Date Reminders Preference
+	if ctx.FormString("_method") == "DUE_DATE_REMINDERS" {
+		if err := user_model.SetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyDueDateReminders, strconv.FormatBool(ctx.FormBool("due_date_reminders"))); err != nil {
+			ctx.ServerError("SetUserSetting", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.due_date_reminders.success"))
+		ctx.Redirect(setting.AppSubURL + "/user/settings/account")
+		return
+	}
 
 	if ctx.HasError() {
 		loadAccountData(ctx)
@@ -343,6 +354,12 @@ func loadAccountData(ctx *context.Context) {
 	}
 	ctx.Data["Emails"] = emails
 	ctx.Data["EmailNotificationsPreference"] = ctx.Doer.EmailNotificationsPreference
+	dueDateReminders, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyDueDateReminders)
+	if err != nil {
+		ctx.ServerError("GetUserSetting", err)
+		return
+	}
+	ctx.Data["DueDateReminders"] = dueDateReminders == "true"
 	ctx.Data["ActivationsPending"] = pendingActivation
 	ctx.Data["CanAddEmails"] = !pendingActivation || !setting.Service.RegisterEmailConfirm
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
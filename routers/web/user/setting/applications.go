@@ -13,6 +13,7 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -69,7 +70,7 @@ func ApplicationsPost(ctx *context.Context) {
 		return
 	}
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
+	if err := auth_service.CreateAccessToken(ctx, ctx.Doer, t); err != nil {
 		ctx.ServerError("NewAccessToken", err)
 		return
 	}
@@ -82,7 +83,7 @@ func ApplicationsPost(ctx *context.Context) {
 
 // DeleteApplication response for delete user access token
 func DeleteApplication(ctx *context.Context) {
-	if err := auth_model.DeleteAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	if err := auth_service.DeleteAccessToken(ctx, ctx.Doer, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
 		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
@@ -93,7 +94,7 @@ func DeleteApplication(ctx *context.Context) {
 
 // RegenerateApplication response for regenerating user access token
 func RegenerateApplication(ctx *context.Context) {
-	if t, err := auth_model.RegenerateAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	if t, err := auth_service.RegenerateAccessToken(ctx, ctx.Doer, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			ctx.Flash.Error(ctx.Tr("error.not_found"))
 		} else {
@@ -25,6 +25,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/modules/web/middleware"
 	"forgejo.org/modules/web/routing"
+	"forgejo.org/routers/api/packages/terraform"
 	"forgejo.org/routers/common"
 	"forgejo.org/routers/web/admin"
 	"forgejo.org/routers/web/auth"
@@ -273,7 +274,7 @@ func Routes() *web.Route {
 	}
 
 	if setting.Metrics.Enabled {
-		prometheus.MustRegister(metrics.NewCollector())
+		prometheus.MustRegister(metrics.NewCollector(), metrics.NewActionsCollector())
 		routes.Get("/metrics", append(mid, Metrics)...)
 	}
 
@@ -478,6 +479,7 @@ func registerRoutes(m *web.Route) {
 			m.Get("/nodeinfo", NodeInfoLinks)
 			m.Get("/webfinger", WebfingerQuery)
 		}, federationEnabled)
+		m.Get("/terraform.json", packagesEnabled, terraform.ServiceDiscovery)
 		m.Get("/change-password", func(ctx *context.Context) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
@@ -801,6 +803,8 @@ func registerRoutes(m *web.Route) {
 			m.Post("/empty", admin.EmptyNotices)
 		})
 
+		m.Get("/audit", admin.AuditEvents)
+
 		m.Group("/applications", func() {
 			m.Get("", admin.Applications)
 			m.Post("/oauth2", web.Bind(forms.EditOAuth2ApplicationForm{}), admin.ApplicationsPost)
@@ -823,6 +827,7 @@ func registerRoutes(m *web.Route) {
 				m.Get("/type/{type:1|2|3|4}/id/{id}", admin.AbuseReportDetails)
 			})
 			m.Post("/abuse_reports/act", admin.PerformAction)
+			m.Post("/abuse_reports/assign", admin.AssignReports)
 		}
 	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableModeration", setting.Moderation.Enabled))
 	// ***** END: Admin *****
@@ -1223,6 +1228,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", web.Bind(structs.EditDeadlineOption{}), repo.UpdateIssueDeadline)
 				m.Post("/watch", repo.IssueWatch)
+				m.Post("/reminder", repo.IssueReminder)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
 				m.Post("/viewed-files", repo.UpdateViewedFiles)
@@ -1566,6 +1572,7 @@ func registerRoutes(m *web.Route) {
 			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/remove_from_merge_queue", context.RepoMustNotBeArchived(), repo.RemoveFromMergeQueue)
 			m.Post("/update", repo.UpdatePullRequest)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), context.RepoRef(), repo.CleanUpPullRequest)
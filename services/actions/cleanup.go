@@ -18,7 +18,7 @@ import (
 	"forgejo.org/modules/timeutil"
 )
 
-// Cleanup removes expired actions logs, data and artifacts
+// Cleanup removes expired actions logs, data and artifacts, and the ephemeral runners which are done
 func Cleanup(ctx context.Context) error {
 	// clean up expired artifacts
 	if err := CleanupArtifacts(ctx); err != nil {
@@ -30,6 +30,11 @@ func Cleanup(ctx context.Context) error {
 		return fmt.Errorf("cleanup logs: %w", err)
 	}
 
+	// clean up orphan ephemeral runners
+	if err := CleanupEphemeralRunners(ctx); err != nil {
+		return fmt.Errorf("cleanup ephemeral runners: %w", err)
+	}
+
 	return nil
 }
 
@@ -132,3 +137,9 @@ func CleanupOfflineRunners(ctx context.Context, duration time.Duration, globalOn
 	olderThan := timeutil.TimeStampNow().AddDuration(-duration)
 	return actions_model.DeleteOfflineRunners(ctx, olderThan, globalOnly)
 }
+
+// CleanupEphemeralRunners removes the ephemeral runners whose task is done and which
+// were left behind, e.g. because the task was stopped while the runner was gone
+func CleanupEphemeralRunners(ctx context.Context) error {
+	return actions_model.DeleteDoneEphemeralRunners(ctx)
+}
@@ -6,11 +6,13 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/actions"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/timeutil"
@@ -68,6 +70,7 @@ func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions) error {
 	}
 
 	CreateCommitStatus(ctx, jobs...)
+	NotifyWorkflowJobStatusUpdate(ctx, jobs...)
 
 	return nil
 }
@@ -83,8 +86,42 @@ func CancelAbandonedJobs(ctx context.Context) error {
 		return err
 	}
 
+	// explain why the waiting jobs which no registered runner could ever pick were abandoned
+	waitingJobs := make([]*actions_model.ActionRunJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Status.IsWaiting() {
+			waitingJobs = append(waitingJobs, job)
+		}
+	}
+	queuedJobs, err := actions_model.DiagnoseQueuedJobs(ctx, waitingJobs)
+	if err != nil {
+		log.Warn("diagnose abandoned jobs: %v", err)
+		return err
+	}
+	failedRuns := make(container.Set[int64])
+	for _, q := range queuedJobs {
+		if !q.HasNoMatchingRunner() || failedRuns.Contains(q.Job.RunID) {
+			continue
+		}
+		if err := q.Job.LoadRun(ctx); err != nil {
+			log.Warn("load run of abandoned job %v: %v", q.Job.ID, err)
+			continue
+		}
+		if err := FailRunPreExecutionError(ctx, q.Job.Run, actions_model.ErrorCodeNoMatchingRunner, []any{
+			q.Job.JobID,
+			strings.Join(q.Job.RunsOn, ", "),
+		}); err != nil {
+			log.Warn("fail run %v of abandoned job %v: %v", q.Job.RunID, q.Job.ID, err)
+			continue
+		}
+		failedRuns.Add(q.Job.RunID)
+	}
+
 	now := timeutil.TimeStampNow()
 	for _, job := range jobs {
+		if failedRuns.Contains(job.RunID) {
+			continue
+		}
 		job.Status = actions_model.StatusCancelled
 		job.Stopped = now
 		if err := db.WithTx(ctx, func(ctx context.Context) error {
@@ -93,8 +130,10 @@ func CancelAbandonedJobs(ctx context.Context) error {
 		}); err != nil {
 			log.Warn("cancel abandoned job %v: %v", job.ID, err)
 			// go on
+			continue
 		}
 		CreateCommitStatus(ctx, job)
+		NotifyWorkflowJobStatusUpdate(ctx, job)
 	}
 
 	return nil
@@ -141,6 +141,7 @@ func createCommitStatus(ctx context.Context, job *actions_model.ActionRunJob) er
 			Description: description,
 			Context:     ctxname,
 			CreatorID:   creator.ID,
+			WorkflowID:  run.WorkflowID,
 			State:       state,
 		}); err != nil {
 		return fmt.Errorf("NewCommitStatus: %w", err)
@@ -0,0 +1,52 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ReviewDeployment approves or rejects the deployment, the job of a rejected deployment fails
+func ReviewDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, reviewerID int64, approve bool) error {
+	if err := deployment.LoadJob(ctx); err != nil {
+		return err
+	}
+	job := deployment.Job
+
+	var rejected bool
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := actions_model.ReviewDeployment(ctx, deployment, reviewerID, approve); err != nil {
+			return err
+		}
+		if approve || job.Attempt+1 != deployment.Attempt {
+			return nil
+		}
+
+		job.Status = actions_model.StatusFailure
+		job.Stopped = timeutil.TimeStampNow()
+		n, err := UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusWaiting, "task_id": 0}, "status", "stopped")
+		rejected = n == 1
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if rejected {
+		CreateCommitStatus(ctx, job)
+		NotifyWorkflowJobStatusUpdate(ctx, job)
+		return EmitJobsIfReady(job.RunID)
+	}
+	return nil
+}
+
+// QueueDeployments lets the runners pick the jobs of the approved deployments whose wait timer is over
+func QueueDeployments(ctx context.Context) error {
+	return actions_model.QueueDeployments(ctx)
+}
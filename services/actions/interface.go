@@ -27,6 +27,8 @@ type API interface {
 	GetRegistrationToken(*context.APIContext)
 	// SearchActionRunJobs get pending Action run jobs
 	SearchActionRunJobs(*context.APIContext)
+	// ListQueuedJobs list the jobs waiting for a runner
+	ListQueuedJobs(*context.APIContext)
 	// ListRunners list runners
 	ListRunners(*context.APIContext)
 	// GetRunner get a runner
@@ -57,6 +57,10 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 	if err != nil {
 		return err
 	}
+	statuses := make(map[int64]actions_model.Status, len(jobs))
+	for _, job := range jobs {
+		statuses[job.ID] = job.Status
+	}
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		idToJobs := make(map[string][]*actions_model.ActionRunJob, len(jobs))
 		for _, job := range jobs {
@@ -106,7 +110,21 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 	}); err != nil {
 		return err
 	}
-	CreateCommitStatus(ctx, jobs...)
+
+	// Besides the resolved jobs, blocked jobs may have been expanded into new jobs or failed with the whole run; compare
+	// the jobs as committed to find the ones that changed.
+	jobs, err = db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{RunID: runID})
+	if err != nil {
+		return err
+	}
+	changed := make([]*actions_model.ActionRunJob, 0, len(jobs))
+	for _, job := range jobs {
+		if status, ok := statuses[job.ID]; !ok || status != job.Status {
+			changed = append(changed, job)
+		}
+	}
+	CreateCommitStatus(ctx, changed...)
+	NotifyWorkflowJobStatusUpdate(ctx, changed...)
 	return nil
 }
 
@@ -304,14 +322,14 @@ func tryHandleIncompleteMatrix(ctx context.Context, blockedJob *actions_model.Ac
 		// Reparsing errors are quite rare here since we were already able to parse this workflow in the past to
 		// generate `blockedJob`, but it would be possible with a remote reusable workflow if the reference disappears
 		// from the remote repo -- eg. it was `@v1` and the `v1` tag was removed.
-		if err := FailRunPreExecutionError(
+		if _, err := failRunPreExecutionError(
 			ctx,
 			blockedJob.Run,
 			actions_model.ErrorCodeJobParsingError,
 			[]any{err.Error()}); err != nil {
 			return behaviourError, fmt.Errorf("setting run into PreExecutionError state failed: %w", err)
 		}
-		// `FailRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
+		// `failRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
 		return behaviourIgnoreAllJobsInRun, nil
 	}
 
@@ -328,29 +346,32 @@ func tryHandleIncompleteMatrix(ctx context.Context, blockedJob *actions_model.Ac
 		if jobID == blockedJob.JobID {
 			if swf.IncompleteMatrix {
 				errorCode, errorDetails := persistentIncompleteMatrixError(blockedJob, swf.IncompleteMatrixNeeds)
-				if err := FailRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
+				if _, err := failRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
 					return behaviourError, fmt.Errorf("setting run into PreExecutionError state failed: %w", err)
 				}
-				// `FailRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
+				// `failRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
 				return behaviourIgnoreAllJobsInRun, nil
 			} else if swf.IncompleteRunsOn {
 				errorCode, errorDetails := persistentIncompleteRunsOnError(blockedJob, swf.IncompleteRunsOnNeeds, swf.IncompleteRunsOnMatrix)
-				if err := FailRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
+				if _, err := failRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
 					return behaviourError, fmt.Errorf("setting run into PreExecutionError state failed: %w", err)
 				}
-				// `FailRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
+				// `failRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
 				return behaviourIgnoreAllJobsInRun, nil
 			} else if swf.IncompleteWith {
 				errorCode, errorDetails := persistentIncompleteWithError(blockedJob, swf.IncompleteWithNeeds, swf.IncompleteWithMatrix)
-				if err := FailRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
+				if _, err := failRunPreExecutionError(ctx, blockedJob.Run, errorCode, errorDetails); err != nil {
 					return behaviourError, fmt.Errorf("setting run into PreExecutionError state failed: %w", err)
 				}
-				// `FailRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
+				// `failRunPreExecutionError` will mark all the pending runs in the job failed; ignore all of them.
 				return behaviourIgnoreAllJobsInRun, nil
 			}
 		}
 	}
 
+	// The jobs expanded from the blocked job deploy to its environment
+	blockedJob.Run.JobEnvironments = map[string]string{blockedJob.JobID: blockedJob.Environment}
+
 	err = db.WithTx(ctx, func(ctx context.Context) error {
 		err := actions_model.InsertRunJobs(ctx, blockedJob.Run, newJobWorkflows)
 		if err != nil {
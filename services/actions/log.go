@@ -6,6 +6,9 @@ package actions
 import (
 	"context"
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	actions_model "forgejo.org/models/actions"
@@ -14,6 +17,9 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	runnerv1 "code.forgejo.org/forgejo/actions-proto/runner/v1"
 )
 
 var (
@@ -94,3 +100,54 @@ func TransferLogs(ctx context.Context, logFilename string) (func(), error) {
 	}
 	return actions.TransferLogs(ctx, logFilename)
 }
+
+// ProcessWorkflowCommands saves the step summaries and the annotations written by the steps of the task
+// in the rows of its log. The summaries and annotations which exceed the limits of the task are dropped.
+func ProcessWorkflowCommands(ctx context.Context, task *actions_model.ActionTask, rows []*runnerv1.LogRow) error {
+	for _, row := range rows {
+		cmd, ok := actions.ParseWorkflowCommand(row.Content)
+		if !ok {
+			continue
+		}
+
+		if cmd.Name == actions.WorkflowCommandStepSummary {
+			if appended, err := actions_model.AppendTaskSummary(ctx, task, cmd.Value); err != nil {
+				return fmt.Errorf("AppendTaskSummary: %w", err)
+			} else if !appended {
+				log.Debug("dropped a step summary of task %d: the summary is too large", task.ID)
+			}
+			continue
+		}
+
+		if inserted, err := actions_model.InsertTaskAnnotation(ctx, newTaskAnnotation(task, cmd)); err != nil {
+			return fmt.Errorf("InsertTaskAnnotation: %w", err)
+		} else if !inserted {
+			log.Debug("dropped an annotation of task %d: the task has too many", task.ID)
+		}
+	}
+	return nil
+}
+
+func newTaskAnnotation(task *actions_model.ActionTask, cmd *actions.WorkflowCommand) *actions_model.ActionTaskAnnotation {
+	annotation := &actions_model.ActionTaskAnnotation{
+		TaskID:    task.ID,
+		RepoID:    task.RepoID,
+		CommitSHA: task.CommitSHA,
+		Level:     actions_model.AnnotationLevel(cmd.Name),
+		Title:     util.TruncateRunes(cmd.Properties["title"], 255),
+		Message:   cmd.Value,
+	}
+	if file := cmd.Properties["file"]; file != "" {
+		// the paths are relative to the root of the repository
+		annotation.Path = strings.TrimPrefix(path.Clean("/"+file), "/")
+	}
+	number := func(key string) int64 {
+		n, _ := strconv.ParseInt(cmd.Properties[key], 10, 64)
+		return max(n, 0)
+	}
+	annotation.StartLine = number("line")
+	annotation.EndLine = number("endLine")
+	annotation.StartColumn = number("col")
+	annotation.EndColumn = number("endColumn")
+	return annotation
+}
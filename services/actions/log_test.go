@@ -13,6 +13,7 @@ import (
 	"forgejo.org/modules/test"
 	"forgejo.org/modules/timeutil"
 
+	runnerv1 "code.forgejo.org/forgejo/actions-proto/runner/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"xorm.io/builder"
@@ -72,3 +73,38 @@ func TestServicesActions_transferLingeringLogs(t *testing.T) {
 	assert.True(t, unittest.BeanExists(t, &actions_model.ActionTask{ID: recentID}, builder.Eq{"log_in_storage": false}))
 	assert.True(t, unittest.BeanExists(t, &actions_model.ActionTask{ID: inStorageID}, builder.Eq{"log_in_storage": true}))
 }
+
+func TestProcessWorkflowCommands(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+
+	require.NoError(t, ProcessWorkflowCommands(t.Context(), task, []*runnerv1.LogRow{
+		{Content: "go build ./..."},
+		{Content: "::error file=./cmd/../main.go,line=3,endLine=5,col=-1,title=Build::undefined: x"},
+		{Content: "::step-summary::# Build%0A%0Aok"},
+		{Content: "::set-output name=x::y"},
+		{Content: "⚠ ::notice::done"},
+	}))
+
+	annotations, err := actions_model.GetTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+
+	assert.Equal(t, actions_model.AnnotationLevelError, annotations[0].Level)
+	assert.Equal(t, "Build", annotations[0].Title)
+	assert.Equal(t, "undefined: x", annotations[0].Message)
+	assert.Equal(t, "main.go", annotations[0].Path)
+	assert.EqualValues(t, 3, annotations[0].StartLine)
+	assert.EqualValues(t, 5, annotations[0].EndLine)
+	assert.EqualValues(t, 0, annotations[0].StartColumn)
+	assert.Equal(t, task.RepoID, annotations[0].RepoID)
+	assert.Equal(t, task.CommitSHA, annotations[0].CommitSHA)
+
+	assert.Equal(t, actions_model.AnnotationLevelNotice, annotations[1].Level)
+	assert.Equal(t, "done", annotations[1].Message)
+	assert.Empty(t, annotations[1].Path)
+
+	summary, err := actions_model.GetTaskSummary(t.Context(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "# Build\n\nok", summary)
+}
@@ -6,6 +6,7 @@ package actions
 import (
 	"context"
 	"errors"
+	"slices"
 
 	actions_model "forgejo.org/models/actions"
 	issues_model "forgejo.org/models/issues"
@@ -54,7 +55,7 @@ func (n *actionsNotifier) NewIssue(ctx context.Context, issue *issues_model.Issu
 	newNotifyInputFromIssue(issue, webhook_module.HookEventIssues).WithPayload(&api.IssuePayload{
 		Action:     api.HookIssueOpened,
 		Index:      issue.Index,
-		Issue:      convert.ToAPIIssue(ctx, issue.Poster, issue),
+		Issue:      convert.ToPayloadIssue(ctx, issue.Poster, issue),
 		Repository: convert.ToRepo(ctx, issue.Repo, permission),
 		Sender:     convert.ToUser(ctx, issue.Poster, nil),
 	}).Notify(withMethod(ctx, "NewIssue"))
@@ -104,7 +105,7 @@ func (n *actionsNotifier) issueChange(ctx context.Context, doer *user_model.User
 		WithPayload(&api.IssuePayload{
 			Action:     api.HookIssueEdited,
 			Index:      issue.Index,
-			Issue:      convert.ToAPIIssue(ctx, doer, issue),
+			Issue:      convert.ToPayloadIssue(ctx, doer, issue),
 			Repository: convert.ToRepo(ctx, issue.Repo, permission),
 			Sender:     convert.ToUser(ctx, doer, nil),
 		}).
@@ -142,7 +143,7 @@ func (n *actionsNotifier) IssueChangeStatus(ctx context.Context, doer *user_mode
 	}
 	apiIssue := &api.IssuePayload{
 		Index:      issue.Index,
-		Issue:      convert.ToAPIIssue(ctx, doer, issue),
+		Issue:      convert.ToPayloadIssue(ctx, doer, issue),
 		Repository: convert.ToRepo(ctx, issue.Repo, permission),
 		Sender:     convert.ToUser(ctx, doer, nil),
 	}
@@ -255,7 +256,7 @@ func notifyIssueChange(ctx context.Context, doer *user_model.User, issue *issues
 		WithPayload(&api.IssuePayload{
 			Action:     action,
 			Index:      issue.Index,
-			Issue:      convert.ToAPIIssue(ctx, doer, issue),
+			Issue:      convert.ToPayloadIssue(ctx, doer, issue),
 			Repository: convert.ToRepo(ctx, issue.Repo, permission),
 			Sender:     convert.ToUser(ctx, doer, nil),
 			Label:      apiLabel,
@@ -320,7 +321,7 @@ func notifyIssueCommentChange(ctx context.Context, doer *user_model.User, commen
 
 	payload := &api.IssueCommentPayload{
 		Action:     action,
-		Issue:      convert.ToAPIIssue(ctx, doer, comment.Issue),
+		Issue:      convert.ToPayloadIssue(ctx, doer, comment.Issue),
 		Comment:    convert.ToAPIComment(ctx, comment.Issue.Repo, comment),
 		Repository: convert.ToRepo(ctx, comment.Issue.Repo, permission),
 		Sender:     convert.ToUser(ctx, doer, nil),
@@ -842,10 +843,17 @@ func UpdateRunJob(ctx context.Context, job *actions_model.ActionRunJob, cond bui
 		return 0, err
 	}
 
+	toWaiting := slices.Contains(cols, "status") && job.Status.IsWaiting()
 	affected, err := actions_model.UpdateRunJobWithoutNotification(ctx, job, cond, cols...)
 	if err != nil {
 		return affected, err
 	}
+	if toWaiting && job.Status.IsDone() {
+		// the job was not allowed to deploy to its environment, the jobs needing it can be resolved
+		if err := EmitJobsIfReady(runID); err != nil {
+			return affected, err
+		}
+	}
 
 	updatedRun, err := actions_model.GetRunByID(ctx, runID)
 	if err != nil {
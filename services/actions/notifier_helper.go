@@ -431,6 +431,8 @@ func handleWorkflows(
 				jobs = []*jobparser.SingleWorkflow{{
 					Name: dwf.EntryName,
 				}}
+			} else if run.JobEnvironments, err = actions_module.JobEnvironments(dwf.Content); err != nil {
+				log.Error("JobEnvironments: %v", err)
 			}
 		}
 
@@ -451,7 +453,9 @@ func handleWorkflows(
 				return err
 			}
 			if errorCode != 0 {
-				return FailRunPreExecutionError(ctx, run, errorCode, errorDetails)
+				// the jobs are notified with the others once the run is committed
+				_, err := failRunPreExecutionError(ctx, run, errorCode, errorDetails)
+				return err
 			}
 			return nil
 		})
@@ -466,6 +470,7 @@ func handleWorkflows(
 			continue
 		}
 		CreateCommitStatus(ctx, alljobs...)
+		NotifyWorkflowJobStatusUpdate(ctx, alljobs...)
 
 		if err := consistencyCheckRun(ctx, run); err != nil {
 			log.Error("SanityCheckRun: %v", err)
@@ -13,41 +13,66 @@ import (
 	"forgejo.org/modules/timeutil"
 )
 
-func killRun(ctx context.Context, run *actions_model.ActionRun, newStatus actions_model.Status) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
-		jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
-		if err != nil {
-			return err
+// stopRunJobs stops every job of the run that is not done yet and returns the
+// jobs whose status changed. The caller notifies them once the transaction is
+// committed.
+func stopRunJobs(ctx context.Context, run *actions_model.ActionRun, newStatus actions_model.Status) ([]*actions_model.ActionRunJob, error) {
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		return nil, err
+	}
+	stopped := make([]*actions_model.ActionRunJob, 0, len(jobs))
+	for _, job := range jobs {
+		oldStatus := job.Status
+		if oldStatus.IsDone() {
+			continue
 		}
-		for _, job := range jobs {
-			oldStatus := job.Status
-			if oldStatus.IsDone() {
-				continue
-			}
-			if job.TaskID == 0 {
-				job.Status = newStatus
-				job.Stopped = timeutil.TimeStampNow()
-				_, err := actions_model.UpdateRunJobWithoutNotification(ctx, job, nil, "status", "stopped")
-				if err != nil {
-					return err
-				}
-				continue
-			}
-			if err := StopTask(ctx, job.TaskID, newStatus); err != nil {
-				return err
+		if job.TaskID == 0 {
+			job.Status = newStatus
+			job.Stopped = timeutil.TimeStampNow()
+			_, err := actions_model.UpdateRunJobWithoutNotification(ctx, job, nil, "status", "stopped")
+			if err != nil {
+				return nil, err
 			}
+			stopped = append(stopped, job)
+			continue
+		}
+		if err := StopTask(ctx, job.TaskID, newStatus); err != nil {
+			return nil, err
+		}
+		// StopTask updated the job in the database, not this copy of it
+		job, err = actions_model.GetRunJobByID(ctx, job.ID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status != oldStatus {
+			stopped = append(stopped, job)
 		}
+	}
 
-		if run.NeedApproval {
-			if err := actions_model.UpdateRunApprovalByID(ctx, run.ID, actions_model.DoesNotNeedApproval, 0); err != nil {
-				return err
-			}
+	if run.NeedApproval {
+		if err := actions_model.UpdateRunApprovalByID(ctx, run.ID, actions_model.DoesNotNeedApproval, 0); err != nil {
+			return nil, err
 		}
+	}
 
-		CreateCommitStatus(ctx, jobs...)
+	return stopped, nil
+}
 
-		return nil
-	})
+func killRun(ctx context.Context, run *actions_model.ActionRun, newStatus actions_model.Status) error {
+	var stopped []*actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		stopped, err = stopRunJobs(ctx, run, newStatus)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, stopped...)
+	NotifyWorkflowJobStatusUpdate(ctx, stopped...)
+
+	return nil
 }
 
 func CancelRun(ctx context.Context, run *actions_model.ActionRun) error {
@@ -55,7 +80,8 @@ func CancelRun(ctx context.Context, run *actions_model.ActionRun) error {
 }
 
 func ApproveRun(ctx context.Context, run *actions_model.ActionRun, doerID int64) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	var approved []*actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
 		if err != nil {
 			return err
@@ -67,21 +93,44 @@ func ApproveRun(ctx context.Context, run *actions_model.ActionRun, doerID int64)
 				if err != nil {
 					return err
 				}
+				approved = append(approved, job)
 			}
 		}
-		CreateCommitStatus(ctx, jobs...)
 
 		return actions_model.UpdateRunApprovalByID(ctx, run.ID, actions_model.DoesNotNeedApproval, doerID)
-	})
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, approved...)
+	NotifyWorkflowJobStatusUpdate(ctx, approved...)
+
+	return nil
 }
 
 func FailRunPreExecutionError(ctx context.Context, run *actions_model.ActionRun, errorCode actions_model.PreExecutionError, details []any) error {
+	stopped, err := failRunPreExecutionError(ctx, run, errorCode, details)
+	if err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, stopped...)
+	NotifyWorkflowJobStatusUpdate(ctx, stopped...)
+
+	return nil
+}
+
+// failRunPreExecutionError is FailRunPreExecutionError for callers that are
+// already in a transaction: it returns the jobs it stopped instead of
+// notifying them.
+func failRunPreExecutionError(ctx context.Context, run *actions_model.ActionRun, errorCode actions_model.PreExecutionError, details []any) ([]*actions_model.ActionRunJob, error) {
 	if run.PreExecutionErrorCode != 0 {
 		// Already have one error; keep it.
-		return nil
+		return nil, nil
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	var stopped []*actions_model.ActionRunJob
+	err := db.WithTx(ctx, func(ctx context.Context) error {
 		run.Status = actions_model.StatusFailure
 		run.PreExecutionErrorCode = errorCode
 		run.PreExecutionErrorDetails = details
@@ -91,8 +140,11 @@ func FailRunPreExecutionError(ctx context.Context, run *actions_model.ActionRun,
 		}
 
 		// Also mark every pending job as Failed so nothing remains in a waiting/blocked state.
-		return killRun(ctx, run, actions_model.StatusFailure)
+		var err error
+		stopped, err = stopRunJobs(ctx, run, actions_model.StatusFailure)
+		return err
 	})
+	return stopped, err
 }
 
 // Perform pre-execution checks that would affect the ability for a job to reach an executing stage.
@@ -113,6 +165,12 @@ func consistencyCheckRun(ctx context.Context, run *actions_model.ActionRun) erro
 			break
 		}
 	}
+	for _, job := range jobs {
+		if job.Environment != "" && job.Status.IsDone() {
+			// the job was not allowed to deploy to its environment, the jobs needing it can be resolved
+			return EmitJobsIfReady(run.ID)
+		}
+	}
 	return nil
 }
 
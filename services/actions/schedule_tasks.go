@@ -187,6 +187,10 @@ func CreateScheduleTask(ctx context.Context, cron *actions_model.ActionSchedule)
 	if err != nil {
 		return err
 	}
+	run.JobEnvironments, err = actions_module.JobEnvironments(cron.Content)
+	if err != nil {
+		return err
+	}
 
 	// Insert the action run and its associated jobs into the database
 	if err := actions_model.InsertRun(ctx, run, workflows); err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 
 	actions_model "forgejo.org/models/actions"
 	secret_model "forgejo.org/models/secret"
@@ -58,6 +59,20 @@ func getSecretsOfJob(ctx context.Context, job *actions_model.ActionRunJob) (map[
 		// FetchActionSecrets logs all errors to the server log.
 		return nil, errors.New("failure to fetch secrets")
 	}
+	if job.Environment == "" {
+		return jobSecrets, nil
+	}
+
+	// the secrets of the environment override the secrets of the repository and its owner
+	env, err := actions_model.GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("failure to get environment: %w", err)
+	}
+	envSecrets, err := secret_model.FetchEnvironmentSecrets(ctx, job.RepoID, env.ID)
+	if err != nil {
+		return nil, errors.New("failure to fetch environment secrets")
+	}
+	maps.Copy(jobSecrets, envSecrets)
 	return jobSecrets, nil
 }
 
@@ -10,21 +10,40 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
+	"forgejo.org/modules/metrics"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
 
 	runnerv1 "code.forgejo.org/forgejo/actions-proto/runner/v1"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"xorm.io/builder"
 )
 
 func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv1.Task, bool, error) {
 	var (
-		task *runnerv1.Task
-		job  *actions_model.ActionRunJob
+		task        *runnerv1.Task
+		job         *actions_model.ActionRunJob
+		queuedSince timeutil.TimeStamp
 	)
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if runner.Ephemeral {
+			// an ephemeral runner picks exactly one task in its lifetime: lock its row so
+			// that concurrent fetches of the same runner cannot both find no task
+			if has, err := db.GetEngine(ctx).ID(runner.ID).ForUpdate().Get(&actions_model.ActionRunner{}); err != nil {
+				return fmt.Errorf("lock ephemeral runner: %w", err)
+			} else if !has {
+				return nil
+			}
+			picked, err := db.Exist[actions_model.ActionTask](ctx, builder.Eq{"runner_id": runner.ID})
+			if err != nil {
+				return fmt.Errorf("find task of ephemeral runner: %w", err)
+			} else if picked {
+				return nil
+			}
+		}
+
 		t, ok, err := actions_model.CreateTaskForRunner(ctx, runner)
 		if err != nil {
 			return fmt.Errorf("CreateTaskForRunner: %w", err)
@@ -37,15 +56,16 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("task LoadAttributes: %w", err)
 		}
 		job = t.Job
+		queuedSince = t.QueuedSince
 
 		secrets, err := getSecretsOfTask(ctx, t)
 		if err != nil {
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
 
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -77,6 +97,8 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 	}
 
 	CreateCommitStatus(ctx, job)
+	NotifyWorkflowJobStatusUpdate(ctx, job)
+	metrics.ObserveActionsJobQueued(job.RunsOn, job.Started.AsTime().Sub(queuedSince.AsTime()))
 
 	return task, true, nil
 }
@@ -146,6 +168,9 @@ func StopTask(ctx context.Context, taskID int64, status actions_model.Status) er
 	if err := task.LoadAttributes(ctx); err != nil {
 		return err
 	}
+	db.AfterTx(ctx, func() {
+		observeJobDone(task.Job)
+	})
 
 	for _, step := range task.Steps {
 		if !step.Status.IsDone() {
@@ -172,13 +197,13 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 		stepStates[v.Id] = v
 	}
 
-	ctx, commiter, err := db.TxContext(ctx)
+	txCtx, commiter, err := db.TxContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer commiter.Close()
 
-	e := db.GetEngine(ctx)
+	e := db.GetEngine(txCtx)
 
 	task := &actions_model.ActionTask{}
 	if has, err := e.ID(state.Id).Get(task); err != nil {
@@ -198,10 +223,10 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 	if state.Result != runnerv1.Result_RESULT_UNSPECIFIED {
 		task.Status = actions_model.Status(state.Result)
 		task.Stopped = timeutil.TimeStamp(state.StoppedAt.AsTime().Unix())
-		if err := actions_model.UpdateTask(ctx, task, "status", "stopped"); err != nil {
+		if err := actions_model.UpdateTask(txCtx, task, "status", "stopped"); err != nil {
 			return nil, err
 		}
-		if _, err := UpdateRunJob(ctx, &actions_model.ActionRunJob{
+		if _, err := UpdateRunJob(txCtx, &actions_model.ActionRunJob{
 			ID:      task.JobID,
 			Status:  task.Status,
 			Stopped: task.Stopped,
@@ -211,12 +236,12 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 	} else {
 		// Force update ActionTask.Updated to avoid the task being judged as a zombie task
 		task.Updated = timeutil.TimeStampNow()
-		if err := actions_model.UpdateTask(ctx, task, "updated"); err != nil {
+		if err := actions_model.UpdateTask(txCtx, task, "updated"); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := task.LoadAttributes(ctx); err != nil {
+	if err := task.LoadAttributes(txCtx); err != nil {
 		return nil, err
 	}
 
@@ -243,6 +268,12 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 		return nil, err
 	}
 
+	if task.Status.IsDone() {
+		// only the first final state is a change, the runner may resend it
+		NotifyWorkflowJobStatusUpdate(ctx, task.Job)
+		observeJobDone(task.Job)
+	}
+
 	return task, nil
 }
 
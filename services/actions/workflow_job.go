@@ -0,0 +1,33 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/metrics"
+	notify_service "forgejo.org/services/notify"
+)
+
+// NotifyWorkflowJobStatusUpdate notifies that the status of the given jobs changed.
+// Like CreateCommitStatus, it won't return an error but will log it.
+func NotifyWorkflowJobStatusUpdate(ctx context.Context, jobs ...*actions_model.ActionRunJob) {
+	for _, job := range jobs {
+		if err := job.LoadAttributes(ctx); err != nil {
+			log.Error("Failed to load the run of job %d: %v", job.ID, err)
+			continue
+		}
+		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job)
+	}
+}
+
+// observeJobDone records the duration of a job that just ended. The jobs that
+// never started, e.g. cancelled while waiting, are not observed.
+func observeJobDone(job *actions_model.ActionRunJob) {
+	if job.Started > 0 && job.Stopped >= job.Started {
+		metrics.ObserveActionsJobDone(job.RunsOn, job.Status.String(), job.Duration())
+	}
+}
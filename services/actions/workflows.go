@@ -187,6 +187,10 @@ func (entry *Workflow) Dispatch(ctx context.Context, inputGetter InputValueGette
 	if err != nil {
 		return nil, nil, err
 	}
+	run.JobEnvironments, err = actions.JobEnvironments(content)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	if err := actions_model.InsertRun(ctx, run, jobs); err != nil {
 		return run, jobNames, err
@@ -0,0 +1,132 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package audit records the security relevant changes in the audit log
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+)
+
+// Target is the object an audit event is about
+type Target struct {
+	Type audit_model.TargetType
+	ID   int64
+	Name string
+}
+
+// UserTarget returns the target for a user
+func UserTarget(u *user_model.User) Target {
+	return Target{Type: audit_model.TargetUser, ID: u.ID, Name: u.Name}
+}
+
+// RepositoryTarget returns the target for a repository
+func RepositoryTarget(repo *repo_model.Repository) Target {
+	return Target{Type: audit_model.TargetRepository, ID: repo.ID, Name: repo.FullName()}
+}
+
+// TeamTarget returns the target for a team
+func TeamTarget(team *organization.Team) Target {
+	return Target{Type: audit_model.TargetTeam, ID: team.ID, Name: team.Name}
+}
+
+// BranchProtectionTarget returns the target for a branch protection rule
+func BranchProtectionTarget(id int64, ruleName string) Target {
+	return Target{Type: audit_model.TargetBranchProtection, ID: id, Name: ruleName}
+}
+
+// BranchProtectionDetail summarizes the settings of a branch protection rule
+func BranchProtectionDetail(pb *git_model.ProtectedBranch) string {
+	return fmt.Sprintf("push: %t, push whitelist: %t, required approvals: %d, status checks: %v, signed commits: %t, apply to admins: %t",
+		pb.CanPush, pb.EnableWhitelist, pb.RequiredApprovals, pb.StatusCheckContexts, pb.RequireSignedCommits, pb.ApplyToAdmins)
+}
+
+// DeployKeyTarget returns the target for a deploy key
+func DeployKeyTarget(key *asymkey_model.DeployKey) Target {
+	return Target{Type: audit_model.TargetDeployKey, ID: key.ID, Name: key.Name}
+}
+
+// DeployKeyDetail describes the access of a deploy key
+func DeployKeyDetail(key *asymkey_model.DeployKey) string {
+	return "access: " + key.Mode.String() + ", fingerprint: " + key.Fingerprint
+}
+
+// AccessTokenTarget returns the target for an access token
+func AccessTokenTarget(token *auth_model.AccessToken) Target {
+	return Target{Type: audit_model.TargetAccessToken, ID: token.ID, Name: token.Name}
+}
+
+// AuthSourceTarget returns the target for an authentication source
+func AuthSourceTarget(source *auth_model.Source) Target {
+	return Target{Type: audit_model.TargetAuthSource, ID: source.ID, Name: source.Name}
+}
+
+// remoteAddresser is implemented by the contexts of the web and API handlers
+type remoteAddresser interface {
+	RemoteAddr() string
+}
+
+func record(ctx context.Context, doer *user_model.User, action audit_model.Action, ownerID, repoID int64, target Target, detail string) {
+	event := &audit_model.Event{
+		Action:     action,
+		OwnerID:    ownerID,
+		RepoID:     repoID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Detail:     detail,
+	}
+	if doer != nil {
+		event.DoerID = doer.ID
+		event.DoerName = doer.Name
+	}
+	if target.Type == audit_model.TargetUser && target.Name == "" && target.ID > 0 {
+		if u, err := user_model.GetPossibleUserByID(ctx, target.ID); err == nil {
+			event.TargetName = u.Name
+		}
+	}
+	// the address is only known when the event is recorded by a request handler
+	if r, ok := ctx.(remoteAddresser); ok {
+		event.IPAddress = r.RemoteAddr()
+	}
+
+	// the audited change already happened, failing to record it must not fail the request
+	if err := audit_model.InsertEvent(ctx, event); err != nil {
+		log.Error("InsertEvent [action: %s, doer: %d, target: %s %d]: %v", action, event.DoerID, target.Type, target.ID, err)
+	}
+}
+
+// RecordInstance records an event that belongs to the instance, like a change of an authentication source
+func RecordInstance(ctx context.Context, doer *user_model.User, action audit_model.Action, target Target, detail string) {
+	record(ctx, doer, action, 0, 0, target, detail)
+}
+
+// RecordOwner records an event that belongs to a user account or an organization
+func RecordOwner(ctx context.Context, doer *user_model.User, ownerID int64, action audit_model.Action, target Target, detail string) {
+	record(ctx, doer, action, ownerID, 0, target, detail)
+}
+
+// RecordAdminChange records the grant or revocation of the administrator privileges of a user, if they changed
+func RecordAdminChange(ctx context.Context, doer, u *user_model.User, wasAdmin bool) {
+	switch {
+	case u.IsAdmin && !wasAdmin:
+		RecordOwner(ctx, doer, u.ID, audit_model.ActionUserAdminGrant, UserTarget(u), "")
+	case !u.IsAdmin && wasAdmin:
+		RecordOwner(ctx, doer, u.ID, audit_model.ActionUserAdminRevoke, UserTarget(u), "")
+	}
+}
+
+// RecordRepo records an event that belongs to a repository
+func RecordRepo(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, action audit_model.Action, target Target, detail string) {
+	record(ctx, doer, action, repo.OwnerID, repo.ID, target, detail)
+}
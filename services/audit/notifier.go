@@ -0,0 +1,51 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&auditNotifier{})
+}
+
+type auditNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &auditNotifier{}
+
+func (n *auditNotifier) AdoptRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoCreate, RepositoryTarget(repo), "adopted")
+}
+
+func (n *auditNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoCreate, RepositoryTarget(repo), "")
+}
+
+func (n *auditNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoCreate, RepositoryTarget(repo), "migrated")
+}
+
+func (n *auditNotifier) ForkRepository(ctx context.Context, doer *user_model.User, oldRepo, repo *repo_model.Repository) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoCreate, RepositoryTarget(repo), "fork of "+oldRepo.FullName())
+}
+
+func (n *auditNotifier) DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoDelete, RepositoryTarget(repo), "")
+}
+
+func (n *auditNotifier) RenameRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldRepoName string) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoRename, RepositoryTarget(repo), "renamed from "+oldRepoName)
+}
+
+func (n *auditNotifier) TransferRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldOwnerName string) {
+	RecordRepo(ctx, doer, repo, audit_model.ActionRepoTransfer, RepositoryTarget(repo), "transferred from "+oldOwnerName)
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// CreateAccessToken creates a new access token and records it in the audit log
+func CreateAccessToken(ctx context.Context, doer *user_model.User, t *auth_model.AccessToken) error {
+	if err := auth_model.NewAccessToken(ctx, t); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, t.UID, audit_model.ActionAccessTokenCreate, audit_service.AccessTokenTarget(t), "scope: "+string(t.Scope))
+	return nil
+}
+
+// DeleteAccessToken deletes an access token of a user and records it in the audit log
+func DeleteAccessToken(ctx context.Context, doer *user_model.User, id, userID int64) error {
+	if err := auth_model.DeleteAccessTokenByID(ctx, id, userID); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, userID, audit_model.ActionAccessTokenDelete, audit_service.Target{Type: audit_model.TargetAccessToken, ID: id}, "")
+	return nil
+}
+
+// RegenerateAccessToken replaces the secret of an access token of a user and records it in the audit log
+func RegenerateAccessToken(ctx context.Context, doer *user_model.User, id, userID int64) (*auth_model.AccessToken, error) {
+	t, err := auth_model.RegenerateAccessTokenByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.RecordOwner(ctx, doer, userID, audit_model.ActionAccessTokenCreate, audit_service.AccessTokenTarget(t), "regenerated, scope: "+string(t.Scope))
+	return t, nil
+}
@@ -5,14 +5,35 @@ package auth
 
 import (
 	"context"
+	"fmt"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
 )
 
+// CreateSource inserts a AuthSource in the DB and records it in the audit log.
+func CreateSource(ctx context.Context, doer *user_model.User, source *auth.Source) error {
+	if err := auth.CreateSource(ctx, source); err != nil {
+		return err
+	}
+	audit_service.RecordInstance(ctx, doer, audit_model.ActionAuthSourceCreate, audit_service.AuthSourceTarget(source), source.TypeName())
+	return nil
+}
+
+// UpdateSource updates a AuthSource record in DB and records it in the audit log.
+func UpdateSource(ctx context.Context, doer *user_model.User, source *auth.Source) error {
+	if err := auth.UpdateSource(ctx, source); err != nil {
+		return err
+	}
+	audit_service.RecordInstance(ctx, doer, audit_model.ActionAuthSourceUpdate, audit_service.AuthSourceTarget(source), fmt.Sprintf("active: %t", source.IsActive))
+	return nil
+}
+
 // DeleteSource deletes a AuthSource record in DB.
-func DeleteSource(ctx context.Context, source *auth.Source) error {
+func DeleteSource(ctx context.Context, doer *user_model.User, source *auth.Source) error {
 	count, err := db.GetEngine(ctx).Count(&user_model.User{LoginSource: source.ID})
 	if err != nil {
 		return err
@@ -37,6 +58,9 @@ func DeleteSource(ctx context.Context, source *auth.Source) error {
 		}
 	}
 
-	_, err = db.GetEngine(ctx).ID(source.ID).Delete(new(auth.Source))
-	return err
+	if _, err = db.GetEngine(ctx).ID(source.ID).Delete(new(auth.Source)); err != nil {
+		return err
+	}
+	audit_service.RecordInstance(ctx, doer, audit_model.ActionAuthSourceDelete, audit_service.AuthSourceTarget(source), "")
+	return nil
 }
@@ -4,10 +4,12 @@
 package ldap
 
 import (
+	"encoding/base64"
 	"strings"
 
 	"forgejo.org/models/auth"
 	"forgejo.org/modules/json"
+	"forgejo.org/modules/keying"
 	"forgejo.org/modules/secret"
 	"forgejo.org/modules/setting"
 )
@@ -61,6 +63,10 @@ type Source struct {
 	authSource *auth.Source
 }
 
+// bindPasswordAdditionalData binds the encrypted bind password to the configuration field,
+// the ID of the source isn't known yet when it is first encrypted.
+var bindPasswordAdditionalData = []byte("cfg:BindPasswordEncrypt")
+
 // FromDB fills up a LDAPConfig from serialized format.
 func (source *Source) FromDB(bs []byte) error {
 	err := json.UnmarshalHandleDoubleEncode(bs, &source)
@@ -68,7 +74,7 @@ func (source *Source) FromDB(bs []byte) error {
 		return err
 	}
 	if source.BindPasswordEncrypt != "" {
-		source.BindPassword, err = secret.DecryptSecret(setting.SecretKey, source.BindPasswordEncrypt)
+		source.BindPassword, err = decryptBindPassword(source.BindPasswordEncrypt)
 		source.BindPasswordEncrypt = ""
 	}
 	return err
@@ -76,15 +82,54 @@ func (source *Source) FromDB(bs []byte) error {
 
 // ToDB exports a LDAPConfig to a serialized format.
 func (source *Source) ToDB() ([]byte, error) {
-	var err error
-	source.BindPasswordEncrypt, err = secret.EncryptSecret(setting.SecretKey, source.BindPassword)
-	if err != nil {
-		return nil, err
-	}
+	source.BindPasswordEncrypt = base64.RawStdEncoding.EncodeToString(keying.AuthSource.Encrypt([]byte(source.BindPassword), bindPasswordAdditionalData))
 	source.BindPassword = ""
 	return json.Marshal(source)
 }
 
+// decryptBindPassword decrypts the bind password, which is either encrypted with keying or, when
+// it was stored by an older version, a hex string encrypted with one of the secret keys.
+func decryptBindPassword(encrypted string) (string, error) {
+	if !isHexString(encrypted) {
+		ciphertext, err := base64.RawStdEncoding.DecodeString(encrypted)
+		if err != nil {
+			return "", err
+		}
+		password, err := keying.AuthSource.Decrypt(ciphertext, bindPasswordAdditionalData)
+		return string(password), err
+	}
+
+	password, err := secret.DecryptSecret(setting.SecretKey, encrypted)
+	for _, key := range setting.PreviousSecretKeys {
+		if err == nil {
+			break
+		}
+		password, err = secret.DecryptSecret(key, encrypted)
+	}
+	return password, err
+}
+
+// IsBindPasswordEncryptedWithSecretKey reports whether the encrypted bind password of a serialized
+// LDAPConfig is encrypted with the current secret key and doesn't have to be re-encrypted.
+func IsBindPasswordEncryptedWithSecretKey(bs []byte) (bool, error) {
+	var source Source
+	if err := json.UnmarshalHandleDoubleEncode(bs, &source); err != nil {
+		return false, err
+	}
+	if source.BindPasswordEncrypt == "" || isHexString(source.BindPasswordEncrypt) {
+		return source.BindPasswordEncrypt == "", nil
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(source.BindPasswordEncrypt)
+	if err != nil {
+		return false, err
+	}
+	return keying.IsCurrentKey(ciphertext), nil
+}
+
+func isHexString(s string) bool {
+	return strings.Trim(s, "0123456789abcdef") == ""
+}
+
 // SecurityProtocolName returns the name of configured security
 // protocol.
 func (source *Source) SecurityProtocolName() string {
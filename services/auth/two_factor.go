@@ -0,0 +1,26 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// DeleteTwoFactor removes the TOTP enrollment of a user and records it in the audit log
+func DeleteTwoFactor(ctx context.Context, doer, u *user_model.User, tf *auth_model.TwoFactor) error {
+	if err := auth_model.DeleteTwoFactorByID(ctx, tf.ID, u.ID); err != nil {
+		return err
+	}
+	detail := ""
+	if doer == nil || doer.ID != u.ID {
+		detail = "reset by an administrator"
+	}
+	audit_service.RecordOwner(ctx, doer, u.ID, audit_model.ActionTwoFactorDisable, audit_service.UserTarget(u), detail)
+	return nil
+}
@@ -5,10 +5,12 @@ package convert
 
 import (
 	"context"
+	"time"
 
 	actions_model "forgejo.org/models/actions"
 	access_model "forgejo.org/models/perm/access"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
 	api "forgejo.org/modules/structs"
 )
 
@@ -47,3 +49,98 @@ func ToActionRun(ctx context.Context, run *actions_model.ActionRun, doer *user_m
 		HTMLURL:           run.HTMLURL(),
 	}
 }
+
+// ToActionWorkflowJob convert actions_model.ActionRunJob to api.ActionWorkflowJob
+// the job needs its run and the repository of the run loaded
+func ToActionWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob) *api.ActionWorkflowJob {
+	if job == nil {
+		return nil
+	}
+
+	htmlURL, err := job.HTMLURL(ctx)
+	if err != nil {
+		log.Error("HTMLURL of job %d: %v", job.ID, err)
+	}
+
+	return &api.ActionWorkflowJob{
+		ID:      job.ID,
+		RunID:   job.RunID,
+		Name:    job.Name,
+		Attempt: job.Attempt,
+		HeadSHA: job.CommitSHA,
+		RunsOn:  job.RunsOn,
+		TaskID:  job.TaskID,
+		Status:  job.Status.String(),
+		Started: job.Started.AsTime(),
+		Stopped: job.Stopped.AsTime(),
+		HTMLURL: htmlURL,
+	}
+}
+
+// ToActionEnvironment convert actions_model.ActionEnvironment to api.ActionEnvironment
+func ToActionEnvironment(ctx context.Context, env *actions_model.ActionEnvironment, doer *user_model.User) (*api.ActionEnvironment, error) {
+	reviewers, err := user_model.GetUserByIDs(ctx, env.ReviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	branchPatterns := env.BranchPatterns
+	if branchPatterns == nil {
+		branchPatterns = []string{}
+	}
+
+	return &api.ActionEnvironment{
+		ID:             env.ID,
+		Name:           env.Name,
+		WaitTimer:      env.WaitTimer,
+		Reviewers:      ToUsers(ctx, doer, reviewers),
+		BranchPatterns: branchPatterns,
+		Created:        env.CreatedUnix.AsTime(),
+		Updated:        env.UpdatedUnix.AsTime(),
+	}, nil
+}
+
+// ToActionDeployment convert actions_model.ActionDeployment to api.ActionDeployment
+// the deployment needs its job loaded
+func ToActionDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, env *actions_model.ActionEnvironment, doer *user_model.User) *api.ActionDeployment {
+	apiDeployment := &api.ActionDeployment{
+		ID:          deployment.ID,
+		Environment: env.Name,
+		RunID:       deployment.RunID,
+		JobID:       deployment.JobID,
+		Attempt:     deployment.Attempt,
+		Ref:         deployment.Ref,
+		CommitSHA:   deployment.CommitSHA,
+		Status:      deployment.Status.String(),
+		JobStatus:   deployment.Job.Status.String(),
+		StartAfter:  deployment.StartAfter.AsTime(),
+		Created:     deployment.Created.AsTime(),
+		Updated:     deployment.Updated.AsTime(),
+	}
+	if deployment.ReviewerID != 0 {
+		reviewer, err := user_model.GetPossibleUserByID(ctx, deployment.ReviewerID)
+		if err != nil {
+			reviewer = user_model.NewGhostUser()
+		}
+		apiDeployment.Reviewer = ToUser(ctx, reviewer, doer)
+	}
+	return apiDeployment
+}
+
+// ToActionQueuedJob convert a queued job to an api.ActionQueuedJob
+func ToActionQueuedJob(q *actions_model.QueuedJob) *api.ActionQueuedJob {
+	return &api.ActionQueuedJob{
+		ID:                    q.Job.ID,
+		RunID:                 q.Job.RunID,
+		RepoID:                q.Job.RepoID,
+		OwnerID:               q.Job.OwnerID,
+		Name:                  q.Job.Name,
+		RunsOn:                q.Job.RunsOn,
+		QueuedSince:           q.QueuedSince().AsTime(),
+		TimeInQueue:           int64(time.Since(q.QueuedSince().AsTime()).Seconds()),
+		OnlineLabels:          q.OnlineLabels,
+		MissingLabels:         q.MissingLabels(),
+		MatchingOnlineRunners: q.MatchingOnlineRunners,
+		MatchingRunners:       q.MatchingRunners,
+		Diagnosis:             q.Diagnosis(),
+	}
+}
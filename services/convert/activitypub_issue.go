@@ -0,0 +1,99 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	fm "forgejo.org/modules/forgefed"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// apActorID returns the ID of the ActivityPub actor of the user, which is its original ID for federated users
+func apActorID(ctx context.Context, u *user_model.User) (string, error) {
+	if !u.IsActivityPub() {
+		return u.APActorID(), nil
+	}
+	_, federatedUser, err := user_model.GetFederatedUserByUserID(ctx, u.ID)
+	if err != nil {
+		return "", err
+	}
+	return federatedUser.NormalizedOriginalURL, nil
+}
+
+// apContent sets the content of the object to the given markdown, both rendered and as source
+func apContent(ctx context.Context, object *ap.Object, content string) error {
+	rendered, err := markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+	}, content)
+	if err != nil {
+		return err
+	}
+	object.Content = ap.DefaultNaturalLanguageValue(string(rendered))
+	object.Source = ap.Source{
+		Content:   ap.DefaultNaturalLanguageValue(content),
+		MediaType: "text/markdown",
+	}
+	return nil
+}
+
+// ToForgeTicket converts an issue to a ForgeFed ticket with the given ID
+func ToForgeTicket(ctx context.Context, issue *issues_model.Issue, ticketID string) (*fm.Ticket, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := issue.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	posterID, err := apActorID(ctx, issue.Poster)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := fm.TicketNew(ap.IRI(ticketID))
+	ticket.AttributedTo = ap.IRI(posterID)
+	ticket.Context = ap.IRI(issue.Repo.APActorID())
+	ticket.Summary = ap.DefaultNaturalLanguageValue(issue.Title)
+	if err := apContent(ctx, &ticket.Object, issue.Content); err != nil {
+		return nil, err
+	}
+	ticket.URL = ap.IRI(issue.HTMLURL())
+	ticket.Published = issue.CreatedUnix.AsTime()
+	ticket.Updated = issue.UpdatedUnix.AsTime()
+	ticket.To = ap.ItemCollection{ap.PublicNS}
+	ticket.IsResolved = issue.IsClosed
+	return ticket, nil
+}
+
+// ToForgeTicketComment converts a comment to an ActivityPub note replying to the ticket with the given ID
+func ToForgeTicketComment(ctx context.Context, comment *issues_model.Comment, ticketID string) (*ap.Object, error) {
+	if err := comment.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if err := comment.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	posterID, err := apActorID(ctx, comment.Poster)
+	if err != nil {
+		return nil, err
+	}
+
+	note := ap.ObjectNew(ap.NoteType)
+	note.ID = ap.IRI(comment.APObjectID())
+	note.AttributedTo = ap.IRI(posterID)
+	note.Context = ap.IRI(ticketID)
+	note.InReplyTo = ap.IRI(ticketID)
+	if err := apContent(ctx, note, comment.Content); err != nil {
+		return nil, err
+	}
+	note.URL = ap.IRI(comment.HTMLURL(ctx))
+	note.Published = comment.CreatedUnix.AsTime()
+	note.To = ap.ItemCollection{ap.PublicNS}
+	return note, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAuditEvent converts an audit event to its API format, the IP address is only included for administrators
+func ToAuditEvent(ctx context.Context, event *audit_model.Event, doer *user_model.User) *api.AuditEvent {
+	result := &api.AuditEvent{
+		ID:         event.ID,
+		Action:     string(event.Action),
+		Doer:       ToUser(ctx, event.Doer, doer),
+		DoerID:     event.DoerID,
+		DoerName:   event.DoerName,
+		OwnerID:    event.OwnerID,
+		RepoID:     event.RepoID,
+		TargetType: string(event.TargetType),
+		TargetID:   event.TargetID,
+		TargetName: event.TargetName,
+		Detail:     event.Detail,
+		Created:    event.CreatedUnix.AsTime(),
+	}
+	if doer != nil && doer.IsAdmin {
+		result.IPAddress = event.IPAddress
+	}
+	return result
+}
@@ -131,6 +131,27 @@ func getWhitelistEntities[T *user_model.User | *organization.Team](entities []T,
 	return whitelistNames
 }
 
+// toStatusCheckSources converts the sources the required status checks are pinned to
+func toStatusCheckSources(ctx context.Context, sources []*git_model.StatusCheckSource) []*api.StatusCheckSource {
+	result := make([]*api.StatusCheckSource, 0, len(sources))
+	for _, source := range sources {
+		apiSource := &api.StatusCheckSource{
+			Context:  source.Context,
+			Workflow: source.Workflow,
+		}
+		if source.Workflow == "" {
+			user, err := user_model.GetPossibleUserByID(ctx, source.UserID)
+			if err != nil {
+				log.Error("GetPossibleUserByID[%d]: %v", source.UserID, err)
+				continue
+			}
+			apiSource.Username = user.Name
+		}
+		result = append(result, apiSource)
+	}
+	return result
+}
+
 // ToBranchProtection convert a ProtectedBranch to api.BranchProtection
 func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo *repo_model.Repository) *api.BranchProtection {
 	readers, err := access_model.GetRepoReaders(ctx, repo)
@@ -169,6 +190,7 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		MergeWhitelistTeams:           mergeWhitelistTeams,
 		EnableStatusCheck:             bp.EnableStatusCheck,
 		StatusCheckContexts:           bp.StatusCheckContexts,
+		StatusCheckSources:            toStatusCheckSources(ctx, bp.StatusCheckSources),
 		RequiredApprovals:             bp.RequiredApprovals,
 		EnableApprovalsWhitelist:      bp.EnableApprovalsWhitelist,
 		ApprovalsWhitelistUsernames:   approvalsWhitelistUsernames,
@@ -182,6 +204,7 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		ApplyToAdmins:                 bp.ApplyToAdmins,
+		EnableMergeQueue:              bp.EnableMergeQueue,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -547,6 +570,7 @@ func ToActionRunner(runner *actions_model.ActionRunner) (api.ActionRunner, error
 		Version:     runner.Version,
 		Status:      status.String(),
 		Labels:      runner.AgentLabels,
+		Ephemeral:   runner.Ephemeral,
 	}
 
 	return actionRunner, nil
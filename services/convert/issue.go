@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/label"
@@ -30,6 +31,17 @@ func ToAPIIssue(ctx context.Context, doer *user_model.User, issue *issues_model.
 	return toIssue(ctx, doer, issue, APIAssetDownloadURL)
 }
 
+// ToPayloadIssue converts an Issue for the payload of an event, e.g. of a webhook,
+// which is read by others than the doer: the parent of the issue is only shown if
+// it is in the same repository or if anyone can read it
+func ToPayloadIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) *api.Issue {
+	apiIssue := toIssue(ctx, doer, issue, APIAssetDownloadURL)
+	if apiIssue.Parent != nil && !canReadParentIssue(ctx, nil, issue) {
+		apiIssue.Parent = nil
+	}
+	return apiIssue
+}
+
 func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, getDownloadURL func(repo *repo_model.Repository, attach *repo_model.Attachment) string) *api.Issue {
 	if err := issue.LoadPoster(ctx); err != nil {
 		return &api.Issue{}
@@ -55,6 +67,7 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		Created:     issue.CreatedUnix.AsTime(),
 		Updated:     issue.UpdatedUnix.AsTime(),
 		PinOrder:    issue.PinOrder,
+		IssueType:   issue.Type.Name(),
 	}
 
 	if issue.Repo != nil {
@@ -115,11 +128,57 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		apiIssue.Deadline = issue.DeadlineUnix.AsTimePtr()
 	}
 
+	if err := issue.LoadParentIssue(ctx); err != nil {
+		return &api.Issue{}
+	}
+	if issue.ParentIssue != nil && canReadParentIssue(ctx, doer, issue) {
+		apiIssue.Parent = &api.IssueMeta{
+			Index: issue.ParentIssue.Index,
+			Owner: issue.ParentIssue.Repo.OwnerName,
+			Name:  issue.ParentIssue.Repo.Name,
+		}
+	}
+	if err := issue.LoadSubIssuesSummary(ctx); err != nil {
+		return &api.Issue{}
+	}
+	if issue.SubIssuesSummary.Total > 0 {
+		apiIssue.SubIssues = &api.SubIssuesSummary{
+			Total:  issue.SubIssuesSummary.Total,
+			Closed: issue.SubIssuesSummary.Closed,
+		}
+	}
+
 	return apiIssue
 }
 
+// canReadParentIssue checks the doer can read the parent of the issue, which
+// may be in another repository of the organization
+func canReadParentIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) bool {
+	if issue.ParentIssue.RepoID == issue.RepoID {
+		return true
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.ParentIssue.Repo, doer)
+	if err != nil {
+		log.Error("GetUserRepoPermission: %v", err)
+		return false
+	}
+	return perm.CanReadIssuesOrPulls(issue.ParentIssue.IsPull)
+}
+
+// loadSubIssues loads the parents and the sub-issues progress of all the issues at once,
+// instead of issue by issue in toIssue
+func loadSubIssues(ctx context.Context, il issues_model.IssueList) {
+	if err := il.LoadParentIssues(ctx); err != nil {
+		log.Error("LoadParentIssues: %v", err)
+	}
+	if err := il.LoadSubIssuesSummaries(ctx); err != nil {
+		log.Error("LoadSubIssuesSummaries: %v", err)
+	}
+}
+
 // ToIssueList converts an IssueList to API format
 func ToIssueList(ctx context.Context, doer *user_model.User, il issues_model.IssueList) []*api.Issue {
+	loadSubIssues(ctx, il)
 	result := make([]*api.Issue, len(il))
 	for i := range il {
 		result[i] = ToIssue(ctx, doer, il[i])
@@ -129,6 +188,7 @@ func ToIssueList(ctx context.Context, doer *user_model.User, il issues_model.Iss
 
 // ToAPIIssueList converts an IssueList to API format
 func ToAPIIssueList(ctx context.Context, doer *user_model.User, il issues_model.IssueList) []*api.Issue {
+	loadSubIssues(ctx, il)
 	result := make([]*api.Issue, len(il))
 	for i := range il {
 		result[i] = ToAPIIssue(ctx, doer, il[i])
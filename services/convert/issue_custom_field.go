@@ -0,0 +1,54 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"strconv"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPICustomField converts a CustomField into API Format
+func ToAPICustomField(field *issues_model.CustomField) *api.CustomField {
+	return &api.CustomField{
+		ID:         field.ID,
+		Name:       field.Name,
+		Type:       field.Type.Name(),
+		Options:    field.Options,
+		IsOrgField: field.BelongsToOrg(),
+		Created:    field.CreatedUnix.AsTime(),
+		Updated:    field.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToAPIIssueCustomFieldValues converts the values of the custom fields of an
+// issue into API Format, grouped by field
+func ToAPIIssueCustomFieldValues(ctx context.Context, values []*issues_model.IssueCustomFieldValue) []*api.IssueCustomFieldValue {
+	result := make([]*api.IssueCustomFieldValue, 0, len(values))
+	var last *api.IssueCustomFieldValue
+	for _, value := range values {
+		if last == nil || last.FieldID != value.FieldID {
+			last = &api.IssueCustomFieldValue{
+				FieldID: value.FieldID,
+				Name:    value.Field.Name,
+				Type:    value.Field.Type.Name(),
+			}
+			result = append(result, last)
+		}
+		v := value.Value
+		if value.Field.Type == issues_model.CustomFieldTypeUser {
+			userID, _ := strconv.ParseInt(v, 10, 64)
+			user, err := user_model.GetPossibleUserByID(ctx, userID)
+			if err != nil {
+				user = user_model.NewGhostUser()
+			}
+			v = user.Name
+		}
+		last.Values = append(last.Values, v)
+	}
+	return result
+}
@@ -8,9 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
@@ -57,3 +59,22 @@ func TestMilestone_APIFormat(t *testing.T) {
 		Deadline:     milestone.DeadlineUnix.AsTimePtr(),
 	}, *ToAPIMilestone(milestone))
 }
+
+func TestToPayloadIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// repo3 and repo5 are private repositories of org3, which user2 owns
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 15})
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, user2.ID, parent, issue))
+
+	apiIssues := ToAPIIssueList(db.DefaultContext, user2, issues_model.IssueList{issue, parent})
+	assert.Equal(t, &api.IssueMeta{Index: 1, Owner: "org3", Name: "repo3"}, apiIssues[0].Parent)
+	assert.Nil(t, apiIssues[0].SubIssues)
+	assert.Nil(t, apiIssues[1].Parent)
+	assert.Equal(t, &api.SubIssuesSummary{Total: 1, Closed: 0}, apiIssues[1].SubIssues)
+
+	// not everyone receiving the events of repo5 can read repo3
+	assert.Nil(t, ToPayloadIssue(db.DefaultContext, user2, issue).Parent)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	moderation_model "forgejo.org/models/moderation"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// loadUsersMap returns the users with the given IDs, by ID
+func loadUsersMap(ctx context.Context, ids []int64) (map[int64]*user_model.User, error) {
+	users, err := user_model.GetPossibleUserByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	usersMap := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		usersMap[u.ID] = u
+	}
+	return usersMap, nil
+}
+
+// ToAbuseReports converts a list of abuse reports to their API format
+func ToAbuseReports(ctx context.Context, reports []*moderation_model.AbuseReport, doer *user_model.User) ([]*api.AbuseReport, error) {
+	userIDs := make([]int64, 0, len(reports)*2)
+	for _, report := range reports {
+		userIDs = append(userIDs, report.ReporterID, report.AssigneeID)
+	}
+	users, err := loadUsersMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.AbuseReport, len(reports))
+	for i, report := range reports {
+		apiReport := &api.AbuseReport{
+			ID:          report.ID,
+			Status:      report.Status.Name(),
+			ContentType: report.ContentType.Name(),
+			ContentID:   report.ContentID,
+			Category:    report.Category.Name(),
+			Remarks:     report.Remarks,
+			Reporter:    ToUser(ctx, users[report.ReporterID], doer),
+			Assignee:    ToUser(ctx, users[report.AssigneeID], doer),
+			Created:     report.CreatedUnix.AsTime(),
+		}
+		if apiReport.Reporter == nil {
+			apiReport.Reporter = ToUser(ctx, user_model.NewGhostUser(), doer)
+		}
+		if report.ResolvedUnix > 0 {
+			resolved := report.ResolvedUnix.AsTime()
+			apiReport.Resolved = &resolved
+		}
+		result[i] = apiReport
+	}
+	return result, nil
+}
+
+// ToModerationActions converts the audit trail of abuse reports to its API format
+func ToModerationActions(ctx context.Context, actions []*moderation_model.ModerationAction, doer *user_model.User) ([]*api.ModerationAction, error) {
+	userIDs := make([]int64, 0, len(actions)*2)
+	for _, action := range actions {
+		userIDs = append(userIDs, action.DoerID, action.TargetUserID)
+	}
+	users, err := loadUsersMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.ModerationAction, len(actions))
+	for i, action := range actions {
+		result[i] = &api.ModerationAction{
+			ID:         action.ID,
+			Action:     action.Type.Name(),
+			Doer:       ToUser(ctx, users[action.DoerID], doer),
+			TargetUser: ToUser(ctx, users[action.TargetUserID], doer),
+			Details:    action.Details,
+			Created:    action.CreatedUnix.AsTime(),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPIProjectAutomationRule converts a project AutomationRule into API Format
+func ToAPIProjectAutomationRule(rule *project_model.AutomationRule) *api.ProjectAutomationRule {
+	return &api.ProjectAutomationRule{
+		ID:        rule.ID,
+		ProjectID: rule.ProjectID,
+		Trigger:   rule.TriggerType.Name(),
+		LabelID:   rule.LabelID,
+		ColumnID:  rule.ColumnID,
+		Created:   rule.CreatedUnix.AsTime(),
+		Updated:   rule.UpdatedUnix.AsTime(),
+	}
+}
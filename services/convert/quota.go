@@ -52,6 +52,13 @@ func toQuotaInfoUsed(used *quota_model.Used) api.QuotaUsed {
 				},
 			},
 		},
+		Count: api.QuotaUsedCount{
+			Repos: api.QuotaUsedCountRepos{
+				Public:  used.Count.Repos.Public,
+				Private: used.Count.Repos.Private,
+			},
+			Packages: used.Count.Packages,
+		},
 	}
 	return info
 }
@@ -21,6 +21,7 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerTransferLingeringLogs()
 	registerScheduleTasks()
+	registerQueueDeployments()
 	registerActionsCleanup()
 	registerOfflineRunnersCleanup()
 	registerCleanupActionUser()
@@ -79,6 +80,17 @@ func registerScheduleTasks() {
 	})
 }
 
+// registerQueueDeployments registers a task that runs every minute to start the jobs whose environment wait timer is over.
+func registerQueueDeployments() {
+	RegisterTaskFatal("queue_deployments", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, cfg Config) error {
+		return actions_service.QueueDeployments(ctx)
+	})
+}
+
 func registerActionsCleanup() {
 	RegisterTaskFatal("cleanup_actions", &BaseConfig{
 		Enabled:    true,
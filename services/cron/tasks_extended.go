@@ -9,12 +9,14 @@ import (
 
 	activities_model "forgejo.org/models/activities"
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/system"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	issue_indexer "forgejo.org/modules/indexer/issues"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/updatechecker"
+	issue_service "forgejo.org/services/issue"
 	moderation_service "forgejo.org/services/moderation"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
@@ -174,6 +176,20 @@ func registerDeleteOldSystemNotices() {
 	})
 }
 
+func registerDeleteOldAuditEvents() {
+	RegisterTaskFatal("delete_old_audit_events", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@every 24h",
+		},
+		OlderThan: 365 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		olderThanConfig := config.(*OlderThanConfig)
+		return audit_model.DeleteOldEvents(ctx, olderThanConfig.OlderThan)
+	})
+}
+
 type GCLFSConfig struct {
 	BaseConfig
 	OlderThan                time.Duration
@@ -245,6 +261,36 @@ func registerRemoveResolvedReports() {
 	})
 }
 
+func registerSendDueDateReminders() {
+	type DueDateReminderConfig struct {
+		BaseConfig
+		DaysBefore int64
+		MaxOverdue time.Duration
+	}
+	RegisterTaskFatal("send_due_date_reminders", &DueDateReminderConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@every 1h",
+		},
+		DaysBefore: 1,
+		MaxOverdue: 7 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		dueDateReminderConfig := config.(*DueDateReminderConfig)
+		return issue_service.SendDueDateReminders(ctx, dueDateReminderConfig.DaysBefore, dueDateReminderConfig.MaxOverdue)
+	})
+}
+
+func registerSendIssueReminders() {
+	RegisterTaskFatal("send_issue_reminders", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.SendIssueReminders(ctx)
+	})
+}
+
 func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
@@ -258,8 +304,11 @@ func initExtendedTasks() {
 	registerDeleteOldActions()
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
+	registerDeleteOldAuditEvents()
 	registerGCLFS()
 	registerRebuildIssueIndexer()
+	registerSendDueDateReminders()
+	registerSendIssueReminders()
 	if setting.Moderation.Enabled {
 		registerRemoveResolvedReports()
 	}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+
+	"forgejo.org/modules/log"
+	secretkey_service "forgejo.org/services/secretkey"
+)
+
+func checkEncryptedSecrets(ctx context.Context, logger log.Logger, autofix bool) error {
+	all, err := secretkey_service.Process(ctx, secretkey_service.Options{Reencrypt: autofix, Verify: true})
+	if err != nil {
+		logger.Critical("Unable to check the encrypted secrets: %v", err)
+		return err
+	}
+
+	for _, stats := range all {
+		if len(stats.Undecryptable) > 0 {
+			logger.Warn("%s: %d of %d secrets cannot be decrypted with any of the secret keys, IDs: %v", stats.Name, len(stats.Undecryptable), stats.Total, stats.Undecryptable)
+		}
+		if stats.Outdated == 0 {
+			continue
+		}
+		if autofix {
+			logger.Info("%s: re-encrypted %d secrets with the current secret key", stats.Name, stats.Reencrypted)
+		} else {
+			logger.Warn("%s: %d secrets are encrypted with a previous secret key, run `forgejo admin regenerate secret-key` to re-encrypt them", stats.Name, stats.Outdated)
+		}
+	}
+	return nil
+}
+
+func init() {
+	Register(&Check{
+		Title:     "Check that the encrypted secrets can be decrypted",
+		Name:      "check-encrypted-secrets",
+		IsDefault: false,
+		Run:       checkEncryptedSecrets,
+		Priority:  7,
+	})
+}
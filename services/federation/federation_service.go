@@ -17,6 +17,7 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/validation"
+	notify_service "forgejo.org/services/notify"
 
 	"github.com/google/uuid"
 )
@@ -25,6 +26,7 @@ func Init() error {
 	if !setting.Federation.Enabled {
 		return nil
 	}
+	notify_service.RegisterNotifier(NewIssueNotifier())
 	return initDeliveryQueue()
 }
 
@@ -0,0 +1,224 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"time"
+
+	"forgejo.org/models/forgefed"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	fm "forgejo.org/modules/forgefed"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/structs"
+	"forgejo.org/services/convert"
+	notify_service "forgejo.org/services/notify"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/go-ap/jsonld"
+)
+
+type issueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &issueNotifier{}
+
+// NewIssueNotifier creates a notifier delivering the events of public issues as ForgeFed activities
+// to the federated participants of the issue and to the repositories followed by its repository.
+func NewIssueNotifier() notify_service.Notifier {
+	return &issueNotifier{}
+}
+
+func (n *issueNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	if !isTicketFederated(ctx, issue, issue.Poster) {
+		return
+	}
+
+	ticketID, err := ticketObjectID(ctx, issue)
+	if err != nil {
+		log.Error("ticketObjectID: %v", err)
+		return
+	}
+	ticket, err := convert.ToForgeTicket(ctx, issue, ticketID)
+	if err != nil {
+		log.Error("ToForgeTicket: %v", err)
+		return
+	}
+	activity, err := fm.NewForgeCreateTicket(issue.Poster.APActorID(), ticket, time.Now())
+	if err != nil {
+		log.Error("NewForgeCreateTicket: %v", err)
+		return
+	}
+	if err := sendTicketActivity(ctx, issue, issue.Poster, activity); err != nil {
+		log.Error("Delivering the ticket of issue %d failed: %v", issue.ID, err)
+	}
+}
+
+func (n *issueNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
+) {
+	if !isTicketFederated(ctx, issue, doer) {
+		return
+	}
+
+	ticketID, err := ticketObjectID(ctx, issue)
+	if err != nil {
+		log.Error("ticketObjectID: %v", err)
+		return
+	}
+	comment.Issue = issue
+	note, err := convert.ToForgeTicketComment(ctx, comment, ticketID)
+	if err != nil {
+		log.Error("ToForgeTicketComment: %v", err)
+		return
+	}
+	activity, err := fm.NewForgeCreateTicketComment(doer.APActorID(), note, time.Now())
+	if err != nil {
+		log.Error("NewForgeCreateTicketComment: %v", err)
+		return
+	}
+	if err := sendTicketActivity(ctx, issue, doer, activity); err != nil {
+		log.Error("Delivering the comment %d failed: %v", comment.ID, err)
+	}
+}
+
+func (n *issueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if !isTicketFederated(ctx, issue, doer) {
+		return
+	}
+
+	ticketID, err := ticketObjectID(ctx, issue)
+	if err != nil {
+		log.Error("ticketObjectID: %v", err)
+		return
+	}
+	var activity any
+	if isClosed {
+		activity, err = fm.NewForgeResolveTicket(doer.APActorID(), ticketID, time.Now())
+	} else {
+		activity, err = fm.NewForgeUndoResolveTicket(doer.APActorID(), ticketID, time.Now())
+	}
+	if err != nil {
+		log.Error("Creating the resolve activity failed: %v", err)
+		return
+	}
+	if err := sendTicketActivity(ctx, issue, doer, activity); err != nil {
+		log.Error("Delivering the status of issue %d failed: %v", issue.ID, err)
+	}
+}
+
+// isTicketFederated reports whether the events of the issue done by doer are to be federated:
+// only the events of public issues done by local users are delivered, the federated users'
+// own servers being responsible for theirs.
+func isTicketFederated(ctx context.Context, issue *issues_model.Issue, doer *user_model.User) bool {
+	if issue.IsPull || doer == nil || doer.IsActivityPub() {
+		return false
+	}
+	if doer.KeepActivityPrivate || doer.Visibility != structs.VisibleTypePublic {
+		return false
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return false
+	}
+	if err := issue.Repo.LoadOwner(ctx); err != nil {
+		log.Error("LoadOwner: %v", err)
+		return false
+	}
+	return !issue.Repo.IsPrivate && issue.Repo.Owner.Visibility == structs.VisibleTypePublic
+}
+
+// ticketObjectID returns the ID of the ticket of the issue, which is the ID of the federated ticket
+// if the issue was created from one.
+func ticketObjectID(ctx context.Context, issue *issues_model.Issue) (string, error) {
+	federatedIssue, err := issues_model.GetFederatedIssueByIssueID(ctx, issue.ID)
+	if err != nil {
+		return "", err
+	}
+	if federatedIssue != nil {
+		return federatedIssue.ObjectID, nil
+	}
+	return issue.APObjectID(), nil
+}
+
+// ticketInboxes returns the inboxes the activities of the issue are delivered to: the ones of the
+// federated participants of the issue and the ones of the repositories its repository follows.
+func ticketInboxes(ctx context.Context, issue *issues_model.Issue, doer *user_model.User) ([]string, error) {
+	inboxes := make([]string, 0, 5)
+	seen := make(map[string]bool)
+	add := func(inbox string) {
+		if !seen[inbox] {
+			seen[inbox] = true
+			inboxes = append(inboxes, inbox)
+		}
+	}
+
+	participantIDs, err := issue.GetParticipantIDsByIssue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := user_model.GetPossibleUserByIDs(ctx, participantIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, participant := range participants {
+		if participant.ID == doer.ID || !participant.IsActivityPub() {
+			continue
+		}
+		_, federatedUser, err := user_model.GetFederatedUserByUserID(ctx, participant.ID)
+		if err != nil {
+			return nil, err
+		}
+		federationHost, err := forgefed.GetFederationHost(ctx, federatedUser.FederationHostID)
+		if err != nil {
+			return nil, err
+		}
+		hostURL := federationHost.AsURL()
+		add(hostURL.JoinPath(federatedUser.InboxPath).String())
+	}
+
+	followingRepos, err := repo_model.FindFollowingReposByRepoID(ctx, issue.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, followingRepo := range followingRepos {
+		add(followingRepo.URI + "/inbox")
+	}
+
+	return inboxes, nil
+}
+
+// sendTicketActivity queues the delivery of an activity of the issue done by doer to its inboxes
+func sendTicketActivity(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, activity any) error {
+	inboxes, err := ticketInboxes(ctx, issue, doer)
+	if err != nil || len(inboxes) == 0 {
+		return err
+	}
+
+	payload, err := jsonld.WithContext(
+		jsonld.IRI(ap.ActivityBaseURI),
+		jsonld.IRI(fm.ForgeFedNamespaceURI),
+	).Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	for _, inbox := range inboxes {
+		if err := deliveryQueue.Push(deliveryQueueItem{
+			InboxURL: inbox,
+			Doer:     doer,
+			Payload:  payload,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
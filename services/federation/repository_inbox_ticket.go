@@ -0,0 +1,224 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	fm "forgejo.org/modules/forgefed"
+	"forgejo.org/modules/log"
+	issue_service "forgejo.org/services/issue"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// ProcessCreateActivity receives a Create activity delivered to a repository inbox and
+// dispatches it according to the type of the created object.
+func ProcessCreateActivity(ctx context.Context, activity *ap.Activity, repositoryID int64, signerKeyID string) (ServiceResult, error) {
+	if activity.Object == nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Create activity without object")
+	}
+	switch activity.Object.GetType() {
+	case fm.TicketType:
+		return ProcessCreateTicketActivity(ctx, activity, repositoryID, signerKeyID)
+	case ap.NoteType:
+		return ProcessCreateTicketCommentActivity(ctx, activity, repositoryID, signerKeyID)
+	default:
+		return ServiceResult{}, NewErrNotAcceptablef("Not a ticket or a note: %v", activity.Object.GetType())
+	}
+}
+
+// ProcessCreateTicketActivity receives a Create activity of a ForgeFed Ticket and does the following:
+// Validation of the activity and of its signer
+// Validation of the ticket context against the local repository
+// Creation of a (remote) federationHost and a forgefed Person if not existing
+// Creation of the issue, unless it was already created from the same ticket
+func ProcessCreateTicketActivity(ctx context.Context, activity *ap.Activity, repositoryID int64, signerKeyID string) (ServiceResult, error) {
+	createTicket, err := fm.NewForgeCreateTicketFromAp(*activity)
+	if err != nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Invalid activity: %v", err)
+	}
+	if err := verifyActivitySigner(ctx, createTicket.Actor.GetLink().String(), signerKeyID); err != nil {
+		return ServiceResult{}, err
+	}
+	log.Trace("Activity validated: %#v", activity)
+	ticket := createTicket.Ticket
+
+	repo, err := loadRepositoryForTickets(ctx, repositoryID)
+	if err != nil {
+		return ServiceResult{}, err
+	}
+	if ticket.Context.GetLink().String() != repo.APActorID() {
+		return ServiceResult{}, NewErrNotAcceptablef("Invalid ticket context: %v", ticket.Context.GetLink())
+	}
+
+	existing, err := issues_model.GetIssueByFederatedObjectID(ctx, ticket.ID.String())
+	if err != nil {
+		return ServiceResult{}, NewErrInternalf("GetIssueByFederatedObjectID failed: %v", err)
+	}
+	if existing != nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Ticket already processed: %v", ticket.ID)
+	}
+
+	actorURI := createTicket.Actor.GetLink().String()
+	user, _, federationHost, err := FindOrCreateFederatedUser(ctx, actorURI)
+	if err != nil {
+		log.Error("Federated user not found (%s): %v", actorURI, err)
+		return ServiceResult{}, NewErrNotAcceptablef("FindOrCreateFederatedUser failed: %v", err)
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    ticket.Summary.String(),
+		PosterID: user.ID,
+		Poster:   user,
+		Content:  objectContent(&ticket.Object),
+	}
+	if err := issue_service.NewIssue(ctx, repo, issue, nil, nil, nil, 0); err != nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Creating the issue failed: %v", err)
+	}
+
+	if err := issues_model.CreateFederatedIssue(ctx, &issues_model.FederatedIssue{
+		IssueID:          issue.ID,
+		FederationHostID: federationHost.ID,
+		ObjectID:         ticket.ID.String(),
+	}); err != nil {
+		return ServiceResult{}, NewErrInternalf("CreateFederatedIssue failed: %v", err)
+	}
+	log.Info("Created issue %s#%d from the ticket %s", repo.FullName(), issue.Index, ticket.ID)
+
+	return NewServiceResultStatusOnly(http.StatusNoContent), nil
+}
+
+// ProcessCreateTicketCommentActivity receives a Create activity of a Note replying to a ticket and does the following:
+// Validation of the activity and of its signer
+// Lookup of the issue the note replies to, either a local issue or one created from a federated ticket
+// Creation of a (remote) federationHost and a forgefed Person if not existing
+// Creation of the comment, unless it was already created from the same note
+func ProcessCreateTicketCommentActivity(ctx context.Context, activity *ap.Activity, repositoryID int64, signerKeyID string) (ServiceResult, error) {
+	createComment, err := fm.NewForgeCreateTicketCommentFromAp(*activity)
+	if err != nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Invalid activity: %v", err)
+	}
+	if err := verifyActivitySigner(ctx, createComment.Actor.GetLink().String(), signerKeyID); err != nil {
+		return ServiceResult{}, err
+	}
+	log.Trace("Activity validated: %#v", activity)
+	note := createComment.Note
+
+	repo, err := loadRepositoryForTickets(ctx, repositoryID)
+	if err != nil {
+		return ServiceResult{}, err
+	}
+
+	issue, err := findTicketIssue(ctx, repo, note.InReplyTo.GetLink().String())
+	if err != nil {
+		return ServiceResult{}, err
+	}
+	if issue.IsLocked {
+		return ServiceResult{}, NewErrNotAcceptablef("The issue is locked: %v", note.InReplyTo.GetLink())
+	}
+
+	known, err := issues_model.IsFederatedCommentKnown(ctx, note.ID.String())
+	if err != nil {
+		return ServiceResult{}, NewErrInternalf("IsFederatedCommentKnown failed: %v", err)
+	}
+	if known {
+		return ServiceResult{}, NewErrNotAcceptablef("Note already processed: %v", note.ID)
+	}
+
+	actorURI := createComment.Actor.GetLink().String()
+	user, _, federationHost, err := FindOrCreateFederatedUser(ctx, actorURI)
+	if err != nil {
+		log.Error("Federated user not found (%s): %v", actorURI, err)
+		return ServiceResult{}, NewErrNotAcceptablef("FindOrCreateFederatedUser failed: %v", err)
+	}
+
+	comment, err := issue_service.CreateIssueComment(ctx, user, repo, issue, objectContent(note), nil)
+	if err != nil {
+		return ServiceResult{}, NewErrNotAcceptablef("Creating the comment failed: %v", err)
+	}
+
+	if err := issues_model.CreateFederatedComment(ctx, &issues_model.FederatedComment{
+		CommentID:        comment.ID,
+		IssueID:          issue.ID,
+		FederationHostID: federationHost.ID,
+		ObjectID:         note.ID.String(),
+	}); err != nil {
+		return ServiceResult{}, NewErrInternalf("CreateFederatedComment failed: %v", err)
+	}
+
+	return NewServiceResultStatusOnly(http.StatusNoContent), nil
+}
+
+// verifyActivitySigner checks that the actor of an activity owns the key the activity is signed with,
+// so that a federated server can't create content on behalf of actors of other servers.
+// The key ID is empty when the signatures are not enforced.
+func verifyActivitySigner(ctx context.Context, actorURI, signerKeyID string) error {
+	if signerKeyID == "" {
+		return nil
+	}
+	_, federatedUser, _, err := findFederatedUser(ctx, actorURI)
+	if err != nil {
+		return NewErrNotAcceptablef("Finding the actor %v failed: %v", actorURI, err)
+	}
+	if federatedUser == nil || !federatedUser.KeyID.Valid || federatedUser.KeyID.String != signerKeyID {
+		return NewErrNotAcceptablef("The activity of %v is signed with the key %v of another actor", actorURI, signerKeyID)
+	}
+	return nil
+}
+
+// loadRepositoryForTickets loads the repository and checks that its issue tracker accepts new content
+func loadRepositoryForTickets(ctx context.Context, repositoryID int64) (*repo_model.Repository, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, repositoryID)
+	if err != nil {
+		return nil, NewErrInternalf("GetRepositoryByID failed: %v", err)
+	}
+	if repo.IsPrivate {
+		return nil, NewErrNotAcceptablef("The repository is private")
+	}
+	if repo.IsArchived {
+		return nil, NewErrNotAcceptablef("The repository is archived")
+	}
+	if !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		return nil, NewErrNotAcceptablef("The repository has no issue tracker")
+	}
+	return repo, nil
+}
+
+// findTicketIssue returns the issue of the repository identified by the given ticket ID,
+// which is either the ID of a local issue or the ID of the ticket an issue was created from.
+func findTicketIssue(ctx context.Context, repo *repo_model.Repository, ticketID string) (*issues_model.Issue, error) {
+	var issue *issues_model.Issue
+	var err error
+	if index := issues_model.ParseIssueAPObjectID(repo.ID, ticketID); index > 0 {
+		issue, err = issues_model.GetIssueByIndex(ctx, repo.ID, index)
+		if issues_model.IsErrIssueNotExist(err) {
+			issue, err = nil, nil
+		}
+	} else {
+		issue, err = issues_model.GetIssueByFederatedObjectID(ctx, ticketID)
+	}
+	if err != nil {
+		return nil, NewErrInternalf("Loading the ticket %v failed: %v", ticketID, err)
+	}
+	if issue == nil || issue.RepoID != repo.ID {
+		return nil, NewErrNotAcceptablef("Unknown ticket: %v", ticketID)
+	}
+	issue.Repo = repo
+	return issue, nil
+}
+
+// objectContent returns the markdown source of the object if available, its content otherwise
+func objectContent(object *ap.Object) string {
+	if object.Source.MediaType == "text/markdown" && len(object.Source.Content) > 0 {
+		return object.Source.Content.String()
+	}
+	return object.Content.String()
+}
@@ -9,11 +9,13 @@ import (
 	ap "github.com/go-ap/activitypub"
 )
 
-func ProcessRepositoryInbox(ctx context.Context, activity *ap.Activity, repositoryID int64) (ServiceResult, error) {
+func ProcessRepositoryInbox(ctx context.Context, activity *ap.Activity, repositoryID int64, signerKeyID string) (ServiceResult, error) {
 	switch activity.Type {
 	case ap.LikeType:
 		return ProcessLikeActivity(ctx, activity, repositoryID)
+	case ap.CreateType:
+		return ProcessCreateActivity(ctx, activity, repositoryID, signerKeyID)
 	default:
-		return ServiceResult{}, NewErrNotAcceptablef("Not a like activity: %v", activity.Type)
+		return ServiceResult{}, NewErrNotAcceptablef("Not a like or create activity: %v", activity.Type)
 	}
 }
@@ -0,0 +1,42 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+
+	gouuid "github.com/google/uuid"
+)
+
+// TypePrefix is prepended to the type of every event
+const TypePrefix = "org.forgejo."
+
+// Event is an event in the structured mode of the CloudEvents 1.0 JSON format
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            any       `json:"data"`
+}
+
+// newEvent serializes an event of the given type, the type is relative to TypePrefix
+func newEvent(typ, subject string, data any) ([]byte, error) {
+	return json.Marshal(&Event{
+		SpecVersion:     "1.0",
+		ID:              gouuid.New().String(),
+		Source:          setting.Firehose.Source,
+		Type:            TypePrefix + typ,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package firehose publishes every event of the instance as CloudEvents to an external message
+// bus, so that they can be consumed from a single place rather than through webhooks.
+package firehose
+
+import (
+	"errors"
+
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+	notify_service "forgejo.org/services/notify"
+)
+
+var (
+	sink          Sink
+	firehoseQueue *queue.WorkerPoolQueue[string]
+)
+
+// Init starts publishing the events if the firehose is enabled
+func Init() error {
+	if !setting.Firehose.Enabled {
+		return nil
+	}
+
+	var err error
+	sink, err = newSink()
+	if err != nil {
+		return err
+	}
+	graceful.GetManager().RunAtTerminate(func() {
+		if err := sink.Close(); err != nil {
+			log.Error("Unable to close the firehose sink: %v", err)
+		}
+	})
+
+	firehoseQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "firehose", handler)
+	if firehoseQueue == nil {
+		return errors.New("unable to create firehose queue")
+	}
+	go graceful.GetManager().RunWithCancel(firehoseQueue)
+
+	notify_service.RegisterNotifier(&firehoseNotifier{})
+	return nil
+}
+
+func handler(events ...string) []string {
+	n, err := sink.Publish(graceful.GetManager().ShutdownContext(), events)
+	if err != nil {
+		log.Error("Unable to publish %d events to the firehose: %v", len(events)-n, err)
+		return events[n:]
+	}
+	return nil
+}
+
+// publish queues an event of the given type, the type is relative to TypePrefix
+func publish(typ, subject string, data *Payload) {
+	event, err := newEvent(typ, subject, data)
+	if err != nil {
+		log.Error("Unable to serialize firehose event %s: %v", typ, err)
+		return
+	}
+	if err := firehoseQueue.Push(string(event)); err != nil {
+		log.Error("Unable to queue firehose event %s: %v", typ, err)
+	}
+}
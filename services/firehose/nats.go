@@ -0,0 +1,232 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+)
+
+const natsTimeout = 30 * time.Second
+
+// natsSink publishes the events to a NATS subject. It speaks the plain text client protocol of
+// NATS: the events of a batch are followed by a PING and are published once the server answers
+// with a PONG, which it only does after it processed everything sent before.
+type natsSink struct {
+	mu      sync.Mutex
+	address string
+	host    string
+	useTLS  bool
+	connect []byte
+	subject string
+
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// natsConnectOptions are the options of the CONNECT message
+type natsConnectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+// natsServerInfo is the part of the INFO message the sink uses
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// newNATSSink parses a nats://[user:password@|token@]host[:port] or tls://… connection string, the
+// connection is established when the first events are published
+func newNATSSink(connStr, subject string) (*natsSink, error) {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats connection string: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid nats connection string scheme %q", u.Scheme)
+	}
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid nats subject %q", subject)
+	}
+
+	s := &natsSink{
+		host:    u.Hostname(),
+		address: u.Host,
+		useTLS:  u.Scheme == "tls",
+		subject: subject,
+	}
+	if u.Port() == "" {
+		s.address = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	opts := natsConnectOptions{
+		TLSRequired: s.useTLS,
+		Name:        setting.AppName,
+		Lang:        "go",
+		Version:     setting.AppVer,
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			opts.User = u.User.Username()
+			opts.Pass = pass
+		} else {
+			opts.AuthToken = u.User.Username()
+		}
+	}
+	s.connect, err = json.Marshal(&opts)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *natsSink) Publish(ctx context.Context, events []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.dial(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := s.publish(events); err != nil {
+		// the state of the connection is unknown, start over with a new one
+		s.closeConn()
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (s *natsSink) publish(events []string) error {
+	if err := s.conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, event := range events {
+		fmt.Fprintf(&buf, "PUB %s %d\r\n%s\r\n", s.subject, len(event), event)
+	}
+	buf.WriteString("PING\r\n")
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return s.waitForPong()
+}
+
+func (s *natsSink) dial(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	if err := s.handshake(); err != nil {
+		s.closeConn()
+		return fmt.Errorf("nats handshake with %s: %w", s.address, err)
+	}
+	return nil
+}
+
+func (s *natsSink) handshake() error {
+	if err := s.conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return err
+	}
+
+	// the server greets the client with an INFO message
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	infoJSON, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("invalid INFO message: %w", err)
+	}
+
+	if s.useTLS || info.TLSRequired {
+		tlsConn := tls.Client(s.conn, &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.Handshake(); err != nil {
+			return err
+		}
+		s.conn = tlsConn
+		s.reader = bufio.NewReader(tlsConn)
+	}
+
+	if _, err := fmt.Fprintf(s.conn, "CONNECT %s\r\nPING\r\n", s.connect); err != nil {
+		return err
+	}
+	return s.waitForPong()
+}
+
+// waitForPong reads the messages of the server until it answers the PING of the client
+func (s *natsSink) waitForPong() error {
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK and INFO updates are ignored
+	}
+}
+
+func (s *natsSink) readLine() (string, error) {
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, net.ErrClosed) {
+			return "", errors.New("nats connection closed")
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (s *natsSink) closeConn() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = nil
+	s.reader = nil
+}
+
+func (s *natsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn()
+	return nil
+}
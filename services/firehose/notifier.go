@@ -0,0 +1,412 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "forgejo.org/models/actions"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/repository"
+	notify_service "forgejo.org/services/notify"
+)
+
+type firehoseNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &firehoseNotifier{}
+
+func publishRepo(typ string, doer *user_model.User, repo *repo_model.Repository, payload *Payload) {
+	if payload == nil {
+		payload = &Payload{}
+	}
+	payload.Doer = toUserRef(doer)
+	payload.Repository = toRepositoryRef(repo)
+	publish(typ, repo.FullName(), payload)
+}
+
+func (*firehoseNotifier) AdoptRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	publishRepo("repository.adopted", doer, repo, &Payload{User: toUserRef(u)})
+}
+
+func (*firehoseNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	publishRepo("repository.created", doer, repo, &Payload{User: toUserRef(u)})
+}
+
+func (*firehoseNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	publishRepo("repository.migrated", doer, repo, &Payload{User: toUserRef(u)})
+}
+
+func (*firehoseNotifier) DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	publishRepo("repository.deleted", doer, repo, nil)
+}
+
+func (*firehoseNotifier) ForkRepository(ctx context.Context, doer *user_model.User, oldRepo, repo *repo_model.Repository) {
+	publishRepo("repository.forked", doer, repo, &Payload{Previous: oldRepo.FullName()})
+}
+
+func (*firehoseNotifier) RenameRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldRepoName string) {
+	publishRepo("repository.renamed", doer, repo, &Payload{Previous: oldRepoName})
+}
+
+func (*firehoseNotifier) TransferRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldOwnerName string) {
+	publishRepo("repository.transferred", doer, repo, &Payload{Previous: oldOwnerName})
+}
+
+func (*firehoseNotifier) RepoPendingTransfer(ctx context.Context, doer, newOwner *user_model.User, repo *repo_model.Repository) {
+	publishRepo("repository.transfer_requested", doer, repo, &Payload{User: toUserRef(newOwner)})
+}
+
+func (*firehoseNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
+	publishRepo("repository.default_branch_changed", nil, repo, &Payload{Ref: repo.DefaultBranch})
+}
+
+// publishIssue publishes an event about an issue or a pull request, the type is relative to the kind of the issue
+func publishIssue(ctx context.Context, typ string, doer *user_model.User, issue *issues_model.Issue, fill func(*Payload)) {
+	payload, ok := issuePayload(ctx, doer, issue)
+	if !ok {
+		return
+	}
+	if fill != nil {
+		fill(payload)
+	}
+	publish(issueKind(issue)+"."+typ, issueSubject(issue), payload)
+}
+
+func (*firehoseNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	publishIssue(ctx, "opened", issue.Poster, issue, nil)
+}
+
+func (*firehoseNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	typ := "reopened"
+	if closeOrReopen {
+		typ = "closed"
+	}
+	publishIssue(ctx, typ, doer, issue, func(p *Payload) {
+		p.Comment = toCommentRef(actionComment)
+		p.After = commitID
+	})
+}
+
+func (*firehoseNotifier) IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
+	publishIssue(ctx, "milestone_changed", doer, issue, func(p *Payload) {
+		p.Previous = fmt.Sprint(oldMilestoneID)
+	})
+}
+
+func (*firehoseNotifier) IssueChangeAssignee(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, assignee *user_model.User, removed bool, comment *issues_model.Comment) {
+	typ := "assigned"
+	if removed {
+		typ = "unassigned"
+	}
+	publishIssue(ctx, typ, doer, issue, func(p *Payload) {
+		p.User = toUserRef(assignee)
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
+	typ := "review_requested"
+	if !isRequest {
+		typ = "review_request_removed"
+	}
+	publishIssue(ctx, typ, doer, issue, func(p *Payload) {
+		p.User = toUserRef(reviewer)
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
+	publishIssue(ctx, "content_changed", doer, issue, nil)
+}
+
+func (*firehoseNotifier) IssueClearLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	publishIssue(ctx, "labels_cleared", doer, issue, nil)
+}
+
+func (*firehoseNotifier) IssueChangeTitle(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string) {
+	publishIssue(ctx, "title_changed", doer, issue, func(p *Payload) {
+		p.Previous = oldTitle
+	})
+}
+
+func (*firehoseNotifier) IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string) {
+	publishIssue(ctx, "ref_changed", doer, issue, func(p *Payload) {
+		p.Ref = issue.Ref
+		p.Previous = oldRef
+	})
+}
+
+func (*firehoseNotifier) IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	publishIssue(ctx, "custom_fields_changed", doer, issue, nil)
+}
+
+func (*firehoseNotifier) IssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) {
+	publishIssue(ctx, "reminded", nil, issue, func(p *Payload) {
+		p.User = toUserRef(receiver)
+		p.Reminder = reminderTypeNames[reminderType]
+	})
+}
+
+func (*firehoseNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, removedLabels []*issues_model.Label) {
+	publishIssue(ctx, "labels_changed", doer, issue, func(p *Payload) {
+		p.AddedLabels = labelNames(addedLabels)
+		p.RemovedLabels = labelNames(removedLabels)
+	})
+}
+
+// publishPull publishes an event about a pull request
+func publishPull(ctx context.Context, typ string, doer *user_model.User, pr *issues_model.PullRequest, fill func(*Payload)) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue of pull request %d: %v", pr.ID, err)
+		return
+	}
+	publishIssue(ctx, typ, doer, pr.Issue, func(p *Payload) {
+		p.Ref = pr.BaseBranch
+		if fill != nil {
+			fill(p)
+		}
+	})
+}
+
+func (*firehoseNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue of pull request %d: %v", pr.ID, err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	publishPull(ctx, "opened", pr.Issue.Poster, pr, nil)
+}
+
+func (*firehoseNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	publishPull(ctx, "merged", doer, pr, func(p *Payload) {
+		p.After = pr.MergedCommitID
+	})
+}
+
+func (*firehoseNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	publishPull(ctx, "auto_merged", doer, pr, func(p *Payload) {
+		p.After = pr.MergedCommitID
+	})
+}
+
+func (*firehoseNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	publishPull(ctx, "synchronized", doer, pr, func(p *Payload) {
+		p.After = pr.HeadCommitID
+	})
+}
+
+func (*firehoseNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, review *issues_model.Review, comment *issues_model.Comment, mentions []*user_model.User) {
+	if err := review.LoadReviewer(ctx); err != nil {
+		log.Error("LoadReviewer: %v", err)
+		return
+	}
+	publishPull(ctx, "reviewed", review.Reviewer, pr, func(p *Payload) {
+		p.Review = toReviewRef(review)
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) PullRequestCodeComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	if err := comment.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	publishPull(ctx, "code_commented", comment.Poster, pr, func(p *Payload) {
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	publishPull(ctx, "target_branch_changed", doer, pr, func(p *Payload) {
+		p.Previous = oldBranch
+	})
+}
+
+func (*firehoseNotifier) PullRequestPushCommits(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, comment *issues_model.Comment) {
+	publishPull(ctx, "commits_pushed", doer, pr, func(p *Payload) {
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) PullReviewDismiss(ctx context.Context, doer *user_model.User, review *issues_model.Review, comment *issues_model.Comment) {
+	if err := review.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue of review %d: %v", review.ID, err)
+		return
+	}
+	publishIssue(ctx, "review_dismissed", doer, review.Issue, func(p *Payload) {
+		p.Review = toReviewRef(review)
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+func (*firehoseNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User) {
+	publishIssue(ctx, "comment_created", doer, issue, func(p *Payload) {
+		p.Comment = toCommentRef(comment)
+	})
+}
+
+// publishComment publishes an event about a comment of an issue or a pull request
+func publishComment(ctx context.Context, typ string, doer *user_model.User, c *issues_model.Comment) {
+	if err := c.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue of comment %d: %v", c.ID, err)
+		return
+	}
+	publishIssue(ctx, typ, doer, c.Issue, func(p *Payload) {
+		p.Comment = toCommentRef(c)
+	})
+}
+
+func (*firehoseNotifier) UpdateComment(ctx context.Context, doer *user_model.User, c *issues_model.Comment, oldContent string) {
+	publishComment(ctx, "comment_edited", doer, c)
+}
+
+func (*firehoseNotifier) DeleteComment(ctx context.Context, doer *user_model.User, c *issues_model.Comment) {
+	publishComment(ctx, "comment_deleted", doer, c)
+}
+
+func (*firehoseNotifier) NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	publishRepo("wiki.page_created", doer, repo, &Payload{WikiPage: page})
+}
+
+func (*firehoseNotifier) EditWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	publishRepo("wiki.page_edited", doer, repo, &Payload{WikiPage: page})
+}
+
+func (*firehoseNotifier) DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string) {
+	publishRepo("wiki.page_deleted", doer, repo, &Payload{WikiPage: page})
+}
+
+func (*firehoseNotifier) NewUserSignUp(ctx context.Context, newUser *user_model.User) {
+	publish("user.signed_up", newUser.Name, &Payload{User: toUserRef(newUser)})
+}
+
+// publishRelease publishes an event about a release
+func publishRelease(ctx context.Context, typ string, doer *user_model.User, rel *repo_model.Release) {
+	if err := rel.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo of release %d: %v", rel.ID, err)
+		return
+	}
+	publish(typ, rel.Repo.FullName()+"/releases/"+rel.TagName, &Payload{
+		Doer:       toUserRef(doer),
+		Repository: toRepositoryRef(rel.Repo),
+		Release:    toReleaseRef(rel),
+	})
+}
+
+func (*firehoseNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	if err := rel.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes of release %d: %v", rel.ID, err)
+		return
+	}
+	publishRelease(ctx, "release.published", rel.Publisher, rel)
+}
+
+func (*firehoseNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	publishRelease(ctx, "release.updated", doer, rel)
+}
+
+func (*firehoseNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	publishRelease(ctx, "release.deleted", doer, rel)
+}
+
+func publishPush(pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits, mirror bool) {
+	publishRepo("repository.pushed", pusher, repo, &Payload{
+		Ref:     opts.RefFullName.String(),
+		Before:  opts.OldCommitID,
+		After:   opts.NewCommitID,
+		Commits: toCommitIDs(commits),
+		Mirror:  mirror,
+	})
+}
+
+func (*firehoseNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	publishPush(pusher, repo, opts, commits, false)
+}
+
+func (*firehoseNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	publishPush(pusher, repo, opts, commits, true)
+}
+
+func (*firehoseNotifier) CreateRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string) {
+	publishRepo("ref.created", doer, repo, &Payload{Ref: refFullName.String(), After: refID})
+}
+
+func (*firehoseNotifier) SyncCreateRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string) {
+	publishRepo("ref.created", doer, repo, &Payload{Ref: refFullName.String(), After: refID, Mirror: true})
+}
+
+func (*firehoseNotifier) DeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	publishRepo("ref.deleted", doer, repo, &Payload{Ref: refFullName.String()})
+}
+
+func (*firehoseNotifier) SyncDeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName) {
+	publishRepo("ref.deleted", doer, repo, &Payload{Ref: refFullName.String(), Mirror: true})
+}
+
+// publishPackage publishes an event about a package version, the subject is the owner of the package
+func publishPackage(typ string, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	publish(typ, fmt.Sprintf("%s/packages/%s/%s/%s", pd.Owner.Name, pd.Package.Type, pd.Package.Name, pd.Version.Version), &Payload{
+		Doer:       toUserRef(doer),
+		User:       toUserRef(pd.Owner),
+		Repository: toRepositoryRef(pd.Repository),
+		Package:    toPackageRef(pd),
+	})
+}
+
+func (*firehoseNotifier) PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	publishPackage("package.created", doer, pd)
+}
+
+func (*firehoseNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
+	publishPackage("package.deleted", doer, pd)
+}
+
+func (*firehoseNotifier) ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorStatus actions_model.Status, lastRun *actions_model.ActionRun) {
+	if err := run.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes of run %d: %v", run.ID, err)
+		return
+	}
+	publish("actions.run_done", fmt.Sprintf("%s/actions/runs/%d", run.Repo.FullName(), run.Index), &Payload{
+		Doer:       toUserRef(run.TriggerUser),
+		Repository: toRepositoryRef(run.Repo),
+		Run:        toRunRef(run, priorStatus),
+	})
+}
+
+func (*firehoseNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob) {
+	if err := job.LoadRun(ctx); err != nil {
+		log.Error("LoadRun of job %d: %v", job.ID, err)
+		return
+	}
+	publish("actions.job_status_updated", fmt.Sprintf("%s/actions/runs/%d/jobs/%d", repo.FullName(), job.Run.Index, job.ID), &Payload{
+		Doer:       toUserRef(sender),
+		Repository: toRepositoryRef(repo),
+		Job:        toJobRef(job),
+	})
+}
+
+func (*firehoseNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	publish("commit_status.created", fmt.Sprintf("%s/commit/%s", repo.FullName(), status.SHA), &Payload{
+		Doer:         toUserRef(sender),
+		Repository:   toRepositoryRef(repo),
+		After:        status.SHA,
+		CommitStatus: toStatusRef(status),
+	})
+}
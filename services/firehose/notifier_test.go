@@ -0,0 +1,47 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	notify_service "forgejo.org/services/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotifierPublishesEveryEvent fails when a method is added to the notifier interface without
+// deciding what the firehose publishes for it, it would otherwise be silently inherited from the NullNotifier
+func TestNotifierPublishesEveryEvent(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "notifier.go", nil, 0)
+	require.NoError(t, err)
+
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil {
+			continue
+		}
+		if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+			if ident, ok := star.X.(*ast.Ident); ok && ident.Name == "firehoseNotifier" {
+				declared[fn.Name.Name] = true
+			}
+		}
+	}
+
+	notifier := reflect.TypeOf((*notify_service.Notifier)(nil)).Elem()
+	for i := range notifier.NumMethod() {
+		name := notifier.Method(i).Name
+		if name == "Run" {
+			// not an event
+			continue
+		}
+		assert.True(t, declared[name], "the firehose notifier does not publish %s", name)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "forgejo.org/models/actions"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/repository"
+)
+
+// Payload is the data of an event. The objects are referenced rather than serialized in full, so
+// that the events remain small and do not depend on the permissions of a particular user.
+type Payload struct {
+	Doer          *UserRef       `json:"doer,omitempty"`
+	User          *UserRef       `json:"user,omitempty"`
+	Repository    *RepositoryRef `json:"repository,omitempty"`
+	Issue         *IssueRef      `json:"issue,omitempty"`
+	Comment       *CommentRef    `json:"comment,omitempty"`
+	Review        *ReviewRef     `json:"review,omitempty"`
+	Release       *ReleaseRef    `json:"release,omitempty"`
+	Package       *PackageRef    `json:"package,omitempty"`
+	Run           *RunRef        `json:"run,omitempty"`
+	Job           *JobRef        `json:"job,omitempty"`
+	CommitStatus  *StatusRef     `json:"commit_status,omitempty"`
+	Ref           string         `json:"ref,omitempty"`
+	Before        string         `json:"before,omitempty"`
+	After         string         `json:"after,omitempty"`
+	Commits       []string       `json:"commits,omitempty"`
+	Mirror        bool           `json:"mirror,omitempty"`
+	WikiPage      string         `json:"wiki_page,omitempty"`
+	Previous      string         `json:"previous,omitempty"`
+	AddedLabels   []string       `json:"added_labels,omitempty"`
+	RemovedLabels []string       `json:"removed_labels,omitempty"`
+	Reminder      string         `json:"reminder,omitempty"`
+}
+
+// UserRef references a user or an organization
+type UserRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RepositoryRef references a repository
+type RepositoryRef struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	Private  bool   `json:"private"`
+}
+
+// IssueRef references an issue or a pull request
+type IssueRef struct {
+	ID       int64  `json:"id"`
+	Index    int64  `json:"index"`
+	Title    string `json:"title"`
+	IsPull   bool   `json:"is_pull"`
+	IsClosed bool   `json:"is_closed"`
+	PosterID int64  `json:"poster_id"`
+}
+
+// CommentRef references a comment
+type CommentRef struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	PosterID int64  `json:"poster_id"`
+}
+
+// ReviewRef references a pull request review
+type ReviewRef struct {
+	ID         int64  `json:"id"`
+	Type       string `json:"type"`
+	ReviewerID int64  `json:"reviewer_id"`
+	CommitID   string `json:"commit_id"`
+}
+
+// ReleaseRef references a release
+type ReleaseRef struct {
+	ID           int64  `json:"id"`
+	TagName      string `json:"tag_name"`
+	Title        string `json:"title"`
+	IsDraft      bool   `json:"is_draft"`
+	IsPrerelease bool   `json:"is_prerelease"`
+}
+
+// PackageRef references a package version
+type PackageRef struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// RunRef references an Actions run
+type RunRef struct {
+	ID          int64  `json:"id"`
+	Index       int64  `json:"index"`
+	WorkflowID  string `json:"workflow_id"`
+	Event       string `json:"event"`
+	CommitSHA   string `json:"commit_sha"`
+	Status      string `json:"status"`
+	PriorStatus string `json:"prior_status"`
+}
+
+// JobRef references a job of an Actions run
+type JobRef struct {
+	ID      int64  `json:"id"`
+	RunID   int64  `json:"run_id"`
+	JobID   string `json:"job_id"`
+	Name    string `json:"name"`
+	Attempt int64  `json:"attempt"`
+	Status  string `json:"status"`
+}
+
+// StatusRef references a commit status
+type StatusRef struct {
+	ID        int64  `json:"id"`
+	SHA       string `json:"sha"`
+	Context   string `json:"context"`
+	State     string `json:"state"`
+	TargetURL string `json:"target_url"`
+}
+
+var reviewTypeNames = map[issues_model.ReviewType]string{
+	issues_model.ReviewTypePending: "pending",
+	issues_model.ReviewTypeApprove: "approve",
+	issues_model.ReviewTypeComment: "comment",
+	issues_model.ReviewTypeReject:  "reject",
+	issues_model.ReviewTypeRequest: "request",
+}
+
+var reminderTypeNames = map[issues_model.ReminderType]string{
+	issues_model.ReminderTypeDueSoon:  "due_soon",
+	issues_model.ReminderTypeOverdue:  "overdue",
+	issues_model.ReminderTypePersonal: "personal",
+}
+
+func toUserRef(u *user_model.User) *UserRef {
+	if u == nil {
+		return nil
+	}
+	return &UserRef{ID: u.ID, Name: u.Name}
+}
+
+func toRepositoryRef(repo *repo_model.Repository) *RepositoryRef {
+	if repo == nil {
+		return nil
+	}
+	return &RepositoryRef{ID: repo.ID, FullName: repo.FullName(), Private: repo.IsPrivate}
+}
+
+func toIssueRef(issue *issues_model.Issue) *IssueRef {
+	return &IssueRef{
+		ID:       issue.ID,
+		Index:    issue.Index,
+		Title:    issue.Title,
+		IsPull:   issue.IsPull,
+		IsClosed: issue.IsClosed,
+		PosterID: issue.PosterID,
+	}
+}
+
+func toCommentRef(c *issues_model.Comment) *CommentRef {
+	if c == nil {
+		return nil
+	}
+	return &CommentRef{ID: c.ID, Type: c.Type.String(), PosterID: c.PosterID}
+}
+
+func toReviewRef(review *issues_model.Review) *ReviewRef {
+	if review == nil {
+		return nil
+	}
+	return &ReviewRef{ID: review.ID, Type: reviewTypeNames[review.Type], ReviewerID: review.ReviewerID, CommitID: review.CommitID}
+}
+
+func toReleaseRef(rel *repo_model.Release) *ReleaseRef {
+	return &ReleaseRef{ID: rel.ID, TagName: rel.TagName, Title: rel.Title, IsDraft: rel.IsDraft, IsPrerelease: rel.IsPrerelease}
+}
+
+func toPackageRef(pd *packages_model.PackageDescriptor) *PackageRef {
+	return &PackageRef{ID: pd.Version.ID, Type: string(pd.Package.Type), Name: pd.Package.Name, Version: pd.Version.Version}
+}
+
+func toRunRef(run *actions_model.ActionRun, priorStatus actions_model.Status) *RunRef {
+	return &RunRef{
+		ID:          run.ID,
+		Index:       run.Index,
+		WorkflowID:  run.WorkflowID,
+		Event:       string(run.Event),
+		CommitSHA:   run.CommitSHA,
+		Status:      run.Status.String(),
+		PriorStatus: priorStatus.String(),
+	}
+}
+
+func toJobRef(job *actions_model.ActionRunJob) *JobRef {
+	return &JobRef{
+		ID:      job.ID,
+		RunID:   job.RunID,
+		JobID:   job.JobID,
+		Name:    job.Name,
+		Attempt: job.Attempt,
+		Status:  job.Status.String(),
+	}
+}
+
+func toStatusRef(status *git_model.CommitStatus) *StatusRef {
+	return &StatusRef{
+		ID:        status.ID,
+		SHA:       status.SHA,
+		Context:   status.Context,
+		State:     string(status.State),
+		TargetURL: status.TargetURL,
+	}
+}
+
+func toCommitIDs(commits *repository.PushCommits) []string {
+	if commits == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(commits.Commits))
+	for _, commit := range commits.Commits {
+		ids = append(ids, commit.Sha1)
+	}
+	return ids
+}
+
+func labelNames(labels []*issues_model.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// issueKind returns the prefix of the event types of an issue or a pull request
+func issueKind(issue *issues_model.Issue) string {
+	if issue.IsPull {
+		return "pull_request"
+	}
+	return "issue"
+}
+
+func issueSubject(issue *issues_model.Issue) string {
+	if issue.IsPull {
+		return fmt.Sprintf("%s/pulls/%d", issue.Repo.FullName(), issue.Index)
+	}
+	return fmt.Sprintf("%s/issues/%d", issue.Repo.FullName(), issue.Index)
+}
+
+// issuePayload returns the payload of an event about an issue, or false if the issue cannot be loaded
+func issuePayload(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (*Payload, bool) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo of issue %d: %v", issue.ID, err)
+		return nil, false
+	}
+	return &Payload{
+		Doer:       toUserRef(doer),
+		Repository: toRepositoryRef(issue.Repo),
+		Issue:      toIssueRef(issue),
+	}, true
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"forgejo.org/modules/nosql"
+	"forgejo.org/modules/setting"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sink publishes serialized events to an external system
+type Sink interface {
+	// Publish publishes the events in order and returns how many of them were published, the
+	// remaining events are published again later
+	Publish(ctx context.Context, events []string) (int, error)
+	Close() error
+}
+
+func newSink() (Sink, error) {
+	switch setting.Firehose.Sink {
+	case "file":
+		return newFileSink(setting.Firehose.FilePath)
+	case "redis":
+		return &redisSink{
+			client: nosql.GetManager().GetRedisClient(setting.Firehose.ConnStr),
+			stream: setting.Firehose.Topic,
+			maxLen: setting.Firehose.MaxLength,
+		}, nil
+	case "nats":
+		return newNATSSink(setting.Firehose.ConnStr, setting.Firehose.Topic)
+	}
+	return nil, fmt.Errorf("unknown firehose sink %q", setting.Firehose.Sink)
+}
+
+// fileSink appends the events to a file, one event per line
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: f}, nil
+}
+
+func (s *fileSink) Publish(_ context.Context, events []string) (int, error) {
+	var size int
+	for _, event := range events {
+		size += len(event) + 1
+	}
+	buf := make([]byte, 0, size)
+	for _, event := range events {
+		buf = append(buf, event...)
+		buf = append(buf, '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// a single write keeps the lines of a batch together when several processes append to the file
+	if _, err := s.file.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// redisSink adds the events to a redis stream, with the event in the "event" field
+type redisSink struct {
+	client nosql.RedisClient
+	stream string
+	maxLen int64
+}
+
+func (s *redisSink) Publish(ctx context.Context, events []string) (int, error) {
+	for i, event := range events {
+		if err := s.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.stream,
+			MaxLen: s.maxLen,
+			Approx: s.maxLen > 0,
+			Values: []any{"event", event},
+		}).Err(); err != nil {
+			return i, fmt.Errorf("XAdd %s: %w", s.stream, err)
+		}
+	}
+	return len(events), nil
+}
+
+func (s *redisSink) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package firehose
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEvent(t *testing.T) {
+	bs, err := newEvent("repository.created", "user2/repo1", &Payload{
+		Doer:       &UserRef{ID: 2, Name: "user2"},
+		Repository: &RepositoryRef{ID: 1, FullName: "user2/repo1"},
+	})
+	require.NoError(t, err)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(bs, &event))
+	assert.Equal(t, "1.0", event["specversion"])
+	assert.Equal(t, "org.forgejo.repository.created", event["type"])
+	assert.Equal(t, "user2/repo1", event["subject"])
+	assert.Equal(t, "application/json", event["datacontenttype"])
+	assert.NotEmpty(t, event["id"])
+	assert.NotEmpty(t, event["time"])
+	assert.Equal(t, map[string]any{
+		"doer":       map[string]any{"id": float64(2), "name": "user2"},
+		"repository": map[string]any{"id": float64(1), "full_name": "user2/repo1", "private": false},
+	}, event["data"])
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "firehose", "events.jsonl")
+	sink, err := newFileSink(path)
+	require.NoError(t, err)
+
+	n, err := sink.Publish(t.Context(), []string{`{"id":"1"}`, `{"id":"2"}`})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = sink.Publish(t.Context(), []string{`{"id":"3"}`})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.NoError(t, sink.Close())
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":\"1\"}\n{\"id\":\"2\"}\n{\"id\":\"3\"}\n", string(bs))
+}
+
+// fakeNATSServer accepts a single connection and records the messages published to it. It rejects
+// the messages published to the "forbidden" subject like a server enforcing permissions.
+func fakeNATSServer(t *testing.T) (string, <-chan string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	connects := make(chan string, 1)
+	messages := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				connects <- strings.TrimPrefix(line, "CONNECT ")
+			case line == "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case strings.HasPrefix(line, "PUB "):
+				var subject string
+				var size int
+				if _, err := fmt.Sscanf(line, "PUB %s %d", &subject, &size); err != nil {
+					return
+				}
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				if subject == "forbidden" {
+					fmt.Fprint(conn, "-ERR 'Permissions Violation for Publish to forbidden'\r\n")
+					continue
+				}
+				messages <- subject + " " + string(payload[:size])
+			}
+		}
+	}()
+	return listener.Addr().String(), connects, messages
+}
+
+func TestNATSSink(t *testing.T) {
+	address, connects, messages := fakeNATSServer(t)
+
+	sink, err := newNATSSink("nats://user:secret@"+address, "forgejo.events")
+	require.NoError(t, err)
+	defer sink.Close()
+
+	n, err := sink.Publish(t.Context(), []string{`{"id":"1"}`, `{"id":"2"}`})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	var connect natsConnectOptions
+	require.NoError(t, json.Unmarshal([]byte(<-connects), &connect))
+	assert.Equal(t, "user", connect.User)
+	assert.Equal(t, "secret", connect.Pass)
+	assert.False(t, connect.Verbose)
+
+	assert.Equal(t, `forgejo.events {"id":"1"}`, <-messages)
+	assert.Equal(t, `forgejo.events {"id":"2"}`, <-messages)
+
+	sink.subject = "forbidden"
+	n, err = sink.Publish(t.Context(), []string{`{"id":"3"}`})
+	require.ErrorContains(t, err, "Permissions Violation")
+	assert.Equal(t, 0, n)
+	assert.Nil(t, sink.conn)
+}
+
+func TestNewNATSSink(t *testing.T) {
+	sink, err := newNATSSink("nats://token@nats.example.com", "forgejo.events")
+	require.NoError(t, err)
+	assert.Equal(t, "nats.example.com:4222", sink.address)
+	assert.False(t, sink.useTLS)
+	assert.Contains(t, string(sink.connect), `"auth_token":"token"`)
+
+	sink, err = newNATSSink("tls://nats.example.com:4443", "forgejo.events")
+	require.NoError(t, err)
+	assert.Equal(t, "nats.example.com:4443", sink.address)
+	assert.True(t, sink.useTLS)
+
+	_, err = newNATSSink("http://nats.example.com", "forgejo.events")
+	require.Error(t, err)
+	_, err = newNATSSink("nats://nats.example.com", "forgejo events")
+	require.Error(t, err)
+}
@@ -229,6 +229,7 @@ type ProtectBranchForm struct {
 	MergeWhitelistTeams           string
 	EnableStatusCheck             bool
 	StatusCheckContexts           string
+	StatusCheckSources            string
 	RequiredApprovals             int64
 	EnableApprovalsWhitelist      bool
 	ApprovalsWhitelistUsers       string
@@ -242,6 +243,7 @@ type ProtectBranchForm struct {
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	ApplyToAdmins                 bool
+	EnableMergeQueue              bool
 }
 
 // Validate validates the fields
@@ -284,6 +286,8 @@ type WebhookCoreForm struct {
 	ActionFailure            bool
 	ActionRecover            bool
 	ActionSuccess            bool
+	Status                   bool
+	WorkflowJob              bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
 	AuthorizationHeader      string
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
@@ -81,6 +82,7 @@ type DiffLine struct {
 	Type          DiffLineType
 	Content       string
 	Conversations []issues_model.CodeConversation
+	Annotations   []*actions_model.ActionTaskAnnotation
 	SectionInfo   *DiffLineSectionInfo
 }
 
@@ -509,6 +511,34 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	return nil
 }
 
+// LoadAnnotations attaches the annotations of the Actions jobs to the last line of the new version they are about
+func (diff *Diff) LoadAnnotations(annotations actions_model.TaskAnnotationList) {
+	byFile := make(map[string]map[int][]*actions_model.ActionTaskAnnotation)
+	for _, a := range annotations {
+		_, end := a.Lines()
+		if end == 0 {
+			continue
+		}
+		if byFile[a.Path] == nil {
+			byFile[a.Path] = make(map[int][]*actions_model.ActionTaskAnnotation)
+		}
+		byFile[a.Path][int(end)] = append(byFile[a.Path][int(end)], a)
+	}
+	for _, file := range diff.Files {
+		byLine, ok := byFile[file.Name]
+		if !ok {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.RightIdx > 0 && line.Type != DiffLineSection {
+					line.Annotations = append(line.Annotations, byLine[line.RightIdx]...)
+				}
+			}
+		}
+	}
+}
+
 const cmdDiffHead = "diff --git "
 
 // ParsePatch builds a Diff object from a io.Reader and some parameters.
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/unittest"
@@ -616,6 +617,18 @@ func TestDiff_LoadCommentsWithOutdated(t *testing.T) {
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Conversations[1], 1)
 }
 
+func TestDiff_LoadAnnotations(t *testing.T) {
+	diff := setupDefaultDiff()
+	diff.LoadAnnotations(actions_model.TaskAnnotationList{
+		{Path: "README.md", StartLine: 2, EndLine: 4},
+		{Path: "README.md", StartLine: 4},
+		{Path: "README.md", StartLine: 5},
+		{Path: "README.md"},
+		{Path: "main.go", StartLine: 4},
+	})
+	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Annotations, 2)
+}
+
 func TestDiffLine_CanComment(t *testing.T) {
 	assert.False(t, (&DiffLine{Type: DiffLineSection}).CanComment())
 	assert.False(t, (&DiffLine{Type: DiffLineAdd, Conversations: []issues_model.CodeConversation{{{Content: "bla"}}}}).CanComment())
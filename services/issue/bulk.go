@@ -0,0 +1,272 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	notify_service "forgejo.org/services/notify"
+)
+
+// BulkEditOptions are the changes applied by BulkEdit. Unset fields are left unchanged.
+type BulkEditOptions struct {
+	AddLabels       []*issues_model.Label
+	RemoveLabels    []*issues_model.Label
+	MilestoneID     optional.Option[int64] // 0 removes the milestone
+	AddAssignees    []*user_model.User
+	RemoveAssignees []*user_model.User
+	IsClosed        optional.Option[bool]
+	ProjectColumn   optional.Option[*project_model.Column] // nil removes the issue from its project
+}
+
+type bulkEditChange struct {
+	issue           *issues_model.Issue
+	addLabels       []*issues_model.Label
+	removeLabels    []*issues_model.Label
+	milestoneID     optional.Option[int64]
+	addAssignees    []*user_model.User
+	removeAssignees []*user_model.User
+	isClosed        optional.Option[bool]
+	projectColumn   optional.Option[*project_model.Column]
+}
+
+func (c *bulkEditChange) isEmpty() bool {
+	return len(c.addLabels) == 0 && len(c.removeLabels) == 0 && !c.milestoneID.Has() &&
+		len(c.addAssignees) == 0 && len(c.removeAssignees) == 0 && !c.isClosed.Has() && !c.projectColumn.Has()
+}
+
+// planBulkEdit computes the changes opts makes to issue, leaving out those that
+// are already in place. It fails like the edit of a single issue would.
+func planBulkEdit(ctx context.Context, issue *issues_model.Issue, opts *BulkEditOptions) (*bulkEditChange, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := issue.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	if err := issue.LoadAssignees(ctx); err != nil {
+		return nil, err
+	}
+
+	change := &bulkEditChange{issue: issue}
+
+	hasLabel := func(label *issues_model.Label) bool {
+		for _, l := range issue.Labels {
+			if l.ID == label.ID {
+				return true
+			}
+		}
+		return false
+	}
+	for _, label := range opts.AddLabels {
+		if !hasLabel(label) && (label.RepoID == issue.RepoID || label.OrgID == issue.Repo.OwnerID) {
+			change.addLabels = append(change.addLabels, label)
+		}
+	}
+	for _, label := range opts.RemoveLabels {
+		if hasLabel(label) {
+			change.removeLabels = append(change.removeLabels, label)
+		}
+	}
+
+	if opts.MilestoneID.Has() && opts.MilestoneID.Value() != issue.MilestoneID {
+		change.milestoneID = opts.MilestoneID
+	}
+
+	isAssigned := func(user *user_model.User) bool {
+		for _, assignee := range issue.Assignees {
+			if assignee.ID == user.ID {
+				return true
+			}
+		}
+		return false
+	}
+	for _, user := range opts.AddAssignees {
+		if !isAssigned(user) {
+			change.addAssignees = append(change.addAssignees, user)
+		}
+	}
+	for _, user := range opts.RemoveAssignees {
+		if isAssigned(user) {
+			change.removeAssignees = append(change.removeAssignees, user)
+		}
+	}
+
+	if opts.IsClosed.Has() && opts.IsClosed.Value() != issue.IsClosed {
+		if issue.IsPull {
+			if err := issue.LoadPullRequest(ctx); err != nil {
+				return nil, err
+			}
+			if issue.PullRequest.HasMerged {
+				return nil, models.ErrPullRequestHasMerged{
+					ID:         issue.PullRequest.ID,
+					IssueID:    issue.ID,
+					HeadRepoID: issue.PullRequest.HeadRepoID,
+					BaseRepoID: issue.PullRequest.BaseRepoID,
+					HeadBranch: issue.PullRequest.HeadBranch,
+					BaseBranch: issue.PullRequest.BaseBranch,
+				}
+			}
+		}
+		if opts.IsClosed.Value() && issue.Repo.IsDependenciesEnabled(ctx) {
+			noDeps, err := issues_model.IssueNoDependenciesLeft(ctx, issue)
+			if err != nil {
+				return nil, err
+			}
+			if !noDeps {
+				return nil, issues_model.ErrDependenciesLeft{IssueID: issue.ID}
+			}
+		}
+		change.isClosed = opts.IsClosed
+	}
+
+	if opts.ProjectColumn.Has() {
+		column := opts.ProjectColumn.Value()
+		if column == nil {
+			if err := issue.LoadProject(ctx); err != nil {
+				return nil, err
+			}
+			if issue.Project != nil {
+				change.projectColumn = opts.ProjectColumn
+			}
+		} else if issue.ProjectColumnID(ctx) != column.ID {
+			change.projectColumn = opts.ProjectColumn
+		}
+	}
+
+	return change, nil
+}
+
+// BulkEdit applies opts to all the issues in a single transaction and returns
+// the issues that were changed. If dryRun is true nothing is changed and the
+// issues that would be changed are returned. Every change creates the same
+// comments and notifications as the edit of a single issue, the notifications
+// being sent once the transaction is committed.
+func BulkEdit(ctx context.Context, doer *user_model.User, issues issues_model.IssueList, opts *BulkEditOptions, dryRun bool) (issues_model.IssueList, error) {
+	changes := make([]*bulkEditChange, 0, len(issues))
+	for _, issue := range issues {
+		change, err := planBulkEdit(ctx, issue, opts)
+		if err != nil {
+			return nil, err
+		}
+		if !change.isEmpty() {
+			changes = append(changes, change)
+		}
+	}
+
+	changed := make(issues_model.IssueList, 0, len(changes))
+	for _, change := range changes {
+		changed = append(changed, change.issue)
+	}
+	if dryRun || len(changes) == 0 {
+		return changed, nil
+	}
+
+	var notifications []func(context.Context)
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, change := range changes {
+			n, err := applyBulkEdit(ctx, doer, change)
+			if err != nil {
+				return err
+			}
+			notifications = append(notifications, n...)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, notify := range notifications {
+		notify(ctx)
+	}
+
+	return changed, nil
+}
+
+// applyBulkEdit makes the changes to a single issue and returns the
+// notifications to send once they are committed.
+func applyBulkEdit(ctx context.Context, doer *user_model.User, change *bulkEditChange) ([]func(context.Context), error) {
+	var notifications []func(context.Context)
+	issue := change.issue
+
+	if len(change.addLabels) > 0 {
+		if err := issues_model.NewIssueLabels(ctx, issue, change.addLabels, doer); err != nil {
+			return nil, err
+		}
+	}
+	for _, label := range change.removeLabels {
+		if err := issues_model.DeleteIssueLabel(ctx, issue, label, doer); err != nil {
+			return nil, err
+		}
+	}
+	if len(change.addLabels) > 0 || len(change.removeLabels) > 0 {
+		notifications = append(notifications, func(ctx context.Context) {
+			notify_service.IssueChangeLabels(ctx, doer, issue, change.addLabels, change.removeLabels)
+		})
+	}
+
+	if change.milestoneID.Has() {
+		oldMilestoneID := issue.MilestoneID
+		issue.MilestoneID = change.milestoneID.Value()
+		if err := changeMilestoneAssign(ctx, doer, issue, oldMilestoneID); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, func(ctx context.Context) {
+			notify_service.IssueChangeMilestone(ctx, doer, issue, oldMilestoneID)
+		})
+	}
+
+	toggleAssignees := func(users []*user_model.User) error {
+		for _, user := range users {
+			removed, comment, err := issues_model.ToggleIssueAssignee(ctx, issue, doer, user.ID)
+			if err != nil {
+				return err
+			}
+			notifications = append(notifications, func(ctx context.Context) {
+				notify_service.IssueChangeAssignee(ctx, doer, issue, user, removed, comment)
+			})
+		}
+		return nil
+	}
+	if err := toggleAssignees(change.removeAssignees); err != nil {
+		return nil, err
+	}
+	if err := toggleAssignees(change.addAssignees); err != nil {
+		return nil, err
+	}
+
+	if change.isClosed.Has() {
+		closed := change.isClosed.Value()
+		comment, err := issues_model.ChangeIssueStatus(ctx, issue, doer, closed)
+		if err != nil {
+			return nil, err
+		}
+		if closed {
+			if err := issues_model.FinishIssueStopwatchIfPossible(ctx, doer, issue); err != nil {
+				return nil, err
+			}
+		}
+		notifications = append(notifications, func(ctx context.Context) {
+			notify_service.IssueChangeStatus(ctx, doer, "", issue, comment, closed)
+		})
+	}
+
+	if change.projectColumn.Has() {
+		var projectID, columnID int64
+		if column := change.projectColumn.Value(); column != nil {
+			projectID, columnID = column.ProjectID, column.ID
+		}
+		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, projectID, columnID); err != nil {
+			return nil, err
+		}
+	}
+
+	return notifications, nil
+}
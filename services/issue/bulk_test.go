@@ -0,0 +1,85 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkEdit(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	label1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+	label2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+
+	loadIssues := func(ids ...int64) issues_model.IssueList {
+		issues, err := issues_model.GetIssuesByIDs(db.DefaultContext, ids, true)
+		require.NoError(t, err)
+		return issues
+	}
+	indexes := func(issues issues_model.IssueList) []int64 {
+		res := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			res = append(res, issue.Index)
+		}
+		return res
+	}
+
+	opts := &BulkEditOptions{
+		AddLabels:    []*issues_model.Label{label2},
+		RemoveLabels: []*issues_model.Label{label1},
+		MilestoneID:  optional.Some[int64](2),
+		IsClosed:     optional.Some(true),
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		changed, err := BulkEdit(db.DefaultContext, doer, loadIssues(1, 11), opts, true)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 5}, indexes(changed))
+
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 1})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 11, MilestoneID: 0}, "is_closed = ?", false)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		changed, err := BulkEdit(db.DefaultContext, doer, loadIssues(1, 11), opts, false)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 5}, indexes(changed))
+
+		for _, id := range []int64{1, 11} {
+			unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: id, LabelID: 1})
+			unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: id, LabelID: 2})
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: id, MilestoneID: 2, IsClosed: true})
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: id, Type: issues_model.CommentTypeMilestone, MilestoneID: 2})
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: id, Type: issues_model.CommentTypeClose})
+		}
+		unittest.CheckConsistencyFor(t, &issues_model.Milestone{}, &issues_model.Issue{})
+
+		changed, err = BulkEdit(db.DefaultContext, doer, loadIssues(1, 11), opts, false)
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+
+	t.Run("Merged pull request", func(t *testing.T) {
+		_, err := BulkEdit(db.DefaultContext, doer, loadIssues(5, 2), &BulkEditOptions{
+			MilestoneID: optional.Some[int64](3),
+			IsClosed:    optional.Some(true),
+		}, false)
+		require.True(t, models.IsErrPullRequestHasMerged(err))
+
+		// the transaction is never started, issue 5 is left untouched
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5, MilestoneID: 0})
+	})
+}
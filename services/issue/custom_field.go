@@ -0,0 +1,91 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+)
+
+// CustomFieldValues are the values of a custom field set on an issue. The
+// values of CustomFieldTypeUser fields are user names.
+type CustomFieldValues struct {
+	Field  *issues_model.CustomField
+	Values []string // no values remove the field from the issue
+}
+
+// SetCustomFields sets the values of custom fields of its repository or of
+// the owner of its repository on the issue
+func SetCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, changes []CustomFieldValues) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	normalized := make([][]string, len(changes))
+	for i, change := range changes {
+		field := change.Field
+		if field.RepoID != issue.RepoID && (!field.BelongsToOrg() || field.OrgID != issue.Repo.OwnerID) {
+			return issues_model.ErrCustomFieldNotExist{ID: field.ID}
+		}
+		values, err := field.NormalizeValues(ctx, change.Values)
+		if err != nil {
+			return err
+		}
+		normalized[i] = values
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for i, change := range changes {
+			if err := issues_model.SetIssueCustomFieldValues(ctx, issue.ID, change.Field, normalized[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeCustomFields(ctx, doer, issue)
+	return nil
+}
+
+// UpdateCustomField updates a custom field, deleting the values of the options
+// that were removed
+func UpdateCustomField(ctx context.Context, doer *user_model.User, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetCustomFieldIssueIDs(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		return err
+	}
+	return notifyCustomFieldsChanged(ctx, doer, issueIDs)
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx context.Context, doer *user_model.User, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetCustomFieldIssueIDs(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		return err
+	}
+	return notifyCustomFieldsChanged(ctx, doer, issueIDs)
+}
+
+func notifyCustomFieldsChanged(ctx context.Context, doer *user_model.User, issueIDs []int64) error {
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		notify_service.IssueChangeCustomFields(ctx, doer, issue)
+	}
+	return nil
+}
@@ -22,12 +22,15 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/storage"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	notify_service "forgejo.org/services/notify"
 	"forgejo.org/services/stats"
 )
 
-// NewIssue creates new issue with labels for repository.
-func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, assigneeIDs []int64) error {
+// NewIssue creates new issue with labels for repository. The issue is added to
+// the project projectID, if not zero, before the notifiers run, so that the
+// automation rules of that project apply.
+func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, assigneeIDs []int64, projectID int64) error {
 	// Check if the user is not blocked by the repo's owner.
 	if user_model.IsBlocked(ctx, repo.OwnerID, issue.PosterID) {
 		return user_model.ErrBlockedByUser
@@ -43,6 +46,12 @@ func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_mo
 		}
 	}
 
+	if projectID > 0 {
+		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, issue.Poster, projectID, 0); err != nil {
+			return err
+		}
+	}
+
 	mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, issue, issue.Poster, issue.Content)
 	if err != nil {
 		return err
@@ -114,6 +123,15 @@ func ChangeIssueRef(ctx context.Context, issue *issues_model.Issue, doer *user_m
 	return nil
 }
 
+// ChangeIssueType changes the type of this issue.
+func ChangeIssueType(ctx context.Context, issue *issues_model.Issue, issueType issues_model.IssueType) error {
+	if issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests have no type")
+	}
+	issue.Type = issueType
+	return issues_model.ChangeIssueType(ctx, issue)
+}
+
 // UpdateAssignees is a helper function to add or delete one or multiple issue assignee(s)
 // Deleting is done the GitHub way (quote from their api documentation):
 // https://developer.github.com/v3/issues/#edit-an-issue
@@ -275,6 +293,9 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&activities_model.Notification{IssueID: issue.ID},
 		&issues_model.Reaction{IssueID: issue.ID},
 		&issues_model.IssueWatch{IssueID: issue.ID},
+		&issues_model.IssueReminder{IssueID: issue.ID},
+		&issues_model.IssueDeadlineReminder{IssueID: issue.ID},
+		&issues_model.IssueCustomFieldValue{IssueID: issue.ID},
 		&issues_model.Stopwatch{IssueID: issue.ID},
 		&issues_model.TrackedTime{IssueID: issue.ID},
 		&project_model.ProjectIssue{IssueID: issue.ID},
@@ -282,7 +303,11 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.PullRequest{IssueID: issue.ID},
 		&issues_model.Comment{RefIssueID: issue.ID},
 		&issues_model.IssueDependency{DependencyID: issue.ID},
+		&issues_model.SubIssue{IssueID: issue.ID},
+		&issues_model.SubIssue{ParentID: issue.ID},
 		&issues_model.Comment{DependentIssueID: issue.ID},
+		&issues_model.FederatedIssue{IssueID: issue.ID},
+		&issues_model.FederatedComment{IssueID: issue.ID},
 	); err != nil {
 		return err
 	}
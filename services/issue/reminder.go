@@ -0,0 +1,129 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	notify_service "forgejo.org/services/notify"
+)
+
+// canBeReminded reports whether the user can be reminded of the issue: they
+// must still be able to sign in and to read the issue
+func canBeReminded(ctx context.Context, user *user_model.User, issue *issues_model.Issue) (bool, error) {
+	if !user.IsActive || user.ProhibitLogin {
+		return false, nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, user)
+	if err != nil {
+		return false, err
+	}
+	return perm.CanReadIssuesOrPulls(issue.IsPull), nil
+}
+
+// SendDueDateReminders reminds the assignees who opted in of the open issues
+// due within daysBefore days and of those whose deadline passed less than
+// maxOverdue ago. Every deadline is reminded of once of each kind.
+func SendDueDateReminders(ctx context.Context, daysBefore int64, maxOverdue time.Duration) error {
+	now := timeutil.TimeStampNow()
+	if err := sendDueDateReminders(ctx, issues_model.ReminderTypeOverdue, now.AddDuration(-maxOverdue), now); err != nil {
+		return err
+	}
+	return sendDueDateReminders(ctx, issues_model.ReminderTypeDueSoon, now, now.Add(daysBefore*24*60*60))
+}
+
+func sendDueDateReminders(ctx context.Context, reminderType issues_model.ReminderType, from, to timeutil.TimeStamp) error {
+	issues, err := issues_model.FindIssuesToRemindOfDeadline(ctx, reminderType, from, to)
+	if err != nil {
+		return err
+	}
+	if err := issues.LoadAssignees(ctx); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		for _, assignee := range issue.Assignees {
+			optedIn, err := user_model.GetUserSetting(ctx, assignee.ID, user_model.SettingsKeyDueDateReminders)
+			if err != nil {
+				return err
+			}
+			if optedIn != "true" {
+				continue
+			}
+			ok, err := canBeReminded(ctx, assignee, issue)
+			if err != nil {
+				return err
+			}
+			if ok {
+				notify_service.IssueReminder(ctx, assignee, issue, reminderType)
+			}
+		}
+
+		if err := issues_model.RecordIssueDeadlineReminder(ctx, issue, reminderType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendIssueReminders sends the reminders users set on issues for themselves
+// that are due and deletes them
+func SendIssueReminders(ctx context.Context) error {
+	reminders, err := issues_model.FindDueIssueReminders(ctx, timeutil.TimeStampNow())
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := issues_model.DeleteIssueReminder(ctx, reminder.UserID, reminder.IssueID); err != nil {
+			return err
+		}
+
+		issue, err := issues_model.GetIssueByID(ctx, reminder.IssueID)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				continue
+			}
+			return err
+		}
+		user, err := user_model.GetUserByID(ctx, reminder.UserID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return err
+		}
+		ok, err := canBeReminded(ctx, user, issue)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.Debug("Skip the reminder of %s on issue %d, they cannot read it anymore", user.Name, issue.ID)
+			continue
+		}
+		notify_service.IssueReminder(ctx, user, issue, issues_model.ReminderTypePersonal)
+	}
+	return nil
+}
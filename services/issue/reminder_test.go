@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendDueDateReminders(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue.DeadlineUnix = timeutil.TimeStampNow().Add(60 * 60)
+	require.NoError(t, issues_model.UpdateIssueCols(db.DefaultContext, issue, "deadline_unix"))
+
+	require.NoError(t, SendDueDateReminders(db.DefaultContext, 1, 7*24*time.Hour))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueDeadlineReminder{IssueID: 1, Type: issues_model.ReminderTypeDueSoon, DeadlineUnix: issue.DeadlineUnix})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueDeadlineReminder{IssueID: 1, Type: issues_model.ReminderTypeOverdue})
+
+	// each deadline is reminded of once
+	require.NoError(t, SendDueDateReminders(db.DefaultContext, 1, 7*24*time.Hour))
+	unittest.AssertCount(t, &issues_model.IssueDeadlineReminder{IssueID: 1}, 1)
+}
+
+func TestSendIssueReminders(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	require.NoError(t, issues_model.SetIssueReminder(db.DefaultContext, 2, 1, now-10))
+	require.NoError(t, issues_model.SetIssueReminder(db.DefaultContext, 2, 2, now+3600))
+
+	require.NoError(t, SendIssueReminders(db.DefaultContext))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueReminder{UserID: 2, IssueID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueReminder{UserID: 2, IssueID: 2})
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplIssueReminder base.TplName = "issue/reminder"
+)
+
+// MailIssueReminder reminds the receiver of an issue that is due soon, overdue
+// or for which they set a reminder
+func MailIssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) error {
+	if setting.MailService == nil {
+		// No mail service configured
+		return nil
+	}
+
+	if !receiver.IsActive || receiver.Email == "" || receiver.EmailNotificationsPreference == user_model.EmailNotificationsDisabled {
+		return nil
+	}
+
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale(receiver.Language)
+	subject := fallbackMailSubject(issue)
+	data := map[string]any{
+		"locale":    locale,
+		"Subject":   subject,
+		"Issue":     issue,
+		"Link":      issue.HTMLURL(),
+		"Deadline":  issue.DeadlineUnix.FormatDate(),
+		"IsDueSoon": reminderType == issues_model.ReminderTypeDueSoon,
+		"IsOverdue": reminderType == issues_model.ReminderTypeOverdue,
+		"Language":  locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplIssueReminder), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(receiver.EmailTo(), subject, content.String())
+	msg.Info = subject
+	SendAsync(msg)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplWebhookDisabled base.TplName = "notify/webhook_disabled"
+)
+
+// webhookOwners returns the users responsible for a webhook and the link to its settings: the
+// owners of the repository or of the organization it belongs to, or the site administrators
+func webhookOwners(ctx context.Context, w *webhook_model.Webhook) ([]*user_model.User, string, error) {
+	hookPath := "/settings/hooks/" + strconv.FormatInt(w.ID, 10)
+
+	var owner *user_model.User
+	var link string
+	switch {
+	case w.RepoID > 0:
+		repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return nil, "", err
+		}
+		owner, link = repo.Owner, repo.HTMLURL()+hookPath
+	case w.OwnerID > 0:
+		u, err := user_model.GetUserByID(ctx, w.OwnerID)
+		if err != nil {
+			return nil, "", err
+		}
+		owner = u
+		if u.IsOrganization() {
+			link = setting.AppURL + "org/" + u.Name + hookPath
+		} else {
+			link = setting.AppURL + "user" + hookPath
+		}
+	default:
+		admins, err := user_model.GetAllAdmins(ctx)
+		return admins, setting.AppURL + "-/admin/hooks/" + strconv.FormatInt(w.ID, 10), err
+	}
+
+	if !owner.IsOrganization() {
+		return []*user_model.User{owner}, link, nil
+	}
+	team, err := organization.GetOwnerTeam(ctx, owner.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: team.ID})
+	return members, link, err
+}
+
+// MailWebhookDisabled notifies the owners of a webhook that it was deactivated because too
+// many deliveries failed in a row
+func MailWebhookDisabled(ctx context.Context, w *webhook_model.Webhook, failures int) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	recipients, link, err := webhookOwners(ctx, w)
+	if err != nil {
+		log.Error("webhookOwners [%d]: %v", w.ID, err)
+		return
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, u := range recipients {
+		if !u.IsActive || u.Email == "" {
+			continue
+		}
+		langMap[u.Language] = append(langMap[u.Language], u)
+	}
+
+	for lang, tos := range langMap {
+		locale := translation.NewLocale(lang)
+		subject := locale.TrString("mail.webhook_disabled.subject", w.URL)
+		data := map[string]any{
+			"locale":   locale,
+			"Subject":  subject,
+			"URL":      w.URL,
+			"Failures": failures,
+			"Link":     link,
+			"Language": locale.Language(),
+		}
+
+		var content bytes.Buffer
+		if err := bodyTemplates.ExecuteTemplate(&content, string(tplWebhookDisabled), data); err != nil {
+			log.Error("ExecuteTemplate [%s]: %v", tplWebhookDisabled, err)
+			return
+		}
+
+		msgs := make([]*Message, 0, len(tos))
+		for _, u := range tos {
+			msg := NewMessage(u.EmailTo(), subject, content.String())
+			msg.Info = subject
+			msgs = append(msgs, msg)
+		}
+		SendAsync(msgs...)
+	}
+}
@@ -94,6 +94,12 @@ func (m *mailNotifier) IssueChangeTitle(ctx context.Context, doer *user_model.Us
 	}
 }
 
+func (m *mailNotifier) IssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) {
+	if err := MailIssueReminder(ctx, receiver, issue, reminderType); err != nil {
+		log.Error("MailIssueReminder: %v", err)
+	}
+}
+
 func (m *mailNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
 	if err := MailParticipants(ctx, pr.Issue, pr.Issue.Poster, activities_model.ActionCreatePullRequest, mentions, nil); err != nil {
 		log.Error("MailParticipants: %v", err)
@@ -0,0 +1,509 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	pull_model "forgejo.org/models/pull"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/process"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/structs"
+	notify_service "forgejo.org/services/notify"
+	pull_service "forgejo.org/services/pull"
+	repo_service "forgejo.org/services/repository"
+	shared_mergequeue "forgejo.org/services/shared/mergequeue"
+)
+
+// The reasons a pull request is ejected from the merge queue, stored in the content of the comment
+const (
+	ReasonChecksFailed  = "checks_failed"
+	ReasonConflict      = "conflict"
+	ReasonHeadChanged   = "head_changed"
+	ReasonMergeFailed   = "merge_failed"
+	ReasonTargetChanged = "target_changed"
+	ReasonDisabled      = "disabled"
+)
+
+var (
+	// processing holds the merge queues being processed and whether they were requested again meanwhile
+	processing     = make(map[string]bool)
+	processingLock sync.Mutex
+)
+
+// Init runs the task queue that processes the merge queues
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	shared_mergequeue.MergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if shared_mergequeue.MergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(shared_mergequeue.MergeQueue)
+	return nil
+}
+
+// handle passed repository IDs and branches and process their merge queue
+func handler(items ...string) []string {
+	for _, s := range items {
+		id, branch, ok := strings.Cut(s, "_")
+		var repoID int64
+		if _, err := fmt.Sscan(id, &repoID); !ok || err != nil {
+			log.Error("could not parse data from pr_merge_queue queue (%v): %v", s, err)
+			continue
+		}
+		handleMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+// IsEnabled returns whether pull requests targeting the branch are merged through a merge queue
+func IsEnabled(ctx context.Context, repoID int64, branch string) (bool, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, branch)
+	if err != nil {
+		return false, err
+	}
+	return pb != nil && pb.EnableMergeQueue, nil
+}
+
+// StartMergeQueueChecks requests the merge queues of a repository to be processed, after the protected
+// branch rules changed
+func StartMergeQueueChecks(ctx context.Context, repoID int64) error {
+	branches, err := pull_model.GetMergeQueueBaseBranches(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	for _, branch := range branches {
+		shared_mergequeue.AddToQueue(repoID, branch)
+	}
+	return nil
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch, it is merged once the
+// checks of its speculative merge with the pull requests queued before it succeed
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, headCommitID, message string, deleteBranch bool) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+			RepoID:                 pr.BaseRepoID,
+			BaseBranch:             pr.BaseBranch,
+			PullID:                 pr.ID,
+			DoerID:                 doer.ID,
+			MergeStyle:             style,
+			Message:                message,
+			DeleteBranchAfterMerge: deleteBranch,
+			HeadCommitID:           headCommitID,
+		}); err != nil {
+			return err
+		}
+
+		return createMergeQueueComment(ctx, issues_model.CommentTypePRAddedToMergeQueue, pr, doer, "")
+	}); err != nil {
+		return err
+	}
+
+	shared_mergequeue.AddToQueue(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch
+func RemoveFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	exists, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return err
+	} else if !exists {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pr.ID}
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return err
+		}
+		return createMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, doer, "")
+	}); err != nil {
+		return err
+	}
+
+	deleteSpeculativeBranch(ctx, doer, pr, entry.BaseBranch)
+
+	// the pull requests queued after this one need to be rebuilt without it
+	shared_mergequeue.AddToQueue(entry.RepoID, entry.BaseBranch)
+	return nil
+}
+
+// GetMergeQueuePosition returns the position, starting at 1, of a pull request in the merge queue of its
+// base branch and the length of that queue. The position is 0 if the pull request is not queued.
+func GetMergeQueuePosition(ctx context.Context, pr *issues_model.PullRequest) (int, int, error) {
+	entries, err := pull_model.GetMergeQueueEntries(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i, entry := range entries {
+		if entry.PullID == pr.ID {
+			return i + 1, len(entries), nil
+		}
+	}
+	return 0, len(entries), nil
+}
+
+func createMergeQueueComment(ctx context.Context, typ issues_model.CommentType, pr *issues_model.PullRequest, doer *user_model.User, reason string) error {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	_, err := issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
+		Type:    typ,
+		Doer:    doer,
+		Repo:    pr.BaseRepo,
+		Issue:   pr.Issue,
+		Content: reason,
+	})
+	return err
+}
+
+func deleteSpeculativeBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, baseBranch string) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		log.Error("%-v LoadBaseRepo: %v", pr, err)
+		return
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return
+	}
+	defer gitRepo.Close()
+
+	branchName := pull_model.MergeQueueBranchName(baseBranch, pr.Index)
+	if !gitRepo.IsBranchExist(branchName) {
+		return
+	}
+	if err := repo_service.DeleteMergeQueueBranch(ctx, doer, pr.BaseRepo, gitRepo, branchName); err != nil {
+		log.Error("%-v DeleteBranch %s: %v", pr, branchName, err)
+	}
+}
+
+// ejectFromMergeQueue removes a pull request that can not be merged from the merge queue, the reason is
+// recorded in a comment unless it is empty
+func ejectFromMergeQueue(ctx context.Context, entry *pull_model.MergeQueueEntry, pr *issues_model.PullRequest, reason string) {
+	log.Trace("Ejecting %-v from the merge queue of %s: %s", pr, entry.BaseBranch, reason)
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return err
+		}
+		if reason == "" {
+			return nil
+		}
+		return createMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, entry.Doer, reason)
+	}); err != nil {
+		log.Error("%-v ejectFromMergeQueue: %v", pr, err)
+		return
+	}
+
+	deleteSpeculativeBranch(ctx, entry.Doer, pr, entry.BaseBranch)
+}
+
+type queuedPull struct {
+	entry *pull_model.MergeQueueEntry
+	pr    *issues_model.PullRequest
+}
+
+// handleMergeQueue brings the speculative merges of a merge queue up to date, merges the pull requests
+// whose checks succeeded and ejects the ones whose checks failed
+func handleMergeQueue(repoID int64, branch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of branch %s in repo %d", branch, repoID))
+	defer finished()
+
+	// Merging pushes to the branch, which requests its merge queue to be processed again while it still
+	// is. Rather than waiting for itself, the request is recorded and served once the current run is over.
+	key := fmt.Sprintf("%d_%s", repoID, branch)
+	processingLock.Lock()
+	if _, ok := processing[key]; ok {
+		processing[key] = true
+		processingLock.Unlock()
+		return
+	}
+	processing[key] = false
+	processingLock.Unlock()
+
+	for {
+		again := processMergeQueue(ctx, repoID, branch)
+
+		processingLock.Lock()
+		if !again && !processing[key] {
+			delete(processing, key)
+			processingLock.Unlock()
+			return
+		}
+		processing[key] = false
+		processingLock.Unlock()
+	}
+}
+
+// processMergeQueue runs through a merge queue once, it returns true if the queue changed in a way that
+// requires to run through it again
+func processMergeQueue(ctx context.Context, repoID int64, branch string) bool {
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetMergeQueueEntries[%d, %s]: %v", repoID, branch, err)
+		return false
+	} else if len(entries) == 0 {
+		return false
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return false
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule[%d, %s]: %v", repoID, branch, err)
+		return false
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return false
+	}
+	defer gitRepo.Close()
+
+	parentBranch := branch
+	parentCommitID, err := gitRepo.GetBranchCommitID(branch)
+	if err != nil {
+		log.Error("GetBranchCommitID[%s]: %v", branch, err)
+		return false
+	}
+
+	// Drop the pull requests that can not be merged anymore and make sure every other one has a speculative
+	// merge built on top of the speculative merge of the pull request queued before it.
+	queued := make([]*queuedPull, 0, len(entries))
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			log.Error("GetPullRequestByID[%d]: %v", entry.PullID, err)
+			return false
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			log.Error("%-v LoadIssue: %v", pr, err)
+			return false
+		}
+		pr.BaseRepo = repo
+		if err := entry.LoadDoer(ctx); err != nil {
+			log.Error("%-v LoadDoer: %v", pr, err)
+			return false
+		}
+
+		if pb == nil || !pb.EnableMergeQueue {
+			ejectFromMergeQueue(ctx, entry, pr, ReasonDisabled)
+			continue
+		}
+		if pr.HasMerged || pr.Issue.IsClosed {
+			ejectFromMergeQueue(ctx, entry, pr, "")
+			continue
+		}
+		if pr.BaseBranch != entry.BaseBranch {
+			ejectFromMergeQueue(ctx, entry, pr, ReasonTargetChanged)
+			continue
+		}
+
+		headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+		if err != nil {
+			log.Error("%-v GetRefCommitID: %v", pr, err)
+			return false
+		}
+		if headCommitID != entry.HeadCommitID {
+			ejectFromMergeQueue(ctx, entry, pr, ReasonHeadChanged)
+			continue
+		}
+
+		if entry.MergeCommitID == "" || !isSameTree(gitRepo, entry.BaseCommitID, parentCommitID) {
+			message := fmt.Sprintf("Merge queue: %s (#%d)", pr.Issue.Title, pr.Index)
+			mergeCommitID, err := pull_service.BuildSpeculativeMerge(ctx, pr, entry.Doer, entry.HeadCommitID, parentBranch, pull_model.MergeQueueBranchName(branch, pr.Index), message)
+			if err != nil {
+				if models.IsErrMergeConflicts(err) || models.IsErrMergeUnrelatedHistories(err) {
+					ejectFromMergeQueue(ctx, entry, pr, ReasonConflict)
+					continue
+				}
+				if models.IsErrSHADoesNotMatch(err) {
+					ejectFromMergeQueue(ctx, entry, pr, ReasonHeadChanged)
+					continue
+				}
+				log.Error("%-v BuildSpeculativeMerge: %v", pr, err)
+				return false
+			}
+
+			entry.BaseCommitID = parentCommitID
+			entry.MergeCommitID = mergeCommitID
+			if err := pull_model.UpdateMergeQueueEntrySpeculativeMerge(ctx, entry); err != nil {
+				log.Error("%-v UpdateMergeQueueEntrySpeculativeMerge: %v", pr, err)
+				return false
+			}
+		}
+
+		parentBranch = pull_model.MergeQueueBranchName(branch, pr.Index)
+		parentCommitID = entry.MergeCommitID
+		queued = append(queued, &queuedPull{entry: entry, pr: pr})
+	}
+
+	// A successful speculative merge vouches for every pull request queued before it, so they can all be
+	// merged. A failed one is only the fault of its own pull request if everything before it succeeded,
+	// otherwise it is rebuilt once the culprit has been ejected.
+	again := false
+	mergeUpTo := -1
+	for i, q := range queued {
+		state, err := getSpeculativeMergeState(ctx, pb, repo, q.entry.MergeCommitID)
+		if err != nil {
+			log.Error("%-v getSpeculativeMergeState: %v", q.pr, err)
+			return false
+		}
+
+		if state.IsSuccess() {
+			mergeUpTo = i
+			continue
+		}
+		if (state.IsFailure() || state.IsError()) && mergeUpTo == i-1 {
+			ejectFromMergeQueue(ctx, q.entry, q.pr, ReasonChecksFailed)
+			again = true
+		}
+		break
+	}
+
+	for _, q := range queued[:mergeUpTo+1] {
+		if merged, retry := mergeQueuedPull(ctx, gitRepo, q); !merged {
+			again = again || retry
+			break
+		}
+	}
+	return again
+}
+
+// isSameTree returns whether two commits have the same content. The pull requests merged from the queue
+// produce the same tree as their speculative merge, so the speculative merges queued after them remain
+// valid even though the merge commits are different.
+func isSameTree(gitRepo *git.Repository, commitID, otherCommitID string) bool {
+	if commitID == otherCommitID {
+		return true
+	}
+	if commitID == "" || otherCommitID == "" {
+		return false
+	}
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return false
+	}
+	otherCommit, err := gitRepo.GetCommit(otherCommitID)
+	if err != nil {
+		return false
+	}
+	return commit.Tree.ID.String() == otherCommit.Tree.ID.String()
+}
+
+// getSpeculativeMergeState returns the state of the required status checks of a speculative merge. It is
+// pending until a required check reported, and successful right away if the branch does not require any:
+// there may be no CI at all to wait for.
+func getSpeculativeMergeState(ctx context.Context, pb *git_model.ProtectedBranch, repo *repo_model.Repository, sha string) (structs.CommitStatusState, error) {
+	if !pb.EnableStatusCheck || len(pb.StatusCheckContexts) == 0 {
+		return structs.CommitStatusSuccess, nil
+	}
+
+	commitStatuses, err := git_model.GetLatestCommitStatusPerSource(ctx, repo.ID, sha)
+	if err != nil {
+		return "", err
+	}
+	if len(commitStatuses) == 0 {
+		return structs.CommitStatusPending, nil
+	}
+
+	state := pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts, pb.StatusCheckSources)
+	if state == "" {
+		return structs.CommitStatusPending, nil
+	}
+	return state, nil
+}
+
+// mergeQueuedPull merges a pull request whose speculative merge succeeded. It returns whether the pull
+// request was merged and, if not, whether the queue must be processed again right away
+func mergeQueuedPull(ctx context.Context, gitRepo *git.Repository, q *queuedPull) (merged, retry bool) {
+	doer, err := user_model.GetUserByID(ctx, q.entry.DoerID)
+	if err != nil {
+		log.Error("Unable to get merge queue User[%d]: %v", q.entry.DoerID, err)
+		ejectFromMergeQueue(ctx, q.entry, q.pr, ReasonMergeFailed)
+		return false, true
+	}
+
+	if err := q.pr.LoadHeadRepo(ctx); err != nil {
+		log.Error("%-v LoadHeadRepo: %v", q.pr, err)
+		return false, true
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, q.pr.BaseRepo, doer)
+	if err != nil {
+		log.Error("GetUserRepoPermission %-v: %v", q.pr.BaseRepo, err)
+		return false, true
+	}
+
+	// the speculative merge only proves the build of the merge is green, the reviews, the
+	// signatures, the work in progress status and the dependencies may have changed since
+	// the pull request was added to the queue
+	if err := pull_service.CheckPullMergeable(ctx, doer, &perm, q.pr, pull_service.MergeCheckTypeGeneral, false); err != nil {
+		if errors.Is(err, pull_service.ErrIsChecking) {
+			// the queue is processed again once the conflict checking is over
+			log.Debug("%-v is being checked for conflicts, it is merged later", q.pr)
+			return false, false
+		}
+		if errors.Is(err, pull_service.ErrUserNotAllowedToMerge) {
+			log.Info("%-v was added to the merge queue by an unauthorized user", q.pr)
+		} else {
+			log.Info("%-v is no longer mergeable: %v", q.pr, err)
+		}
+		ejectFromMergeQueue(ctx, q.entry, q.pr, ReasonMergeFailed)
+		return false, true
+	}
+
+	if err := pull_service.Merge(ctx, q.pr, doer, gitRepo, q.entry.MergeStyle, q.entry.HeadCommitID, q.entry.Message, true); err != nil {
+		log.Error("%-v pull_service.Merge: %v", q.pr, err)
+		ejectFromMergeQueue(ctx, q.entry, q.pr, ReasonMergeFailed)
+		return false, true
+	}
+
+	// the pull request is merged, there is nothing to comment on
+	ejectFromMergeQueue(ctx, q.entry, q.pr, "")
+
+	if q.entry.DeleteBranchAfterMerge && q.pr.HeadRepo != nil {
+		headGitRepo, err := gitrepo.OpenRepository(ctx, q.pr.HeadRepo)
+		if err != nil {
+			log.Error("OpenRepository %-v: %v", q.pr.HeadRepo, err)
+			return true, false
+		}
+		defer headGitRepo.Close()
+
+		if err := repo_service.DeleteBranchAfterMerge(ctx, doer, q.pr, headGitRepo); err != nil {
+			log.Error("%-v repo_service.DeleteBranchAfterMerge: %v", q.pr, err)
+		}
+	}
+	return true, false
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	pull_model "forgejo.org/models/pull"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/repository"
+	notify_service "forgejo.org/services/notify"
+	shared_mergequeue "forgejo.org/services/shared/mergequeue"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+func (n *mergeQueueNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() || strings.HasPrefix(opts.RefFullName.BranchName(), pull_model.MergeQueueBranchPrefix) {
+		return
+	}
+
+	// the speculative merges of the pull requests queued for this branch may have to be rebuilt
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repo.ID, opts.RefFullName.BranchName())
+	if err != nil {
+		log.Error("GetMergeQueueEntries: %v", err)
+		return
+	}
+	if len(entries) > 0 {
+		shared_mergequeue.AddToQueue(repo.ID, opts.RefFullName.BranchName())
+	}
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	shared_mergequeue.StartMergeQueueCheckByPull(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	shared_mergequeue.StartMergeQueueCheckByPull(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if !issue.IsPull || !isClosed {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	shared_mergequeue.StartMergeQueueCheckByPull(ctx, issue.PullRequest)
+}
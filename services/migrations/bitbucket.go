@@ -0,0 +1,810 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	base "forgejo.org/modules/migration"
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/structs"
+)
+
+var (
+	_ base.Downloader        = &BitbucketDownloader{}
+	_ base.DownloaderFactory = &BitbucketDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&BitbucketDownloaderFactory{})
+}
+
+// bitbucketCloudAPIURL is the base URL of the Bitbucket Cloud REST API
+const bitbucketCloudAPIURL = "https://api.bitbucket.org"
+
+// BitbucketDownloaderFactory defines a downloader factory for both Bitbucket Cloud and Bitbucket Server
+type BitbucketDownloaderFactory struct{}
+
+// New returns a downloader related to this factory according MigrateOptions
+func (f *BitbucketDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isBitbucketCloudHost(u.Hostname()) {
+		baseURL, projectKey, repoSlug, err := parseBitbucketServerURL(u)
+		if err != nil {
+			return nil, err
+		}
+		log.Trace("Create Bitbucket Server downloader. BaseURL: %s Project: %s RepoName: %s", baseURL, projectKey, repoSlug)
+		return NewBitbucketServerDownloader(ctx, baseURL, projectKey, repoSlug, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+	}
+
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid path: %s", u.Path)
+	}
+	workspace, repoSlug := fields[0], strings.TrimSuffix(fields[1], ".git")
+
+	log.Trace("Create Bitbucket Cloud downloader. Workspace: %s RepoName: %s", workspace, repoSlug)
+	return NewBitbucketDownloader(ctx, bitbucketCloudAPIURL, workspace, repoSlug, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *BitbucketDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.BitbucketService
+}
+
+// isBitbucketCloudHost reports whether host is the one of Bitbucket Cloud, any other host being a Bitbucket Server instance
+func isBitbucketCloudHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "bitbucket.org" || host == "www.bitbucket.org"
+}
+
+// setBitbucketAuth authenticates a request against Bitbucket, an access token taking precedence over the credentials
+func setBitbucketAuth(req *http.Request, username, password, token string) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+}
+
+// formatBitbucketCloneURL adds authentication into remote URLs. Bitbucket accepts access tokens
+// as the password of the x-token-auth user.
+func formatBitbucketCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if opts.AuthToken != "" {
+		username := opts.AuthUsername
+		if username == "" {
+			username = "x-token-auth"
+		}
+		u.User = url.UserPassword(username, opts.AuthToken)
+	} else if opts.AuthUsername != "" {
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	} else {
+		u.User = nil
+	}
+	return u.String(), nil
+}
+
+type bitbucketUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	AccountID   string `json:"account_id"`
+	UUID        string `json:"uuid"`
+}
+
+// name returns the name the user is known by, which is its nickname when it has one
+func (u *bitbucketUser) name() string {
+	if u == nil {
+		return ""
+	}
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.DisplayName
+}
+
+type bitbucketLink struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+type bitbucketContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketRepository struct {
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	IsPrivate   bool   `json:"is_private"`
+	MainBranch  *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Owner *struct {
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+	} `json:"owner"`
+	Links struct {
+		HTML  bitbucketLink   `json:"html"`
+		Clone []bitbucketLink `json:"clone"`
+	} `json:"links"`
+}
+
+// bitbucketPage is the envelope of the paginated responses of Bitbucket Cloud
+type bitbucketPage[T any] struct {
+	Values []T    `json:"values"`
+	Page   int    `json:"page"`
+	Next   string `json:"next"`
+}
+
+type bitbucketIssue struct {
+	ID        int64            `json:"id"`
+	Title     string           `json:"title"`
+	Content   bitbucketContent `json:"content"`
+	State     string           `json:"state"`
+	Kind      string           `json:"kind"`
+	Priority  string           `json:"priority"`
+	Reporter  *bitbucketUser   `json:"reporter"`
+	Assignee  *bitbucketUser   `json:"assignee"`
+	CreatedOn time.Time        `json:"created_on"`
+	UpdatedOn time.Time        `json:"updated_on"`
+	Milestone *struct {
+		Name string `json:"name"`
+	} `json:"milestone"`
+	Component *struct {
+		Name string `json:"name"`
+	} `json:"component"`
+}
+
+type bitbucketComment struct {
+	ID        int64            `json:"id"`
+	Content   bitbucketContent `json:"content"`
+	User      *bitbucketUser   `json:"user"`
+	CreatedOn time.Time        `json:"created_on"`
+	UpdatedOn time.Time        `json:"updated_on"`
+	Deleted   bool             `json:"deleted"`
+	Inline    *struct {
+		Path string `json:"path"`
+		From *int   `json:"from"`
+		To   *int   `json:"to"`
+	} `json:"inline"`
+	Parent *struct {
+		ID int64 `json:"id"`
+	} `json:"parent"`
+}
+
+type bitbucketPullRequestEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit *struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Repository *struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int64                        `json:"id"`
+	Title       string                       `json:"title"`
+	Description string                       `json:"description"`
+	State       string                       `json:"state"`
+	Draft       bool                         `json:"draft"`
+	Author      *bitbucketUser               `json:"author"`
+	Source      bitbucketPullRequestEndpoint `json:"source"`
+	Destination bitbucketPullRequestEndpoint `json:"destination"`
+	MergeCommit *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+	CreatedOn    time.Time `json:"created_on"`
+	UpdatedOn    time.Time `json:"updated_on"`
+	Participants []struct {
+		User           *bitbucketUser `json:"user"`
+		Role           string         `json:"role"`
+		Approved       bool           `json:"approved"`
+		State          string         `json:"state"`
+		ParticipatedOn *time.Time     `json:"participated_on"`
+	} `json:"participants"`
+}
+
+type bitbucketIssueContext struct {
+	IsPullRequest bool
+}
+
+// Bitbucket Cloud issue attributes which are migrated as scoped labels
+var (
+	bitbucketIssueKinds      = []string{"bug", "enhancement", "proposal", "task"}
+	bitbucketIssuePriorities = []string{"trivial", "minor", "major", "critical", "blocker"}
+	bitbucketClosedStates    = []string{"duplicate", "invalid", "wontfix"}
+)
+
+// BitbucketDownloader implements a Downloader interface to get repository information
+// from Bitbucket Cloud
+//   - maxIssueIndex is updated in GetIssues() to ensure PR and Issue numbers do not overlap,
+//     because Bitbucket has individual Issue and Pull Request numbers.
+type BitbucketDownloader struct {
+	base.NullDownloader
+	ctx           context.Context
+	client        *http.Client
+	baseURL       *url.URL
+	cloneBaseURL  string
+	workspace     string
+	repoName      string
+	username      string
+	password      string
+	token         string
+	commitMap     map[string]string
+	commentMap    map[int64][]*bitbucketComment
+	maxIssueIndex int64
+}
+
+// NewBitbucketDownloader creates a Bitbucket Cloud downloader for the repository workspace/repoName,
+// baseURL being the one of the REST API
+func NewBitbucketDownloader(ctx context.Context, baseURL, workspace, repoName, username, password, token string) *BitbucketDownloader {
+	u, _ := url.Parse(baseURL)
+	return &BitbucketDownloader{
+		ctx:       ctx,
+		baseURL:   u,
+		workspace: workspace,
+		repoName:  repoName,
+		username:  username,
+		password:  password,
+		token:     token,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy: proxy.Proxy(),
+			},
+		},
+		commitMap:  make(map[string]string),
+		commentMap: make(map[int64][]*bitbucketComment),
+	}
+}
+
+// SetContext set context
+func (d *BitbucketDownloader) SetContext(ctx context.Context) {
+	d.ctx = ctx
+}
+
+// String implements Stringer
+func (d *BitbucketDownloader) String() string {
+	return fmt.Sprintf("migration from Bitbucket Cloud %s/%s", d.workspace, d.repoName)
+}
+
+func (d *BitbucketDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketDownloader %s %s/%s>", d.baseURL, d.workspace, d.repoName)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/2.0/repositories/%s/%s", url.PathEscape(d.workspace), url.PathEscape(d.repoName)) + fmt.Sprintf(format, args...)
+}
+
+func (d *BitbucketDownloader) callAPI(endpoint string, parameter url.Values, result any) error {
+	u, err := d.baseURL.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if parameter != nil {
+		u.RawQuery = parameter.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(d.ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Forgejo/"+setting.AppVer)
+	setBitbucketAuth(req, d.username, d.password, d.token)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s for %s", resp.Status, endpoint)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// bitbucketAllPages collects the values of all the pages of a paginated endpoint
+func bitbucketAllPages[T any](d *BitbucketDownloader, endpoint string, parameter url.Values) ([]T, error) {
+	if parameter == nil {
+		parameter = url.Values{}
+	}
+	parameter.Set("pagelen", "100")
+
+	var values []T
+	for page := 1; ; page++ {
+		parameter.Set("page", strconv.Itoa(page))
+		var result bitbucketPage[T]
+		if err := d.callAPI(endpoint, parameter, &result); err != nil {
+			return nil, err
+		}
+		values = append(values, result.Values...)
+		if result.Next == "" {
+			return values, nil
+		}
+	}
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketDownloader) GetRepoInfo() (*base.Repository, error) {
+	var repo bitbucketRepository
+	if err := d.callAPI(d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	var cloneURL string
+	for _, link := range repo.Links.Clone {
+		if link.Name == "https" {
+			cloneURL = link.Href
+		}
+	}
+	u, err := url.Parse(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	u.User = nil
+	d.cloneBaseURL = u.Scheme + "://" + u.Host
+
+	var defaultBranch, owner string
+	if repo.MainBranch != nil {
+		defaultBranch = repo.MainBranch.Name
+	}
+	if repo.Owner != nil {
+		owner = repo.Owner.Username
+	}
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         owner,
+		IsPrivate:     repo.IsPrivate,
+		Description:   repo.Description,
+		CloneURL:      u.String(),
+		OriginalURL:   repo.Links.HTML.Href,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+// GetMilestones returns milestones
+func (d *BitbucketDownloader) GetMilestones() ([]*base.Milestone, error) {
+	rawMilestones, err := bitbucketAllPages[struct {
+		Name string `json:"name"`
+	}](d, d.repoEndpoint("/milestones"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := make([]*base.Milestone, 0, len(rawMilestones))
+	for _, milestone := range rawMilestones {
+		milestones = append(milestones, &base.Milestone{
+			Title: milestone.Name,
+			State: "open",
+		})
+	}
+	return milestones, nil
+}
+
+// GetLabels returns labels. Bitbucket Cloud has no labels: the kinds, priorities and components
+// of the issues as well as the reasons they were closed for are migrated as scoped labels.
+func (d *BitbucketDownloader) GetLabels() ([]*base.Label, error) {
+	components, err := bitbucketAllPages[struct {
+		Name string `json:"name"`
+	}](d, d.repoEndpoint("/components"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, len(bitbucketIssueKinds)+len(bitbucketIssuePriorities)+len(bitbucketClosedStates)+len(components))
+	for _, kind := range bitbucketIssueKinds {
+		labels = append(labels, &base.Label{
+			Name:      "Kind/" + kind,
+			Color:     "428bca",
+			Exclusive: true,
+		})
+	}
+	for _, priority := range bitbucketIssuePriorities {
+		labels = append(labels, &base.Label{
+			Name:      "Priority/" + priority,
+			Color:     "d9534f",
+			Exclusive: true,
+		})
+	}
+	for _, state := range bitbucketClosedStates {
+		labels = append(labels, &base.Label{
+			Name:        "Closed As/" + state,
+			Description: "Closed with the reason of " + state,
+			Color:       "7f8c8d",
+			Exclusive:   true,
+		})
+	}
+	for _, component := range components {
+		labels = append(labels, &base.Label{
+			Name:      "Component/" + component.Name,
+			Color:     "5cb85c",
+			Exclusive: true,
+		})
+	}
+	return labels, nil
+}
+
+// GetReleases returns releases. Bitbucket Cloud has no releases, they are migrated from the tags.
+func (d *BitbucketDownloader) GetReleases() ([]*base.Release, error) {
+	tags, err := bitbucketAllPages[struct {
+		Name    string     `json:"name"`
+		Message string     `json:"message"`
+		Date    *time.Time `json:"date"`
+		Tagger  *struct {
+			User *bitbucketUser `json:"user"`
+		} `json:"tagger"`
+		Target struct {
+			Hash   string    `json:"hash"`
+			Date   time.Time `json:"date"`
+			Author struct {
+				User *bitbucketUser `json:"user"`
+			} `json:"author"`
+		} `json:"target"`
+	}](d, d.repoEndpoint("/refs/tags"), url.Values{"sort": {"-target.date"}})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*base.Release, 0, len(tags))
+	for _, tag := range tags {
+		created := tag.Target.Date
+		if tag.Date != nil {
+			created = *tag.Date
+		}
+		publisher := tag.Target.Author.User
+		if tag.Tagger != nil && tag.Tagger.User != nil {
+			publisher = tag.Tagger.User
+		}
+		releases = append(releases, &base.Release{
+			TagName:         tag.Name,
+			TargetCommitish: tag.Target.Hash,
+			Name:            tag.Name,
+			Body:            strings.TrimSpace(tag.Message),
+			Created:         created,
+			Published:       created,
+			PublisherID:     -1,
+			PublisherName:   publisher.name(),
+		})
+	}
+	return releases, nil
+}
+
+// GetIssues returns issues according start and limit
+func (d *BitbucketDownloader) GetIssues(page, perPage int) ([]*base.Issue, bool, error) {
+	var result bitbucketPage[bitbucketIssue]
+	err := d.callAPI(
+		d.repoEndpoint("/issues"),
+		url.Values{
+			"page":    {strconv.Itoa(page)},
+			"pagelen": {strconv.Itoa(perPage)},
+			"sort":    {"id"},
+		},
+		&result,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(result.Values))
+	for _, issue := range result.Values {
+		labels := make([]*base.Label, 0, 4)
+		if issue.Kind != "" {
+			labels = append(labels, &base.Label{Name: "Kind/" + issue.Kind})
+		}
+		if issue.Priority != "" {
+			labels = append(labels, &base.Label{Name: "Priority/" + issue.Priority})
+		}
+		if issue.Component != nil {
+			labels = append(labels, &base.Label{Name: "Component/" + issue.Component.Name})
+		}
+
+		state := "open"
+		var closed *time.Time
+		switch issue.State {
+		case "new", "open", "on hold":
+		default:
+			state = "closed"
+			closed = &issue.UpdatedOn
+			for _, closedState := range bitbucketClosedStates {
+				if issue.State == closedState {
+					labels = append(labels, &base.Label{Name: "Closed As/" + closedState})
+				}
+			}
+		}
+
+		var milestone string
+		if issue.Milestone != nil {
+			milestone = issue.Milestone.Name
+		}
+		var assignees []string
+		if issue.Assignee != nil {
+			assignees = []string{issue.Assignee.name()}
+		}
+
+		d.maxIssueIndex = max(d.maxIssueIndex, issue.ID)
+		issues = append(issues, &base.Issue{
+			Number:       issue.ID,
+			Title:        issue.Title,
+			Content:      issue.Content.Raw,
+			PosterID:     -1,
+			PosterName:   issue.Reporter.name(),
+			Milestone:    milestone,
+			State:        state,
+			Created:      issue.CreatedOn,
+			Updated:      issue.UpdatedOn,
+			Closed:       closed,
+			Labels:       labels,
+			Assignees:    assignees,
+			ForeignIndex: issue.ID,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		})
+	}
+
+	return issues, result.Next == "", nil
+}
+
+// getPullRequestComments returns the comments of a pull request, which are shared by its comments and reviews
+func (d *BitbucketDownloader) getPullRequestComments(index int64) ([]*bitbucketComment, error) {
+	if comments, ok := d.commentMap[index]; ok {
+		return comments, nil
+	}
+	comments, err := bitbucketAllPages[*bitbucketComment](d, d.repoEndpoint("/pullrequests/%d/comments", index), nil)
+	if err != nil {
+		return nil, err
+	}
+	d.commentMap[index] = comments
+	return comments, nil
+}
+
+// GetComments returns comments according issueNumber
+func (d *BitbucketDownloader) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	context, ok := commentable.GetContext().(bitbucketIssueContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+
+	var rawComments []*bitbucketComment
+	var err error
+	if context.IsPullRequest {
+		rawComments, err = d.getPullRequestComments(commentable.GetForeignIndex())
+	} else {
+		rawComments, err = bitbucketAllPages[*bitbucketComment](d, d.repoEndpoint("/issues/%d/comments", commentable.GetForeignIndex()), nil)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(rawComments))
+	for _, comment := range rawComments {
+		// the inline comments are migrated with the reviews and the issue comments
+		// without content are the records of the changes made to the issue
+		if comment.Deleted || comment.Inline != nil || comment.Content.Raw == "" {
+			continue
+		}
+		comments = append(comments, &base.Comment{
+			IssueIndex: commentable.GetLocalIndex(),
+			Index:      comment.ID,
+			PosterID:   -1,
+			PosterName: comment.User.name(),
+			Content:    comment.Content.Raw,
+			Created:    comment.CreatedOn,
+			Updated:    comment.UpdatedOn,
+		})
+	}
+	return comments, true, nil
+}
+
+// resolveCommit returns the full hash of a commit of repository, Bitbucket Cloud abbreviating them
+// in pull requests
+func (d *BitbucketDownloader) resolveCommit(repository, hash string) string {
+	if hash == "" || repository == "" {
+		return ""
+	}
+	if fullHash, ok := d.commitMap[hash]; ok {
+		return fullHash
+	}
+
+	var commit struct {
+		Hash string `json:"hash"`
+	}
+	if err := d.callAPI("/2.0/repositories/"+repository+"/commit/"+url.PathEscape(hash), nil, &commit); err != nil {
+		log.Warn("Bitbucket: unable to resolve commit %s of %s: %v", hash, repository, err)
+		return ""
+	}
+	d.commitMap[hash] = commit.Hash
+	return commit.Hash
+}
+
+func (d *BitbucketDownloader) convertPullRequestEndpoint(endpoint bitbucketPullRequestEndpoint) base.PullRequestBranch {
+	branch := base.PullRequestBranch{
+		Ref: endpoint.Branch.Name,
+	}
+	if endpoint.Repository == nil {
+		// the repository of the branch was deleted
+		return branch
+	}
+	owner, _, _ := strings.Cut(endpoint.Repository.FullName, "/")
+	branch.OwnerName = owner
+	branch.RepoName = endpoint.Repository.Name
+	branch.CloneURL = d.cloneBaseURL + "/" + endpoint.Repository.FullName + ".git"
+	if endpoint.Commit != nil {
+		branch.SHA = d.resolveCommit(endpoint.Repository.FullName, endpoint.Commit.Hash)
+	}
+	return branch
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (d *BitbucketDownloader) GetPullRequests(page, perPage int) ([]*base.PullRequest, bool, error) {
+	var result bitbucketPage[bitbucketPullRequest]
+	err := d.callAPI(
+		d.repoEndpoint("/pullrequests"),
+		url.Values{
+			"page":    {strconv.Itoa(page)},
+			"pagelen": {strconv.Itoa(perPage)},
+			"sort":    {"id"},
+			"state":   {"OPEN", "MERGED", "DECLINED", "SUPERSEDED"},
+		},
+		&result,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Values))
+	for _, pr := range result.Values {
+		state := "closed"
+		var closed *time.Time
+		if pr.State == "OPEN" {
+			state = "open"
+		} else {
+			closed = &pr.UpdatedOn
+		}
+
+		merged := pr.State == "MERGED"
+		var mergedTime *time.Time
+		var mergeCommitSHA string
+		if merged {
+			mergedTime = &pr.UpdatedOn
+			if pr.MergeCommit != nil {
+				mergeCommitSHA = d.resolveCommit(d.workspace+"/"+d.repoName, pr.MergeCommit.Hash)
+			}
+		}
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         d.maxIssueIndex + pr.ID,
+			Title:          pr.Title,
+			Content:        pr.Description,
+			PosterID:       -1,
+			PosterName:     pr.Author.name(),
+			State:          state,
+			Created:        pr.CreatedOn,
+			Updated:        pr.UpdatedOn,
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			Head:           d.convertPullRequestEndpoint(pr.Source),
+			Base:           d.convertPullRequestEndpoint(pr.Destination),
+			IsDraft:        pr.Draft,
+			ForeignIndex:   pr.ID,
+			Context:        bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.cloneBaseURL, d)
+	}
+
+	return pullRequests, result.Next == "", nil
+}
+
+// GetReviews returns the approvals of a pull request as well as its inline comments
+func (d *BitbucketDownloader) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	var pr bitbucketPullRequest
+	if err := d.callAPI(d.repoEndpoint("/pullrequests/%d", reviewable.GetForeignIndex()), nil, &pr); err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(pr.Participants))
+	for _, participant := range pr.Participants {
+		var state string
+		switch {
+		case participant.Approved:
+			state = base.ReviewStateApproved
+		case participant.State == "changes_requested":
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		createdAt := pr.UpdatedOn
+		if participant.ParticipatedOn != nil {
+			createdAt = *participant.ParticipatedOn
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerID:   -1,
+			ReviewerName: participant.User.name(),
+			CreatedAt:    createdAt,
+			State:        state,
+		})
+	}
+
+	comments, err := d.getPullRequestComments(reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+	var headSHA string
+	if pr.Source.Commit != nil && pr.Source.Repository != nil {
+		headSHA = d.resolveCommit(pr.Source.Repository.FullName, pr.Source.Commit.Hash)
+	}
+	for _, comment := range comments {
+		if comment.Deleted || comment.Inline == nil {
+			continue
+		}
+		reviewComment := &base.ReviewComment{
+			ID:        comment.ID,
+			Content:   comment.Content.Raw,
+			TreePath:  comment.Inline.Path,
+			CommitID:  headSHA,
+			PosterID:  -1,
+			CreatedAt: comment.CreatedOn,
+			UpdatedAt: comment.UpdatedOn,
+		}
+		if comment.Parent != nil {
+			reviewComment.InReplyTo = comment.Parent.ID
+		}
+		// comments on removed lines only have a line in the original file
+		switch {
+		case comment.Inline.To != nil:
+			reviewComment.Line = *comment.Inline.To
+			reviewComment.DiffHunk = fmt.Sprintf("@@ -%d +%d @@", *comment.Inline.To, *comment.Inline.To)
+		case comment.Inline.From != nil:
+			reviewComment.Line = -*comment.Inline.From
+			reviewComment.DiffHunk = fmt.Sprintf("@@ -%d +%d @@", *comment.Inline.From, *comment.Inline.From)
+		}
+		reviews = append(reviews, &base.Review{
+			ID:           comment.ID,
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerID:   -1,
+			ReviewerName: comment.User.name(),
+			CommitID:     headSHA,
+			CreatedAt:    comment.CreatedOn,
+			State:        base.ReviewStateCommented,
+			Comments:     []*base.ReviewComment{reviewComment},
+		})
+	}
+
+	return reviews, nil
+}
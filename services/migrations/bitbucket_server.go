@@ -0,0 +1,541 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	base "forgejo.org/modules/migration"
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+)
+
+var _ base.Downloader = &BitbucketServerDownloader{}
+
+// parseBitbucketServerURL splits the URL of a Bitbucket Server repository, be it its clone URL
+// (/scm/PROJECT/repo.git) or the one of its web pages (/projects/PROJECT/repos/repo/browse), into
+// the base URL of the instance, the key of the project and the slug of the repository.
+// The repositories of users belong to the project ~USER.
+func parseBitbucketServerURL(u *url.URL) (baseURL, projectKey, repoSlug string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, field := range fields {
+		switch {
+		case field == "scm" && len(fields) >= i+3:
+			projectKey, repoSlug = fields[i+1], strings.TrimSuffix(fields[i+2], ".git")
+		case (field == "projects" || field == "users") && len(fields) >= i+4 && fields[i+2] == "repos":
+			projectKey, repoSlug = fields[i+1], fields[i+3]
+			if field == "users" {
+				projectKey = "~" + projectKey
+			}
+		default:
+			continue
+		}
+
+		instance := *u
+		instance.User, instance.RawQuery, instance.Fragment = nil, "", ""
+		instance.Path = "/" + strings.Join(fields[:i], "/")
+		return strings.TrimSuffix(instance.String(), "/"), projectKey, repoSlug, nil
+	}
+	return "", "", "", fmt.Errorf("invalid path: %s", u.Path)
+}
+
+type bitbucketServerUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type bitbucketServerRepository struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	Project     struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Clone []bitbucketLink `json:"clone"`
+		Self  []bitbucketLink `json:"self"`
+	} `json:"links"`
+}
+
+// cloneURL returns the HTTP clone URL of the repository
+func (r *bitbucketServerRepository) cloneURL() string {
+	if r == nil {
+		return ""
+	}
+	for _, link := range r.Links.Clone {
+		if link.Name == "http" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// bitbucketServerPage is the envelope of the paginated responses of Bitbucket Server
+type bitbucketServerPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerRef struct {
+	ID           string                     `json:"id"`
+	DisplayID    string                     `json:"displayId"`
+	LatestCommit string                     `json:"latestCommit"`
+	Repository   *bitbucketServerRepository `json:"repository"`
+}
+
+type bitbucketServerPullRequest struct {
+	ID          int64              `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	State       string             `json:"state"`
+	Draft       bool               `json:"draft"`
+	Locked      bool               `json:"locked"`
+	CreatedDate int64              `json:"createdDate"`
+	UpdatedDate int64              `json:"updatedDate"`
+	ClosedDate  int64              `json:"closedDate"`
+	FromRef     bitbucketServerRef `json:"fromRef"`
+	ToRef       bitbucketServerRef `json:"toRef"`
+	Author      struct {
+		User bitbucketServerUser `json:"user"`
+	} `json:"author"`
+	Properties struct {
+		MergeCommit *struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+type bitbucketServerComment struct {
+	ID          int64                     `json:"id"`
+	Text        string                    `json:"text"`
+	Author      bitbucketServerUser       `json:"author"`
+	CreatedDate int64                     `json:"createdDate"`
+	UpdatedDate int64                     `json:"updatedDate"`
+	Comments    []*bitbucketServerComment `json:"comments"`
+}
+
+type bitbucketServerActivity struct {
+	ID            int64                   `json:"id"`
+	CreatedDate   int64                   `json:"createdDate"`
+	User          bitbucketServerUser     `json:"user"`
+	Action        string                  `json:"action"`
+	CommentAction string                  `json:"commentAction"`
+	Comment       *bitbucketServerComment `json:"comment"`
+	CommentAnchor *struct {
+		Path     string `json:"path"`
+		Line     int    `json:"line"`
+		LineType string `json:"lineType"`
+		ToHash   string `json:"toHash"`
+	} `json:"commentAnchor"`
+}
+
+// bitbucketServerTime converts the milliseconds since the epoch used by Bitbucket Server to a time
+func bitbucketServerTime(milliseconds int64) time.Time {
+	return time.UnixMilli(milliseconds).UTC()
+}
+
+// BitbucketServerDownloader implements a Downloader interface to get repository information
+// from Bitbucket Server and Bitbucket Data Center. They have no issue tracker, only the labels,
+// the pull requests and their reviews as well as the tags are migrated.
+type BitbucketServerDownloader struct {
+	base.NullDownloader
+	ctx         context.Context
+	client      *http.Client
+	baseURL     string
+	projectKey  string
+	repoName    string
+	username    string
+	password    string
+	token       string
+	activityMap map[int64][]*bitbucketServerActivity
+}
+
+// NewBitbucketServerDownloader creates a Bitbucket Server downloader for the repository repoName
+// of the project projectKey
+func NewBitbucketServerDownloader(ctx context.Context, baseURL, projectKey, repoName, username, password, token string) *BitbucketServerDownloader {
+	return &BitbucketServerDownloader{
+		ctx:        ctx,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		projectKey: projectKey,
+		repoName:   repoName,
+		username:   username,
+		password:   password,
+		token:      token,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy: proxy.Proxy(),
+			},
+		},
+		activityMap: make(map[int64][]*bitbucketServerActivity),
+	}
+}
+
+// SetContext set context
+func (d *BitbucketServerDownloader) SetContext(ctx context.Context) {
+	d.ctx = ctx
+}
+
+// String implements Stringer
+func (d *BitbucketServerDownloader) String() string {
+	return fmt.Sprintf("migration from Bitbucket Server %s %s/%s", d.baseURL, d.projectKey, d.repoName)
+}
+
+func (d *BitbucketServerDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketServerDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketServerDownloader %s %s/%s>", d.baseURL, d.projectKey, d.repoName)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketServerDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketServerDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", url.PathEscape(d.projectKey), url.PathEscape(d.repoName)) + fmt.Sprintf(format, args...)
+}
+
+func (d *BitbucketServerDownloader) callAPI(endpoint string, parameter url.Values, result any) error {
+	// the endpoint is appended since Bitbucket Server may be served from a sub path
+	u, err := url.Parse(d.baseURL + endpoint)
+	if err != nil {
+		return err
+	}
+	if parameter != nil {
+		u.RawQuery = parameter.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(d.ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Forgejo/"+setting.AppVer)
+	setBitbucketAuth(req, d.username, d.password, d.token)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s for %s", resp.Status, endpoint)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// bitbucketServerAllPages collects the values of all the pages of a paginated endpoint
+func bitbucketServerAllPages[T any](d *BitbucketServerDownloader, endpoint string, parameter url.Values) ([]T, error) {
+	if parameter == nil {
+		parameter = url.Values{}
+	}
+	parameter.Set("limit", "100")
+
+	var values []T
+	for start := 0; ; {
+		parameter.Set("start", strconv.Itoa(start))
+		var result bitbucketServerPage[T]
+		if err := d.callAPI(endpoint, parameter, &result); err != nil {
+			return nil, err
+		}
+		values = append(values, result.Values...)
+		if result.IsLastPage {
+			return values, nil
+		}
+		start = result.NextPageStart
+	}
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketServerDownloader) GetRepoInfo() (*base.Repository, error) {
+	var repo bitbucketServerRepository
+	if err := d.callAPI(d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	var defaultBranch struct {
+		DisplayID string `json:"displayId"`
+	}
+	if err := d.callAPI(d.repoEndpoint("/default-branch"), nil, &defaultBranch); err != nil {
+		// an empty repository has no default branch
+		log.Warn("Bitbucket Server: unable to get the default branch of %s/%s: %v", d.projectKey, d.repoName, err)
+	}
+
+	var originalURL string
+	if len(repo.Links.Self) > 0 {
+		originalURL = strings.TrimSuffix(repo.Links.Self[0].Href, "/browse")
+	}
+	cloneURL, err := url.Parse(repo.cloneURL())
+	if err != nil {
+		return nil, err
+	}
+	cloneURL.User = nil
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         repo.Project.Key,
+		IsPrivate:     !repo.Public,
+		Description:   repo.Description,
+		CloneURL:      cloneURL.String(),
+		OriginalURL:   originalURL,
+		DefaultBranch: defaultBranch.DisplayID,
+	}, nil
+}
+
+// GetLabels returns labels
+func (d *BitbucketServerDownloader) GetLabels() ([]*base.Label, error) {
+	rawLabels, err := bitbucketServerAllPages[struct {
+		Name string `json:"name"`
+	}](d, d.repoEndpoint("/labels"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, len(rawLabels))
+	for _, label := range rawLabels {
+		labels = append(labels, &base.Label{
+			Name:  label.Name,
+			Color: "428bca",
+		})
+	}
+	return labels, nil
+}
+
+// GetReleases returns releases. Bitbucket Server has no releases, they are migrated from the tags.
+func (d *BitbucketServerDownloader) GetReleases() ([]*base.Release, error) {
+	tags, err := bitbucketServerAllPages[bitbucketServerRef](d, d.repoEndpoint("/tags"), url.Values{"orderBy": {"MODIFICATION"}})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*base.Release, 0, len(tags))
+	for _, tag := range tags {
+		var commit struct {
+			Author          bitbucketServerUser `json:"author"`
+			AuthorTimestamp int64               `json:"authorTimestamp"`
+			Message         string              `json:"message"`
+		}
+		if err := d.callAPI(d.repoEndpoint("/commits/%s", url.PathEscape(tag.LatestCommit)), nil, &commit); err != nil {
+			return nil, err
+		}
+		created := bitbucketServerTime(commit.AuthorTimestamp)
+		releases = append(releases, &base.Release{
+			TagName:         tag.DisplayID,
+			TargetCommitish: tag.LatestCommit,
+			Name:            tag.DisplayID,
+			Created:         created,
+			Published:       created,
+			PublisherID:     commit.Author.ID,
+			PublisherName:   commit.Author.Name,
+			PublisherEmail:  commit.Author.EmailAddress,
+		})
+	}
+	return releases, nil
+}
+
+func (d *BitbucketServerDownloader) convertRef(ref bitbucketServerRef) base.PullRequestBranch {
+	branch := base.PullRequestBranch{
+		Ref: strings.TrimPrefix(ref.ID, "refs/heads/"),
+		SHA: ref.LatestCommit,
+	}
+	if ref.Repository != nil {
+		branch.OwnerName = ref.Repository.Project.Key
+		branch.RepoName = ref.Repository.Slug
+		branch.CloneURL = ref.Repository.cloneURL()
+	}
+	return branch
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (d *BitbucketServerDownloader) GetPullRequests(page, perPage int) ([]*base.PullRequest, bool, error) {
+	var result bitbucketServerPage[bitbucketServerPullRequest]
+	err := d.callAPI(
+		d.repoEndpoint("/pull-requests"),
+		url.Values{
+			"state": {"ALL"},
+			"order": {"OLDEST"},
+			"start": {strconv.Itoa((page - 1) * perPage)},
+			"limit": {strconv.Itoa(perPage)},
+		},
+		&result,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Values))
+	for _, pr := range result.Values {
+		state := "closed"
+		var closed *time.Time
+		if pr.State == "OPEN" {
+			state = "open"
+		} else if pr.ClosedDate != 0 {
+			closedDate := bitbucketServerTime(pr.ClosedDate)
+			closed = &closedDate
+		}
+
+		merged := pr.State == "MERGED"
+		var mergedTime *time.Time
+		var mergeCommitSHA string
+		if merged {
+			mergedTime = closed
+			if pr.Properties.MergeCommit != nil {
+				mergeCommitSHA = pr.Properties.MergeCommit.ID
+			}
+		}
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.ID,
+			Title:          pr.Title,
+			Content:        pr.Description,
+			PosterID:       pr.Author.User.ID,
+			PosterName:     pr.Author.User.Name,
+			PosterEmail:    pr.Author.User.EmailAddress,
+			State:          state,
+			Created:        bitbucketServerTime(pr.CreatedDate),
+			Updated:        bitbucketServerTime(pr.UpdatedDate),
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			IsLocked:       pr.Locked,
+			IsDraft:        pr.Draft,
+			Head:           d.convertRef(pr.FromRef),
+			Base:           d.convertRef(pr.ToRef),
+			ForeignIndex:   pr.ID,
+			Context:        bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.baseURL, d)
+	}
+
+	return pullRequests, result.IsLastPage, nil
+}
+
+// getActivities returns the activities of a pull request, which are shared by its comments and reviews
+func (d *BitbucketServerDownloader) getActivities(index int64) ([]*bitbucketServerActivity, error) {
+	if activities, ok := d.activityMap[index]; ok {
+		return activities, nil
+	}
+	activities, err := bitbucketServerAllPages[*bitbucketServerActivity](d, d.repoEndpoint("/pull-requests/%d/activities", index), nil)
+	if err != nil {
+		return nil, err
+	}
+	// the activities are listed from the most recent one
+	for i, j := 0, len(activities)-1; i < j; i, j = i+1, j-1 {
+		activities[i], activities[j] = activities[j], activities[i]
+	}
+	d.activityMap[index] = activities
+	return activities, nil
+}
+
+// flattenBitbucketServerComments returns the comment followed by all of its replies
+func flattenBitbucketServerComments(comment *bitbucketServerComment, parentID int64, visit func(comment *bitbucketServerComment, parentID int64)) {
+	visit(comment, parentID)
+	for _, reply := range comment.Comments {
+		flattenBitbucketServerComments(reply, comment.ID, visit)
+	}
+}
+
+// GetComments returns the general comments of a pull request and their replies
+func (d *BitbucketServerDownloader) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	activities, err := d.getActivities(commentable.GetForeignIndex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(activities))
+	for _, activity := range activities {
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil || activity.CommentAnchor != nil {
+			continue
+		}
+		flattenBitbucketServerComments(activity.Comment, 0, func(comment *bitbucketServerComment, _ int64) {
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterID:    comment.Author.ID,
+				PosterName:  comment.Author.Name,
+				PosterEmail: comment.Author.EmailAddress,
+				Content:     comment.Text,
+				Created:     bitbucketServerTime(comment.CreatedDate),
+				Updated:     bitbucketServerTime(comment.UpdatedDate),
+			})
+		})
+	}
+	return comments, true, nil
+}
+
+// GetReviews returns the approvals and the requests for changes of a pull request as well as its
+// comments on the diff
+func (d *BitbucketServerDownloader) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	activities, err := d.getActivities(reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(activities))
+	for _, activity := range activities {
+		review := &base.Review{
+			ID:           activity.ID,
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerID:   activity.User.ID,
+			ReviewerName: activity.User.Name,
+			CreatedAt:    bitbucketServerTime(activity.CreatedDate),
+		}
+		switch {
+		case activity.Action == "APPROVED":
+			review.State = base.ReviewStateApproved
+		case activity.Action == "REVIEWED":
+			// the reviewer marked the pull request as needing work
+			review.State = base.ReviewStateChangesRequested
+		case activity.Action == "COMMENTED" && activity.CommentAction == "ADDED" && activity.Comment != nil && activity.CommentAnchor != nil:
+			anchor := activity.CommentAnchor
+			line := anchor.Line
+			if anchor.LineType == "REMOVED" {
+				line = -line
+			}
+			review.State = base.ReviewStateCommented
+			review.CommitID = anchor.ToHash
+			flattenBitbucketServerComments(activity.Comment, 0, func(comment *bitbucketServerComment, parentID int64) {
+				reviewComment := &base.ReviewComment{
+					ID:        comment.ID,
+					InReplyTo: parentID,
+					Content:   comment.Text,
+					TreePath:  anchor.Path,
+					CommitID:  anchor.ToHash,
+					PosterID:  comment.Author.ID,
+					CreatedAt: bitbucketServerTime(comment.CreatedDate),
+					UpdatedAt: bitbucketServerTime(comment.UpdatedDate),
+				}
+				// comments on a whole file have no line
+				if line != 0 {
+					reviewComment.Line = line
+					reviewComment.DiffHunk = fmt.Sprintf("@@ -%d +%d @@", anchor.Line, anchor.Line)
+				}
+				review.Comments = append(review.Comments, reviewComment)
+			})
+		default:
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/unittest"
+	base "forgejo.org/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketServerDownloadRepo(t *testing.T) {
+	fixturePath := "./testdata/bitbucket_server/full_download"
+	server := unittest.NewMockWebServer(t, "https://bitbucket.example.com", fixturePath, false)
+	defer server.Close()
+
+	downloader := NewBitbucketServerDownloader(t.Context(), server.URL, "TEST", "test_repo", "", "", "")
+	repo, err := downloader.GetRepoInfo()
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "TEST",
+		IsPrivate:     true,
+		Description:   "Test repository for testing migration from bitbucket server to forgejo",
+		CloneURL:      server.URL + "/scm/test/test_repo.git",
+		OriginalURL:   server.URL + "/projects/TEST/repos/test_repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	// Bitbucket Server has no issue tracker
+	_, err = downloader.GetMilestones()
+	assert.True(t, base.IsErrNotSupported(err))
+	_, _, err = downloader.GetIssues(1, 10)
+	assert.True(t, base.IsErrNotSupported(err))
+
+	labels, err := downloader.GetLabels()
+	require.NoError(t, err)
+	assertLabelsEqual(t, []*base.Label{
+		{
+			Name:  "bug",
+			Color: "428bca",
+		},
+		{
+			Name:  "ui",
+			Color: "428bca",
+		},
+	}, labels)
+
+	releases, err := downloader.GetReleases()
+	require.NoError(t, err)
+	assertReleasesEqual(t, []*base.Release{
+		{
+			TagName:         "v1.0.0",
+			TargetCommitish: "0720a3ec57c1f843568298117b874319e7deee75",
+			Name:            "v1.0.0",
+			Created:         time.Date(2025, 3, 11, 13, 58, 12, 0, time.UTC),
+			Published:       time.Date(2025, 3, 11, 13, 58, 12, 0, time.UTC),
+			PublisherID:     101,
+			PublisherName:   "alice",
+			PublisherEmail:  "alice@example.com",
+		},
+	}, releases)
+
+	prs, isEnd, err := downloader.GetPullRequests(1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:      1,
+			Title:       "Fix the crash on startup",
+			Content:     "The crash happened when the configuration was missing",
+			PosterID:    102,
+			PosterName:  "bob",
+			PosterEmail: "bob@example.com",
+			State:       "closed",
+			Created:     time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC),
+			Closed:      timePtr(time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC)),
+			Head: base.PullRequestBranch{
+				Ref:       "fix-crash",
+				SHA:       "1d1e4b0c6a2f9f3c8e5d7b6a4c3e2f1a0b9c8d7e",
+				RepoName:  "test_repo",
+				OwnerName: "TEST",
+				CloneURL:  server.URL + "/scm/test/test_repo.git",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "0720a3ec57c1f843568298117b874319e7deee75",
+				RepoName:  "test_repo",
+				OwnerName: "TEST",
+				CloneURL:  server.URL + "/scm/test/test_repo.git",
+			},
+			Merged:         true,
+			MergedTime:     timePtr(time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC)),
+			MergeCommitSHA: "c59c2b5e4f1d7a3b8e6f9d0a1b2c3d4e5f6a7b8c",
+		},
+		{
+			Number:      2,
+			Title:       "Dark theme",
+			Content:     "A first draft of the dark theme",
+			PosterID:    101,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			State:       "open",
+			Created:     time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2025, 3, 12, 16, 0, 0, 0, time.UTC),
+			Head: base.PullRequestBranch{
+				Ref:       "dark-theme",
+				SHA:       "9f8e7d6c5b4a39281706f5e4d3c2b1a098765432",
+				RepoName:  "test_repo",
+				OwnerName: "~CONTRIBUTOR",
+				CloneURL:  server.URL + "/scm/~contributor/test_repo.git",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6",
+				RepoName:  "test_repo",
+				OwnerName: "TEST",
+				CloneURL:  server.URL + "/scm/test/test_repo.git",
+			},
+		},
+	}, prs)
+	assert.True(t, prs[1].IsDraft)
+	assert.True(t, prs[1].IsForkPullRequest())
+
+	// the comments on the diff are migrated as reviews
+	comments, _, err := downloader.GetComments(prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  1,
+			PosterID:    101,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			Created:     time.Date(2025, 3, 11, 9, 40, 0, 0, time.UTC),
+			Updated:     time.Date(2025, 3, 11, 9, 40, 0, 0, time.UTC),
+			Content:     "Thanks for the fix!",
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			ID:           302,
+			IssueIndex:   1,
+			ReviewerID:   101,
+			ReviewerName: "alice",
+			CommitID:     "1d1e4b0c6a2f9f3c8e5d7b6a4c3e2f1a0b9c8d7e",
+			CreatedAt:    time.Date(2025, 3, 11, 10, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        202,
+					Content:   "Please check for nil here",
+					TreePath:  "main.go",
+					DiffHunk:  "@@ -12 +12 @@",
+					Line:      12,
+					CommitID:  "1d1e4b0c6a2f9f3c8e5d7b6a4c3e2f1a0b9c8d7e",
+					PosterID:  101,
+					CreatedAt: time.Date(2025, 3, 11, 10, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2025, 3, 11, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:        203,
+					InReplyTo: 202,
+					Content:   "Done",
+					TreePath:  "main.go",
+					DiffHunk:  "@@ -12 +12 @@",
+					Line:      12,
+					CommitID:  "1d1e4b0c6a2f9f3c8e5d7b6a4c3e2f1a0b9c8d7e",
+					PosterID:  102,
+					CreatedAt: time.Date(2025, 3, 11, 11, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2025, 3, 11, 11, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			ID:           303,
+			IssueIndex:   1,
+			ReviewerID:   101,
+			ReviewerName: "alice",
+			CreatedAt:    time.Date(2025, 3, 11, 10, 10, 0, 0, time.UTC),
+			State:        base.ReviewStateChangesRequested,
+		},
+		{
+			ID:           305,
+			IssueIndex:   1,
+			ReviewerID:   101,
+			ReviewerName: "alice",
+			CreatedAt:    time.Date(2025, 3, 11, 11, 45, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
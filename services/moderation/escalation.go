@@ -0,0 +1,223 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"forgejo.org/models/issues"
+	"forgejo.org/models/moderation"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	repo_service "forgejo.org/services/repository"
+	user_service "forgejo.org/services/user"
+)
+
+var ErrAssigneeNotModerator = errors.New("abuse reports can only be assigned to administrators")
+
+// ReportAbuse saves a new abuse report and, when the reported content reaches the threshold configured
+// for the category of the report, automatically acts upon it until a moderator reviews the reports.
+// A failure of the automatic action is logged but does not prevent the report from being submitted.
+func ReportAbuse(ctx context.Context, report *moderation.AbuseReport) error {
+	if err := moderation.ReportAbuse(ctx, report); err != nil {
+		return err
+	}
+	if report.ID == 0 {
+		// the report was ignored because the reporter already submitted one for the same content
+		return nil
+	}
+
+	if err := escalate(ctx, report.ContentType, report.ContentID, report.Category); err != nil {
+		log.Error("Failed to escalate the reports for content with type %d and ID %d: %v", report.ContentType, report.ContentID, err)
+	}
+	return nil
+}
+
+// reportsThreshold returns the number of distinct reporters needed for the given category
+// before the reported content is automatically acted upon; 0 means never.
+func reportsThreshold(category moderation.AbuseCategoryType) int {
+	switch category {
+	case moderation.AbuseCategoryTypeSpam:
+		return setting.Moderation.SpamReportsThreshold
+	case moderation.AbuseCategoryTypeMalware:
+		return setting.Moderation.MalwareReportsThreshold
+	case moderation.AbuseCategoryTypeIllegalContent:
+		return setting.Moderation.IllegalContentReportsThreshold
+	case moderation.AbuseCategoryTypeOther:
+		return setting.Moderation.OtherReportsThreshold
+	default:
+		return 0
+	}
+}
+
+// escalate checks whether enough distinct users reported the content within the given category
+// and if so suspends the reported account (or the poster of the reported issue or comment) or
+// makes the reported repository private. The action is recorded within the audit trail and the
+// reports are kept open so that a moderator can review them.
+func escalate(ctx context.Context, contentType moderation.ReportedContentType, contentID int64, category moderation.AbuseCategoryType) error {
+	threshold := reportsThreshold(category)
+	if threshold <= 0 {
+		return nil
+	}
+
+	reporters, err := moderation.CountDistinctReporters(ctx, contentType, contentID, category)
+	if err != nil {
+		return err
+	}
+	if reporters < int64(threshold) {
+		return nil
+	}
+
+	reports, err := moderation.GetOpenReportsByTypeAndContentID(ctx, contentType, contentID)
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	// act only once for as long as the reports are not reviewed
+	alreadyActed, err := moderation.HasAutomaticActionSince(ctx, contentType, contentID, reports[0].CreatedUnix)
+	if err != nil || alreadyActed {
+		return err
+	}
+
+	details := fmt.Sprintf("reported by %d distinct users within the same category, the threshold being %d", reporters, threshold)
+
+	switch contentType {
+	case moderation.ReportedContentTypeUser:
+		return suspendAutomatically(ctx, contentType, contentID, contentID, details)
+	case moderation.ReportedContentTypeRepository:
+		return hideRepositoryAutomatically(ctx, contentID, details)
+	case moderation.ReportedContentTypeIssue:
+		issue, err := issues.GetIssueByID(ctx, contentID)
+		if err != nil {
+			return err
+		}
+		return suspendAutomatically(ctx, contentType, contentID, issue.PosterID, details)
+	case moderation.ReportedContentTypeComment:
+		comment, err := issues.GetCommentByID(ctx, contentID)
+		if err != nil {
+			return err
+		}
+		return suspendAutomatically(ctx, contentType, contentID, comment.PosterID, details)
+	}
+	return nil
+}
+
+// suspendAutomatically suspends the account with the given ID, unless it belongs to an administrator,
+// is an organization or is already suspended.
+func suspendAutomatically(ctx context.Context, contentType moderation.ReportedContentType, contentID, userID int64, details string) error {
+	abuser, err := user.GetUserByID(ctx, userID)
+	if err != nil {
+		if user.IsErrUserNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if abuser.IsAdmin || abuser.IsOrganization() || abuser.ProhibitLogin {
+		log.Info("Abuse reports threshold reached for content with type %d and ID %d, but the account %s cannot be suspended automatically.", contentType, contentID, abuser.Name)
+		return nil
+	}
+
+	if err := user_service.UpdateAuth(ctx, abuser, &user_service.UpdateAuthOptions{ProhibitLogin: optional.Some(true)}); err != nil {
+		return err
+	}
+	log.Info("Account %s suspended automatically pending the review of the abuse reports for content with type %d and ID %d.", abuser.Name, contentType, contentID)
+
+	return moderation.RecordModerationAction(ctx, &moderation.ModerationAction{
+		ContentType:  contentType,
+		ContentID:    contentID,
+		Type:         moderation.ModerationActionTypeSuspendAccount,
+		TargetUserID: abuser.ID,
+		Details:      details,
+	})
+}
+
+// hideRepositoryAutomatically makes the repository with the given ID private. The recorded action
+// keeps it private: as long as the reports are open, only an administrator can make it public again
+// (see moderation.IsRepositoryHiddenPendingReview).
+func hideRepositoryAutomatically(ctx context.Context, repoID int64, details string) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if repo.IsPrivate {
+		return nil
+	}
+
+	repo.IsPrivate = true
+	if err := repo_service.UpdateRepository(ctx, repo, true); err != nil {
+		return err
+	}
+	log.Info("Repository %s made private automatically pending the review of its abuse reports.", repo.FullName())
+
+	return moderation.RecordModerationAction(ctx, &moderation.ModerationAction{
+		ContentType:  moderation.ReportedContentTypeRepository,
+		ContentID:    repoID,
+		Type:         moderation.ModerationActionTypeHideRepo,
+		TargetUserID: repo.OwnerID,
+		Details:      details,
+	})
+}
+
+// RecordAction adds the action done by doer for the reports of the given content to their audit trail.
+func RecordAction(ctx context.Context, doer *user.User, contentType moderation.ReportedContentType, contentID int64, actionType moderation.ModerationActionType, targetUserID int64) error {
+	return moderation.RecordModerationAction(ctx, &moderation.ModerationAction{
+		ContentType:  contentType,
+		ContentID:    contentID,
+		DoerID:       doer.ID,
+		Type:         actionType,
+		TargetUserID: targetUserID,
+	})
+}
+
+// AssignReports assigns the open reports of the given content to a moderator, or removes
+// their assignment when assignee is nil, and records it within the audit trail.
+func AssignReports(ctx context.Context, doer *user.User, contentType moderation.ReportedContentType, contentID int64, assignee *user.User) error {
+	actionType := moderation.ModerationActionTypeUnassign
+	var assigneeID int64
+	if assignee != nil {
+		if !assignee.IsAdmin {
+			return ErrAssigneeNotModerator
+		}
+		actionType = moderation.ModerationActionTypeAssign
+		assigneeID = assignee.ID
+	}
+
+	if err := moderation.AssignReports(ctx, contentType, contentID, assigneeID); err != nil {
+		return err
+	}
+	return RecordAction(ctx, doer, contentType, contentID, actionType, assigneeID)
+}
+
+// ResolveReports marks the open reports of the given content as handled or ignored
+// and records it within the audit trail.
+func ResolveReports(ctx context.Context, doer *user.User, contentType moderation.ReportedContentType, contentID int64, reportAction ReportAction) error {
+	var err error
+	var actionType moderation.ModerationActionType
+
+	switch reportAction {
+	case ReportActionMarkAsHandled:
+		err = moderation.MarkAsHandled(ctx, contentType, contentID)
+		actionType = moderation.ModerationActionTypeMarkAsHandled
+	case ReportActionMarkAsIgnored:
+		err = moderation.MarkAsIgnored(ctx, contentType, contentID)
+		actionType = moderation.ModerationActionTypeMarkAsIgnored
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return RecordAction(ctx, doer, contentType, contentID, actionType, 0)
+}
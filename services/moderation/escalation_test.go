@@ -0,0 +1,152 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package moderation
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	moderation_model "forgejo.org/models/moderation"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportUser(t *testing.T, reporterID, userID int64, category moderation_model.AbuseCategoryType) {
+	t.Helper()
+	require.NoError(t, ReportAbuse(db.DefaultContext, &moderation_model.AbuseReport{
+		ReporterID:  reporterID,
+		ContentType: moderation_model.ReportedContentTypeUser,
+		ContentID:   userID,
+		Category:    category,
+		Remarks:     "test",
+	}))
+}
+
+func TestEscalationSuspendsReportedUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Moderation.SpamReportsThreshold, 3)()
+
+	reportUser(t, 2, 10, moderation_model.AbuseCategoryTypeSpam)
+	reportUser(t, 4, 10, moderation_model.AbuseCategoryTypeSpam)
+	// reports within another category do not count towards the threshold
+	reportUser(t, 5, 10, moderation_model.AbuseCategoryTypeOther)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).ProhibitLogin)
+
+	reportUser(t, 8, 10, moderation_model.AbuseCategoryTypeSpam)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).ProhibitLogin)
+
+	actions, err := moderation_model.GetModerationActions(db.DefaultContext, moderation_model.ReportedContentTypeUser, 10)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	assert.True(t, actions[0].IsAutomatic())
+	assert.Equal(t, moderation_model.ModerationActionTypeSuspendAccount, actions[0].Type)
+	assert.EqualValues(t, 10, actions[0].TargetUserID)
+
+	// the reports are kept open for a moderator to review them
+	unittest.AssertCount(t, &moderation_model.AbuseReport{
+		ContentType: moderation_model.ReportedContentTypeUser,
+		ContentID:   10,
+		Status:      moderation_model.ReportStatusTypeOpen,
+	}, 4)
+}
+
+func TestEscalationActsOnlyOnce(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Moderation.SpamReportsThreshold, 1)()
+
+	reportUser(t, 2, 10, moderation_model.AbuseCategoryTypeSpam)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).ProhibitLogin)
+
+	// a moderator lifts the suspension while the reports are still open
+	_, err := db.GetEngine(db.DefaultContext).ID(10).Cols("prohibit_login").Update(&user_model.User{ProhibitLogin: false})
+	require.NoError(t, err)
+
+	reportUser(t, 4, 10, moderation_model.AbuseCategoryTypeSpam)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).ProhibitLogin)
+	unittest.AssertCount(t, &moderation_model.ModerationAction{ContentType: moderation_model.ReportedContentTypeUser, ContentID: 10}, 1)
+}
+
+func TestEscalationHidesReportedRepository(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Moderation.SpamReportsThreshold, 1)()
+
+	require.NoError(t, ReportAbuse(db.DefaultContext, &moderation_model.AbuseReport{
+		ReporterID:  4,
+		ContentType: moderation_model.ReportedContentTypeRepository,
+		ContentID:   1,
+		Category:    moderation_model.AbuseCategoryTypeSpam,
+		Remarks:     "test",
+	}))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}).IsPrivate)
+
+	// the owner cannot make the repository public until a moderator reviews the reports
+	hidden, err := moderation_model.IsRepositoryHiddenPendingReview(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.True(t, hidden)
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	require.NoError(t, ResolveReports(db.DefaultContext, admin, moderation_model.ReportedContentTypeRepository, 1, ReportActionMarkAsHandled))
+	hidden, err = moderation_model.IsRepositoryHiddenPendingReview(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.False(t, hidden)
+}
+
+func TestEscalationDisabledByDefault(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	reportUser(t, 2, 10, moderation_model.AbuseCategoryTypeSpam)
+	reportUser(t, 4, 10, moderation_model.AbuseCategoryTypeSpam)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).ProhibitLogin)
+	unittest.AssertCount(t, &moderation_model.ModerationAction{}, 0)
+}
+
+func TestAssignReports(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	regular := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	reportUser(t, 4, 10, moderation_model.AbuseCategoryTypeSpam)
+
+	err := AssignReports(db.DefaultContext, admin, moderation_model.ReportedContentTypeUser, 10, regular)
+	require.ErrorIs(t, err, ErrAssigneeNotModerator)
+
+	require.NoError(t, AssignReports(db.DefaultContext, admin, moderation_model.ReportedContentTypeUser, 10, admin))
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ContentType: moderation_model.ReportedContentTypeUser, ContentID: 10, AssigneeID: admin.ID})
+
+	require.NoError(t, AssignReports(db.DefaultContext, admin, moderation_model.ReportedContentTypeUser, 10, nil))
+	unittest.AssertNotExistsBean(t, &moderation_model.AbuseReport{ContentType: moderation_model.ReportedContentTypeUser, ContentID: 10, AssigneeID: admin.ID})
+
+	actions, err := moderation_model.GetModerationActions(db.DefaultContext, moderation_model.ReportedContentTypeUser, 10)
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, moderation_model.ModerationActionTypeAssign, actions[0].Type)
+	assert.Equal(t, admin.ID, actions[0].TargetUserID)
+	assert.Equal(t, moderation_model.ModerationActionTypeUnassign, actions[1].Type)
+}
+
+func TestResolveReportsRecordsAction(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	reportUser(t, 4, 10, moderation_model.AbuseCategoryTypeSpam)
+	require.NoError(t, ResolveReports(db.DefaultContext, admin, moderation_model.ReportedContentTypeUser, 10, ReportActionMarkAsIgnored))
+
+	unittest.AssertCount(t, &moderation_model.AbuseReport{
+		ContentType: moderation_model.ReportedContentTypeUser,
+		ContentID:   10,
+		Status:      moderation_model.ReportStatusTypeOpen,
+	}, 0)
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.ModerationAction{
+		ContentType: moderation_model.ReportedContentTypeUser,
+		ContentID:   10,
+		DoerID:      admin.ID,
+		Type:        moderation_model.ModerationActionTypeMarkAsIgnored,
+	})
+}
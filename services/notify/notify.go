@@ -8,6 +8,7 @@ import (
 	"slices"
 
 	actions_model "forgejo.org/models/actions"
+	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -251,6 +252,20 @@ func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues
 	}
 }
 
+// IssueReminder notifies a reminder of an issue to notifiers
+func IssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) {
+	for _, notifier := range notifiers {
+		notifier.IssueReminder(ctx, receiver, issue, reminderType)
+	}
+}
+
+// IssueChangeCustomFields notifies change of the custom field values of an issue to notifiers
+func IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeCustomFields(ctx, doer, issue)
+	}
+}
+
 // CreateRepository notifies create repository to notifiers
 func CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -388,3 +403,18 @@ func ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorSt
 		notifier.ActionRunNowDone(ctx, run, priorStatus, lastRun)
 	}
 }
+
+// WorkflowJobStatusUpdate notifies that the status of a job of an ActionRun changed.
+// The job needs its run and the repository of the run loaded.
+func WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob) {
+	for _, notifier := range notifiers {
+		notifier.WorkflowJobStatusUpdate(ctx, repo, sender, job)
+	}
+}
+
+// CreateCommitStatus notifies that a commit status was created
+func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	for _, notifier := range notifiers {
+		notifier.CreateCommitStatus(ctx, repo, commit, sender, status)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -138,6 +139,14 @@ func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.Use
 	addedLabels, removedLabels []*issues_model.Label) {
 }
 
+// IssueReminder places a place holder function
+func (*NullNotifier) IssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) {
+}
+
+// IssueChangeCustomFields places a place holder function
+func (*NullNotifier) IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+}
+
 // CreateRepository places a place holder function
 func (*NullNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 }
@@ -212,3 +221,11 @@ func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.R
 // ActionRunNowDone places a place holder function
 func (*NullNotifier) ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorStatus actions_model.Status, lastRun *actions_model.ActionRun) {
 }
+
+// WorkflowJobStatusUpdate places a place holder function
+func (*NullNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob) {
+}
+
+// CreateCommitStatus places a place holder function
+func (*NullNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+}
@@ -9,6 +9,7 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -52,6 +53,10 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
 
+	if err := issues_model.DeleteCustomFieldsByOrgID(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteCustomFieldsByOrgID: %w", err)
+	}
+
 	if err := commiter.Commit(); err != nil {
 		return err
 	}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"context"
+
+	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// NewTeam creates a team and records it in the audit log
+func NewTeam(ctx context.Context, doer *user_model.User, t *org_model.Team) error {
+	if err := models.NewTeam(ctx, t); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, t.OrgID, audit_model.ActionTeamCreate, audit_service.TeamTarget(t), "access: "+t.AccessMode.String())
+	return nil
+}
+
+// UpdateTeam updates a team and records it in the audit log
+func UpdateTeam(ctx context.Context, doer *user_model.User, t *org_model.Team, authChanged, includeAllChanged bool) error {
+	if err := models.UpdateTeam(ctx, t, authChanged, includeAllChanged); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, t.OrgID, audit_model.ActionTeamUpdate, audit_service.TeamTarget(t), "access: "+t.AccessMode.String())
+	return nil
+}
+
+// DeleteTeam deletes a team and records it in the audit log
+func DeleteTeam(ctx context.Context, doer *user_model.User, t *org_model.Team) error {
+	if err := models.DeleteTeam(ctx, t); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, t.OrgID, audit_model.ActionTeamDelete, audit_service.TeamTarget(t), "")
+	return nil
+}
+
+// AddTeamMember adds a user to a team and records it in the audit log
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *org_model.Team, userID int64) error {
+	return addTeamMember(ctx, doer, team, userID, "team: "+team.Name)
+}
+
+func addTeamMember(ctx context.Context, doer *user_model.User, team *org_model.Team, userID int64, detail string) error {
+	if err := models.AddTeamMember(ctx, team, userID); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, team.OrgID, audit_model.ActionTeamMemberAdd, audit_service.Target{Type: audit_model.TargetUser, ID: userID}, detail)
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team and records it in the audit log
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *org_model.Team, userID int64) error {
+	if err := models.RemoveTeamMember(ctx, team, userID); err != nil {
+		return err
+	}
+	audit_service.RecordOwner(ctx, doer, team.OrgID, audit_model.ActionTeamMemberRemove, audit_service.Target{Type: audit_model.TargetUser, ID: userID}, "team: "+team.Name)
+	return nil
+}
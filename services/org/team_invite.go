@@ -8,6 +8,7 @@ import (
 
 	org_model "forgejo.org/models/organization"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
 	"forgejo.org/services/mailer"
 )
 
@@ -20,3 +21,15 @@ func CreateTeamInvite(ctx context.Context, inviter *user_model.User, team *org_m
 
 	return mailer.MailTeamInvite(ctx, inviter, team, invite)
 }
+
+// AcceptTeamInvite adds the doer to the team they were invited to and removes the invite
+func AcceptTeamInvite(ctx context.Context, doer *user_model.User, invite *org_model.TeamInvite, team *org_model.Team, inviter *user_model.User) error {
+	if err := addTeamMember(ctx, doer, team, doer.ID, "team: "+team.Name+", invited by "+inviter.Name); err != nil {
+		return err
+	}
+
+	if err := org_model.RemoveInviteByID(ctx, invite.ID, team.ID); err != nil {
+		log.Error("RemoveInviteByID: %v", err)
+	}
+	return nil
+}
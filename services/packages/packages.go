@@ -14,6 +14,7 @@ import (
 
 	"forgejo.org/models/db"
 	packages_model "forgejo.org/models/packages"
+	quota_model "forgejo.org/models/quota"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/json"
@@ -130,6 +131,10 @@ func createPackageAndVersion(ctx context.Context, pvci *PackageCreationInfo, all
 		LowerName:        packages_model.ResolvePackageName(pvci.Name, pvci.PackageType),
 		SemverCompatible: pvci.SemverCompatible,
 	}
+	if err := checkNewPackageQuota(ctx, pvci); err != nil {
+		return nil, false, err
+	}
+
 	var err error
 	if p, err = packages_model.TryInsertPackage(ctx, p); err != nil {
 		if errors.Is(err, packages_model.ErrDuplicatePackage) {
@@ -349,6 +354,27 @@ func CheckCountQuotaExceeded(ctx context.Context, doer, owner *user_model.User)
 	return nil
 }
 
+// checkNewPackageQuota checks if the owner is allowed to have one more package,
+// in case the package to be created does not exist yet.
+func checkNewPackageQuota(ctx context.Context, pvci *PackageCreationInfo) error {
+	_, err := packages_model.GetPackageByName(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, packages_model.ErrPackageNotExist) {
+		return err
+	}
+
+	ok, err := quota_model.EvaluateForUser(ctx, pvci.Owner.ID, quota_model.LimitSubjectCountPackages)
+	if err != nil {
+		log.Error("quota_model.EvaluateForUser failed: %v", err)
+		return err
+	}
+	if !ok {
+		return ErrQuotaTotalCount
+	}
+	return nil
+}
+
 // CheckSizeQuotaExceeded checks if the upload size is bigger than the allowed size
 // The check is skipped if the doer is an admin.
 func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type, uploadSize int64) error {
@@ -402,6 +428,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	user_model "forgejo.org/models/user"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// GetOrCreateKeyPair gets or creates the PGP keys used to sign the checksums of provider releases
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, ownerID, terraform_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, ownerID, terraform_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = generateKeypair()
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, terraform_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, terraform_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+func generateKeypair() (string, string, error) {
+	e, err := openpgp.NewEntity("", "Terraform Registry", "", nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	var priv strings.Builder
+	var pub strings.Builder
+
+	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.SerializePrivate(w, nil); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.Serialize(w); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	return priv.String(), pub.String(), nil
+}
+
+func readEntity(armored string) (*openpgp.Entity, error) {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return nil, err
+	}
+	return openpgp.ReadEntity(packet.NewReader(block.Body))
+}
+
+// GetPublicKey returns the id and the armored public key used to sign the provider releases of the owner
+func GetPublicKey(ctx context.Context, ownerID int64) (string, string, error) {
+	_, pub, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return "", "", err
+	}
+
+	e, err := readEntity(pub)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.ToUpper(e.PrimaryKey.KeyIdString()), pub, nil
+}
+
+// BuildShasums creates the SHA256SUMS file content of a provider release
+func BuildShasums(pd *packages_model.PackageDescriptor) []byte {
+	pfds := make([]*packages_model.PackageFileDescriptor, 0, len(pd.Files))
+	for _, pfd := range pd.Files {
+		if pfd.Properties.GetByName(terraform_module.PropertyOS) != "" {
+			pfds = append(pfds, pfd)
+		}
+	}
+	sort.Slice(pfds, func(i, j int) bool {
+		return pfds[i].File.Name < pfds[j].File.Name
+	})
+
+	var buf bytes.Buffer
+	for _, pfd := range pfds {
+		fmt.Fprintf(&buf, "%s  %s\n", pfd.Blob.HashSHA256, pfd.File.Name)
+	}
+	return buf.Bytes()
+}
+
+// SignShasums creates a binary detached signature of the SHA256SUMS file content with the key of the owner
+func SignShasums(ctx context.Context, ownerID int64, shasums []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := readEntity(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := openpgp.DetachSign(&buf, e, bytes.NewReader(shasums), nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
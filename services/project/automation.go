@@ -0,0 +1,102 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+
+	"xorm.io/builder"
+)
+
+// Init registers the notifier applying the automation rules of the projects
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+// ApplyAutomationRules moves the issue to the column of the oldest automation
+// rule of its project triggered by trigger. For AutomationTriggerLabelAdded only
+// the rules of the added labels apply. An issue opened in a project follows the
+// rules of that project, one opened outside of any project is added to the first
+// repository project with an AutomationTriggerIssueOpened rule.
+func ApplyAutomationRules(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, trigger project_model.AutomationTrigger, addedLabels []*issues_model.Label) error {
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+
+	var cond builder.Cond
+	switch {
+	case issue.Project != nil:
+		cond = builder.Eq{"project.id": issue.Project.ID}
+	case trigger == project_model.AutomationTriggerIssueOpened:
+		cond = builder.Eq{"project.repo_id": issue.RepoID}
+	default:
+		return nil
+	}
+
+	rules, err := project_model.FindAutomationRulesByTrigger(ctx, trigger, cond)
+	if err != nil {
+		return err
+	}
+	rule := matchAutomationRule(rules, trigger, addedLabels)
+	if rule == nil {
+		return nil
+	}
+
+	if issue.Project == nil {
+		return issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, rule.ProjectID, rule.ColumnID)
+	}
+	if issue.ProjectColumnID(ctx) == rule.ColumnID {
+		return nil
+	}
+	column, err := project_model.GetColumn(ctx, rule.ColumnID)
+	if err != nil {
+		return err
+	}
+	return project_model.MoveIssueToColumn(ctx, issue.ID, column)
+}
+
+func matchAutomationRule(rules []*project_model.AutomationRule, trigger project_model.AutomationTrigger, addedLabels []*issues_model.Label) *project_model.AutomationRule {
+	for _, rule := range rules {
+		if trigger != project_model.AutomationTriggerLabelAdded {
+			return rule
+		}
+		for _, label := range addedLabels {
+			if label.ID == rule.LabelID {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyPullRequestAutomationRules applies the automation rules triggered by
+// trigger to the pull request and to the issues it closes
+func ApplyPullRequestAutomationRules(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, trigger project_model.AutomationTrigger) error {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if err := ApplyAutomationRules(ctx, doer, pr.Issue, trigger, nil); err != nil {
+		return err
+	}
+
+	refs, err := pr.ResolveCrossReferences(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if err := ref.LoadIssue(ctx); err != nil {
+			return err
+		}
+		if err := ApplyAutomationRules(ctx, doer, ref.Issue, trigger, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAutomationRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	for _, rule := range []*project_model.AutomationRule{
+		{ProjectID: 1, TriggerType: project_model.AutomationTriggerIssueOpened, ColumnID: 2},
+		{ProjectID: 1, TriggerType: project_model.AutomationTriggerIssueClosed, ColumnID: 3},
+		{ProjectID: 1, TriggerType: project_model.AutomationTriggerLabelAdded, LabelID: 2, ColumnID: 2},
+	} {
+		rule.CreatorID = doer.ID
+		require.NoError(t, project_model.NewAutomationRule(db.DefaultContext, rule))
+	}
+
+	t.Run("Move", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, ApplyAutomationRules(db.DefaultContext, doer, issue, project_model.AutomationTriggerIssueClosed, nil))
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectColumnID: 3})
+
+		// no rule for the trigger
+		require.NoError(t, ApplyAutomationRules(db.DefaultContext, doer, issue, project_model.AutomationTriggerIssueReopened, nil))
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectColumnID: 3})
+	})
+
+	t.Run("Label", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+		label1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+		label2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+
+		require.NoError(t, ApplyAutomationRules(db.DefaultContext, doer, issue, project_model.AutomationTriggerLabelAdded, []*issues_model.Label{label1}))
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 5, ProjectColumnID: 3})
+
+		require.NoError(t, ApplyAutomationRules(db.DefaultContext, doer, issue, project_model.AutomationTriggerLabelAdded, []*issues_model.Label{label1, label2}))
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 5, ProjectColumnID: 2})
+	})
+
+	t.Run("Opened", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 11})
+		require.NoError(t, ApplyAutomationRules(db.DefaultContext, doer, issue, project_model.AutomationTriggerIssueOpened, nil))
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 11, ProjectID: 1, ProjectColumnID: 2})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 11, Type: issues_model.CommentTypeProject, ProjectID: 1})
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	notify_service "forgejo.org/services/notify"
+)
+
+type projectNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &projectNotifier{}
+
+// NewNotifier create a new projectNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &projectNotifier{}
+}
+
+func (n *projectNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if issue.IsPull {
+		return
+	}
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	if err := ApplyAutomationRules(ctx, issue.Poster, issue, project_model.AutomationTriggerIssueOpened, nil); err != nil {
+		log.Error("ApplyAutomationRules[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	trigger := project_model.AutomationTriggerIssueReopened
+	if closeOrReopen {
+		trigger = project_model.AutomationTriggerIssueClosed
+	}
+	if err := ApplyAutomationRules(ctx, doer, issue, trigger, nil); err != nil {
+		log.Error("ApplyAutomationRules[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, removedLabels []*issues_model.Label) {
+	if len(addedLabels) == 0 {
+		return
+	}
+	if err := ApplyAutomationRules(ctx, doer, issue, project_model.AutomationTriggerLabelAdded, addedLabels); err != nil {
+		log.Error("ApplyAutomationRules[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	if err := ApplyPullRequestAutomationRules(ctx, pr.Issue.Poster, pr, project_model.AutomationTriggerPullRequestOpened); err != nil {
+		log.Error("ApplyPullRequestAutomationRules[%d]: %v", pr.ID, err)
+	}
+}
+
+func (n *projectNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := ApplyPullRequestAutomationRules(ctx, doer, pr, project_model.AutomationTriggerPullRequestMerged); err != nil {
+		log.Error("ApplyPullRequestAutomationRules[%d]: %v", pr.ID, err)
+	}
+}
+
+func (n *projectNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	n.MergePullRequest(ctx, doer, pr)
+}
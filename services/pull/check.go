@@ -26,6 +26,7 @@ import (
 	"forgejo.org/modules/queue"
 	asymkey_service "forgejo.org/services/asymkey"
 	shared_automerge "forgejo.org/services/shared/automerge"
+	shared_mergequeue "forgejo.org/services/shared/mergequeue"
 )
 
 // prPatchCheckerQueue represents a queue to handle update pull request tests
@@ -285,6 +286,8 @@ func testPR(id int64) {
 
 	if pr, updated := testPRProtected(ctx, id); pr != nil && updated {
 		shared_automerge.AddToQueueIfMergeable(ctx, pr)
+		// the merge queue waits for the conflict checking before merging the pull request
+		shared_mergequeue.StartMergeQueueCheckByPull(ctx, pr)
 	}
 }
 
@@ -9,9 +9,9 @@ import (
 	"errors"
 	"fmt"
 
-	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/gitrepo"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/structs"
@@ -19,8 +19,9 @@ import (
 	"github.com/gobwas/glob"
 )
 
-// MergeRequiredContextsCommitStatus returns a commit status state for given required contexts
-func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus, requiredContexts []string) structs.CommitStatusState {
+// MergeRequiredContextsCommitStatus returns a commit status state for given required contexts. A required
+// context pinned to a source in sources is only matched by the statuses that source reported.
+func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus, requiredContexts []string, sources []*git_model.StatusCheckSource) structs.CommitStatusState {
 	// matchedCount is the number of `CommitStatus.Context` that match any context of `requiredContexts`
 	matchedCount := 0
 	returnedStatus := structs.CommitStatusSuccess
@@ -35,10 +36,16 @@ func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus,
 			}
 		}
 
-		for _, gp := range requiredContextsGlob {
+		requiredContextsSource := make(map[string]*git_model.StatusCheckSource, len(sources))
+		for _, source := range sources {
+			requiredContextsSource[source.Context] = source
+		}
+
+		for ctx, gp := range requiredContextsGlob {
+			source := requiredContextsSource[ctx]
 			var targetStatus structs.CommitStatusState
 			for _, commitStatus := range commitStatuses {
-				if gp.Match(commitStatus.Context) {
+				if gp.Match(commitStatus.Context) && (source == nil || source.Matches(commitStatus)) {
 					targetStatus = commitStatus.State
 					matchedCount++
 					break
@@ -60,7 +67,7 @@ func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus,
 	}
 
 	if matchedCount == 0 && returnedStatus == structs.CommitStatusSuccess {
-		status := git_model.CalcCommitStatus(commitStatuses)
+		status := git_model.CalcCommitStatus(latestCommitStatusPerContext(commitStatuses))
 		if status != nil {
 			return status.State
 		}
@@ -70,6 +77,19 @@ func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus,
 	return returnedStatus
 }
 
+// latestCommitStatusPerContext drops the statuses reported by other sources for the same context from a list
+// sorted from the most recent status to the oldest, as returned by git_model.GetLatestCommitStatusPerSource
+func latestCommitStatusPerContext(commitStatuses []*git_model.CommitStatus) []*git_model.CommitStatus {
+	seen := make(container.Set[string], len(commitStatuses))
+	latest := make([]*git_model.CommitStatus, 0, len(commitStatuses))
+	for _, commitStatus := range commitStatuses {
+		if seen.Add(commitStatus.Context) {
+			latest = append(latest, commitStatus)
+		}
+	}
+	return latest
+}
+
 // IsPullCommitStatusPass returns if all required status checks PASS
 func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
 	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
@@ -122,9 +142,9 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 		return "", fmt.Errorf("LoadBaseRepo: %w", err)
 	}
 
-	commitStatuses, _, err := git_model.GetLatestCommitStatus(ctx, pr.BaseRepo.ID, sha, db.ListOptionsAll)
+	commitStatuses, err := git_model.GetLatestCommitStatusPerSource(ctx, pr.BaseRepo.ID, sha)
 	if err != nil {
-		return "", fmt.Errorf("GetLatestCommitStatus: %w", err)
+		return "", fmt.Errorf("GetLatestCommitStatusPerSource: %w", err)
 	}
 
 	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
@@ -132,9 +152,11 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
 	}
 	var requiredContexts []string
+	var sources []*git_model.StatusCheckSource
 	if pb != nil {
 		requiredContexts = pb.StatusCheckContexts
+		sources = pb.StatusCheckSources
 	}
 
-	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts), nil
+	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts, sources), nil
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	git_model "forgejo.org/models/git"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/structs"
 
 	"github.com/stretchr/testify/assert"
@@ -58,8 +59,41 @@ func TestMergeRequiredContextsCommitStatus(t *testing.T) {
 	}
 
 	for i, commitStatuses := range testCases {
-		if MergeRequiredContextsCommitStatus(commitStatuses, testCasesRequiredContexts[i]) != testCasesExpected[i] {
+		if MergeRequiredContextsCommitStatus(commitStatuses, testCasesRequiredContexts[i], nil) != testCasesExpected[i] {
 			assert.Fail(t, "Test case failed", "Test case %d failed", i+1)
 		}
 	}
 }
+
+func TestMergeRequiredContextsCommitStatusPinnedSource(t *testing.T) {
+	sources := []*git_model.StatusCheckSource{
+		{Context: "ci/build", Workflow: "build.yml"},
+		{Context: "deploy/*", UserID: 5},
+	}
+
+	// the most recent status first, as returned by GetLatestCommitStatusPerSource
+	commitStatuses := []*git_model.CommitStatus{
+		{Context: "ci/build", State: structs.CommitStatusSuccess, CreatorID: 2},
+		{Context: "ci/build", State: structs.CommitStatusFailure, CreatorID: user_model.ActionsUserID, WorkflowID: "build.yml"},
+		{Context: "deploy/staging", State: structs.CommitStatusSuccess, CreatorID: 5},
+	}
+	assert.Equal(t, structs.CommitStatusFailure, MergeRequiredContextsCommitStatus(commitStatuses, []string{"ci/build", "deploy/*"}, sources))
+	assert.Equal(t, structs.CommitStatusSuccess, MergeRequiredContextsCommitStatus(commitStatuses, []string{"ci/build", "deploy/*"}, nil))
+
+	// a status spoofed by another user does not satisfy a pinned check
+	commitStatuses = []*git_model.CommitStatus{
+		{Context: "ci/build", State: structs.CommitStatusSuccess, CreatorID: 2},
+		{Context: "ci/build", State: structs.CommitStatusSuccess, CreatorID: user_model.ActionsUserID, WorkflowID: "other.yml"},
+		{Context: "deploy/staging", State: structs.CommitStatusSuccess, CreatorID: 5},
+	}
+	assert.Equal(t, structs.CommitStatusPending, MergeRequiredContextsCommitStatus(commitStatuses, []string{"ci/build", "deploy/*"}, sources))
+
+	commitStatuses = []*git_model.CommitStatus{
+		{Context: "ci/build", State: structs.CommitStatusSuccess, CreatorID: user_model.ActionsUserID, WorkflowID: "build.yml"},
+		{Context: "deploy/staging", State: structs.CommitStatusSuccess, CreatorID: 2},
+	}
+	assert.Equal(t, structs.CommitStatusPending, MergeRequiredContextsCommitStatus(commitStatuses, []string{"ci/build", "deploy/*"}, sources))
+
+	commitStatuses = append(commitStatuses, &git_model.CommitStatus{Context: "deploy/staging", State: structs.CommitStatusSuccess, CreatorID: 5})
+	assert.Equal(t, structs.CommitStatusSuccess, MergeRequiredContextsCommitStatus(commitStatuses, []string{"ci/build", "deploy/*"}, sources))
+}
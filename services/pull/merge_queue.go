@@ -0,0 +1,101 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
+)
+
+// BuildSpeculativeMerge merges the head of the pull request into parentBranch, which is either the base
+// branch of the pull request or the speculative branch of the pull request queued before it, and force
+// pushes the result to targetBranch. The push goes through the hooks so that the workflows and other
+// checks of the repository run against the combined result. It returns the id of the merge commit.
+func BuildSpeculativeMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID, parentBranch, targetBranch, message string) (string, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", err
+	}
+
+	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	mergeCtx := &mergeContext{
+		prContext: prCtx,
+		doer:      doer,
+	}
+
+	trackingCommitID, _, err := git.NewCommand(ctx, "show-ref", "--hash").AddDynamicArguments(git.BranchPrefix + trackingBranch).RunStdString(&git.RunOpts{Dir: mergeCtx.tmpBasePath})
+	if err != nil {
+		return "", fmt.Errorf("unable to get sha of head branch in %v %w", pr, err)
+	}
+	if strings.TrimSpace(trackingCommitID) != expectedHeadCommitID {
+		return "", models.ErrSHADoesNotMatch{
+			GivenSHA:   expectedHeadCommitID,
+			CurrentSHA: trackingCommitID,
+		}
+	}
+
+	if parentBranch != pr.BaseBranch {
+		if err := git.NewCommand(ctx, "fetch", "--no-tags", "--update-head-ok", "origin").AddDynamicArguments("+" + git.BranchPrefix + parentBranch + ":" + git.BranchPrefix + baseBranch).
+			Run(mergeCtx.RunOpts()); err != nil {
+			log.Error("%-v Unable to fetch %s as base branch in %s: %v\n%s\n%s", pr, parentBranch, mergeCtx.tmpBasePath, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+			return "", fmt.Errorf("unable to fetch %s as base branch: %w", parentBranch, err)
+		}
+	}
+
+	mergeCtx.outbuf.Reset()
+	mergeCtx.errbuf.Reset()
+	if err := prepareTemporaryRepoForMerge(mergeCtx); err != nil {
+		return "", err
+	}
+
+	mergeCtx.sig = doer.NewGitSig()
+	mergeCtx.committer = mergeCtx.sig
+
+	commitTimeStr := time.Now().Format(time.RFC3339)
+	mergeCtx.env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+mergeCtx.sig.Name,
+		"GIT_AUTHOR_EMAIL="+mergeCtx.sig.Email,
+		"GIT_AUTHOR_DATE="+commitTimeStr,
+		"GIT_COMMITTER_NAME="+mergeCtx.committer.Name,
+		"GIT_COMMITTER_EMAIL="+mergeCtx.committer.Email,
+		"GIT_COMMITTER_DATE="+commitTimeStr,
+	)
+
+	// The speculative result is always built as a merge commit: the tree is what matters to the checks
+	// and it is the same for every merge style that can succeed.
+	if err := doMergeStyleMerge(mergeCtx, message); err != nil {
+		return "", err
+	}
+
+	mergeCommitID, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get full commit id for the speculative merge: %w", err)
+	}
+
+	// the protected branch rules do not apply to the speculative branches, see preReceiveBranch
+	mergeCtx.env = append(repo_module.FullPushingEnvironment(doer, doer, pr.BaseRepo, pr.BaseRepo.Name, 0),
+		repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerMergeQueue))
+	if err := git.NewCommand(ctx, "push", "--force", "origin").AddDynamicArguments(baseBranch + ":" + git.BranchPrefix + targetBranch).
+		Run(mergeCtx.RunOpts()); err != nil {
+		return "", fmt.Errorf("git push: %s", mergeCtx.errbuf.String())
+	}
+	mergeCtx.outbuf.Reset()
+	mergeCtx.errbuf.Reset()
+
+	return mergeCommitID, nil
+}
@@ -76,6 +76,9 @@ func ParseFileName(uri string) (ext string, tp git.ArchiveType, err error) {
 	case strings.HasSuffix(uri, ".tar.gz"):
 		ext = ".tar.gz"
 		tp = git.TARGZ
+	case strings.HasSuffix(uri, ".tar.zst"):
+		ext = ".tar.zst"
+		tp = git.TARZST
 	case strings.HasSuffix(uri, ".bundle"):
 		ext = ".bundle"
 		tp = git.BUNDLE
@@ -89,7 +92,7 @@ func ParseFileName(uri string) (ext string, tp git.ArchiveType, err error) {
 // resulting ArchiveRequest is suitable for being passed to Await()
 // if it's determined that the request still needs to be satisfied.
 func NewRequest(ctx context.Context, repoID int64, repo *git.Repository, refName string, fileType git.ArchiveType) (*ArchiveRequest, error) {
-	if fileType < git.ZIP || fileType > git.BUNDLE {
+	if fileType < git.ZIP || fileType > git.TARZST {
 		return nil, ErrUnknownArchiveFormat{RequestFormat: fileType.String()}
 	}
 
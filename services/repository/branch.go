@@ -15,6 +15,7 @@ import (
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/perm/access"
+	pull_model "forgejo.org/models/pull"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
@@ -434,6 +435,22 @@ func DeleteBranch(ctx context.Context, doer *user_model.User, repo *repo_model.R
 		return git_model.ErrBranchIsProtected
 	}
 
+	return deleteBranch(ctx, doer, repo, gitRepo, branchName)
+}
+
+// DeleteMergeQueueBranch deletes the branch a speculative merge of the merge queue was pushed to,
+// the protected branch rules do not apply to these branches
+func DeleteMergeQueueBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, branchName string) error {
+	if !strings.HasPrefix(branchName, pull_model.MergeQueueBranchPrefix) {
+		return fmt.Errorf("%s is not a merge queue branch", branchName)
+	}
+	if err := repo.MustNotBeArchived(); err != nil {
+		return err
+	}
+	return deleteBranch(ctx, doer, repo, gitRepo, branchName)
+}
+
+func deleteBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, branchName string) error {
 	rawBranch, err := git_model.GetBranch(ctx, repo.ID, branchName)
 	if err != nil && !git_model.IsErrBranchNotExist(err) {
 		return fmt.Errorf("GetBranch: %v", err)
@@ -8,45 +8,72 @@ import (
 	"context"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	repo_module "forgejo.org/modules/repository"
+	audit_service "forgejo.org/services/audit"
 )
 
+// AddCollaborator adds a user as a collaborator of a repository and records it in the audit log.
+func AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User) error {
+	if err := repo_module.AddCollaborator(ctx, repo, u); err != nil {
+		return err
+	}
+	audit_service.RecordRepo(ctx, doer, repo, audit_model.ActionCollaboratorAdd, audit_service.UserTarget(u), "")
+	return nil
+}
+
+// ChangeCollaborationAccessMode sets the access mode of a collaborator and records it in the audit log.
+func ChangeCollaborationAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, uid int64, mode perm.AccessMode) error {
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, repo, uid, mode); err != nil {
+		return err
+	}
+	audit_service.RecordRepo(ctx, doer, repo, audit_model.ActionCollaboratorChangeAccess, audit_service.Target{Type: audit_model.TargetUser, ID: uid}, "access: "+mode.String())
+	return nil
+}
+
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, uid int64) (err error) {
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, uid int64) (err error) {
 	collaboration := &repo_model.Collaboration{
 		RepoID: repo.ID,
 		UserID: uid,
 	}
 
-	ctx, committer, err := db.TxContext(ctx)
+	dbCtx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return err
 	}
 	defer committer.Close()
 
-	if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
+	if has, err := db.GetEngine(dbCtx).Delete(collaboration); err != nil {
 		return err
 	} else if has == 0 {
 		return committer.Commit()
 	}
-	if err = access_model.RecalculateAccesses(ctx, repo); err != nil {
+	if err = access_model.RecalculateAccesses(dbCtx, repo); err != nil {
 		return err
 	}
 
-	if err = repo_model.WatchRepo(ctx, uid, repo.ID, false); err != nil {
+	if err = repo_model.WatchRepo(dbCtx, uid, repo.ID, false); err != nil {
 		return err
 	}
 
-	if err = models.ReconsiderWatches(ctx, repo, uid); err != nil {
+	if err = models.ReconsiderWatches(dbCtx, repo, uid); err != nil {
 		return err
 	}
 
 	// Unassign a user from any issue (s)he has been assigned to in the repository
-	if err := models.ReconsiderRepoIssuesAssignee(ctx, repo, uid); err != nil {
+	if err := models.ReconsiderRepoIssuesAssignee(dbCtx, repo, uid); err != nil {
 		return err
 	}
 
-	return committer.Commit()
+	if err := committer.Commit(); err != nil {
+		return err
+	}
+	audit_service.RecordRepo(ctx, doer, repo, audit_model.ActionCollaboratorRemove, audit_service.Target{Type: audit_model.TargetUser, ID: uid}, "")
+	return nil
 }
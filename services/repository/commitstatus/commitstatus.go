@@ -18,8 +18,11 @@ import (
 	"forgejo.org/modules/gitrepo"
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
 	api "forgejo.org/modules/structs"
+	notify_service "forgejo.org/services/notify"
 	shared_automerge "forgejo.org/services/shared/automerge"
+	shared_mergequeue "forgejo.org/services/shared/mergequeue"
 )
 
 func getCacheKey(repoID int64, brancheName string) string {
@@ -105,6 +108,8 @@ func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, creato
 		return err
 	}
 
+	notify_service.CreateCommitStatus(ctx, repo, repo_module.CommitToPushCommit(commit), creator, status)
+
 	defaultBranchCommit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
 	if err != nil {
 		return fmt.Errorf("GetBranchCommit[%s]: %w", repo.DefaultBranch, err)
@@ -122,6 +127,11 @@ func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, creato
 		}
 	}
 
+	// a failure ejects a pull request from the merge queue as much as a success may let it be merged
+	if err := shared_mergequeue.StartMergeQueueCheckBySHA(ctx, sha, repo); err != nil {
+		return fmt.Errorf("StartMergeQueueCheckBySHA[repo_id: %d, sha: %s]: %w", repo.ID, sha, err)
+	}
+
 	return nil
 }
 
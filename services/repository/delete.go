@@ -170,6 +170,8 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&webhook.Webhook{RepoID: repoID},
 		&secret_model.Secret{RepoID: repoID},
 		&actions_model.ActionTaskStep{RepoID: repoID},
+		&actions_model.ActionTaskSummary{RepoID: repoID},
+		&actions_model.ActionTaskAnnotation{RepoID: repoID},
 		&actions_model.ActionTask{RepoID: repoID},
 		&actions_model.ActionRunJob{RepoID: repoID},
 		&actions_model.ActionRun{RepoID: repoID},
@@ -178,6 +180,9 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&actions_model.ActionSchedule{RepoID: repoID},
 		&actions_model.ActionArtifact{RepoID: repoID},
 		&actions_model.ActionUser{RepoID: repoID},
+		&actions_model.ActionVariable{RepoID: repoID},
+		&actions_model.ActionEnvironment{RepoID: repoID},
+		&actions_model.ActionDeployment{RepoID: repoID},
 		&repo_model.RepoArchiveDownloadCount{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
 	); err != nil {
@@ -235,6 +240,10 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return fmt.Errorf("unable to delete projects for repo[%d]: %w", repoID, err)
 	}
 
+	if err := issues_model.DeleteCustomFieldsByRepoID(ctx, repoID); err != nil {
+		return fmt.Errorf("unable to delete custom fields for repo[%d]: %w", repoID, err)
+	}
+
 	// Remove LFS objects
 	var lfsObjects []*git_model.LFSMetaObject
 	if err = sess.Where("repository_id=?", repoID).Find(&lfsObjects); err != nil {
@@ -0,0 +1,33 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	git_model "forgejo.org/models/git"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// UpdateProtectBranch saves a branch protection rule and records it in the audit log
+func UpdateProtectBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, protectBranch *git_model.ProtectedBranch, opts git_model.WhitelistOptions) error {
+	if err := git_model.UpdateProtectBranch(ctx, repo, protectBranch, opts); err != nil {
+		return err
+	}
+	audit_service.RecordRepo(ctx, doer, repo, audit_model.ActionBranchProtectionUpdate,
+		audit_service.BranchProtectionTarget(protectBranch.ID, protectBranch.RuleName), audit_service.BranchProtectionDetail(protectBranch))
+	return nil
+}
+
+// DeleteProtectedBranch deletes a branch protection rule and records it in the audit log
+func DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) error {
+	if err := git_model.DeleteProtectedBranch(ctx, repo, rule.ID); err != nil {
+		return err
+	}
+	audit_service.RecordRepo(ctx, doer, repo, audit_model.ActionBranchProtectionDelete, audit_service.BranchProtectionTarget(rule.ID, rule.RuleName), "")
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package secretkey re-encrypts the data stored in the database with the
+// current secret key, so that the previous secret keys can be retired.
+package secretkey
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	admin_model "forgejo.org/models/admin"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	secret_model "forgejo.org/models/secret"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/keying"
+	"forgejo.org/modules/migration"
+	"forgejo.org/modules/structs"
+	"forgejo.org/services/auth/source/ldap"
+
+	"xorm.io/builder"
+)
+
+// Options controls what is done with the encrypted values
+type Options struct {
+	// Reencrypt re-encrypts the values that are not encrypted with the current secret key
+	Reencrypt bool
+	// Verify also decrypts the values that are already encrypted with the current secret key,
+	// otherwise they are skipped
+	Verify bool
+}
+
+// Stats counts the encrypted values of a column
+type Stats struct {
+	// Name is the table and column of the values
+	Name string
+	// Total is the number of encrypted values
+	Total int
+	// Outdated is the number of values that are not encrypted with the current secret key
+	Outdated int
+	// Reencrypted is the number of outdated values that were re-encrypted
+	Reencrypted int
+	// Undecryptable are the IDs of the rows that cannot be decrypted with any of the secret keys
+	Undecryptable []int64
+}
+
+// process decrypts a value and returns the value encrypted with the current secret key if it
+// has to be updated
+func (s *Stats) process(key keying.Context, id int64, ciphertext, additionalData []byte, opts Options) ([]byte, bool) {
+	s.Total++
+
+	isCurrent := keying.IsCurrentKey(ciphertext)
+	if isCurrent && !opts.Verify {
+		return nil, false
+	}
+
+	plaintext, err := key.Decrypt(ciphertext, additionalData)
+	if err != nil {
+		s.Undecryptable = append(s.Undecryptable, id)
+		return nil, false
+	}
+	if isCurrent {
+		return nil, false
+	}
+
+	s.Outdated++
+	if !opts.Reencrypt {
+		return nil, false
+	}
+	return key.Encrypt(plaintext, additionalData), true
+}
+
+// Process goes through every encrypted value stored in the database. The values are processed in
+// batches ordered by ID and the values already encrypted with the current secret key are skipped
+// unless verified, so the re-encryption can be interrupted and resumed at any time.
+func Process(ctx context.Context, opts Options) ([]*Stats, error) {
+	all := make([]*Stats, 0, 6)
+	for _, process := range []func(context.Context, Options) (*Stats, error){
+		processTwoFactors,
+		processActionSecrets,
+		processPushMirrors,
+		processWebhooks,
+		processMigrateTasks,
+		processLDAPSources,
+	} {
+		stats, err := process(ctx, opts)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, stats)
+	}
+	return all, nil
+}
+
+// updateIfUnchanged updates a column of a row, unless it was modified since it was read
+func updateIfUnchanged(ctx context.Context, stats *Stats, bean any, id int64, column string, old any) error {
+	n, err := db.GetEngine(ctx).ID(id).Where(builder.Eq{column: old}).Cols(column).NoAutoTime().Update(bean)
+	if err != nil {
+		return fmt.Errorf("update %s of %d: %w", stats.Name, id, err)
+	}
+	stats.Reencrypted += int(n)
+	return nil
+}
+
+func processTwoFactors(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "two_factor.secret"}
+	return stats, db.Iterate(ctx, nil, func(ctx context.Context, bean *auth_model.TwoFactor) error {
+		if len(bean.Secret) == 0 {
+			return nil
+		}
+		old := bean.Secret
+		ciphertext, ok := stats.process(keying.TOTP, bean.ID, old, keying.ColumnAndID("secret", bean.ID), opts)
+		if !ok {
+			return nil
+		}
+		bean.Secret = ciphertext
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "secret", old)
+	})
+}
+
+func processActionSecrets(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "secret.data"}
+	return stats, db.Iterate(ctx, nil, func(ctx context.Context, bean *secret_model.Secret) error {
+		if len(bean.Data) == 0 {
+			return nil
+		}
+		old := bean.Data
+		ciphertext, ok := stats.process(keying.ActionSecret, bean.ID, old, keying.ColumnAndID("data", bean.ID), opts)
+		if !ok {
+			return nil
+		}
+		bean.Data = ciphertext
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "data", old)
+	})
+}
+
+func processPushMirrors(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "push_mirror.private_key"}
+	return stats, db.Iterate(ctx, nil, func(ctx context.Context, bean *repo_model.PushMirror) error {
+		if len(bean.PrivateKey) == 0 {
+			return nil
+		}
+		old := bean.PrivateKey
+		ciphertext, ok := stats.process(keying.PushMirror, bean.ID, old, keying.ColumnAndID("private_key", bean.ID), opts)
+		if !ok {
+			return nil
+		}
+		bean.PrivateKey = ciphertext
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "private_key", old)
+	})
+}
+
+func processWebhooks(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "webhook.header_authorization_encrypted"}
+	return stats, db.Iterate(ctx, nil, func(ctx context.Context, bean *webhook_model.Webhook) error {
+		if len(bean.HeaderAuthorizationEncrypted) == 0 {
+			return nil
+		}
+		old := bean.HeaderAuthorizationEncrypted
+		ciphertext, ok := stats.process(keying.Webhook, bean.ID, old, keying.ColumnAndID("header_authorization_encrypted", bean.ID), opts)
+		if !ok {
+			return nil
+		}
+		bean.HeaderAuthorizationEncrypted = ciphertext
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "header_authorization_encrypted", old)
+	})
+}
+
+func processMigrateTasks(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "task.payload_content"}
+	return stats, db.Iterate(ctx, builder.Eq{"type": structs.TaskTypeMigrateRepo}, func(ctx context.Context, bean *admin_model.Task) error {
+		var payload migration.MigrateOptions
+		if err := json.Unmarshal([]byte(bean.PayloadContent), &payload); err != nil {
+			stats.Total++
+			stats.Undecryptable = append(stats.Undecryptable, bean.ID)
+			return nil
+		}
+
+		changed := false
+		for _, field := range []struct {
+			selector string
+			value    *string
+		}{
+			{"clone_addr_encrypted", &payload.CloneAddrEncrypted},
+			{"auth_password_encrypted", &payload.AuthPasswordEncrypted},
+			{"auth_token_encrypted", &payload.AuthTokenEncrypted},
+		} {
+			if *field.value == "" {
+				continue
+			}
+			old, err := base64.RawStdEncoding.DecodeString(*field.value)
+			if err != nil {
+				stats.Total++
+				stats.Undecryptable = append(stats.Undecryptable, bean.ID)
+				continue
+			}
+			ciphertext, ok := stats.process(keying.MigrateTask, bean.ID, old, keying.ColumnAndJSONSelectorAndID("payload_content", field.selector, bean.ID), opts)
+			if !ok {
+				continue
+			}
+			*field.value = base64.RawStdEncoding.EncodeToString(ciphertext)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+
+		bs, err := json.Marshal(&payload)
+		if err != nil {
+			return err
+		}
+		old := bean.PayloadContent
+		bean.PayloadContent = string(bs)
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "payload_content", old)
+	})
+}
+
+// authSourceConfig is the serialized configuration of an authentication source, it is read without
+// being converted because the conversion fails when the secrets cannot be decrypted
+type authSourceConfig struct {
+	ID   int64 `xorm:"pk autoincr"`
+	Type auth_model.Type
+	Cfg  string `xorm:"TEXT"`
+}
+
+func (authSourceConfig) TableName() string {
+	return "login_source"
+}
+
+func processLDAPSources(ctx context.Context, opts Options) (*Stats, error) {
+	stats := &Stats{Name: "login_source.cfg"}
+	return stats, db.Iterate(ctx, builder.In("`type`", auth_model.LDAP, auth_model.DLDAP), func(ctx context.Context, bean *authSourceConfig) error {
+		isCurrent, err := ldap.IsBindPasswordEncryptedWithSecretKey([]byte(bean.Cfg))
+		if err == nil && isCurrent && !opts.Verify {
+			stats.Total++
+			return nil
+		}
+
+		source := &ldap.Source{}
+		if err == nil {
+			err = source.FromDB([]byte(bean.Cfg))
+		}
+		stats.Total++
+		if err != nil {
+			stats.Undecryptable = append(stats.Undecryptable, bean.ID)
+			return nil
+		}
+		if isCurrent {
+			return nil
+		}
+
+		stats.Outdated++
+		if !opts.Reencrypt {
+			return nil
+		}
+		bs, err := source.ToDB()
+		if err != nil {
+			return err
+		}
+		old := bean.Cfg
+		bean.Cfg = string(bs)
+		return updateIfUnchanged(ctx, stats, bean, bean.ID, "cfg", old)
+	})
+}
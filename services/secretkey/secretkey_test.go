@@ -0,0 +1,80 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretkey
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	secret_model "forgejo.org/models/secret"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/keying"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStats(t *testing.T, all []*Stats, name string) *Stats {
+	t.Helper()
+	for _, stats := range all {
+		if stats.Name == name {
+			return stats
+		}
+	}
+	require.FailNow(t, "no stats", name)
+	return nil
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// not encrypted with any of the secret keys
+	undecryptable := &secret_model.Secret{OwnerID: 2, Name: "UNDECRYPTABLE", Data: make([]byte, 128)}
+	require.NoError(t, db.Insert(db.DefaultContext, undecryptable))
+
+	// the fixture is encrypted in the unversioned format
+	twoFactor := unittest.AssertExistsAndLoadBean(t, &auth_model.TwoFactor{ID: 1})
+	assert.False(t, keying.IsCurrentKey(twoFactor.Secret))
+
+	t.Run("Verify", func(t *testing.T) {
+		all, err := Process(db.DefaultContext, Options{Verify: true})
+		require.NoError(t, err)
+
+		stats := getStats(t, all, "two_factor.secret")
+		assert.Equal(t, 1, stats.Total)
+		assert.Equal(t, 1, stats.Outdated)
+		assert.Zero(t, stats.Reencrypted)
+		assert.Empty(t, stats.Undecryptable)
+
+		stats = getStats(t, all, "secret.data")
+		assert.Equal(t, []int64{undecryptable.ID}, stats.Undecryptable)
+	})
+
+	t.Run("Reencrypt", func(t *testing.T) {
+		all, err := Process(db.DefaultContext, Options{Reencrypt: true})
+		require.NoError(t, err)
+
+		stats := getStats(t, all, "two_factor.secret")
+		assert.Equal(t, 1, stats.Reencrypted)
+
+		reencrypted := unittest.AssertExistsAndLoadBean(t, &auth_model.TwoFactor{ID: 1})
+		assert.True(t, keying.IsCurrentKey(reencrypted.Secret))
+		secret, err := keying.TOTP.Decrypt(reencrypted.Secret, keying.ColumnAndID("secret", reencrypted.ID))
+		require.NoError(t, err)
+		plaintext, err := keying.TOTP.Decrypt(twoFactor.Secret, keying.ColumnAndID("secret", twoFactor.ID))
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, secret)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		all, err := Process(db.DefaultContext, Options{Reencrypt: true})
+		require.NoError(t, err)
+
+		stats := getStats(t, all, "two_factor.secret")
+		assert.Equal(t, 1, stats.Total)
+		assert.Zero(t, stats.Outdated)
+		assert.Zero(t, stats.Reencrypted)
+	})
+}
@@ -11,21 +11,38 @@ import (
 )
 
 func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data string) (*secret_model.Secret, bool, error) {
-	if err := ValidateName(name); err != nil {
-		return nil, false, err
-	}
-
-	s, exists, err := db.Get[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+	return createOrUpdateSecret(ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
 		RepoID:  repoID,
 		Name:    name,
-	}.ToConds())
+	}, data, func() (*secret_model.Secret, error) {
+		return secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data)
+	})
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of an environment of the repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, data string) (*secret_model.Secret, bool, error) {
+	return createOrUpdateSecret(ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	}, data, func() (*secret_model.Secret, error) {
+		return secret_model.InsertEncryptedEnvironmentSecret(ctx, repoID, environmentID, name, data)
+	})
+}
+
+func createOrUpdateSecret(ctx context.Context, opts secret_model.FindSecretsOptions, data string, insert func() (*secret_model.Secret, error)) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(opts.Name); err != nil {
+		return nil, false, err
+	}
+
+	s, exists, err := db.Get[secret_model.Secret](ctx, opts.ToConds())
 	if err != nil {
 		return nil, false, err
 	}
 
 	if !exists {
-		s, err := secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data)
+		s, err := insert()
 		if err != nil {
 			return nil, false, err
 		}
@@ -56,15 +73,28 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 }
 
 func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
-	if err := ValidateName(name); err != nil {
-		return err
-	}
-
-	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+	return deleteSecretByName(ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
 		RepoID:  repoID,
 		Name:    name,
 	})
+}
+
+// DeleteEnvironmentSecretByName deletes a secret of an environment of the repository
+func DeleteEnvironmentSecretByName(ctx context.Context, repoID, environmentID int64, name string) error {
+	return deleteSecretByName(ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	})
+}
+
+func deleteSecretByName(ctx context.Context, opts secret_model.FindSecretsOptions) error {
+	if err := ValidateName(opts.Name); err != nil {
+		return err
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	issues_model "forgejo.org/models/issues"
+	pull_model "forgejo.org/models/pull"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+)
+
+// MergeQueue represents a queue of the protected branches whose merge queue needs to be processed
+var MergeQueue *queue.WorkerPoolQueue[string]
+
+// AddToQueue requests the merge queue of a branch to be processed
+func AddToQueue(repoID int64, branch string) {
+	log.Trace("Adding branch %s of repo %d to the merge queue", branch, repoID)
+	if err := MergeQueue.Push(fmt.Sprintf("%d_%s", repoID, branch)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Error adding branch %s of repo %d to the merge queue: %v", branch, repoID, err)
+	}
+}
+
+// StartMergeQueueCheckBySHA requests the merge queues with a speculative merge at the given SHA to be processed
+func StartMergeQueueCheckBySHA(ctx context.Context, sha string, repo *repo_model.Repository) error {
+	entries, err := pull_model.GetMergeQueueEntriesByMergeCommitID(ctx, repo.ID, sha)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		AddToQueue(entry.RepoID, entry.BaseBranch)
+	}
+	return nil
+}
+
+// StartMergeQueueCheckByPull requests the merge queue the pull request is in, if any, to be processed
+func StartMergeQueueCheckByPull(ctx context.Context, pr *issues_model.PullRequest) {
+	exists, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		log.Error("GetMergeQueueEntryByPullID: %v", err)
+		return
+	}
+	if exists {
+		AddToQueue(entry.RepoID, entry.BaseBranch)
+	}
+}
@@ -235,6 +235,14 @@ func (ns *notificationService) IssueChangeAssignee(ctx context.Context, doer *us
 	}
 }
 
+func (ns *notificationService) IssueReminder(ctx context.Context, receiver *user_model.User, issue *issues_model.Issue, reminderType issues_model.ReminderType) {
+	_ = ns.issueQueue.Push(issueNotificationOpts{
+		IssueID:              issue.ID,
+		NotificationAuthorID: receiver.ID,
+		ReceiverID:           receiver.ID,
+	})
+}
+
 func (ns *notificationService) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
 	if isRequest {
 		opts := issueNotificationOpts{
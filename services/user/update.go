@@ -14,6 +14,7 @@ import (
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/mailer"
 )
 
@@ -41,10 +42,14 @@ type UpdateOptions struct {
 	RepoAdminChangeTeamAccess    optional.Option[bool]
 	EnableRepoUnitHints          optional.Option[bool]
 	KeepPronounsPrivate          optional.Option[bool]
+
+	// Doer is recorded in the audit log when the administrator privileges change
+	Doer *user_model.User
 }
 
 func UpdateUser(ctx context.Context, u *user_model.User, opts *UpdateOptions) error {
 	cols := make([]string, 0, 20)
+	wasAdmin := u.IsAdmin
 
 	if opts.KeepEmailPrivate.Has() {
 		u.KeepEmailPrivate = opts.KeepEmailPrivate.Value()
@@ -178,7 +183,11 @@ func UpdateUser(ctx context.Context, u *user_model.User, opts *UpdateOptions) er
 		cols = append(cols, "last_login_unix")
 	}
 
-	return user_model.UpdateUserCols(ctx, u, cols...)
+	if err := user_model.UpdateUserCols(ctx, u, cols...); err != nil {
+		return err
+	}
+	audit_service.RecordAdminChange(ctx, opts.Doer, u, wasAdmin)
+	return nil
 }
 
 type UpdateAuthOptions struct {
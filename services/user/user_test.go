@@ -216,7 +216,7 @@ func TestRenameUser(t *testing.T) {
 	})
 
 	t.Run("Non usable username", func(t *testing.T) {
-		usernames := []string{"--diff", ".well-known", "gitea-actions", "aaa.atom", "aa.png"}
+		usernames := []string{"--diff", ".well-known", "gitea-actions", "terraform", "aaa.atom", "aa.png"}
 		for _, username := range usernames {
 			require.Error(t, user_model.IsUsableUsername(username), "non-usable username: %s", username)
 			require.Error(t, RenameUser(db.DefaultContext, user, username), "non-usable username: %s", username)
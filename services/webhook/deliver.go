@@ -5,12 +5,21 @@ package webhook
 
 import (
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +34,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/timeutil"
 	webhook_module "forgejo.org/modules/webhook"
+	"forgejo.org/services/mailer"
 
 	"github.com/gobwas/glob"
 )
@@ -60,6 +70,9 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 	if err != nil {
 		return fmt.Errorf("cannot create http request for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
 	}
+	if err := signDelivery(req, w, t, body, time.Now()); err != nil {
+		return fmt.Errorf("cannot sign http request for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
+	}
 
 	// Record delivery information.
 	t.RequestInfo = &webhook_model.HookRequest{
@@ -105,6 +118,10 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
+	// retryable is set when the delivery failed because of a timeout, a connection error
+	// or an error of the receiver, it is attempted again later
+	retryable := false
+
 	// All code from this point will update the hook task
 	defer func() {
 		t.Delivered = timeutil.TimeStampNanoNow()
@@ -115,6 +132,9 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		} else {
 			log.Trace("Hook delivery failed: %s", t.UUID)
 		}
+		if w.IsActive && !setting.DisableWebhooks {
+			recordAttempt(ctx, w, t, retryable)
+		}
 
 		if err := webhook_model.UpdateHookTask(ctx, t); err != nil {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
@@ -143,6 +163,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 
 	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
+		retryable = true
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
 		return fmt.Errorf("unable to deliver webhook task[%d] in %s due to error in http client: %w", t.ID, w.URL, err)
 	}
@@ -150,6 +171,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 
 	// Status code is 20x can be seen as succeed.
 	t.IsSucceed = resp.StatusCode/100 == 2
+	retryable = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
 	t.ResponseInfo.Status = resp.StatusCode
 	for k, vals := range resp.Header {
 		t.ResponseInfo.Headers[k] = strings.Join(vals, ",")
@@ -164,6 +186,113 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 	return nil
 }
 
+// signDelivery adds the headers of the Standard Webhooks specification (https://www.standardwebhooks.com/)
+// to the request. The signed content is the UUID of the delivery, the timestamp and the body separated by
+// dots. It is signed with HMAC-SHA256 by every valid secret of the webhook ("v1"), so that both the current
+// and the previous secret are accepted during a rotation, and by the key of the instance with Ed25519 ("v1a")
+// or RSA PKCS #1 v1.5 with SHA-256 ("v1r"). The signatures are separated by spaces.
+//
+// The legacy signature headers (X-Forgejo-Signature and the like) only hold one signature, by the current
+// secret. During a rotation, the signature of the body by the previous secret is sent in the
+// X-Forgejo-Previous-Signature header.
+func signDelivery(req *http.Request, w *webhook_model.Webhook, t *webhook_model.HookTask, body []byte, now time.Time) error {
+	secrets := w.ValidSecrets()
+	if len(secrets) > 1 && req.Header.Get("X-Forgejo-Signature") != "" {
+		mac := hmac.New(sha256.New, []byte(secrets[1]))
+		_, _ = mac.Write(body)
+		req.Header.Set("X-Forgejo-Previous-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+	if len(secrets) == 0 && signingKey == nil {
+		return nil
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	content := make([]byte, 0, len(t.UUID)+len(timestamp)+len(body)+2)
+	content = append(content, t.UUID+"."+timestamp+"."...)
+	content = append(content, body...)
+
+	signatures := make([]string, 0, len(secrets)+1)
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(content)
+		signatures = append(signatures, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	switch key := signingKey.(type) {
+	case ed25519.PrivateKey:
+		signatures = append(signatures, "v1a,"+base64.StdEncoding.EncodeToString(ed25519.Sign(key, content)))
+	case *rsa.PrivateKey:
+		hashed := sha256.Sum256(content)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+		if err != nil {
+			return err
+		}
+		signatures = append(signatures, "v1r,"+base64.StdEncoding.EncodeToString(signature))
+	}
+	if signingKey != nil {
+		req.Header.Set("Webhook-Key-Id", signingKeyID)
+	}
+
+	req.Header.Set("Webhook-Id", t.UUID)
+	req.Header.Set("Webhook-Timestamp", timestamp)
+	req.Header.Set("Webhook-Signature", strings.Join(signatures, " "))
+	return nil
+}
+
+// retryBackoff returns the delay before the next attempt of a delivery that failed the given
+// number of times, it doubles with every attempt
+func retryBackoff(attempts int) time.Duration {
+	backoff := setting.Webhook.RetryBackoff
+	for i := 1; i < attempts && backoff < setting.Webhook.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, setting.Webhook.MaxRetryBackoff)
+}
+
+// recordAttempt schedules the next attempt of a failed delivery or dead-letters it when it cannot
+// be retried, the webhook is deactivated when too many deliveries failed in a row
+func recordAttempt(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask, retryable bool) {
+	t.Attempts++
+	t.NextRetryUnix = 0
+
+	if t.IsSucceed {
+		if err := webhook_model.ResetWebhookConsecutiveFailures(ctx, w); err != nil {
+			log.Error("ResetWebhookConsecutiveFailures [%d]: %v", w.ID, err)
+		}
+		return
+	}
+
+	if retryable && t.Attempts <= setting.Webhook.MaxRetries {
+		t.IsDelivered = false
+		t.NextRetryUnix = timeutil.TimeStampNow().AddDuration(retryBackoff(t.Attempts))
+		log.Trace("Hook delivery %s will be retried at %v", t.UUID, t.NextRetryUnix)
+		return
+	}
+	t.IsDeadLetter = true
+
+	failures, err := webhook_model.IncreaseWebhookConsecutiveFailures(ctx, w)
+	if err != nil {
+		log.Error("IncreaseWebhookConsecutiveFailures [%d]: %v", w.ID, err)
+		return
+	}
+	if setting.Webhook.DisableAfterFailures <= 0 || failures < setting.Webhook.DisableAfterFailures {
+		return
+	}
+
+	disabled, err := webhook_model.DisableWebhook(ctx, w)
+	if err != nil {
+		log.Error("DisableWebhook [%d]: %v", w.ID, err)
+		return
+	}
+	if disabled {
+		log.Warn("Webhook[%d] %s was deactivated after %d failed deliveries in a row", w.ID, w.URL, failures)
+		mailer.MailWebhookDisabled(ctx, w, failures)
+	}
+}
+
+// retryCheckInterval is how often the failed deliveries are checked for a due retry
+const retryCheckInterval = 15 * time.Second
+
 var (
 	webhookHTTPClient *http.Client
 	once              sync.Once
@@ -200,6 +329,10 @@ func webhookProxy(allowList *hostmatcher.HostMatchList) func(req *http.Request)
 
 // Init starts the hooks delivery thread
 func Init() error {
+	if err := initSigningKey(); err != nil {
+		return err
+	}
+
 	timeout := time.Duration(setting.Webhook.DeliverTimeout) * time.Second
 
 	allowedHostListValue := setting.Webhook.AllowedHostList
@@ -224,10 +357,38 @@ func Init() error {
 	go graceful.GetManager().RunWithCancel(hookQueue)
 
 	go graceful.GetManager().RunWithShutdownContext(populateWebhookSendingQueue)
+	go graceful.GetManager().RunWithShutdownContext(retryFailedDeliveries)
 
 	return nil
 }
 
+// retryFailedDeliveries regularly queues the failed deliveries whose next attempt is due
+func retryFailedDeliveries(ctx context.Context) {
+	ctx, _, finished := process.GetManager().AddTypedContext(ctx, "Webhook: Retry failed deliveries", process.SystemProcessType, true)
+	defer finished()
+
+	ticker := time.NewTicker(retryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		taskIDs, err := webhook_model.FindDueRetryHookTaskIDs(ctx)
+		if err != nil {
+			log.Error("FindDueRetryHookTaskIDs: %v", err)
+			continue
+		}
+		for _, taskID := range taskIDs {
+			if err := enqueueHookTask(taskID); err != nil {
+				log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", taskID, err)
+			}
+		}
+	}
+}
+
 func populateWebhookSendingQueue(ctx context.Context) {
 	ctx, _, finished := process.GetManager().AddContext(ctx, "Webhook: Populate sending queue")
 	defer finished()
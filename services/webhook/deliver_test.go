@@ -4,10 +4,21 @@
 package webhook
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +29,7 @@ import (
 	"forgejo.org/modules/hostmatcher"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
 	webhook_module "forgejo.org/modules/webhook"
 
 	"github.com/stretchr/testify/assert"
@@ -217,6 +229,282 @@ func TestWebhookDeliverHookTask(t *testing.T) {
 	})
 }
 
+func TestWebhookDeliverRetry(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Webhook.MaxRetries, 1)()
+	defer test.MockVariableValue(&setting.Webhook.DisableAfterFailures, 1)()
+
+	status := http.StatusInternalServerError
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+
+	newHook := func(t *testing.T) *webhook_model.Webhook {
+		hook := &webhook_model.Webhook{
+			RepoID:      3,
+			URL:         s.URL + "/webhook",
+			ContentType: webhook_model.ContentTypeJSON,
+			IsActive:    true,
+			Type:        webhook_module.GITEA,
+		}
+		require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook, ""))
+		return hook
+	}
+	newTask := func(t *testing.T, hook *webhook_model.Webhook) *webhook_model.HookTask {
+		hookTask, err := webhook_model.CreateHookTask(db.DefaultContext, &webhook_model.HookTask{
+			HookID:         hook.ID,
+			EventType:      webhook_module.HookEventPush,
+			PayloadVersion: 2,
+		})
+		require.NoError(t, err)
+		return hookTask
+	}
+
+	t.Run("Server error", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		hook := newHook(t)
+		hookTask := newTask(t, hook)
+
+		require.NoError(t, Deliver(t.Context(), hookTask))
+		hookTask = unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: hookTask.ID})
+		assert.False(t, hookTask.IsSucceed)
+		assert.False(t, hookTask.IsDelivered)
+		assert.False(t, hookTask.IsDeadLetter)
+		assert.Equal(t, 1, hookTask.Attempts)
+		assert.Positive(t, hookTask.NextRetryUnix)
+
+		// the second failure exhausts the retries and deactivates the webhook
+		require.NoError(t, Deliver(t.Context(), hookTask))
+		hookTask = unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: hookTask.ID})
+		assert.True(t, hookTask.IsDelivered)
+		assert.True(t, hookTask.IsDeadLetter)
+		assert.Equal(t, 2, hookTask.Attempts)
+		assert.Zero(t, hookTask.NextRetryUnix)
+
+		hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+		assert.False(t, hook.IsActive)
+		assert.Equal(t, 1, hook.ConsecutiveFailures)
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		status = http.StatusNotFound
+		hook := newHook(t)
+		hookTask := newTask(t, hook)
+
+		require.NoError(t, Deliver(t.Context(), hookTask))
+		hookTask = unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: hookTask.ID})
+		assert.True(t, hookTask.IsDelivered)
+		assert.True(t, hookTask.IsDeadLetter)
+		assert.Equal(t, 1, hookTask.Attempts)
+	})
+
+	t.Run("Success resets the failures", func(t *testing.T) {
+		status = http.StatusOK
+		hook := newHook(t)
+		hook.ConsecutiveFailures = 3
+		require.NoError(t, webhook_model.UpdateWebhook(t.Context(), hook))
+		hookTask := newTask(t, hook)
+
+		require.NoError(t, Deliver(t.Context(), hookTask))
+		hookTask = unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: hookTask.ID})
+		assert.True(t, hookTask.IsSucceed)
+		assert.False(t, hookTask.IsDeadLetter)
+		assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID}).ConsecutiveFailures)
+	})
+}
+
+// verifyStandardWebhook verifies the signatures of a delivery the way a receiver does: the delivery is
+// accepted if one of the signatures matches one of the secrets or the public key of the instance
+func verifyStandardWebhook(header http.Header, body []byte, secrets []string, publicKey crypto.PublicKey) error {
+	timestamp := header.Get("Webhook-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return err
+	}
+	// reject old deliveries, they could be replayed
+	if time.Since(time.Unix(unix, 0)).Abs() > 5*time.Minute {
+		return errors.New("timestamp is too far from now")
+	}
+
+	content := []byte(header.Get("Webhook-Id") + "." + timestamp + "." + string(body))
+	for _, versionedSignature := range strings.Fields(header.Get("Webhook-Signature")) {
+		version, encoded, _ := strings.Cut(versionedSignature, ",")
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		switch version {
+		case "v1":
+			for _, secret := range secrets {
+				mac := hmac.New(sha256.New, []byte(secret))
+				_, _ = mac.Write(content)
+				if hmac.Equal(signature, mac.Sum(nil)) {
+					return nil
+				}
+			}
+		case "v1a":
+			if key, ok := publicKey.(ed25519.PublicKey); ok && ed25519.Verify(key, content, signature) {
+				return nil
+			}
+		case "v1r":
+			hashed := sha256.Sum256(content)
+			if key, ok := publicKey.(*rsa.PublicKey); ok && rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature) == nil {
+				return nil
+			}
+		}
+	}
+	return errors.New("no valid signature")
+}
+
+// legacySignature returns the signature of the body by the secret in the X-Forgejo-Signature format
+func legacySignature(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookDeliverSignature(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var header http.Header
+	var body []byte
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	deliver := func(t *testing.T, hook *webhook_model.Webhook) {
+		t.Helper()
+		hookTask, err := webhook_model.CreateHookTask(db.DefaultContext, &webhook_model.HookTask{
+			HookID:         hook.ID,
+			EventType:      webhook_module.HookEventPush,
+			PayloadVersion: 2,
+		})
+		require.NoError(t, err)
+		require.NoError(t, Deliver(t.Context(), hookTask))
+		assert.Equal(t, hookTask.UUID, header.Get("Webhook-Id"))
+	}
+	newHook := func(t *testing.T, secret string) *webhook_model.Webhook {
+		t.Helper()
+		hook := &webhook_model.Webhook{
+			RepoID:      3,
+			URL:         s.URL + "/webhook",
+			ContentType: webhook_model.ContentTypeJSON,
+			IsActive:    true,
+			Type:        webhook_module.FORGEJO,
+			Secret:      secret,
+		}
+		require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook, ""))
+		return hook
+	}
+
+	t.Run("Secret", func(t *testing.T) {
+		hook := newHook(t, "current")
+		deliver(t, hook)
+
+		require.NoError(t, verifyStandardWebhook(header, body, []string{"current"}, nil))
+		require.Error(t, verifyStandardWebhook(header, body, []string{"another"}, nil))
+		require.Error(t, verifyStandardWebhook(header, append(body, ' '), []string{"current"}, nil))
+		assert.Empty(t, header.Get("Webhook-Key-Id"))
+	})
+
+	t.Run("Secret rotation", func(t *testing.T) {
+		hook := newHook(t, "old")
+		hook.SetSecret("new", time.Hour)
+		require.NoError(t, webhook_model.UpdateWebhook(t.Context(), hook))
+		deliver(t, hook)
+
+		// both secrets are valid during the overlap, the receiver can be updated at any time
+		require.NoError(t, verifyStandardWebhook(header, body, []string{"old"}, nil))
+		require.NoError(t, verifyStandardWebhook(header, body, []string{"new"}, nil))
+		// the legacy headers are signed by the new secret, the previous signature is in its own header
+		assert.Equal(t, legacySignature(body, "new"), header.Get("X-Forgejo-Signature"))
+		assert.Equal(t, legacySignature(body, "old"), header.Get("X-Forgejo-Previous-Signature"))
+
+		hook.PreviousSecretExpiresUnix = timeutil.TimeStampNow() - 1
+		require.NoError(t, webhook_model.UpdateWebhook(t.Context(), hook))
+		deliver(t, hook)
+
+		require.Error(t, verifyStandardWebhook(header, body, []string{"old"}, nil))
+		require.NoError(t, verifyStandardWebhook(header, body, []string{"new"}, nil))
+		assert.Equal(t, legacySignature(body, "new"), header.Get("X-Forgejo-Signature"))
+		assert.Empty(t, header.Get("X-Forgejo-Previous-Signature"))
+	})
+
+	t.Run("No secret", func(t *testing.T) {
+		hook := newHook(t, "")
+		deliver(t, hook)
+
+		assert.Empty(t, header.Get("Webhook-Signature"))
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		keyID, err := publicKeyID(publicKey)
+		require.NoError(t, err)
+		defer test.MockVariableValue[crypto.Signer](&signingKey, privateKey)()
+		defer test.MockVariableValue(&signingKeyID, keyID)()
+
+		hook := newHook(t, "")
+		deliver(t, hook)
+
+		assert.Equal(t, keyID, header.Get("Webhook-Key-Id"))
+		require.NoError(t, verifyStandardWebhook(header, body, nil, publicKey))
+		require.Error(t, verifyStandardWebhook(header, append(body, ' '), nil, publicKey))
+	})
+
+	t.Run("RSA", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		keyID, err := publicKeyID(privateKey.Public())
+		require.NoError(t, err)
+		defer test.MockVariableValue[crypto.Signer](&signingKey, privateKey)()
+		defer test.MockVariableValue(&signingKeyID, keyID)()
+
+		hook := newHook(t, "secret")
+		deliver(t, hook)
+
+		assert.Equal(t, keyID, header.Get("Webhook-Key-Id"))
+		require.NoError(t, verifyStandardWebhook(header, body, nil, &privateKey.PublicKey))
+		require.NoError(t, verifyStandardWebhook(header, body, []string{"secret"}, nil))
+	})
+}
+
+func TestLoadOrCreateSigningKey(t *testing.T) {
+	for _, algorithm := range []string{"ed25519", "rsa"} {
+		t.Run(algorithm, func(t *testing.T) {
+			keyPath := filepath.Join(t.TempDir(), "webhook", "signing_private.pem")
+
+			created, err := loadOrCreateSigningKey(keyPath, algorithm)
+			require.NoError(t, err)
+			loaded, err := loadOrCreateSigningKey(keyPath, algorithm)
+			require.NoError(t, err)
+			assert.True(t, loaded.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(created.Public()))
+		})
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "signing_private.pem")
+	_, err := loadOrCreateSigningKey(keyPath, "ed25519")
+	require.NoError(t, err)
+	_, err = loadOrCreateSigningKey(keyPath, "rsa")
+	require.Error(t, err)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.MaxRetryBackoff, 5*time.Minute)()
+
+	assert.Equal(t, time.Minute, retryBackoff(1))
+	assert.Equal(t, 2*time.Minute, retryBackoff(2))
+	assert.Equal(t, 4*time.Minute, retryBackoff(3))
+	assert.Equal(t, 5*time.Minute, retryBackoff(4))
+	assert.Equal(t, 5*time.Minute, retryBackoff(30))
+}
+
 func TestWebhookDeliverSpecificTypes(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/base"
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
@@ -331,6 +332,68 @@ func getActionPayloadInfo(p *api.ActionPayload, linkFormatter linkFormatter) (te
 	return text, color
 }
 
+func getStatusPayloadInfo(p *api.CommitStatusPayload, linkFormatter linkFormatter) (text string, color int) {
+	repoLink := linkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	commitLink := base.TruncateString(p.SHA, 7)
+	if p.Commit != nil {
+		commitLink = linkFormatter(p.Commit.URL, commitLink)
+	}
+	contextLink := p.Context
+	if p.TargetURL != "" {
+		contextLink = linkFormatter(p.TargetURL, p.Context)
+	}
+
+	text = fmt.Sprintf("[%s] Commit status %s of %s is %s", repoLink, contextLink, commitLink, p.State)
+	if p.Description != "" {
+		text += ": " + p.Description
+	}
+
+	switch p.State {
+	case api.CommitStatusSuccess:
+		color = greenColor
+	case api.CommitStatusPending:
+		color = yellowColor
+	case api.CommitStatusWarning:
+		color = orangeColor
+	default:
+		color = redColor
+	}
+
+	return text, color
+}
+
+func getWorkflowJobPayloadInfo(p *api.WorkflowJobPayload, linkFormatter linkFormatter) (text string, color int) {
+	jobLink := linkFormatter(p.WorkflowJob.HTMLURL, p.WorkflowJob.Name)
+	repoLink := linkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+
+	switch p.Action {
+	case api.HookWorkflowJobQueued:
+		text = fmt.Sprintf("[%s] Job %s queued", repoLink, jobLink)
+		color = yellowColor
+	case api.HookWorkflowJobWaiting:
+		text = fmt.Sprintf("[%s] Job %s waiting", repoLink, jobLink)
+		color = yellowColor
+	case api.HookWorkflowJobInProgress:
+		text = fmt.Sprintf("[%s] Job %s started", repoLink, jobLink)
+		color = yellowColor
+	case api.HookWorkflowJobCompleted:
+		text = fmt.Sprintf("[%s] Job %s completed: %s", repoLink, jobLink, p.WorkflowJob.Status)
+		switch p.WorkflowJob.Status {
+		case "success", "skipped":
+			color = greenColor
+		case "cancelled":
+			color = greyColor
+		default:
+			color = redColor
+		}
+	}
+	if p.Run != nil {
+		text += " in " + linkFormatter(p.Run.HTMLURL, p.Run.Title)
+	}
+
+	return text, color
+}
+
 // ToHook convert models.Webhook to api.Hook
 // This function is not part of the convert package to prevent an import cycle
 func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
@@ -372,3 +435,19 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		Created:             w.CreatedUnix.AsTime(),
 	}, nil
 }
+
+// ToHookDelivery converts a hook task to an API delivery
+func ToHookDelivery(t *webhook_model.HookTask) *api.HookDelivery {
+	delivery := &api.HookDelivery{
+		ID:        t.ID,
+		UUID:      t.UUID,
+		Event:     string(t.EventType),
+		Attempts:  t.Attempts,
+		Delivered: t.Delivered.AsTime(),
+	}
+	if t.ResponseInfo != nil {
+		delivery.StatusCode = t.ResponseInfo.Status
+		delivery.Response = t.ResponseInfo.Body
+	}
+	return delivery
+}
@@ -286,6 +286,52 @@ func ActionTestPayload() *api.ActionPayload {
 	}
 }
 
+func commitStatusTestPayload() *api.CommitStatusPayload {
+	return &api.CommitStatusPayload{
+		SHA:         "2020558fe2e34debb818a514715839cabd25e778",
+		Context:     "ci/build",
+		State:       api.CommitStatusSuccess,
+		TargetURL:   "http://localhost:3000/test/repo/actions/runs/69/jobs/0",
+		Description: "Successful in 1m",
+		Commit: &api.PayloadCommit{
+			ID:  "2020558fe2e34debb818a514715839cabd25e778",
+			URL: "http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778",
+		},
+		Repo: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+	}
+}
+
+func workflowJobTestPayload() *api.WorkflowJobPayload {
+	return &api.WorkflowJobPayload{
+		Action: api.HookWorkflowJobCompleted,
+		WorkflowJob: &api.ActionWorkflowJob{
+			ID:      3,
+			RunID:   69,
+			Name:    "build",
+			Status:  "success",
+			HTMLURL: "http://localhost:3000/test/repo/actions/runs/69/jobs/0/attempt/1",
+		},
+		Run: ActionTestPayload().Run,
+		Repo: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+	}
+}
+
 func pullRequestTestPayload() *api.PullRequestPayload {
 	return &api.PullRequestPayload{
 		Action: api.HookIssueOpened,
@@ -724,3 +770,91 @@ func TestGetActionPayloadInfo(t *testing.T) {
 		assert.Equal(t, c.color, color, "case %d", i)
 	}
 }
+
+func TestGetStatusPayloadInfo(t *testing.T) {
+	p := commitStatusTestPayload()
+
+	cases := []struct {
+		state api.CommitStatusState
+		text  string
+		color int
+	}{
+		{
+			api.CommitStatusSuccess,
+			"[test/repo] Commit status ci/build of 2020558 is success: Successful in 1m",
+			greenColor,
+		},
+		{
+			api.CommitStatusPending,
+			"[test/repo] Commit status ci/build of 2020558 is pending: Successful in 1m",
+			yellowColor,
+		},
+		{
+			api.CommitStatusFailure,
+			"[test/repo] Commit status ci/build of 2020558 is failure: Successful in 1m",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.State = c.state
+		text, color := getStatusPayloadInfo(p, noneLinkFormatter)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+	}
+
+	p.Description = ""
+	p.TargetURL = ""
+	text, _ := getStatusPayloadInfo(p, htmlLinkFormatter)
+	assert.Equal(t, `[<a href="http://localhost:3000/test/repo">test/repo</a>] Commit status ci/build of <a href="http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778">2020558</a> is failure`, text)
+}
+
+func TestGetWorkflowJobPayloadInfo(t *testing.T) {
+	p := workflowJobTestPayload()
+
+	cases := []struct {
+		action api.HookWorkflowJobAction
+		status string
+		text   string
+		color  int
+	}{
+		{
+			api.HookWorkflowJobQueued,
+			"waiting",
+			"[test/repo] Job build queued in Build release",
+			yellowColor,
+		},
+		{
+			api.HookWorkflowJobWaiting,
+			"blocked",
+			"[test/repo] Job build waiting in Build release",
+			yellowColor,
+		},
+		{
+			api.HookWorkflowJobInProgress,
+			"running",
+			"[test/repo] Job build started in Build release",
+			yellowColor,
+		},
+		{
+			api.HookWorkflowJobCompleted,
+			"success",
+			"[test/repo] Job build completed: success in Build release",
+			greenColor,
+		},
+		{
+			api.HookWorkflowJobCompleted,
+			"failure",
+			"[test/repo] Job build completed: failure in Build release",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		p.WorkflowJob.Status = c.status
+		text, color := getWorkflowJobPayloadInfo(p, noneLinkFormatter)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
@@ -309,3 +310,32 @@ func TestAction(t *testing.T) {
 		assertActionEqual(t, oldSuccessRun, payloadContent.LastRun)
 	})
 }
+
+func TestCreateCommitStatus(t *testing.T) {
+	defer unittest.OverrideFixtures("services/webhook/TestPushCommits")()
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2, OwnerID: user.ID})
+
+	NewNotifier().CreateCommitStatus(db.DefaultContext, repo, pushCommits().HeadCommit, user, &git_model.CommitStatus{
+		ID:          7,
+		State:       structs.CommitStatusSuccess,
+		TargetURL:   "https://example.com/builds/7",
+		Description: "the build passed",
+		Context:     "ci/test-commit-status",
+	})
+
+	hookTask := unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{}, unittest.Cond("event_type == 'status' AND payload_content LIKE '%ci/test-commit-status%'"))
+
+	var payloadContent structs.CommitStatusPayload
+	require.NoError(t, json.Unmarshal([]byte(hookTask.PayloadContent), &payloadContent))
+	assert.Equal(t, "2c54faec6c45d31c1abfaecdab471eac6633738a", payloadContent.SHA)
+	assert.Equal(t, structs.CommitStatusSuccess, payloadContent.State)
+	assert.Equal(t, "https://example.com/builds/7", payloadContent.TargetURL)
+	assert.Equal(t, "the build passed", payloadContent.Description)
+	require.NotNil(t, payloadContent.Commit)
+	assert.Equal(t, "2c54faec6c45d31c1abfaecdab471eac6633738a", payloadContent.Commit.ID)
+	assert.Equal(t, repo.ID, payloadContent.Repo.ID)
+	assert.Equal(t, user.Name, payloadContent.Sender.UserName)
+}
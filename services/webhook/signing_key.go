@@ -0,0 +1,133 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+)
+
+var (
+	// signingKey is the key of the instance signing the deliveries, nil if they are not signed with it
+	signingKey crypto.Signer
+	// signingKeyID identifies signingKey, it is sent along the signatures so that the receivers can
+	// tell which key to verify them with after the key was replaced
+	signingKeyID string
+)
+
+// initSigningKey loads the key of the instance signing the deliveries, it is created if it does not exist
+func initSigningKey() error {
+	signingKey, signingKeyID = nil, ""
+	if setting.Webhook.SigningAlgorithm == "" {
+		return nil
+	}
+
+	key, err := loadOrCreateSigningKey(setting.Webhook.SigningPrivateKeyFile, setting.Webhook.SigningAlgorithm)
+	if err != nil {
+		return fmt.Errorf("webhook signing key %s: %w", setting.Webhook.SigningPrivateKeyFile, err)
+	}
+	keyID, err := publicKeyID(key.Public())
+	if err != nil {
+		return err
+	}
+	signingKey, signingKeyID = key, keyID
+	return nil
+}
+
+func loadOrCreateSigningKey(keyPath, algorithm string) (crypto.Signer, error) {
+	isExist, err := util.IsExist(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		var key any
+		switch algorithm {
+		case "ed25519":
+			_, key, err = ed25519.GenerateKey(rand.Reader)
+		case "rsa":
+			key, err = rsa.GenerateKey(rand.Reader, 3072)
+		default:
+			return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+		}
+		if err != nil {
+			return nil, err
+		}
+		bytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(keyPath), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: bytes}), 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	bytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, fmt.Errorf("no valid PEM data found in %s", keyPath)
+	} else if block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("expected PRIVATE KEY, got %s in %s", block.Type, keyPath)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		if algorithm == "ed25519" {
+			return key, nil
+		}
+	case *rsa.PrivateKey:
+		if algorithm == "rsa" {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("the key in %s is not an %s key", keyPath, algorithm)
+}
+
+// publicKeyID is the hexadecimal SHA-256 of the public key in the PKIX format, truncated to 16 characters
+func publicKeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// SigningKey returns the public key the deliveries are signed with, nil if they are not signed with a key of the instance
+func SigningKey() (*api.WebhookSigningKey, error) {
+	if signingKey == nil {
+		return nil, nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &api.WebhookSigningKey{
+		KeyID:     signingKeyID,
+		Algorithm: setting.Webhook.SigningAlgorithm,
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, nil
+}
@@ -211,6 +211,18 @@ func (telegramConvertor) Action(p *api.ActionPayload) (TelegramPayload, error) {
 	return createTelegramPayload(text), nil
 }
 
+func (telegramConvertor) Status(p *api.CommitStatusPayload) (TelegramPayload, error) {
+	text, _ := getStatusPayloadInfo(p, htmlLinkFormatter)
+
+	return createTelegramPayload(text), nil
+}
+
+func (telegramConvertor) WorkflowJob(p *api.WorkflowJobPayload) (TelegramPayload, error) {
+	text, _ := getWorkflowJobPayloadInfo(p, htmlLinkFormatter)
+
+	return createTelegramPayload(text), nil
+}
+
 func createTelegramPayload(message string) TelegramPayload {
 	return TelegramPayload{
 		Message:           markup.Sanitize(strings.TrimSpace(message)),
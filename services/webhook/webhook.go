@@ -268,3 +268,18 @@ func ReplayHookTask(ctx context.Context, w *webhook_model.Webhook, uuid string)
 
 	return enqueueHookTask(task.ID)
 }
+
+// RedeliverFailedHookTasks replays the failed deliveries of a webhook, all of them if uuids is empty
+func RedeliverFailedHookTasks(ctx context.Context, w *webhook_model.Webhook, uuids []string) ([]*webhook_model.HookTask, error) {
+	tasks, err := webhook_model.RedeliverFailedHookTasks(ctx, w.ID, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if err := enqueueHookTask(task.ID); err != nil {
+			return tasks, err
+		}
+	}
+	return tasks, nil
+}
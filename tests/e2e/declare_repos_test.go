@@ -54,7 +54,7 @@ func DeclareGitRepos(t *testing.T) func() {
 			Content:     content,
 			CreatedUnix: now.Add(-age),
 		}
-		require.NoError(t, issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil))
+		require.NoError(t, issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil, 0))
 	}
 
 	cleanupFunctions := []func(){
@@ -0,0 +1,57 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIActionsQueuedJobs(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+
+	req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/actions/queue", repo.FullName())).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var jobs []*api.ActionQueuedJob
+	DecodeJSON(t, resp, &jobs)
+	assert.Equal(t, "4", resp.Header().Get("X-Total-Count"))
+
+	found := false
+	for _, job := range jobs {
+		assert.Equal(t, repo.ID, job.RepoID)
+		if job.ID == 393 {
+			found = true
+			assert.Equal(t, []string{"ubuntu-latest"}, job.RunsOn)
+			assert.Equal(t, []string{"ubuntu-latest"}, job.MissingLabels)
+			assert.Equal(t, "no_matching_runner", job.Diagnosis)
+		}
+	}
+	assert.True(t, found)
+
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/actions/queue", repo.FullName())).AddTokenAuth(otherToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeReadAdmin)
+	req = NewRequest(t, "GET", "/api/v1/admin/actions/queue").AddTokenAuth(adminToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &jobs)
+	assert.Equal(t, "6", resp.Header().Get("X-Total-Count"))
+	req = NewRequest(t, "GET", "/api/v1/admin/actions/queue").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusForbidden)
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"forgejo.org/models/forgefed"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/models/user"
+	"forgejo.org/modules/activitypub"
+	forgefed_modules "forgejo.org/modules/forgefed"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/routers"
+	"forgejo.org/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityPubRepositoryInboxTicket(t *testing.T) {
+	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+
+	mock := test.NewFederationServerMock()
+	federatedSrv := mock.DistantServer(t)
+	defer federatedSrv.Close()
+
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		repositoryID := 1
+		localRepo := u.JoinPath(fmt.Sprintf("/api/v1/activitypub/repository-id/%d", repositoryID)).String()
+		localRepoInbox := localRepo + "/inbox"
+		actor := fmt.Sprintf("%s/api/v1/activitypub/user-id/15", federatedSrv.URL)
+		ticketID := fmt.Sprintf("%s/api/v1/activitypub/repository-id/1/issues/42", federatedSrv.URL)
+
+		ctx, _ := contexttest.MockAPIContext(t, localRepoInbox)
+		cf, err := activitypub.NewClientFactoryWithTimeout(60 * time.Second)
+		require.NoError(t, err)
+
+		c, err := cf.WithKeysDirect(ctx, mock.Persons[0].PrivKey,
+			mock.Persons[0].KeyID(federatedSrv.URL))
+		require.NoError(t, err)
+
+		createTicket := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Ticket","id":"%[2]s","attributedTo":"%[1]s","context":"%[3]s",`+
+				`"summary":"Federated bug","content":"<p>It is broken</p>",`+
+				`"source":{"content":"It is **broken**","mediaType":"text/markdown"}}}`,
+			actor, ticketID, localRepo))
+		resp, err := c.Post(createTicket, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		federationHost := unittest.AssertExistsAndLoadBean(t, &forgefed.FederationHost{HostFqdn: "127.0.0.1"})
+		federatedUser := unittest.AssertExistsAndLoadBean(t, &user.FederatedUser{ExternalID: "15", FederationHostID: federationHost.ID})
+		federatedIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.FederatedIssue{ObjectID: ticketID})
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: federatedIssue.IssueID})
+		assert.EqualValues(t, repositoryID, issue.RepoID)
+		assert.Equal(t, federatedUser.UserID, issue.PosterID)
+		assert.Equal(t, "Federated bug", issue.Title)
+		assert.Equal(t, "It is **broken**", issue.Content)
+
+		// Replaying the ticket does not create another issue
+		resp, err = c.Post(createTicket, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		unittest.AssertCount(t, &issues_model.FederatedIssue{}, 1)
+
+		// A comment on the federated ticket
+		noteID := ticketID + "#comment-1"
+		createNote := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Note","id":"%[2]s","attributedTo":"%[1]s","inReplyTo":"%[3]s",`+
+				`"content":"Still broken"}}`,
+			actor, noteID, ticketID))
+		resp, err = c.Post(createNote, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		federatedComment := unittest.AssertExistsAndLoadBean(t, &issues_model.FederatedComment{ObjectID: noteID})
+		comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: federatedComment.CommentID})
+		assert.Equal(t, issue.ID, comment.IssueID)
+		assert.Equal(t, federatedUser.UserID, comment.PosterID)
+		assert.Equal(t, "Still broken", comment.Content)
+
+		// A comment on a local issue
+		localIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: int64(repositoryID), Index: 1})
+		createLocalNote := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Note","id":"%[2]s/notes/2","attributedTo":"%[1]s","inReplyTo":"%[3]s",`+
+				`"content":"Me too"}}`,
+			actor, federatedSrv.URL, localIssue.APObjectID()))
+		resp, err = c.Post(createLocalNote, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: localIssue.ID, PosterID: federatedUser.UserID, Content: "Me too"})
+
+		// A comment on an unknown ticket
+		createUnknownNote := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Note","id":"%[2]s/notes/3","attributedTo":"%[1]s","inReplyTo":"%[2]s/tickets/unknown",`+
+				`"content":"Lost"}}`,
+			actor, federatedSrv.URL))
+		resp, err = c.Post(createUnknownNote, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+
+		// The local issue is served as a ticket
+		body, err := c.GetBody(localIssue.APObjectID())
+		require.NoError(t, err)
+		var ticket forgefed_modules.Ticket
+		require.NoError(t, ticket.UnmarshalJSON(body))
+		assert.Equal(t, forgefed_modules.TicketType, ticket.Type)
+		assert.Equal(t, localIssue.Title, ticket.Summary.String())
+		assert.Equal(t, localRepo, ticket.Context.GetLink().String())
+	})
+}
+
+func TestActivityPubRepositoryInboxTicketInvalidContext(t *testing.T) {
+	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+
+	mock := test.NewFederationServerMock()
+	federatedSrv := mock.DistantServer(t)
+	defer federatedSrv.Close()
+
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		localRepoInbox := u.JoinPath("/api/v1/activitypub/repository-id/1/inbox").String()
+		otherRepo := u.JoinPath("/api/v1/activitypub/repository-id/4").String()
+		actor := fmt.Sprintf("%s/api/v1/activitypub/user-id/15", federatedSrv.URL)
+
+		ctx, _ := contexttest.MockAPIContext(t, localRepoInbox)
+		cf, err := activitypub.NewClientFactoryWithTimeout(60 * time.Second)
+		require.NoError(t, err)
+
+		c, err := cf.WithKeysDirect(ctx, mock.Persons[0].PrivKey,
+			mock.Persons[0].KeyID(federatedSrv.URL))
+		require.NoError(t, err)
+
+		// The ticket belongs to the issue tracker of another repository
+		createTicket := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Ticket","id":"%[2]s/tickets/1","attributedTo":"%[1]s","context":"%[3]s",`+
+				`"summary":"Misdelivered"}}`,
+			actor, federatedSrv.URL, otherRepo))
+		resp, err := c.Post(createTicket, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		unittest.AssertCount(t, &issues_model.FederatedIssue{}, 0)
+	})
+}
+
+func TestActivityPubRepositoryInboxTicketForgedActor(t *testing.T) {
+	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+
+	mock := test.NewFederationServerMock()
+	federatedSrv := mock.DistantServer(t)
+	defer federatedSrv.Close()
+
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		localRepo := u.JoinPath("/api/v1/activitypub/repository-id/1").String()
+		localRepoInbox := localRepo + "/inbox"
+		actor := fmt.Sprintf("%s/api/v1/activitypub/user-id/15", federatedSrv.URL)
+
+		ctx, _ := contexttest.MockAPIContext(t, localRepoInbox)
+		cf, err := activitypub.NewClientFactoryWithTimeout(60 * time.Second)
+		require.NoError(t, err)
+
+		// The activities of the person 15 are signed with the key of the person 30
+		c, err := cf.WithKeysDirect(ctx, mock.Persons[1].PrivKey,
+			mock.Persons[1].KeyID(federatedSrv.URL))
+		require.NoError(t, err)
+
+		createTicket := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Ticket","id":"%[2]s/tickets/1","attributedTo":"%[1]s","context":"%[3]s",`+
+				`"summary":"Forged"}}`,
+			actor, federatedSrv.URL, localRepo))
+		resp, err := c.Post(createTicket, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		unittest.AssertCount(t, &issues_model.FederatedIssue{}, 0)
+
+		localIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Index: 1})
+		createNote := []byte(fmt.Sprintf(
+			`{"type":"Create",`+
+				`"actor":"%[1]s",`+
+				`"object":{"type":"Note","id":"%[2]s/notes/1","attributedTo":"%[1]s","inReplyTo":"%[3]s",`+
+				`"content":"Forged"}}`,
+			actor, federatedSrv.URL, localIssue.APObjectID()))
+		resp, err = c.Post(createNote, localRepoInbox)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		unittest.AssertCount(t, &issues_model.FederatedComment{}, 0)
+	})
+}
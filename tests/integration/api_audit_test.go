@@ -0,0 +1,91 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/perm"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIAuditEvents(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	session := loginUser(t, owner.Name)
+	testCtx := NewAPITestContext(t, owner.Name, repo.Name, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeReadUser)
+	doAPIAddCollaborator(testCtx, user4.Name, perm.AccessModeWrite)(t)
+
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{
+		Action:     audit_model.ActionCollaboratorAdd,
+		DoerID:     owner.ID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetUser,
+		TargetID:   user4.ID,
+	})
+
+	t.Run("Repository", func(t *testing.T) {
+		req := NewRequestf(t, "GET", "/api/v1/repos/%s/%s/audit_events", owner.Name, repo.Name).
+			AddTokenAuth(testCtx.Token)
+		resp := session.MakeRequest(t, req, http.StatusOK)
+
+		var events []*api.AuditEvent
+		DecodeJSON(t, resp, &events)
+		require.Len(t, events, 2)
+		assert.Equal(t, string(audit_model.ActionCollaboratorChangeAccess), events[0].Action)
+		assert.Equal(t, "access: write", events[0].Detail)
+		assert.Equal(t, string(audit_model.ActionCollaboratorAdd), events[1].Action)
+		assert.Equal(t, owner.Name, events[0].DoerName)
+		assert.Equal(t, user4.Name, events[0].TargetName)
+		// only administrators see where the change was made from
+		assert.Empty(t, events[0].IPAddress)
+	})
+
+	t.Run("RepositoryNotAdmin", func(t *testing.T) {
+		token := getUserToken(t, user4.Name, auth_model.AccessTokenScopeReadRepository)
+		req := NewRequestf(t, "GET", "/api/v1/repos/%s/%s/audit_events", owner.Name, repo.Name).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("User", func(t *testing.T) {
+		token := getUserToken(t, user4.Name, auth_model.AccessTokenScopeReadUser)
+		req := NewRequest(t, "GET", "/api/v1/user/audit_events?action=collaborator_add").
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var events []*api.AuditEvent
+		DecodeJSON(t, resp, &events)
+		require.Len(t, events, 1)
+		assert.Equal(t, repo.ID, events[0].RepoID)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		token := getUserToken(t, "user1", auth_model.AccessTokenScopeReadAdmin)
+		req := NewRequestf(t, "GET", "/api/v1/admin/audit_events?user=%s&action=collaborator_add", user4.Name).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var events []*api.AuditEvent
+		DecodeJSON(t, resp, &events)
+		require.Len(t, events, 1)
+		assert.NotEmpty(t, events[0].IPAddress)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/audit_events?user=does-not-exist").AddTokenAuth(token), http.StatusNotFound)
+	})
+}
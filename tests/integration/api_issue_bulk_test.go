@@ -0,0 +1,84 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIBulkEditIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteIssue)
+	urlStr := fmt.Sprintf("/api/v1/repos/%s/%s/issues/bulk", owner.Name, repo.Name)
+
+	option := &api.BulkEditIssuesOption{
+		Filter: api.BulkEditIssuesFilter{
+			Indexes: []int64{1, 2, 3},
+			Type:    "issues",
+		},
+		Changes: api.BulkEditIssuesChanges{
+			AddLabels: []int64{2},
+		},
+		DryRun: true,
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", urlStr, option).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var result api.BulkEditIssuesResult
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, []int64{1}, result.Indexes)
+		assert.True(t, result.DryRun)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		option.DryRun = false
+		req := NewRequestWithJSON(t, "POST", urlStr, option).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var result api.BulkEditIssuesResult
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, []int64{1}, result.Indexes)
+		assert.False(t, result.DryRun)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeLabel, LabelID: 2})
+	})
+
+	t.Run("Merged pull request", func(t *testing.T) {
+		state := "closed"
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{
+			Filter:  api.BulkEditIssuesFilter{Indexes: []int64{2}, Type: "pulls"},
+			Changes: api.BulkEditIssuesChanges{State: &state},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusPreconditionFailed)
+	})
+
+	t.Run("Invalid label", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{
+			Changes: api.BulkEditIssuesChanges{AddLabels: []int64{5}},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("No permission", func(t *testing.T) {
+		token := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteIssue)
+		req := NewRequestWithJSON(t, "POST", urlStr, option).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIIssueCustomFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteIssue)
+	urlStr := fmt.Sprintf("/api/v1/repos/%s/%s/custom_fields", owner.Name, repo.Name)
+
+	// select fields need options
+	req := NewRequestWithJSON(t, "POST", urlStr, &api.CreateCustomFieldOption{
+		Name: "Priority",
+		Type: "single_select",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", urlStr, &api.CreateCustomFieldOption{
+		Name:    "Priority",
+		Type:    "single_select",
+		Options: []string{"low", "high"},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var priority api.CustomField
+	DecodeJSON(t, resp, &priority)
+	assert.Equal(t, "single_select", priority.Type)
+	assert.Equal(t, []string{"low", "high"}, priority.Options)
+	assert.False(t, priority.IsOrgField)
+
+	req = NewRequestWithJSON(t, "POST", urlStr, &api.CreateCustomFieldOption{
+		Name: "Reviewer",
+		Type: "user",
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusCreated)
+	var reviewer api.CustomField
+	DecodeJSON(t, resp, &reviewer)
+
+	req = NewRequest(t, "GET", urlStr).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var fields []*api.CustomField
+	DecodeJSON(t, resp, &fields)
+	assert.Len(t, fields, 2)
+
+	t.Run("Values", func(t *testing.T) {
+		issueURL := fmt.Sprintf("/api/v1/repos/%s/%s/issues/1/custom_fields", owner.Name, repo.Name)
+
+		req := NewRequestWithJSON(t, "PUT", issueURL, &api.SetIssueCustomFieldsOption{
+			Fields: []api.IssueCustomFieldValueOption{{FieldID: priority.ID, Values: []string{"urgent"}}},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", issueURL, &api.SetIssueCustomFieldsOption{
+			Fields: []api.IssueCustomFieldValueOption{
+				{FieldID: priority.ID, Values: []string{"high"}},
+				{FieldID: reviewer.ID, Values: []string{"user5"}},
+			},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var values []*api.IssueCustomFieldValue
+		DecodeJSON(t, resp, &values)
+		if assert.Len(t, values, 2) {
+			assert.Equal(t, []string{"high"}, values[0].Values)
+			assert.Equal(t, "Reviewer", values[1].Name)
+			assert.Equal(t, []string{"user5"}, values[1].Values)
+		}
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueCustomFieldValue{IssueID: 1, FieldID: reviewer.ID, Value: "5"})
+
+		req = NewRequest(t, "GET", issueURL).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &values)
+		assert.Len(t, values, 2)
+
+		// the filter is formatted as field_id:value
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/issues?custom_fields=%s", owner.Name, repo.Name, "high")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	fieldURL := fmt.Sprintf("%s/%d", urlStr, priority.ID)
+	name := "Severity"
+	req = NewRequestWithJSON(t, "PATCH", fieldURL, &api.EditCustomFieldOption{
+		Name:    &name,
+		Options: []string{"low", "medium"},
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &priority)
+	assert.Equal(t, "Severity", priority.Name)
+	// the value of the removed option is deleted
+	unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{FieldID: priority.ID})
+
+	req = NewRequest(t, "DELETE", fieldURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: priority.ID})
+
+	req = NewRequest(t, "DELETE", fieldURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+}
+
+func TestAPIOrgCustomFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns org3, which owns repo3
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization, auth_model.AccessTokenScopeWriteIssue)
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/custom_fields", &api.CreateCustomFieldOption{
+		Name: "Estimate",
+		Type: "number",
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var field api.CustomField
+	DecodeJSON(t, resp, &field)
+	assert.True(t, field.IsOrgField)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/custom_fields").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var fields []*api.CustomField
+	DecodeJSON(t, resp, &fields)
+	if assert.Len(t, fields, 1) {
+		assert.Equal(t, field.ID, fields[0].ID)
+	}
+
+	// the field cannot be set on the issues of the repositories of other owners
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/issues/1/custom_fields", &api.SetIssueCustomFieldsOption{
+		Fields: []api.IssueCustomFieldValueOption{{FieldID: field.ID, Values: []string{"3"}}},
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/org3/custom_fields/%d", field.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPISubIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	urlStr := "/api/v1/repos/user2/repo1/issues/1/sub_issues"
+
+	for _, index := range []int64{2, 4} {
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.IssueMeta{Index: index}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var subIssue api.Issue
+		DecodeJSON(t, resp, &subIssue)
+		assert.Equal(t, index, subIssue.Index)
+		if assert.NotNil(t, subIssue.Parent) {
+			assert.Equal(t, &api.IssueMeta{Index: 1, Owner: "user2", Name: "repo1"}, subIssue.Parent)
+		}
+	}
+
+	// the issue already has a parent
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/3/sub_issues", &api.IssueMeta{Index: 2}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusConflict)
+	// the parent is a sub-issue of the issue
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/2/sub_issues", &api.IssueMeta{Index: 1}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// the issue with index 4 is closed
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var parent api.Issue
+	DecodeJSON(t, resp, &parent)
+	assert.Equal(t, &api.SubIssuesSummary{Total: 2, Closed: 1}, parent.SubIssues)
+
+	req = NewRequestWithJSON(t, "PATCH", urlStr, &api.ReorderSubIssuesOption{
+		SubIssues: []api.IssueMeta{{Index: 4}, {Index: 2}},
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var subIssues []*api.Issue
+	DecodeJSON(t, resp, &subIssues)
+	if assert.Len(t, subIssues, 2) {
+		assert.EqualValues(t, 4, subIssues[0].Index)
+		assert.EqualValues(t, 2, subIssues[1].Index)
+	}
+
+	req = NewRequestWithJSON(t, "DELETE", urlStr, &api.IssueMeta{Index: 4}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 5})
+
+	req = NewRequest(t, "GET", urlStr).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &subIssues)
+	assert.Len(t, subIssues, 1)
+}
+
+func TestAPIIssueType(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{
+		Title:     "epic",
+		IssueType: "epic",
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var issue api.Issue
+	DecodeJSON(t, resp, &issue)
+	assert.Equal(t, "epic", issue.IssueType)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID, Type: issues_model.IssueTypeEpic})
+
+	urlStr := fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d", issue.Index)
+	req = NewRequestWithJSON(t, "PATCH", urlStr, &api.EditIssueOption{IssueType: util.ToPointer("epos")}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "PATCH", urlStr, &api.EditIssueOption{IssueType: util.ToPointer("")}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusCreated)
+	DecodeJSON(t, resp, &issue)
+	assert.Empty(t, issue.IssueType)
+
+	// pull requests have no type
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/2", &api.EditIssueOption{IssueType: util.ToPointer("task")}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,287 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, setting.AppURL+"api/packages/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppURL+"api/packages/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "network"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Network module"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{
+			"main.tf":   `resource "aws_vpc" "this" {}`,
+			"README.md": readme,
+		} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		uploadURL := fmt.Sprintf("/api/packages/%s/terraform/modules/%s/%s/%s", user.Name, moduleName, moduleSystem, moduleVersion)
+		registryURL := fmt.Sprintf("/api/packages/terraform/modules/v1/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			assert.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			require.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.Equal(t, &terraform_module.Metadata{Kind: terraform_module.KindModule, Readme: readme}, pd.Metadata)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			assert.Len(t, pd.Files, 1)
+			assert.Equal(t, "network-aws-1.2.0.tar.gz", pd.Files[0].File.Name)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/9.9.9/download")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", registryURL+"/"+moduleVersion+"/download")
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			location := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, setting.AppURL+strings.TrimPrefix(uploadURL, "/")+"/network-aws-1.2.0.tar.gz", location)
+
+			req = NewRequest(t, "GET", strings.TrimPrefix(location, setting.AppURL[:len(setting.AppURL)-1]))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "2.0.0"
+		prefix := fmt.Sprintf("terraform-provider-%s_%s_", providerType, providerVersion)
+		archives := map[string][]byte{
+			prefix + "linux_amd64.zip":  []byte("linux"),
+			prefix + "darwin_arm64.zip": []byte("darwin"),
+		}
+
+		rootURL := fmt.Sprintf("/api/packages/%s/terraform/providers/%s/%s", user.Name, providerType, providerVersion)
+		registryURL := fmt.Sprintf("/api/packages/terraform/providers/v1/%s/%s", user.Name, providerType)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", rootURL+"/"+prefix+"linux_amd64.zip", bytes.NewReader(archives[prefix+"linux_amd64.zip"]))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", rootURL+"/provider.zip", bytes.NewReader([]byte("invalid"))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			for filename, content := range archives {
+				req = NewRequestWithBody(t, "PUT", rootURL+"/"+filename, bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusCreated)
+			}
+
+			req = NewRequestWithBody(t, "PUT", rootURL+"/"+prefix+"manifest.json", strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", rootURL+"/"+prefix+"linux_amd64.zip", bytes.NewReader(archives[prefix+"linux_amd64.zip"])).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+
+			pv, err := packages.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pv)
+			require.NoError(t, err)
+			assert.Equal(t, &terraform_module.Metadata{Kind: terraform_module.KindProvider}, pd.Metadata)
+			assert.Len(t, pd.Files, 3)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			assert.Len(t, result.Versions[0].Platforms, 2)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/"+providerVersion+"/download/windows/amd64")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", registryURL+"/"+providerVersion+"/download/linux/amd64")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				ShasumsURL          string   `json:"shasums_url"`
+				ShasumsSignatureURL string   `json:"shasums_signature_url"`
+				Shasum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			filename := prefix + "linux_amd64.zip"
+			hash := sha256.Sum256(archives[filename])
+
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, filename, result.Filename)
+			assert.Equal(t, hex.EncodeToString(hash[:]), result.Shasum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			localURL := func(u string) string {
+				return strings.TrimPrefix(u, setting.AppURL[:len(setting.AppURL)-1])
+			}
+
+			req = NewRequest(t, "GET", localURL(result.DownloadURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, archives[filename], resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", localURL(result.ShasumsURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			shasums := resp.Body.Bytes()
+			assert.Contains(t, string(shasums), fmt.Sprintf("%s  %s\n", result.Shasum, filename))
+			assert.Equal(t, 2, strings.Count(string(shasums), "\n"))
+
+			req = NewRequest(t, "GET", localURL(result.ShasumsSignatureURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			require.Len(t, keyring, 1)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].KeyID, strings.ToUpper(keyring[0].PrimaryKey.KeyIdString()))
+
+			signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(shasums), bytes.NewReader(resp.Body.Bytes()), nil)
+			require.NoError(t, err)
+			assert.Equal(t, keyring[0].PrimaryKey.KeyId, signer.PrimaryKey.KeyId)
+		})
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIProjectAutomationRules(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteIssue)
+	urlStr := fmt.Sprintf("/api/v1/repos/%s/%s/projects/1/automation", owner.Name, repo.Name)
+
+	// column 4 belongs to another project
+	req := NewRequestWithJSON(t, "POST", urlStr, &api.CreateProjectAutomationRuleOption{
+		Trigger:  "issue_closed",
+		ColumnID: 4,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", urlStr, &api.CreateProjectAutomationRuleOption{
+		Trigger:  "label_added",
+		LabelID:  2,
+		ColumnID: 3,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var rule api.ProjectAutomationRule
+	DecodeJSON(t, resp, &rule)
+	assert.Equal(t, "label_added", rule.Trigger)
+	assert.EqualValues(t, 2, rule.LabelID)
+	assert.EqualValues(t, 3, rule.ColumnID)
+
+	req = NewRequest(t, "GET", urlStr).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var rules []*api.ProjectAutomationRule
+	DecodeJSON(t, resp, &rules)
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, rule.ID, rules[0].ID)
+	}
+
+	t.Run("Trigger", func(t *testing.T) {
+		// issue 1 is in the column 1 of the project
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/%s/issues/1/labels", owner.Name, repo.Name), &api.IssueLabelsOption{
+			Labels: []any{2},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 3})
+	})
+
+	ruleURL := fmt.Sprintf("%s/%d", urlStr, rule.ID)
+	column := int64(2)
+	trigger := "issue_reopened"
+	req = NewRequestWithJSON(t, "PATCH", ruleURL, &api.EditProjectAutomationRuleOption{
+		Trigger:  &trigger,
+		ColumnID: &column,
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &rule)
+	assert.Equal(t, "issue_reopened", rule.Trigger)
+	assert.Zero(t, rule.LabelID)
+	assert.EqualValues(t, 2, rule.ColumnID)
+
+	req = NewRequest(t, "DELETE", ruleURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &project_model.AutomationRule{ID: rule.ID})
+
+	req = NewRequest(t, "GET", ruleURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+}
+
+func TestProjectAutomationNewIssueInProject(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	project := &project_model.Project{
+		Title:     "Triage",
+		RepoID:    repo.ID,
+		CreatorID: owner.ID,
+		Type:      project_model.TypeRepository,
+	}
+	require.NoError(t, project_model.NewProject(db.DefaultContext, project))
+	column := &project_model.Column{Title: "Incoming", ProjectID: project.ID, CreatorID: owner.ID}
+	require.NoError(t, project_model.NewColumn(db.DefaultContext, column))
+
+	// the rule of the oldest project of the repository only applies to the issues opened outside of any project
+	for _, rule := range []*project_model.AutomationRule{
+		{ProjectID: 1, TriggerType: project_model.AutomationTriggerIssueOpened, ColumnID: 2, CreatorID: owner.ID},
+		{ProjectID: project.ID, TriggerType: project_model.AutomationTriggerIssueOpened, ColumnID: column.ID, CreatorID: owner.ID},
+	} {
+		require.NoError(t, project_model.NewAutomationRule(db.DefaultContext, rule))
+	}
+
+	session := loginUser(t, owner.Name)
+	req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/issues/new", owner.Name, repo.Name), map[string]string{
+		"title":      "Issue opened in a project",
+		"project_id": strconv.FormatInt(project.ID, 10),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Title: "Issue opened in a project"})
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: issue.ID, ProjectID: project.ID, ProjectColumnID: column.ID})
+	unittest.AssertCount(t, &issues_model.Comment{IssueID: issue.ID, Type: issues_model.CommentTypeProject}, 1)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, Type: issues_model.CommentTypeProject, ProjectID: project.ID})
+}
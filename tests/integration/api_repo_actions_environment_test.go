@@ -0,0 +1,179 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoActionEnvironments(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	url := fmt.Sprintf("/api/v1/repos/%s/actions/environments", repo.FullName())
+
+	t.Run("CreateOrUpdate", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PUT", url+"/production", api.CreateOrUpdateActionEnvironmentOption{
+			WaitTimer: 5,
+			Reviewers: []string{user.Name},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var env api.ActionEnvironment
+		DecodeJSON(t, resp, &env)
+		assert.Equal(t, "production", env.Name)
+		assert.EqualValues(t, 5, env.WaitTimer)
+		if assert.Len(t, env.Reviewers, 1) {
+			assert.Equal(t, user.ID, env.Reviewers[0].ID)
+		}
+		assert.Empty(t, env.BranchPatterns)
+
+		req = NewRequestWithJSON(t, "PUT", url+"/production", api.CreateOrUpdateActionEnvironmentOption{
+			BranchPatterns: []string{"main", "release/*"},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &env)
+		assert.EqualValues(t, 0, env.WaitTimer)
+		assert.Empty(t, env.Reviewers)
+		assert.Equal(t, []string{"main", "release/*"}, env.BranchPatterns)
+
+		for _, opt := range []api.CreateOrUpdateActionEnvironmentOption{
+			{Reviewers: []string{"user-does-not-exist"}},
+			{WaitTimer: -1},
+			{BranchPatterns: []string{"release/[a-"}},
+		} {
+			req = NewRequestWithJSON(t, "PUT", url+"/staging", opt).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		}
+
+		req = NewRequestWithJSON(t, "PUT", url+"/staging", api.CreateOrUpdateActionEnvironmentOption{}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		req := NewRequest(t, "GET", url).AddTokenAuth(otherToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var envs []*api.ActionEnvironment
+		DecodeJSON(t, resp, &envs)
+		if assert.Len(t, envs, 1) {
+			assert.Equal(t, "production", envs[0].Name)
+		}
+
+		req = NewRequest(t, "GET", url+"/production").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "GET", url+"/staging").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", url+"/production/deployments").AddTokenAuth(otherToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var deployments []*api.ActionDeployment
+		DecodeJSON(t, resp, &deployments)
+		assert.Empty(t, deployments)
+		req = NewRequest(t, "GET", url+"/production/deployments?status=unknown").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Secrets", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PUT", url+"/production/secrets/deploy_key", api.CreateOrUpdateSecretOption{Data: "s3cr3t"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequestWithJSON(t, "PUT", url+"/production/secrets/deploy_key", api.CreateOrUpdateSecretOption{Data: "s3cr3t2"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequestWithJSON(t, "PUT", url+"/production/secrets/GITEA_KEY", api.CreateOrUpdateSecretOption{Data: "s3cr3t"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// the secret of the environment is not a secret of the repository
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/actions/secrets", repo.FullName())).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var secrets []*api.Secret
+		DecodeJSON(t, resp, &secrets)
+		assert.Empty(t, secrets)
+
+		req = NewRequest(t, "GET", url+"/production/secrets").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &secrets)
+		if assert.Len(t, secrets, 1) {
+			assert.Equal(t, "DEPLOY_KEY", secrets[0].Name)
+		}
+		req = NewRequest(t, "GET", url+"/production/secrets").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", url+"/production/secrets/deploy_key").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", url+"/production/secrets/deploy_key").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Variables", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", url+"/production/variables/target", api.CreateVariableOption{Value: "prod"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequestWithJSON(t, "POST", url+"/production/variables/target", api.CreateVariableOption{Value: "prod"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+		req = NewRequestWithJSON(t, "PUT", url+"/production/variables/target", api.UpdateVariableOption{Value: "production"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", url+"/production/variables").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var variables []*api.ActionVariable
+		DecodeJSON(t, resp, &variables)
+		if assert.Len(t, variables, 1) {
+			assert.Equal(t, "TARGET", variables[0].Name)
+			assert.Equal(t, "production", variables[0].Data)
+		}
+
+		req = NewRequest(t, "DELETE", url+"/production/variables/target").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", url+"/production/variables/target").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PUT", url+"/production/secrets/deploy_key", api.CreateOrUpdateSecretOption{Data: "s3cr3t"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "DELETE", url+"/production").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		req = NewRequest(t, "DELETE", url+"/production").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "GET", url+"/production").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		// the secrets of the environment are deleted with it
+		req = NewRequestWithJSON(t, "PUT", url+"/production", api.CreateOrUpdateActionEnvironmentOption{}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequest(t, "GET", url+"/production/secrets").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var secrets []*api.Secret
+		DecodeJSON(t, resp, &secrets)
+		assert.Empty(t, secrets)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/actions/deployments/%d/review", repo.FullName(), 999999),
+			api.ReviewActionDeploymentOption{Action: "approve"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+		req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/actions/deployments/%d/review", repo.FullName(), 999999),
+			api.ReviewActionDeploymentOption{Action: "maybe"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+}
@@ -18,6 +18,7 @@ import (
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/zstd"
 	"forgejo.org/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +59,18 @@ func TestAPIDownloadArchive(t *testing.T) {
 	// The locked URL should give the same bytes as the non-locked one
 	assert.Equal(t, bs, bs2)
 
+	link, _ = url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/archive/master.tar.zst", user2.Name, repo.Name))
+	resp = MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
+	bs, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	zstdReader, err := zstd.NewReader(bytes.NewReader(bs))
+	require.NoError(t, err)
+	defer zstdReader.Close()
+	fileNames, err = fileNamesFromTar(zstdReader)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, fileNames, []string{"repo1/", "repo1/README.md"})
+	assert.Equal(t, "application/zstd", resp.Header().Get("Content-Type"))
+
 	link, _ = url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/archive/master.bundle", user2.Name, repo.Name))
 	resp = MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
 	bs, err = io.ReadAll(resp.Body)
@@ -117,6 +130,10 @@ func fileNamesFromTarGzip(gzippedTar []byte) ([]string, error) {
 		return []string{}, err
 	}
 
+	return fileNamesFromTar(archive)
+}
+
+func fileNamesFromTar(archive io.Reader) ([]string, error) {
 	files := []string{}
 	reader := tar.NewReader(archive)
 	for {
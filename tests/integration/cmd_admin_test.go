@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"testing"
 
+	audit_model "forgejo.org/models/audit"
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/models/unittest"
@@ -184,6 +185,16 @@ func Test_Cmd_AdminUserResetMFA(t *testing.T) {
 		_, err = auth_model.GetWebAuthnCredentialByID(t.Context(), authn.ID)
 		require.ErrorContains(t, err, "WebAuthn credential does not exist")
 
+		// the command line has no doer but the reset is audited
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{
+			Action:     audit_model.ActionTwoFactorDisable,
+			DoerID:     0,
+			OwnerID:    user.ID,
+			TargetType: audit_model.TargetUser,
+			TargetID:   user.ID,
+			Detail:     "reset by an administrator",
+		})
+
 		_, err = runMainApp("admin", "user", "delete", "--username", name)
 		require.NoError(t, err)
 	})
@@ -0,0 +1,288 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	pull_model "forgejo.org/models/pull"
+	repo_model "forgejo.org/models/repo"
+	unit_model "forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	pull_service "forgejo.org/services/pull"
+	commitstatus_service "forgejo.org/services/repository/commitstatus"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullMergeQueue(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", []unit_model.Type{unit_model.TypePullRequests}, nil, nil)
+		defer f()
+
+		ctx := NewAPITestContext(t, "user2", repo.Name, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteUser)
+		doProtectBranch(ctx, "main", parameterProtectBranch{
+			"enable_push":           "true",
+			"enable_status_check":   "true",
+			"status_check_contexts": "ci",
+			"enable_merge_queue":    "true",
+		})(t)
+
+		setStatus := func(t *testing.T, sha string, state api.CommitStatusState) {
+			t.Helper()
+			err := commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, sha, &git_model.CommitStatus{
+				State:   state,
+				Context: "ci",
+			})
+			require.NoError(t, err)
+		}
+		getEntry := func(t *testing.T, pr *issues_model.PullRequest) *pull_model.MergeQueueEntry {
+			t.Helper()
+			exists, entry, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+			require.NoError(t, err)
+			require.True(t, exists)
+			return entry
+		}
+
+		pulls := make([]*issues_model.PullRequest, 0, 3)
+		for _, name := range []string{"one", "two", "three"} {
+			pr := createMergeQueuePullRequest(t, user2, repo, name)
+			headCommitID, err := gitrepo.GetBranchCommitID(db.DefaultContext, repo, name)
+			require.NoError(t, err)
+			setStatus(t, headCommitID, api.CommitStatusSuccess)
+			pulls = append(pulls, pr)
+		}
+
+		t.Run("Queue", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			ctx.ExpectedCode = http.StatusAccepted
+			for _, pr := range pulls {
+				doAPIMergePullRequestForm(t, ctx, "user2", repo.Name, pr.Index, &forms.MergePullRequestForm{
+					Do: string(repo_model.MergeStyleMerge),
+				})
+			}
+
+			ctx.ExpectedCode = http.StatusConflict
+			doAPIMergePullRequestForm(t, ctx, "user2", repo.Name, pulls[0].Index, &forms.MergePullRequestForm{
+				Do: string(repo_model.MergeStyleMerge),
+			})
+			ctx.ExpectedCode = 0
+
+			for _, pr := range pulls {
+				entry := getEntry(t, pr)
+				assert.NotEmpty(t, entry.MergeCommitID)
+
+				commitID, err := gitrepo.GetBranchCommitID(db.DefaultContext, repo, pull_model.MergeQueueBranchName("main", pr.Index))
+				require.NoError(t, err)
+				assert.Equal(t, entry.MergeCommitID, commitID)
+
+				unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: pr.IssueID, Type: issues_model.CommentTypePRAddedToMergeQueue})
+			}
+		})
+
+		t.Run("FailureBehindPending", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// the failure may be caused by the first pull request, which is still pending
+			setStatus(t, getEntry(t, pulls[1]).MergeCommitID, api.CommitStatusFailure)
+			for _, pr := range pulls {
+				getEntry(t, pr)
+			}
+		})
+
+		t.Run("MergeAndEject", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			previousMergeCommitID := getEntry(t, pulls[2]).MergeCommitID
+			setStatus(t, getEntry(t, pulls[0]).MergeCommitID, api.CommitStatusSuccess)
+
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pulls[0].ID})
+			assert.True(t, pr.HasMerged)
+			unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pulls[0].ID})
+
+			pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pulls[1].ID})
+			assert.False(t, pr.HasMerged)
+			unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pulls[1].ID})
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: pulls[1].IssueID, Type: issues_model.CommentTypePRRemovedFromMergeQueue, Content: "checks_failed"})
+
+			// the third pull request must be rebuilt without the ejected one
+			assert.NotEqual(t, previousMergeCommitID, getEntry(t, pulls[2]).MergeCommitID)
+		})
+
+		t.Run("MergeLast", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			setStatus(t, getEntry(t, pulls[2]).MergeCommitID, api.CommitStatusSuccess)
+
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pulls[2].ID})
+			assert.True(t, pr.HasMerged)
+
+			entries, err := pull_model.GetMergeQueueEntries(db.DefaultContext, repo.ID, "main")
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+
+		t.Run("Content", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			gitRepo, err := gitrepo.OpenRepository(db.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+
+			commit, err := gitRepo.GetBranchCommit("main")
+			require.NoError(t, err)
+
+			_, err = commit.GetTreeEntryByPath("one.txt")
+			require.NoError(t, err)
+			_, err = commit.GetTreeEntryByPath("three.txt")
+			require.NoError(t, err)
+			_, err = commit.GetTreeEntryByPath("two.txt")
+			assert.True(t, git.IsErrNotExist(err))
+		})
+	})
+}
+
+func TestPullMergeQueueWithoutRequiredChecks(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", []unit_model.Type{unit_model.TypePullRequests}, nil, nil)
+		defer f()
+
+		// the rule also protects the branches of the speculative merges, but not against the merge queue
+		ctx := NewAPITestContext(t, "user2", repo.Name, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteUser)
+		doProtectBranch(ctx, "**", parameterProtectBranch{
+			"enable_merge_queue": "true",
+		})(t)
+
+		pr := createMergeQueuePullRequest(t, user2, repo, "one")
+
+		// there is no CI to wait for, the pull request is merged right away
+		ctx.ExpectedCode = http.StatusAccepted
+		doAPIMergePullRequestForm(t, ctx, "user2", repo.Name, pr.Index, &forms.MergePullRequestForm{
+			Do: string(repo_model.MergeStyleMerge),
+		})
+
+		pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+		assert.True(t, pr.HasMerged)
+		unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pr.ID})
+
+		_, err := gitrepo.GetBranchCommitID(db.DefaultContext, repo, pull_model.MergeQueueBranchName("main", pr.Index))
+		require.Error(t, err)
+	})
+}
+
+func TestPullMergeQueueWorkInProgress(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", []unit_model.Type{unit_model.TypePullRequests}, nil, nil)
+		defer f()
+
+		ctx := NewAPITestContext(t, "user2", repo.Name, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteUser)
+		doProtectBranch(ctx, "main", parameterProtectBranch{
+			"enable_push":           "true",
+			"enable_status_check":   "true",
+			"status_check_contexts": "ci",
+			"enable_merge_queue":    "true",
+		})(t)
+
+		pr := createMergeQueuePullRequest(t, user2, repo, "one")
+		headCommitID, err := gitrepo.GetBranchCommitID(db.DefaultContext, repo, "one")
+		require.NoError(t, err)
+		require.NoError(t, commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, headCommitID, &git_model.CommitStatus{
+			State:   api.CommitStatusSuccess,
+			Context: "ci",
+		}))
+
+		ctx.ExpectedCode = http.StatusAccepted
+		doAPIMergePullRequestForm(t, ctx, "user2", repo.Name, pr.Index, &forms.MergePullRequestForm{
+			Do: string(repo_model.MergeStyleMerge),
+		})
+
+		// the pull request is no longer mergeable once it is queued, a green build does not override that
+		require.NoError(t, pr.LoadIssue(db.DefaultContext))
+		require.NoError(t, issue_service.ChangeTitle(db.DefaultContext, pr.Issue, user2, "WIP: "+pr.Issue.Title))
+
+		_, entry, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+		require.NoError(t, err)
+		require.NoError(t, commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, entry.MergeCommitID, &git_model.CommitStatus{
+			State:   api.CommitStatusSuccess,
+			Context: "ci",
+		}))
+
+		pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+		assert.False(t, pr.HasMerged)
+		unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pr.ID})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: pr.IssueID, Type: issues_model.CommentTypePRRemovedFromMergeQueue, Content: "merge_failed"})
+	})
+}
+
+func createMergeQueuePullRequest(t *testing.T, user *user_model.User, repo *repo_model.Repository, name string) *issues_model.PullRequest {
+	t.Helper()
+
+	_, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user, &files_service.ChangeRepoFilesOptions{
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "create",
+				TreePath:      name + ".txt",
+				ContentReader: strings.NewReader(name),
+			},
+		},
+		Message:   "Add " + name,
+		OldBranch: "main",
+		NewBranch: name,
+		Author: &files_service.IdentityOptions{
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		Committer: &files_service.IdentityOptions{
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		Dates: &files_service.CommitDateOptions{
+			Author:    time.Now(),
+			Committer: time.Now(),
+		},
+	})
+	require.NoError(t, err)
+
+	pullIssue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Title:    "Add " + name,
+		PosterID: user.ID,
+		Poster:   user,
+		IsPull:   true,
+	}
+	pullRequest := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: name,
+		BaseBranch: "main",
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		Type:       issues_model.PullRequestGitea,
+	}
+	err = pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pullRequest, nil)
+	require.NoError(t, err)
+
+	return pullRequest
+}
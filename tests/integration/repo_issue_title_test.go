@@ -139,7 +139,7 @@ func createIssue(t *testing.T, user *user_model.User, repo *repo_model.Repositor
 		Poster:   user,
 	}
 
-	err := issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil)
+	err := issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil, 0)
 	require.NoError(t, err)
 
 	return issue
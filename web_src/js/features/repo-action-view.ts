@@ -40,6 +40,8 @@ export async function initRepositoryActionView() {
       viewingOutOfDateRun: el.getAttribute('data-locale-viewing-out-of-date-run'),
       viewMostRecentRun: el.getAttribute('data-locale-view-most-recent-run'),
       preExecutionError: el.getAttribute('data-locale-pre-execution-error'),
+      annotations: el.getAttribute('data-locale-annotations'),
+      summary: el.getAttribute('data-locale-summary'),
       status: {
         unknown: el.getAttribute('data-locale-status-unknown'),
         waiting: el.getAttribute('data-locale-status-waiting'),